@@ -24,6 +24,7 @@ import (
 	"github.com/terraincognita07/ovumcy/internal/cli"
 	"github.com/terraincognita07/ovumcy/internal/db"
 	"github.com/terraincognita07/ovumcy/internal/i18n"
+	"github.com/terraincognita07/ovumcy/internal/services"
 )
 
 func main() {
@@ -49,6 +50,10 @@ func main() {
 	}
 	defaultLanguage := getEnv("DEFAULT_LANGUAGE", "ru")
 	cookieSecure := getEnvBool("COOKIE_SECURE", false)
+	registrationMode, err := services.ParseRegistrationMode(getEnv("REGISTRATION_MODE", string(services.RegistrationModeOpen)))
+	if err != nil {
+		log.Fatalf("invalid REGISTRATION_MODE: %v", err)
+	}
 
 	loginLimitMax := getEnvInt("RATE_LIMIT_LOGIN_MAX", 8)
 	loginLimitWindow := getEnvDuration("RATE_LIMIT_LOGIN_WINDOW", 15*time.Minute)
@@ -83,6 +88,7 @@ func main() {
 	if err != nil {
 		log.Fatalf("handler init failed: %v", err)
 	}
+	handler.SetRegistrationMode(registrationMode)
 
 	appConfig := fiber.Config{
 		AppName:               "Ovumcy",
@@ -142,10 +148,11 @@ func main() {
 	}()
 
 	log.Printf(
-		"Ovumcy listening on http://0.0.0.0:%s (rev: %s, tz: %s, rate_limits: login=%d/%s forgot=%d/%s api=%d/%s, trusted_proxy=%t)",
+		"Ovumcy listening on http://0.0.0.0:%s (rev: %s, tz: %s, registration: %s, rate_limits: login=%d/%s forgot=%d/%s api=%d/%s, trusted_proxy=%t)",
 		port,
 		buildRevision(),
 		location.String(),
+		registrationMode,
 		loginLimitMax,
 		loginLimitWindow,
 		forgotLimitMax,
@@ -201,6 +208,40 @@ func tryRunCLICommand() (bool, error) {
 		dbPath := getEnv("DB_PATH", filepath.Join("data", "ovumcy.db"))
 		email := strings.TrimSpace(os.Args[2])
 		return true, cli.RunResetPasswordCommand(dbPath, email)
+	case "create-registration-invite":
+		if len(os.Args) > 3 {
+			return true, fmt.Errorf("usage: ovumcy create-registration-invite [ttl]")
+		}
+		ttl := services.DefaultRegistrationInviteTTL
+		if len(os.Args) == 3 {
+			parsed, err := time.ParseDuration(strings.TrimSpace(os.Args[2]))
+			if err != nil || parsed < time.Minute {
+				return true, fmt.Errorf("invalid ttl %q: use a duration such as 24h", os.Args[2])
+			}
+			ttl = parsed
+		}
+		dbPath := getEnv("DB_PATH", filepath.Join("data", "ovumcy.db"))
+		return true, cli.RunCreateRegistrationInviteCommand(dbPath, ttl)
+	case "backtest":
+		if len(os.Args) != 3 {
+			return true, fmt.Errorf("usage: ovumcy backtest <email>")
+		}
+		dbPath := getEnv("DB_PATH", filepath.Join("data", "ovumcy.db"))
+		return true, cli.RunBacktestCommand(dbPath, os.Args[2], mustLoadLocation(getEnv("TZ", "Local")))
+	case "convert-spotting":
+		dryRun := len(os.Args) == 4 && os.Args[3] == "--dry-run"
+		if len(os.Args) != 3 && !dryRun {
+			return true, fmt.Errorf("usage: ovumcy convert-spotting <email> [--dry-run]")
+		}
+		dbPath := getEnv("DB_PATH", filepath.Join("data", "ovumcy.db"))
+		return true, cli.RunConvertSpottingCommand(dbPath, os.Args[2], dryRun, mustLoadLocation(getEnv("TZ", "Local")))
+	case "import":
+		options, err := cli.ParseImportArgs(os.Args[2:])
+		if err != nil {
+			return true, err
+		}
+		dbPath := getEnv("DB_PATH", filepath.Join("data", "ovumcy.db"))
+		return true, cli.RunImportCommand(dbPath, options, mustLoadLocation(getEnv("TZ", "Local")))
 	default:
 		return false, nil
 	}
@@ -314,6 +355,7 @@ func csrfMiddlewareConfig(cookieSecure bool) csrf.Config {
 		CookieHTTPOnly: true,
 		CookieSecure:   cookieSecure,
 		ContextKey:     "csrf",
+		Next:           api.IsBearerTokenRequest,
 	}
 }
 
@@ -62,6 +62,9 @@ func TestCSRFMiddlewareConfigUsesCookieSecureFlag(t *testing.T) {
 	if secureConfig.KeyLookup != "form:csrf_token" {
 		t.Fatalf("expected csrf key lookup form:csrf_token, got %q", secureConfig.KeyLookup)
 	}
+	if secureConfig.Next == nil {
+		t.Fatal("expected csrf middleware to skip bearer token requests")
+	}
 
 	insecureConfig := csrfMiddlewareConfig(false)
 	if insecureConfig.CookieSecure {
@@ -0,0 +1,215 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/db"
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+	"gorm.io/gorm"
+)
+
+func createAPITokenForTest(t *testing.T, database *gorm.DB, user models.User, scope string) (string, models.APIToken) {
+	t.Helper()
+
+	service := services.NewAPITokenService(db.NewAPITokenRepository(database))
+	rawToken, token, err := service.Create(&user, "test token", scope, time.Now())
+	if err != nil {
+		t.Fatalf("create api token: %v", err)
+	}
+	return rawToken, token
+}
+
+func bearerRequestForTest(t *testing.T, app *fiber.App, method string, path string, rawToken string, body io.Reader) *http.Response {
+	t.Helper()
+
+	request := httptest.NewRequest(method, path, body)
+	request.Header.Set("Authorization", "Bearer "+rawToken)
+	if body != nil {
+		request.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+	}
+
+	response, err := app.Test(request, -1)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	return response
+}
+
+func dayPayloadForTest(t *testing.T) io.Reader {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]any{
+		"is_period":   true,
+		"flow":        models.FlowLight,
+		"symptom_ids": []uint{},
+		"notes":       "",
+	})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	return bytes.NewReader(body)
+}
+
+func TestAPITokenReadScopeAllowsReadsOnly(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "api-token-read@example.com", "StrongPass1", true)
+	rawToken, token := createAPITokenForTest(t, database, user, models.APITokenScopeRead)
+
+	for _, path := range []string{"/api/days?from=2026-02-01&to=2026-02-28", "/api/stats/overview", "/api/export/json"} {
+		response := bearerRequestForTest(t, app, http.MethodGet, path, rawToken, nil)
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			t.Fatalf("GET %s: expected status 200, got %d", path, response.StatusCode)
+		}
+	}
+
+	write := bearerRequestForTest(t, app, http.MethodPost, "/api/days/2026-02-10", rawToken, dayPayloadForTest(t))
+	defer write.Body.Close()
+	if write.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected read-only token write to return 403, got %d", write.StatusCode)
+	}
+	if errorValue := readAPIError(t, write.Body); errorValue != "api token is read-only" {
+		t.Fatalf("expected read-only error, got %q", errorValue)
+	}
+
+	var stored models.APIToken
+	if err := database.First(&stored, token.ID).Error; err != nil {
+		t.Fatalf("load api token: %v", err)
+	}
+	if stored.LastUsedAt == nil {
+		t.Fatal("expected last used timestamp to be recorded")
+	}
+}
+
+func TestAPITokenReadWriteScopeBypassesCSRF(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestAppWithCSRF(t)
+	user := createOnboardingTestUser(t, database, "api-token-write@example.com", "StrongPass1", true)
+	rawToken, _ := createAPITokenForTest(t, database, user, models.APITokenScopeReadWrite)
+
+	response := bearerRequestForTest(t, app, http.MethodPost, "/api/days/2026-02-10", rawToken, dayPayloadForTest(t))
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected bearer write without csrf token to succeed, got %d", response.StatusCode)
+	}
+
+	var count int64
+	if err := database.Model(&models.DailyLog{}).Where("user_id = ?", user.ID).Count(&count).Error; err != nil {
+		t.Fatalf("count logs: %v", err)
+	}
+	if count == 0 {
+		t.Fatal("expected day to be stored through the api token")
+	}
+}
+
+func TestAPITokenRejectedOutsideDataEndpoints(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "api-token-scope@example.com", "StrongPass1", true)
+	rawToken, _ := createAPITokenForTest(t, database, user, models.APITokenScopeReadWrite)
+
+	settings := bearerRequestForTest(t, app, http.MethodPost, "/api/settings/api-tokens", rawToken, nil)
+	defer settings.Body.Close()
+	if settings.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected settings route to reject api tokens, got %d", settings.StatusCode)
+	}
+
+	deleteAccount := bearerRequestForTest(t, app, http.MethodDelete, "/api/settings/delete-account", rawToken, nil)
+	defer deleteAccount.Body.Close()
+	if deleteAccount.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected delete-account to reject api tokens, got %d", deleteAccount.StatusCode)
+	}
+
+	unknown := bearerRequestForTest(t, app, http.MethodGet, "/api/days", "ovm_not-a-real-token", nil)
+	defer unknown.Body.Close()
+	if unknown.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected unknown token to return 401, got %d", unknown.StatusCode)
+	}
+}
+
+func TestAPITokenSettingsCreateListAndRevoke(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "api-token-settings@example.com", "StrongPass1", true)
+	authCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+
+	invalid := postSessionFormForTest(t, app, authCookie, "/api/settings/api-tokens", url.Values{"name": {"  "}})
+	defer invalid.Body.Close()
+	if invalid.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected blank name to return 400, got %d", invalid.StatusCode)
+	}
+
+	create := postSessionFormForTest(t, app, authCookie, "/api/settings/api-tokens", url.Values{
+		"name":  {"Backup script"},
+		"scope": {"read"},
+	})
+	defer create.Body.Close()
+	if create.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", create.StatusCode)
+	}
+	payload := struct {
+		ID    uint   `json:"id"`
+		Token string `json:"token"`
+		Scope string `json:"scope"`
+	}{}
+	if err := json.NewDecoder(create.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode create response: %v", err)
+	}
+	if !strings.HasPrefix(payload.Token, services.APITokenPrefix) || payload.Scope != models.APITokenScopeRead {
+		t.Fatalf("unexpected create response: %#v", payload)
+	}
+
+	var stored models.APIToken
+	if err := database.First(&stored, payload.ID).Error; err != nil {
+		t.Fatalf("load api token: %v", err)
+	}
+	if stored.TokenHash == payload.Token || strings.Contains(stored.TokenHash, payload.Token) {
+		t.Fatal("expected api token to be stored hashed")
+	}
+
+	body := smokeGET(t, app, authCookie, "/settings", http.StatusOK)
+	if !strings.Contains(body, "Backup script") || !strings.Contains(body, "Never used") {
+		t.Fatal("expected settings to list the token as never used")
+	}
+
+	used := bearerRequestForTest(t, app, http.MethodGet, "/api/stats/overview", payload.Token, nil)
+	used.Body.Close()
+	body = smokeGET(t, app, authCookie, "/settings", http.StatusOK)
+	if strings.Contains(body, "Never used") || !strings.Contains(body, "Last used") {
+		t.Fatal("expected settings to show the last used timestamp")
+	}
+
+	revoke := postSessionFormForTest(t, app, authCookie, "/api/settings/api-tokens/"+strconv.FormatUint(uint64(payload.ID), 10)+"/revoke", url.Values{})
+	revoke.Body.Close()
+	if revoke.StatusCode != http.StatusOK {
+		t.Fatalf("expected revoke status 200, got %d", revoke.StatusCode)
+	}
+
+	afterRevoke := bearerRequestForTest(t, app, http.MethodGet, "/api/stats/overview", payload.Token, nil)
+	defer afterRevoke.Body.Close()
+	if afterRevoke.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected revoked token to return 401, got %d", afterRevoke.StatusCode)
+	}
+
+	missing := postSessionFormForTest(t, app, authCookie, "/api/settings/api-tokens/"+strconv.FormatUint(uint64(payload.ID), 10)+"/revoke", url.Values{})
+	defer missing.Body.Close()
+	if missing.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected second revoke to return 404, got %d", missing.StatusCode)
+	}
+}
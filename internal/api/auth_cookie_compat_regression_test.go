@@ -10,7 +10,10 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/terraincognita07/ovumcy/internal/db"
 	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+	"gorm.io/gorm"
 )
 
 func TestLoginSetsSealedAuthCookieValue(t *testing.T) {
@@ -47,7 +50,7 @@ func TestLoginSetsSealedAuthCookieValue(t *testing.T) {
 func TestAuthMiddlewareAcceptsLegacyJWTAuthCookieFallback(t *testing.T) {
 	app, database := newOnboardingTestApp(t)
 	user := createOnboardingTestUser(t, database, "legacy-auth-cookie@example.com", "StrongPass1", true)
-	legacyToken := buildLegacyJWTForUser(t, user)
+	legacyToken := buildLegacyJWTForUser(t, database, user)
 
 	request := httptest.NewRequest(http.MethodGet, "/dashboard", nil)
 	request.Header.Set("Cookie", authCookieName+"="+legacyToken)
@@ -63,13 +66,18 @@ func TestAuthMiddlewareAcceptsLegacyJWTAuthCookieFallback(t *testing.T) {
 	}
 }
 
-func buildLegacyJWTForUser(t *testing.T, user models.User) string {
+func buildLegacyJWTForUser(t *testing.T, database *gorm.DB, user models.User) string {
 	t.Helper()
 
 	now := time.Now()
+	sessionToken, _, err := services.NewSessionService(db.NewSessionRepository(database)).Start(user.ID, services.SessionClient{}, time.Hour, now)
+	if err != nil {
+		t.Fatalf("start session: %v", err)
+	}
 	claims := authClaims{
-		UserID: user.ID,
-		Role:   user.Role,
+		UserID:    user.ID,
+		Role:      user.Role,
+		SessionID: sessionToken,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   strconv.FormatUint(uint64(user.ID), 10),
 			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
@@ -2,6 +2,7 @@ package api
 
 import (
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -40,6 +41,21 @@ func buildRegisterPageData(c *fiber.Ctx, messages map[string]string, flash Flash
 	}
 }
 
+func (handler *Handler) applyRegisterPartnerInvite(c *fiber.Ctx, data fiber.Map) {
+	inviteToken := strings.TrimSpace(c.Query("invite"))
+	if inviteToken == "" {
+		return
+	}
+
+	handler.ensureDependencies()
+	if _, err := handler.partnerService.ResolveInvite(inviteToken, time.Now().In(handler.location)); err != nil {
+		data["ErrorKey"] = authErrorTranslationKey("invalid partner invite")
+		return
+	}
+	data["InviteToken"] = inviteToken
+	data["IsFirstLaunch"] = false
+}
+
 func buildForgotPasswordPageData(c *fiber.Ctx, messages map[string]string, flash FlashPayload) fiber.Map {
 	return fiber.Map{
 		"Title":    localizedPageTitle(messages, "meta.title.forgot_password", "Ovumcy | Password Recovery"),
@@ -47,6 +63,13 @@ func buildForgotPasswordPageData(c *fiber.Ctx, messages map[string]string, flash
 	}
 }
 
+func buildTwoFactorPageData(c *fiber.Ctx, messages map[string]string, flash FlashPayload) fiber.Map {
+	return fiber.Map{
+		"Title":    localizedPageTitle(messages, "meta.title.two_factor", "Ovumcy | Two-Factor Authentication"),
+		"ErrorKey": authErrorKeyFromFlashOrQuery(c, flash.AuthError),
+	}
+}
+
 func (handler *Handler) buildResetPasswordPageData(c *fiber.Ctx, messages map[string]string, flash FlashPayload) fiber.Map {
 	token, forcedReset := handler.readResetPasswordCookie(c)
 	invalidToken := false
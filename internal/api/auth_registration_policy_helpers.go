@@ -0,0 +1,69 @@
+package api
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func registrationPolicyErrorMessage(err error) (int, string) {
+	switch {
+	case errors.Is(err, services.ErrRegistrationDisabled):
+		return fiber.StatusForbidden, "registration disabled"
+	case errors.Is(err, services.ErrRegistrationInviteRequired):
+		return fiber.StatusForbidden, "registration invite required"
+	case errors.Is(err, services.ErrRegistrationInviteInvalid):
+		return fiber.StatusBadRequest, "invalid registration invite"
+	default:
+		return fiber.StatusInternalServerError, "failed to create account"
+	}
+}
+
+func (handler *Handler) respondRegistrationPolicyError(c *fiber.Ctx, err error) error {
+	status, message := registrationPolicyErrorMessage(err)
+	if status == fiber.StatusInternalServerError {
+		return apiError(c, status, message)
+	}
+	return handler.respondAuthError(c, status, message)
+}
+
+func (handler *Handler) applyRegisterPolicy(c *fiber.Ctx, data fiber.Map) error {
+	if inviteToken, _ := data["InviteToken"].(string); inviteToken != "" {
+		return nil
+	}
+
+	handler.ensureDependencies()
+	registrationToken := strings.TrimSpace(c.Query("registration_token"))
+	if _, err := handler.registrationService.AuthorizeSignUp(registrationToken, time.Now().In(handler.location)); err != nil {
+		status, message := registrationPolicyErrorMessage(err)
+		if status == fiber.StatusInternalServerError {
+			return err
+		}
+		data["RegistrationClosed"] = true
+		if errorKey, _ := data["ErrorKey"].(string); errorKey == "" {
+			data["ErrorKey"] = authErrorTranslationKey(message)
+		}
+		return nil
+	}
+
+	if registrationToken != "" {
+		data["RegistrationToken"] = registrationToken
+	}
+	return nil
+}
+
+func (handler *Handler) publicSignUpClosed() (bool, error) {
+	handler.ensureDependencies()
+	_, err := handler.registrationService.AuthorizeSignUp("", time.Now().In(handler.location))
+	switch {
+	case err == nil:
+		return false, nil
+	case errors.Is(err, services.ErrRegistrationDisabled), errors.Is(err, services.ErrRegistrationInviteRequired):
+		return true, nil
+	default:
+		return false, err
+	}
+}
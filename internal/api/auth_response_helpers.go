@@ -22,6 +22,12 @@ func (handler *Handler) respondAuthError(c *fiber.Ctx, status int, message strin
 			if email != "" {
 				redirectValues.Set("email", email)
 			}
+			if invite := strings.TrimSpace(c.FormValue("invite")); invite != "" {
+				redirectValues.Set("invite", invite)
+			}
+			if registrationToken := strings.TrimSpace(c.FormValue("registration_token")); registrationToken != "" {
+				redirectValues.Set("registration_token", registrationToken)
+			}
 			return c.Redirect("/register?"+redirectValues.Encode(), fiber.StatusSeeOther)
 		case "/api/auth/login":
 			flash.LoginEmail = normalizeLoginEmail(c.FormValue("email"))
@@ -33,6 +39,9 @@ func (handler *Handler) respondAuthError(c *fiber.Ctx, status int, message strin
 		case "/api/auth/reset-password":
 			handler.setFlashCookie(c, flash)
 			return c.Redirect("/reset-password", fiber.StatusSeeOther)
+		case "/api/auth/login/two-factor":
+			handler.setFlashCookie(c, flash)
+			return c.Redirect("/login/two-factor", fiber.StatusSeeOther)
 		default:
 			handler.setFlashCookie(c, flash)
 			return c.Redirect("/login", fiber.StatusSeeOther)
@@ -0,0 +1,244 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"gorm.io/gorm"
+)
+
+func postSessionFormForTest(t *testing.T, app *fiber.App, cookie string, path string, form url.Values) *http.Response {
+	t.Helper()
+
+	request := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Cookie", cookie)
+
+	response, err := app.Test(request, -1)
+	if err != nil {
+		t.Fatalf("POST %s failed: %v", path, err)
+	}
+	return response
+}
+
+func listSessionsForTest(t *testing.T, database *gorm.DB, userID uint) []models.AuthSession {
+	t.Helper()
+
+	sessions := make([]models.AuthSession, 0)
+	if err := database.Where("user_id = ?", userID).Order("id ASC").Find(&sessions).Error; err != nil {
+		t.Fatalf("load sessions: %v", err)
+	}
+	return sessions
+}
+
+func TestLoginPersistsSessionWithDeviceDetails(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "session-login@example.com", "StrongPass1", true)
+
+	form := url.Values{"email": {user.Email}, "password": {"StrongPass1"}}
+	request := httptest.NewRequest(http.MethodPost, "/api/auth/login", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("User-Agent", "SessionTestBrowser/1.0")
+	response, err := app.Test(request, -1)
+	if err != nil {
+		t.Fatalf("login request failed: %v", err)
+	}
+	response.Body.Close()
+
+	sessions := listSessionsForTest(t, database, user.ID)
+	if len(sessions) != 1 {
+		t.Fatalf("expected one persisted session, got %d", len(sessions))
+	}
+	if sessions[0].UserAgent != "SessionTestBrowser/1.0" {
+		t.Fatalf("expected user agent to be stored, got %q", sessions[0].UserAgent)
+	}
+	if strings.TrimSpace(sessions[0].IPAddress) == "" {
+		t.Fatal("expected client ip to be stored")
+	}
+
+	authCookie := responseCookie(response.Cookies(), authCookieName)
+	if authCookie == nil {
+		t.Fatal("expected auth cookie in login response")
+	}
+	body := smokeGET(t, app, authCookie.Name+"="+authCookie.Value, "/settings", http.StatusOK)
+	if !strings.Contains(body, "SessionTestBrowser/1.0") {
+		t.Fatal("expected settings page to list the signed-in device")
+	}
+	if !strings.Contains(body, `action="/api/settings/sessions/revoke-all"`) {
+		t.Fatal("expected settings page to offer signing out everywhere")
+	}
+}
+
+func TestRevokedSessionCookieIsRejected(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "session-revoke@example.com", "StrongPass1", true)
+	laptopCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+	phoneCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+
+	sessions := listSessionsForTest(t, database, user.ID)
+	if len(sessions) != 2 {
+		t.Fatalf("expected two sessions, got %d", len(sessions))
+	}
+	phoneSessionID := sessions[1].ID
+
+	response := postSessionFormForTest(t, app, laptopCookie, "/api/settings/sessions/"+strconv.FormatUint(uint64(phoneSessionID), 10)+"/revoke", url.Values{})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected revoke status 200, got %d", response.StatusCode)
+	}
+
+	smokeGET(t, app, laptopCookie, "/dashboard", http.StatusOK)
+	smokeGET(t, app, phoneCookie, "/dashboard", http.StatusSeeOther)
+}
+
+func TestRevokeSessionRejectsForeignSession(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "session-owner-a@example.com", "StrongPass1", true)
+	other := createOnboardingTestUser(t, database, "session-owner-b@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+	otherCookie := loginAndExtractAuthCookie(t, app, other.Email, "StrongPass1")
+	otherSession := listSessionsForTest(t, database, other.ID)[0]
+
+	response := postSessionFormForTest(t, app, ownerCookie, "/api/settings/sessions/"+strconv.FormatUint(uint64(otherSession.ID), 10)+"/revoke", url.Values{})
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404 for foreign session, got %d", response.StatusCode)
+	}
+	if errorValue := readAPIError(t, response.Body); errorValue != "session not found" {
+		t.Fatalf("expected session not found error, got %q", errorValue)
+	}
+	smokeGET(t, app, otherCookie, "/dashboard", http.StatusOK)
+}
+
+func TestRevokeAllSessionsSignsOutEveryDevice(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "session-revoke-all@example.com", "StrongPass1", true)
+	laptopCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+	phoneCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+
+	response := postSessionFormForTest(t, app, laptopCookie, "/api/settings/sessions/revoke-all", url.Values{})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected revoke-all status 200, got %d", response.StatusCode)
+	}
+
+	if sessions := listSessionsForTest(t, database, user.ID); len(sessions) != 0 {
+		t.Fatalf("expected no sessions left, got %d", len(sessions))
+	}
+	smokeGET(t, app, laptopCookie, "/dashboard", http.StatusSeeOther)
+	smokeGET(t, app, phoneCookie, "/dashboard", http.StatusSeeOther)
+}
+
+func TestLogoutRevokesCurrentSession(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "session-logout@example.com", "StrongPass1", true)
+	authCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+
+	response := postSessionFormForTest(t, app, authCookie, "/api/auth/logout", url.Values{})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected logout status 200, got %d", response.StatusCode)
+	}
+
+	smokeGET(t, app, authCookie, "/dashboard", http.StatusSeeOther)
+}
+
+func TestChangePasswordRevokesOtherSessions(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "session-change-password@example.com", "StrongPass1", true)
+	laptopCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+	phoneCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+
+	response := postSessionFormForTest(t, app, laptopCookie, "/api/settings/change-password", url.Values{
+		"current_password": {"StrongPass1"},
+		"new_password":     {"EvenStronger2"},
+		"confirm_password": {"EvenStronger2"},
+	})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected change-password status 200, got %d", response.StatusCode)
+	}
+
+	smokeGET(t, app, laptopCookie, "/dashboard", http.StatusOK)
+	smokeGET(t, app, phoneCookie, "/dashboard", http.StatusSeeOther)
+}
+
+func TestRecoveryCodeResetRevokesExistingSessions(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "session-reset@example.com", "StrongPass1", true)
+	stolenCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+
+	recoveryCode := mustSetRecoveryCodeForUser(t, database, user.ID)
+	resetCookieValue := requestResetCookieByRecoveryCode(t, app, recoveryCode)
+
+	form := url.Values{"password": {"EvenStronger2"}, "confirm_password": {"EvenStronger2"}}
+	request := httptest.NewRequest(http.MethodPost, "/api/auth/reset-password", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Cookie", resetPasswordCookieName+"="+resetCookieValue)
+	response, err := app.Test(request, -1)
+	if err != nil {
+		t.Fatalf("reset-password request failed: %v", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusSeeOther {
+		t.Fatalf("expected reset status 303, got %d", response.StatusCode)
+	}
+
+	smokeGET(t, app, stolenCookie, "/dashboard", http.StatusSeeOther)
+
+	freshCookie := responseCookie(response.Cookies(), authCookieName)
+	if freshCookie == nil {
+		t.Fatal("expected new auth cookie after reset")
+	}
+	smokeGET(t, app, freshCookie.Name+"="+freshCookie.Value, "/dashboard", http.StatusOK)
+	if sessions := listSessionsForTest(t, database, user.ID); len(sessions) != 1 {
+		t.Fatalf("expected only the new session to remain, got %d", len(sessions))
+	}
+}
+
+func TestAuthTokenWithoutSessionIsRejected(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "session-missing-sid@example.com", "StrongPass1", true)
+
+	now := time.Now()
+	claims := authClaims{
+		UserID: user.ID,
+		Role:   user.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatUint(uint64(user.ID), 10),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("test-secret-key"))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+
+	smokeGET(t, app, authCookieName+"="+token, "/dashboard", http.StatusSeeOther)
+}
@@ -40,7 +40,7 @@ func TestBuildCalendarDaysUsesLatestLogPerDateDeterministically(t *testing.T) {
 		},
 	}
 
-	days := handler.buildCalendarDays(monthStart, logs, services.CycleStats{}, now)
+	days := handler.buildCalendarDays(nil, nil, monthStart, logs, services.CycleStats{}, now)
 
 	day17 := findCalendarDayByDateString(t, days, "2026-02-17")
 	if day17.IsPeriod {
@@ -68,7 +68,7 @@ func TestBuildCalendarDaysProjectsOvulationIntoFutureCycles(t *testing.T) {
 		FertilityWindowEnd:   time.Date(2026, time.February, 24, 0, 0, 0, 0, time.UTC),
 	}
 
-	days := handler.buildCalendarDays(monthStart, nil, stats, now)
+	days := handler.buildCalendarDays(nil, nil, monthStart, nil, stats, now)
 
 	ovulationDay := findCalendarDayByDateString(t, days, "2026-03-23")
 	if !ovulationDay.IsOvulation {
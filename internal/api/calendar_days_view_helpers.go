@@ -7,8 +7,9 @@ import (
 	"github.com/terraincognita07/ovumcy/internal/services"
 )
 
-func (handler *Handler) buildCalendarDays(monthStart time.Time, logs []models.DailyLog, stats services.CycleStats, now time.Time) []CalendarDay {
-	states := services.BuildCalendarDayStates(monthStart, logs, stats, now, handler.location)
+func (handler *Handler) buildCalendarDays(viewer *models.User, dataOwner *models.User, monthStart time.Time, logs []models.DailyLog, stats services.CycleStats, now time.Time) []CalendarDay {
+	states := services.BuildCalendarDayStates(dataOwner, monthStart, logs, stats, now, handler.location)
+	services.SanitizeCalendarDayStatesForViewer(viewer, states)
 	days := make([]CalendarDay, 0, len(states))
 	for _, state := range states {
 		cellClass := "calendar-cell"
@@ -17,6 +18,9 @@ func (handler *Handler) buildCalendarDays(monthStart time.Time, logs []models.Da
 		if state.IsPeriod {
 			cellClass += " calendar-cell-period"
 			badgeClass += " calendar-tag-period"
+		} else if state.IsSpotting {
+			cellClass += " calendar-cell-spotting"
+			badgeClass += " calendar-tag-spotting"
 		} else if state.IsPredicted {
 			cellClass += " calendar-cell-predicted"
 			badgeClass += " calendar-tag-predicted"
@@ -26,6 +30,9 @@ func (handler *Handler) buildCalendarDays(monthStart time.Time, logs []models.Da
 		} else if state.IsFertility {
 			cellClass += " calendar-cell-fertile"
 			badgeClass += " calendar-tag-fertile"
+		} else if state.IsPredictionRange {
+			cellClass += " calendar-cell-predicted-range"
+			badgeClass += " calendar-tag-predicted-range"
 		}
 		if !state.InMonth {
 			cellClass += " calendar-cell-out"
@@ -36,20 +43,30 @@ func (handler *Handler) buildCalendarDays(monthStart time.Time, logs []models.Da
 		}
 
 		days = append(days, CalendarDay{
-			Date:         state.Date,
-			DateString:   state.DateString,
-			Day:          state.Day,
-			InMonth:      state.InMonth,
-			IsToday:      state.IsToday,
-			IsPeriod:     state.IsPeriod,
-			IsPredicted:  state.IsPredicted,
-			IsFertility:  state.IsFertility,
-			IsOvulation:  state.IsOvulation,
-			HasData:      state.HasData,
-			CellClass:    cellClass,
-			TextClass:    textClass,
-			BadgeClass:   badgeClass,
-			OvulationDot: state.IsOvulation,
+			Date:              state.Date,
+			DateString:        state.DateString,
+			Day:               state.Day,
+			InMonth:           state.InMonth,
+			IsToday:           state.IsToday,
+			IsPeriod:          state.IsPeriod,
+			IsSpotting:        state.IsSpotting,
+			IsPredicted:       state.IsPredicted,
+			IsPredictionRange: state.IsPredictionRange,
+			IsFertility:       state.IsFertility,
+			IsOvulation:       state.IsOvulation,
+			HasData:           state.HasData,
+			CellClass:         cellClass,
+			TextClass:         textClass,
+			BadgeClass:        badgeClass,
+			OvulationDot:      state.IsOvulation,
+			FertilityStatus:   state.FertilityStatus,
+			FertilityRule:     state.FertilityRule,
+
+			Intimacy:            state.Intimacy,
+			IntimacyUnprotected: state.IntimacyUnprotected,
+
+			CycleStartOverride: state.CycleStartOverride,
+			CycleExcluded:      state.CycleExcluded,
 		})
 	}
 	return days
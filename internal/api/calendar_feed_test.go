@@ -0,0 +1,140 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func enableCalendarFeedForTest(t *testing.T, app *fiber.App, authCookie string, form url.Values) string {
+	t.Helper()
+
+	response := postSessionFormForTest(t, app, authCookie, "/api/settings/calendar-feed", form)
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		t.Fatalf("expected calendar feed status 201, got %d", response.StatusCode)
+	}
+	payload := struct {
+		URL string `json:"url"`
+	}{}
+	if err := json.NewDecoder(response.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode calendar feed response: %v", err)
+	}
+	parsed, err := url.Parse(payload.URL)
+	if err != nil || !strings.HasPrefix(parsed.Path, "/calendar/feed/") || !strings.HasSuffix(parsed.Path, ".ics") {
+		t.Fatalf("unexpected calendar feed url %q", payload.URL)
+	}
+	return parsed.Path
+}
+
+func fetchCalendarFeedForTest(t *testing.T, app *fiber.App, path string) (int, string, http.Header) {
+	t.Helper()
+
+	response, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil), -1)
+	if err != nil {
+		t.Fatalf("calendar feed request failed: %v", err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("read calendar feed: %v", err)
+	}
+	return response.StatusCode, string(body), response.Header
+}
+
+func TestCalendarFeedServesLoggedAndPredictedPeriods(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "calendar-feed@example.com", "StrongPass1", true)
+	authCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+
+	periodStart := time.Now().UTC().AddDate(0, 0, -10)
+	for offset := 0; offset < 3; offset++ {
+		day := time.Date(periodStart.Year(), periodStart.Month(), periodStart.Day()+offset, 0, 0, 0, 0, time.UTC)
+		if err := database.Create(&models.DailyLog{UserID: user.ID, Date: day, IsPeriod: true, Flow: models.FlowMedium, SymptomIDs: []uint{}}).Error; err != nil {
+			t.Fatalf("create period log: %v", err)
+		}
+	}
+
+	feedPath := enableCalendarFeedForTest(t, app, authCookie, url.Values{"cycles": {"3"}, "include_ovulation": {"true"}})
+
+	status, body, header := fetchCalendarFeedForTest(t, app, feedPath)
+	if status != http.StatusOK {
+		t.Fatalf("expected feed status 200, got %d", status)
+	}
+	if !strings.HasPrefix(header.Get(fiber.HeaderContentType), "text/calendar") {
+		t.Fatalf("expected text/calendar content type, got %q", header.Get(fiber.HeaderContentType))
+	}
+	if strings.Count(body, "SUMMARY:Period\r\n") != 1 {
+		t.Fatalf("expected one logged period event, got:\n%s", body)
+	}
+	if strings.Count(body, "SUMMARY:Period (predicted)\r\n") != 3 {
+		t.Fatalf("expected three predicted periods, got:\n%s", body)
+	}
+	if !strings.Contains(body, "SUMMARY:Ovulation (predicted)\r\n") || strings.Contains(body, "Fertile window") {
+		t.Fatalf("expected ovulation without fertile window, got:\n%s", body)
+	}
+
+	update := postSessionFormForTest(t, app, authCookie, "/api/settings/calendar-feed/options", url.Values{"cycles": {"3"}, "neutral_titles": {"true"}})
+	update.Body.Close()
+	if update.StatusCode != http.StatusOK {
+		t.Fatalf("expected options update status 200, got %d", update.StatusCode)
+	}
+	_, neutralBody, _ := fetchCalendarFeedForTest(t, app, feedPath)
+	if strings.Contains(neutralBody, "Period") || strings.Contains(neutralBody, "Ovulation") || !strings.Contains(neutralBody, "SUMMARY:Personal\r\n") {
+		t.Fatalf("expected neutral titles only, got:\n%s", neutralBody)
+	}
+}
+
+func TestCalendarFeedTokenLifecycle(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "calendar-feed-lifecycle@example.com", "StrongPass1", true)
+	authCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+
+	if status, _, _ := fetchCalendarFeedForTest(t, app, "/calendar/feed/unknown-token.ics"); status != http.StatusNotFound {
+		t.Fatalf("expected unknown token status 404, got %d", status)
+	}
+
+	firstPath := enableCalendarFeedForTest(t, app, authCookie, url.Values{})
+	secondPath := enableCalendarFeedForTest(t, app, authCookie, url.Values{})
+	if firstPath == secondPath {
+		t.Fatal("expected a new link after regenerating")
+	}
+	if status, _, _ := fetchCalendarFeedForTest(t, app, firstPath); status != http.StatusNotFound {
+		t.Fatalf("expected old link to stop working, got %d", status)
+	}
+
+	settingsBody := smokeGET(t, app, authCookie, "/settings", http.StatusOK)
+	if !strings.Contains(settingsBody, "data-calendar-feed-options") {
+		t.Fatal("expected feed options form in settings once enabled")
+	}
+
+	invalid := postSessionFormForTest(t, app, authCookie, "/api/settings/calendar-feed/options", url.Values{"cycles": {"40"}})
+	defer invalid.Body.Close()
+	if invalid.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected invalid cycles status 400, got %d", invalid.StatusCode)
+	}
+	if errorValue := readAPIError(t, invalid.Body); errorValue != "invalid calendar feed input" {
+		t.Fatalf("expected invalid calendar feed input, got %q", errorValue)
+	}
+
+	disable := postSessionFormForTest(t, app, authCookie, "/api/settings/calendar-feed/disable", url.Values{})
+	disable.Body.Close()
+	if disable.StatusCode != http.StatusOK {
+		t.Fatalf("expected disable status 200, got %d", disable.StatusCode)
+	}
+	if status, _, _ := fetchCalendarFeedForTest(t, app, secondPath); status != http.StatusNotFound {
+		t.Fatalf("expected disabled feed status 404, got %d", status)
+	}
+}
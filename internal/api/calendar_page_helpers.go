@@ -5,21 +5,29 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
 )
 
 func (handler *Handler) buildCalendarViewData(user *models.User, language string, messages map[string]string, now time.Time, monthStart time.Time, selectedDate string) (fiber.Map, string, error) {
+	dataOwner, err := handler.resolveDataOwner(user)
+	if err != nil {
+		return nil, "failed to load calendar", err
+	}
+
 	logRangeStart, logRangeEnd := calendarLogRange(monthStart)
-	logs, err := handler.fetchLogsForUser(user.ID, logRangeStart, logRangeEnd)
+	logs, err := handler.fetchLogsForUser(dataOwner.ID, logRangeStart, logRangeEnd)
 	if err != nil {
 		return nil, "failed to load calendar", err
 	}
+	sanitizeLogsForViewer(user, logs)
 
-	stats, _, err := handler.buildCycleStatsForRange(user, now.AddDate(-2, 0, 0), now, now)
+	stats, _, err := handler.buildCycleStatsForRange(dataOwner, now.AddDate(-2, 0, 0), now, now)
 	if err != nil {
 		return nil, "failed to load stats", err
 	}
 
-	days := handler.buildCalendarDays(monthStart, logs, stats, now)
+	days := handler.buildCalendarDays(user, dataOwner, monthStart, logs, stats, now)
+	stats = services.SanitizeCycleStatsForViewer(user, stats)
 	prevMonth, nextMonth := calendarAdjacentMonthValues(monthStart)
 
 	data := fiber.Map{
@@ -34,6 +42,7 @@ func (handler *Handler) buildCalendarViewData(user *models.User, language string
 		"Today":        dateAtLocation(now, handler.location).Format("2006-01-02"),
 		"Stats":        stats,
 		"IsOwner":      isOwnerUser(user),
+		"ShowIntimacy": services.IntimacyVisibleForViewer(user),
 	}
 	return data, "", nil
 }
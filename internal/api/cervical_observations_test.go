@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func TestUpsertDayStoresCervicalObservations(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "mucus-upsert@example.com", "StrongPass1", true)
+	authCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+	handler := &Handler{db: database, location: time.UTC}
+
+	form := url.Values{
+		"flow":            {models.FlowNone},
+		"mucus":           {"egg_white"},
+		"cervix_position": {"high"},
+		"cervix_firmness": {"soft"},
+	}
+	request := httptest.NewRequest(http.MethodPost, "/api/days/2026-02-19", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Cookie", authCookie)
+	response, err := app.Test(request, -1)
+	if err != nil {
+		t.Fatalf("upsert request failed: %v", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+	entry := loadDayLogForTest(t, handler, user.ID, "2026-02-19")
+	if entry.Mucus != models.MucusEggWhite || entry.CervixPosition != models.CervixPositionHigh || entry.CervixFirmness != models.CervixFirmnessSoft {
+		t.Fatalf("expected stored observations, got %#v", entry)
+	}
+
+	response = postDayJSONForTest(t, app, authCookie, "2026-02-19", map[string]any{"flow": models.FlowNone, "notes": "kept"})
+	response.Body.Close()
+	entry = loadDayLogForTest(t, handler, user.ID, "2026-02-19")
+	if entry.Mucus != models.MucusEggWhite || entry.Notes != "kept" {
+		t.Fatalf("expected save without observation fields to keep them, got %#v", entry)
+	}
+
+	response = postDayJSONForTest(t, app, authCookie, "2026-02-19", map[string]any{"flow": models.FlowNone, "mucus": "sticky"})
+	response.Body.Close()
+	entry = loadDayLogForTest(t, handler, user.ID, "2026-02-19")
+	if entry.Mucus != models.MucusSticky || entry.CervixPosition != "" || entry.CervixFirmness != "" {
+		t.Fatalf("expected observations to be replaced together, got %#v", entry)
+	}
+
+	for _, payload := range []map[string]any{
+		{"mucus": "slippery"},
+		{"cervix_position": "sideways"},
+	} {
+		payload["flow"] = models.FlowNone
+		response = postDayJSONForTest(t, app, authCookie, "2026-02-19", payload)
+		if response.StatusCode != http.StatusBadRequest {
+			t.Fatalf("expected status 400 for %#v, got %d", payload, response.StatusCode)
+		}
+		if message := readAPIError(t, response.Body); message != "invalid mucus value" && message != "invalid cervix value" {
+			t.Fatalf("expected observation error for %#v, got %q", payload, message)
+		}
+		response.Body.Close()
+	}
+}
+
+func TestCervicalObservationsHiddenFromPartner(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "mucus-owner@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+
+	day := time.Date(2026, time.February, 12, 0, 0, 0, 0, time.UTC)
+	entry := models.DailyLog{UserID: owner.ID, Date: day, IsPeriod: true, Flow: models.FlowLight, Mucus: models.MucusCreamy, CervixPosition: models.CervixPositionLow}
+	if err := database.Create(&entry).Error; err != nil {
+		t.Fatalf("create log: %v", err)
+	}
+
+	partner := createLinkedPartnerForTest(t, app, database, ownerCookie, "mucus-partner@example.com")
+	partnerCookie := loginAndExtractAuthCookie(t, app, partner.Email, "StrongPass1")
+	body := smokeGET(t, app, partnerCookie, "/api/days?from=2026-02-01&to=2026-02-28", http.StatusOK)
+	logs := []models.DailyLog{}
+	if err := json.Unmarshal([]byte(body), &logs); err != nil {
+		t.Fatalf("decode partner days: %v", err)
+	}
+	if len(logs) != 1 || logs[0].Mucus != "" || logs[0].CervixPosition != "" {
+		t.Fatalf("expected observations hidden from partner, got %#v", logs)
+	}
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func TestContraceptionProfileSuppressesOvulation(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "contraception@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+	handler := &Handler{db: database, location: time.UTC}
+
+	today := services.DateAtLocation(time.Now().UTC(), time.UTC)
+	start := today.AddDate(0, 0, -30)
+	response := postSessionFormForTest(t, app, ownerCookie, "/api/settings/contraception", url.Values{
+		"method":       {"combined_pill"},
+		"pack_length":  {"28"},
+		"placebo_days": {"7"},
+		"start":        {start.Format("2006-01-02")},
+	})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+
+	stored := models.User{}
+	if err := database.First(&stored, owner.ID).Error; err != nil {
+		t.Fatalf("load owner: %v", err)
+	}
+	if stored.Contraception.Method != models.ContraceptionCombinedPill || stored.Contraception.Start == nil {
+		t.Fatalf("expected stored contraception profile, got %#v", stored.Contraception)
+	}
+
+	// Day 23 of the first pack is a placebo day, so this bleed must not
+	// become a cycle start.
+	placeboDay := start.AddDate(0, 0, 22).Format("2006-01-02")
+	response = postDayJSONForTest(t, app, ownerCookie, placeboDay, map[string]any{"is_period": true, "flow": models.FlowMedium})
+	response.Body.Close()
+
+	form := url.Values{"flow": {models.FlowNone}, "pill_taken": {"false", "true"}}
+	response = postSessionFormForTest(t, app, ownerCookie, "/api/days/"+today.Format("2006-01-02"), form)
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+	if entry := loadDayLogForTest(t, handler, owner.ID, today.Format("2006-01-02")); !entry.PillTaken {
+		t.Fatalf("expected pill taken to be stored, got %#v", entry)
+	}
+
+	body := smokeGET(t, app, ownerCookie, "/api/stats/overview", http.StatusOK)
+	if !strings.Contains(body, `"contraception_active":true`) || !strings.Contains(body, `"last_withdrawal_bleed":"`+placeboDay) {
+		t.Fatalf("expected active contraception with the withdrawal bleed, got %s", body)
+	}
+	if body := smokeGET(t, app, ownerCookie, "/api/predictions", http.StatusOK); !strings.Contains(body, `"predictions":[]`) {
+		t.Fatalf("expected no natural-cycle predictions, got %s", body)
+	}
+
+	body = smokeGET(t, app, ownerCookie, "/dashboard", http.StatusOK)
+	if !strings.Contains(body, "data-pill-pack") || !strings.Contains(body, "Pack day") || !strings.Contains(body, "Suppressed") {
+		t.Fatal("expected pack grid and suppressed ovulation on the dashboard")
+	}
+	if !strings.Contains(body, `name="pill_taken" value="true" checked`) {
+		t.Fatal("expected pill taken checkbox to be checked")
+	}
+
+	partner := createLinkedPartnerForTest(t, app, database, ownerCookie, "contraception-partner@example.com")
+	partnerCookie := loginAndExtractAuthCookie(t, app, partner.Email, "StrongPass1")
+	if body := smokeGET(t, app, partnerCookie, "/dashboard", http.StatusOK); strings.Contains(body, "data-pill-pack") {
+		t.Fatal("expected no pack grid for partner")
+	}
+}
+
+func TestContraceptionRejectsInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "contraception-invalid@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format("2006-01-02")
+
+	for _, testCase := range []struct {
+		form    url.Values
+		message string
+	}{
+		{form: url.Values{"method": {"implant"}, "pack_length": {"28"}, "placebo_days": {"7"}}, message: "invalid contraception method"},
+		{form: url.Values{"method": {"combined_pill"}, "pack_length": {"10"}, "placebo_days": {"7"}}, message: "invalid pack length"},
+		{form: url.Values{"method": {"combined_pill"}, "pack_length": {"28"}, "placebo_days": {"7"}}, message: "contraception start required"},
+		{form: url.Values{"method": {"combined_pill"}, "pack_length": {"28"}, "placebo_days": {"7"}, "start": {tomorrow}}, message: "invalid contraception start"},
+	} {
+		response := postSessionFormForTest(t, app, ownerCookie, "/api/settings/contraception", testCase.form)
+		if response.StatusCode != http.StatusBadRequest {
+			t.Fatalf("expected status 400 for %v, got %d", testCase.form, response.StatusCode)
+		}
+		if message := readAPIError(t, response.Body); message != testCase.message {
+			t.Fatalf("expected %q, got %q", testCase.message, message)
+		}
+		response.Body.Close()
+	}
+}
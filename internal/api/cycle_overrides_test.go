@@ -0,0 +1,91 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func TestCycleOverridesCorrectStartsAndExcludeCycles(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "cycle-overrides@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+
+	today := services.DateAtLocation(time.Now().UTC(), time.UTC)
+	for _, daysAgo := range []int{84, 83, 56, 55, 28, 27} {
+		entry := models.DailyLog{UserID: owner.ID, Date: today.AddDate(0, 0, -daysAgo), IsPeriod: true, Flow: models.FlowMedium}
+		if err := database.Create(&entry).Error; err != nil {
+			t.Fatalf("create period log: %v", err)
+		}
+	}
+
+	excludedDay := today.AddDate(0, 0, -50)
+	response := postSessionFormForTest(t, app, ownerCookie, "/api/days/"+excludedDay.Format("2006-01-02"), url.Values{
+		"is_period":            {"false"},
+		"cycle_start_override": {""},
+		"cycle_exclusion":      {models.CycleExclusionIllness},
+	})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+
+	stored := models.DailyLog{}
+	if err := database.Where("user_id = ? AND cycle_exclusion = ?", owner.ID, models.CycleExclusionIllness).First(&stored).Error; err != nil {
+		t.Fatalf("expected the exclusion to be stored: %v", err)
+	}
+
+	statsBody := smokeGET(t, app, ownerCookie, "/stats", http.StatusOK)
+	if !strings.Contains(statsBody, `id="excluded-cycles-section"`) ||
+		!strings.Contains(statsBody, `data-excluded-cycle="`+today.AddDate(0, 0, -56).Format("2006-01-02")+`"`) ||
+		!strings.Contains(statsBody, `data-excluded-reason="illness"`) {
+		t.Fatal("expected the excluded cycle on the stats page")
+	}
+	calendarBody := smokeGET(t, app, ownerCookie, "/calendar?month="+excludedDay.Format("2006-01"), http.StatusOK)
+	if !strings.Contains(calendarBody, "data-cycle-excluded") {
+		t.Fatal("expected the exclusion marker on the calendar")
+	}
+
+	response = postDayJSONForTest(t, app, ownerCookie, today.AddDate(0, 0, -28).Format("2006-01-02"), map[string]any{
+		"is_period":            true,
+		"flow":                 models.FlowMedium,
+		"cycle_start_override": models.CycleStartOverrideNotStart,
+	})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+	refreshed := models.User{}
+	if err := database.First(&refreshed, owner.ID).Error; err != nil {
+		t.Fatalf("load user: %v", err)
+	}
+	if refreshed.LastPeriodStart == nil || services.DateAtLocation(*refreshed.LastPeriodStart, time.UTC).Format("2006-01-02") != today.AddDate(0, 0, -56).Format("2006-01-02") {
+		t.Fatalf("expected the overridden day not to start a cycle, got %v", refreshed.LastPeriodStart)
+	}
+
+	response = postDayJSONForTest(t, app, ownerCookie, today.Format("2006-01-02"), map[string]any{
+		"is_period":       false,
+		"cycle_exclusion": "holiday",
+	})
+	if response.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", response.StatusCode)
+	}
+	if message := readAPIError(t, response.Body); message != "invalid cycle override value" {
+		t.Fatalf("expected cycle override error, got %q", message)
+	}
+	response.Body.Close()
+
+	partner := createLinkedPartnerForTest(t, app, database, ownerCookie, "cycle-overrides-partner@example.com")
+	partnerCookie := loginAndExtractAuthCookie(t, app, partner.Email, "StrongPass1")
+	partnerDays := smokeGET(t, app, partnerCookie, "/api/days?from="+excludedDay.Format("2006-01-02")+"&to="+excludedDay.Format("2006-01-02"), http.StatusOK)
+	if strings.Contains(partnerDays, models.CycleExclusionIllness) || !strings.Contains(partnerDays, `"CycleExclusion":"other"`) {
+		t.Fatalf("expected the exclusion reason to be hidden from partners, got %s", partnerDays)
+	}
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func TestBuildCalendarDaysCarriesObservedFertilityStatus(t *testing.T) {
+	t.Parallel()
+
+	handler := &Handler{location: time.UTC}
+	monthStart := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, time.March, 4, 0, 0, 0, 0, time.UTC)
+	logs := []models.DailyLog{
+		{Date: time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC), IsPeriod: true, Flow: models.FlowMedium, Mucus: models.MucusDry},
+		{Date: time.Date(2026, time.March, 3, 0, 0, 0, 0, time.UTC), Flow: models.FlowNone, Mucus: models.MucusCreamy},
+	}
+
+	owner := &models.User{Role: models.RoleOwner}
+	days := handler.buildCalendarDays(owner, owner, monthStart, logs, services.CycleStats{}, now)
+	day2 := findCalendarDayByDateString(t, days, "2026-03-02")
+	if day2.FertilityStatus != services.FertilityStatusInfertile || day2.FertilityRule != services.FertilityRuleFirstFiveDays {
+		t.Fatalf("expected 2026-03-02 observed infertile, got %#v", day2)
+	}
+	day3 := findCalendarDayByDateString(t, days, "2026-03-03")
+	if day3.FertilityStatus != services.FertilityStatusFertile || day3.FertilityRule != services.FertilityRuleMucusObserved {
+		t.Fatalf("expected 2026-03-03 observed fertile, got %#v", day3)
+	}
+
+	partner := &models.User{Role: models.RolePartner}
+	days = handler.buildCalendarDays(partner, owner, monthStart, logs, services.CycleStats{}, now)
+	if day3 := findCalendarDayByDateString(t, days, "2026-03-03"); day3.FertilityStatus != "" || day3.FertilityRule != "" {
+		t.Fatalf("expected observed status hidden from partner, got %#v", day3)
+	}
+}
+
+func TestCalendarPageRendersObservedFertilityStatus(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "fertility-status@example.com", "StrongPass1", true)
+	authCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+
+	today := services.DateAtLocation(time.Now().UTC(), time.UTC)
+	entry := models.DailyLog{UserID: user.ID, Date: today, IsPeriod: true, Flow: models.FlowMedium, Mucus: models.MucusDry}
+	if err := database.Create(&entry).Error; err != nil {
+		t.Fatalf("create log: %v", err)
+	}
+
+	body := smokeGET(t, app, authCookie, "/calendar?month="+today.Format("2006-01"), http.StatusOK)
+	if !strings.Contains(body, `data-fertility-status="infertile" data-fertility-rule="first_five_days"`) {
+		t.Fatalf("expected observed infertile marker for today")
+	}
+	if !strings.Contains(body, "Observed infertile: first five cycle days without mucus") {
+		t.Fatalf("expected translated rule in marker title")
+	}
+	if !strings.Contains(body, "Observed fertile (temperature and mucus)") {
+		t.Fatalf("expected observed status legend")
+	}
+}
@@ -11,12 +11,22 @@ func (handler *Handler) withDependencies(database *gorm.DB) *Handler {
 	handler.authService = services.NewAuthService(handler.repositories.Users)
 	handler.dayService = services.NewDayService(handler.repositories.DailyLogs, handler.repositories.Users)
 	handler.symptomService = services.NewSymptomService(handler.repositories.Symptoms, handler.repositories.DailyLogs)
+	handler.medicationService = services.NewMedicationService(handler.repositories.Medications)
+	handler.metricService = services.NewMetricService(handler.repositories.Metrics)
 	handler.statsService = services.NewStatsService(handler.dayService, handler.symptomService)
-	handler.exportService = services.NewExportService(handler.dayService, handler.symptomService)
+	handler.exportService = services.NewExportService(handler.dayService, handler.symptomService, handler.medicationService, handler.metricService)
 	handler.settingsService = services.NewSettingsService(handler.repositories.Users)
 	handler.notificationService = services.NewNotificationService()
 	handler.onboardingSvc = services.NewOnboardingService(handler.repositories.Users)
 	handler.setupService = services.NewSetupService(handler.repositories.Users)
+	handler.partnerService = services.NewPartnerService(handler.repositories.Partners, handler.repositories.Users)
+	handler.registrationService = services.NewRegistrationService(handler.registrationMode, handler.repositories.Users, handler.repositories.RegistrationInvites)
+	handler.sessionService = services.NewSessionService(handler.repositories.Sessions)
+	handler.twoFactorService = services.NewTwoFactorService(handler.repositories.Users)
+	handler.apiTokenService = services.NewAPITokenService(handler.repositories.APITokens)
+	handler.importService = services.NewImportService(newImportTransactor(handler.repositories))
+	handler.calendarFeedService = services.NewCalendarFeedService(handler.repositories.CalendarFeeds)
+	handler.predictionHistory = services.NewPredictionHistoryService(handler.repositories.PredictionSnapshots, handler.dayService)
 	return handler
 }
 
@@ -37,11 +47,17 @@ func (handler *Handler) ensureDependencies() {
 	if handler.symptomService == nil {
 		handler.symptomService = services.NewSymptomService(handler.repositories.Symptoms, handler.repositories.DailyLogs)
 	}
+	if handler.medicationService == nil {
+		handler.medicationService = services.NewMedicationService(handler.repositories.Medications)
+	}
+	if handler.metricService == nil {
+		handler.metricService = services.NewMetricService(handler.repositories.Metrics)
+	}
 	if handler.statsService == nil {
 		handler.statsService = services.NewStatsService(handler.dayService, handler.symptomService)
 	}
 	if handler.exportService == nil {
-		handler.exportService = services.NewExportService(handler.dayService, handler.symptomService)
+		handler.exportService = services.NewExportService(handler.dayService, handler.symptomService, handler.medicationService, handler.metricService)
 	}
 	if handler.settingsService == nil {
 		handler.settingsService = services.NewSettingsService(handler.repositories.Users)
@@ -55,4 +71,45 @@ func (handler *Handler) ensureDependencies() {
 	if handler.setupService == nil {
 		handler.setupService = services.NewSetupService(handler.repositories.Users)
 	}
+	if handler.partnerService == nil {
+		handler.partnerService = services.NewPartnerService(handler.repositories.Partners, handler.repositories.Users)
+	}
+	if handler.registrationService == nil {
+		handler.registrationService = services.NewRegistrationService(handler.registrationMode, handler.repositories.Users, handler.repositories.RegistrationInvites)
+	}
+	if handler.sessionService == nil {
+		handler.sessionService = services.NewSessionService(handler.repositories.Sessions)
+	}
+	if handler.twoFactorService == nil {
+		handler.twoFactorService = services.NewTwoFactorService(handler.repositories.Users)
+	}
+	if handler.apiTokenService == nil {
+		handler.apiTokenService = services.NewAPITokenService(handler.repositories.APITokens)
+	}
+	if handler.importService == nil {
+		handler.importService = services.NewImportService(newImportTransactor(handler.repositories))
+	}
+	if handler.calendarFeedService == nil {
+		handler.calendarFeedService = services.NewCalendarFeedService(handler.repositories.CalendarFeeds)
+	}
+	if handler.predictionHistory == nil {
+		handler.predictionHistory = services.NewPredictionHistoryService(handler.repositories.PredictionSnapshots, handler.dayService)
+	}
+}
+
+// SetRegistrationMode applies the REGISTRATION_MODE policy to sign-up requests.
+func (handler *Handler) SetRegistrationMode(mode services.RegistrationMode) {
+	handler.registrationMode = mode
+	handler.registrationService = nil
+	handler.ensureDependencies()
+}
+
+// newImportTransactor runs each import in one transaction so a failed write
+// leaves no partial import behind.
+func newImportTransactor(repositories *db.Repositories) services.ImportTransactor {
+	return func(fn func(stores services.ImportStores) error) error {
+		return repositories.Transaction(func(tx *db.Repositories) error {
+			return fn(services.NewImportStores(tx.DailyLogs, tx.Users, tx.Symptoms, tx.Medications, tx.Metrics))
+		})
+	}
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/terraincognita07/ovumcy/internal/db"
 	"github.com/terraincognita07/ovumcy/internal/i18n"
+	"github.com/terraincognita07/ovumcy/internal/models"
 	"github.com/terraincognita07/ovumcy/internal/services"
 	"gorm.io/gorm"
 )
@@ -18,10 +19,12 @@ type Handler struct {
 	secretKey           []byte
 	location            *time.Location
 	cookieSecure        bool
+	registrationMode    services.RegistrationMode
 	i18n                *i18n.Manager
 	templates           map[string]*template.Template
 	partials            map[string]*template.Template
 	recoveryLimiter     *attemptLimiter
+	twoFactorLimiter    *attemptLimiter
 	repositories        *db.Repositories
 	authService         *services.AuthService
 	dayService          *services.DayService
@@ -32,23 +35,44 @@ type Handler struct {
 	notificationService *services.NotificationService
 	onboardingSvc       *services.OnboardingService
 	setupService        *services.SetupService
+	partnerService      *services.PartnerService
+	registrationService *services.RegistrationService
+	sessionService      *services.SessionService
+	twoFactorService    *services.TwoFactorService
+	apiTokenService     *services.APITokenService
+	importService       *services.ImportService
+	calendarFeedService *services.CalendarFeedService
+	predictionHistory   *services.PredictionHistoryService
+	medicationService   *services.MedicationService
+	metricService       *services.MetricService
 }
 
 type CalendarDay struct {
-	Date         time.Time
-	DateString   string
-	Day          int
-	InMonth      bool
-	IsToday      bool
-	IsPeriod     bool
-	IsPredicted  bool
-	IsFertility  bool
-	IsOvulation  bool
-	HasData      bool
-	CellClass    string
-	TextClass    string
-	BadgeClass   string
-	OvulationDot bool
+	Date        time.Time
+	DateString  string
+	Day         int
+	InMonth     bool
+	IsToday     bool
+	IsPeriod    bool
+	IsSpotting  bool
+	IsPredicted bool
+	// IsPredictionRange marks days inside the uncertainty range of a
+	// predicted period start.
+	IsPredictionRange bool
+	IsFertility       bool
+	IsOvulation       bool
+	HasData           bool
+	CellClass         string
+	TextClass         string
+	BadgeClass        string
+	OvulationDot      bool
+	FertilityStatus   string
+	FertilityRule     string
+	// Intimacy is only set for owners who track it.
+	Intimacy            bool
+	IntimacyUnprotected bool
+	CycleStartOverride  string
+	CycleExcluded       bool
 }
 
 type SymptomCount struct {
@@ -56,9 +80,46 @@ type SymptomCount struct {
 	Icon             string
 	Count            int
 	TotalDays        int
+	AverageSeverity  float64
 	FrequencySummary string
 }
 
+type PartnerSharingView struct {
+	Partner         models.User
+	SharedSymptomID map[uint]bool
+}
+
+type TwoFactorSettingsView struct {
+	Enabled bool
+	Pending bool
+	Secret  string
+	URI     string
+}
+
+type SessionView struct {
+	ID        uint
+	UserAgent string
+	IPAddress string
+	LastSeen  string
+	Current   bool
+}
+
+type APITokenView struct {
+	ID        uint
+	Name      string
+	ReadWrite bool
+	CreatedAt string
+	LastUsed  string
+}
+
+type ImportPreviewView struct {
+	Format        string
+	Mode          string
+	Document      string
+	Report        services.ImportReport
+	ConflictDates []string
+}
+
 type FlashPayload struct {
 	AuthError       string `json:"auth_error,omitempty"`
 	SettingsError   string `json:"settings_error,omitempty"`
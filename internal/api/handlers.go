@@ -29,14 +29,15 @@ func NewHandler(database *gorm.DB, secret string, templateDir string, location *
 	}
 
 	handler := &Handler{
-		db:              database,
-		secretKey:       []byte(secret),
-		location:        location,
-		cookieSecure:    cookieSecure,
-		i18n:            i18nManager,
-		templates:       templates,
-		partials:        partials,
-		recoveryLimiter: newAttemptLimiter(),
+		db:               database,
+		secretKey:        []byte(secret),
+		location:         location,
+		cookieSecure:     cookieSecure,
+		i18n:             i18nManager,
+		templates:        templates,
+		partials:         partials,
+		recoveryLimiter:  newAttemptLimiter(),
+		twoFactorLimiter: newAttemptLimiter(),
 	}
 	return handler.withDependencies(database), nil
 }
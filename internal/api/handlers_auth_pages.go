@@ -16,8 +16,14 @@ func (handler *Handler) ShowLoginPage(c *fiber.Ctx) error {
 		return apiError(c, fiber.StatusInternalServerError, "failed to load setup state")
 	}
 
+	signUpClosed, err := handler.publicSignUpClosed()
+	if err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to load setup state")
+	}
+
 	flash := handler.popFlashCookie(c)
 	data := buildLoginPageData(c, currentMessages(c), flash, needsSetup)
+	data["HideSignUpLink"] = signUpClosed
 	return handler.render(c, "login", data)
 }
 
@@ -37,6 +43,10 @@ func (handler *Handler) ShowRegisterPage(c *fiber.Ctx) error {
 
 	flash := handler.popFlashCookie(c)
 	data := buildRegisterPageData(c, currentMessages(c), flash, needsSetup)
+	handler.applyRegisterPartnerInvite(c, data)
+	if err := handler.applyRegisterPolicy(c, data); err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to load setup state")
+	}
 	return handler.render(c, "register", data)
 }
 
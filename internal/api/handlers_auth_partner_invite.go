@@ -0,0 +1,36 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/db"
+)
+
+func (handler *Handler) registerPartnerFromInvite(c *fiber.Ctx, credentials credentialsInput) error {
+	handler.ensureDependencies()
+	now := time.Now().In(handler.location)
+
+	invite, err := handler.partnerService.ResolveInvite(credentials.InviteToken, now)
+	if err != nil {
+		return handler.respondAuthError(c, fiber.StatusBadRequest, "invalid partner invite")
+	}
+
+	user, recoveryCode, err := handler.authService.BuildOwnerUserWithRecovery(credentials.Email, credentials.Password, now)
+	if err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to create account")
+	}
+	if err := handler.partnerService.AcceptInvite(invite, &user, now); err != nil {
+		if errors.Is(err, db.ErrPartnerInviteUnavailable) {
+			return handler.respondAuthError(c, fiber.StatusBadRequest, "invalid partner invite")
+		}
+		return handler.respondAuthError(c, fiber.StatusConflict, "email already exists")
+	}
+
+	if err := handler.setAuthCookie(c, &user, true); err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to create session")
+	}
+
+	return handler.renderRecoveryCodeResponse(c, &user, recoveryCode, fiber.StatusCreated)
+}
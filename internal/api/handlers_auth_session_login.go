@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/db"
+	"github.com/terraincognita07/ovumcy/internal/models"
 	"github.com/terraincognita07/ovumcy/internal/services"
 )
 
@@ -15,6 +17,15 @@ func (handler *Handler) Register(c *fiber.Ctx) error {
 	}
 
 	handler.ensureDependencies()
+	now := time.Now().In(handler.location)
+	grant := services.RegistrationGrant{}
+	if credentials.InviteToken == "" {
+		grant, err = handler.registrationService.AuthorizeSignUp(credentials.RegistrationToken, now)
+		if err != nil {
+			return handler.respondRegistrationPolicyError(c, err)
+		}
+	}
+
 	if err := handler.authService.ValidateRegistrationCredentials(credentials.Password, credentials.ConfirmPassword); err != nil {
 		switch {
 		case errors.Is(err, services.ErrAuthPasswordMismatch):
@@ -34,11 +45,18 @@ func (handler *Handler) Register(c *fiber.Ctx) error {
 		return handler.respondAuthError(c, fiber.StatusConflict, "email already exists")
 	}
 
-	user, recoveryCode, err := handler.authService.BuildOwnerUserWithRecovery(credentials.Email, credentials.Password, time.Now().In(handler.location))
+	if credentials.InviteToken != "" {
+		return handler.registerPartnerFromInvite(c, credentials)
+	}
+
+	user, recoveryCode, err := handler.authService.BuildOwnerUserWithRecovery(credentials.Email, credentials.Password, now)
 	if err != nil {
 		return apiError(c, fiber.StatusInternalServerError, "failed to create account")
 	}
-	if err := handler.authService.CreateUser(&user); err != nil {
+	if err := handler.registrationService.CreateUser(&user, grant, now); err != nil {
+		if errors.Is(err, db.ErrRegistrationInviteUnavailable) {
+			return handler.respondAuthError(c, fiber.StatusBadRequest, "invalid registration invite")
+		}
 		return handler.respondAuthError(c, fiber.StatusConflict, "email already exists")
 	}
 
@@ -65,12 +83,27 @@ func (handler *Handler) Login(c *fiber.Ctx) error {
 		return handler.respondAuthError(c, fiber.StatusUnauthorized, "invalid credentials")
 	}
 
+	if services.IsTwoFactorEnabled(&user) {
+		if err := handler.setTwoFactorChallengeCookie(c, &user, credentials.RememberMe); err != nil {
+			return apiError(c, fiber.StatusInternalServerError, "failed to create session")
+		}
+		if acceptsJSON(c) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "two factor required",
+			})
+		}
+		return redirectToPath(c, "/login/two-factor")
+	}
+
+	return handler.completeLogin(c, &user, credentials.RememberMe)
+}
+
+// completeLogin finishes a login once every required factor has been checked.
+func (handler *Handler) completeLogin(c *fiber.Ctx, user *models.User, rememberMe bool) error {
 	if user.MustChangePassword {
-		token, err := handler.buildPasswordResetToken(user.ID, user.PasswordHash, 30*time.Minute)
-		if err != nil {
+		if err := handler.setForcedPasswordChangeCookie(c, user); err != nil {
 			return apiError(c, fiber.StatusInternalServerError, "failed to create reset token")
 		}
-		handler.setResetPasswordCookie(c, token, true)
 		if acceptsJSON(c) {
 			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
 				"error": "password change required",
@@ -79,14 +112,35 @@ func (handler *Handler) Login(c *fiber.Ctx) error {
 		return redirectToPath(c, buildResetPasswordPath())
 	}
 
-	if err := handler.setAuthCookie(c, &user, credentials.RememberMe); err != nil {
+	if err := handler.setAuthCookie(c, user, rememberMe); err != nil {
 		return apiError(c, fiber.StatusInternalServerError, "failed to create session")
 	}
 
-	return redirectOrJSON(c, postLoginRedirectPath(&user))
+	return redirectOrJSON(c, postLoginRedirectPath(user))
+}
+
+// setForcedPasswordChangeCookie lets a user whose password must be changed
+// reach the reset form without a session.
+func (handler *Handler) setForcedPasswordChangeCookie(c *fiber.Ctx, user *models.User) error {
+	token, err := handler.buildPasswordResetToken(user.ID, user.PasswordHash, 30*time.Minute)
+	if err != nil {
+		return err
+	}
+	handler.setResetPasswordCookie(c, token, true)
+	return nil
 }
 
 func (handler *Handler) Logout(c *fiber.Ctx) error {
+	if user, ok := currentUser(c); ok {
+		if session, ok := currentSession(c); ok {
+			handler.ensureDependencies()
+			_ = handler.sessionService.Revoke(user.ID, session.ID)
+		}
+	}
+	return handler.respondSignedOut(c)
+}
+
+func (handler *Handler) respondSignedOut(c *fiber.Ctx) error {
 	handler.clearAuthCookie(c)
 	handler.clearRecoveryCodePageCookie(c)
 	handler.clearResetPasswordCookie(c)
@@ -80,6 +80,9 @@ func (handler *Handler) ResetPassword(c *fiber.Ctx) error {
 	if err != nil {
 		return apiError(c, fiber.StatusInternalServerError, "failed to reset password")
 	}
+	if err := handler.sessionService.RevokeOthers(user.ID, 0); err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to reset password")
+	}
 
 	if err := handler.setAuthCookie(c, user, true); err != nil {
 		return apiError(c, fiber.StatusInternalServerError, "failed to create session")
@@ -9,6 +9,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
 )
 
 func (handler *Handler) setAuthCookie(c *fiber.Ctx, user *models.User, rememberMe bool) error {
@@ -17,7 +18,16 @@ func (handler *Handler) setAuthCookie(c *fiber.Ctx, user *models.User, rememberM
 		tokenTTL = rememberAuthTokenTTL
 	}
 
-	token, err := handler.buildToken(user, tokenTTL)
+	handler.ensureDependencies()
+	sessionToken, _, err := handler.sessionService.Start(user.ID, services.SessionClient{
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+		IPAddress: c.IP(),
+	}, tokenTTL, time.Now())
+	if err != nil {
+		return err
+	}
+
+	token, err := handler.buildToken(user, sessionToken, tokenTTL)
 	if err != nil {
 		return err
 	}
@@ -53,15 +63,16 @@ func (handler *Handler) clearAuthCookie(c *fiber.Ctx) {
 	})
 }
 
-func (handler *Handler) buildToken(user *models.User, ttl time.Duration) (string, error) {
+func (handler *Handler) buildToken(user *models.User, sessionToken string, ttl time.Duration) (string, error) {
 	if ttl <= 0 {
 		ttl = defaultAuthTokenTTL
 	}
 	now := time.Now()
 
 	claims := authClaims{
-		UserID: user.ID,
-		Role:   user.Role,
+		UserID:    user.ID,
+		Role:      user.Role,
+		SessionID: sessionToken,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   strconv.FormatUint(uint64(user.ID), 10),
 			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func (handler *Handler) ShowTwoFactorPage(c *fiber.Ctx) error {
+	if _, ok := handler.readTwoFactorChallengeCookie(c); !ok {
+		return c.Redirect("/login", fiber.StatusSeeOther)
+	}
+
+	flash := handler.popFlashCookie(c)
+	data := buildTwoFactorPageData(c, currentMessages(c), flash)
+	return handler.render(c, "two_factor", data)
+}
+
+func (handler *Handler) VerifyTwoFactorLogin(c *fiber.Ctx) error {
+	const twoFactorAttemptsLimit = 8
+	const twoFactorAttemptsWindow = 15 * time.Minute
+
+	now := time.Now()
+	limiterKey := requestLimiterKey(c)
+	if handler.twoFactorLimiter.tooManyRecent(limiterKey, now, twoFactorAttemptsLimit, twoFactorAttemptsWindow) {
+		return handler.respondAuthError(c, fiber.StatusTooManyRequests, "too many two factor attempts")
+	}
+
+	challenge, ok := handler.readTwoFactorChallengeCookie(c)
+	if !ok {
+		return handler.respondTwoFactorChallengeExpired(c)
+	}
+
+	input := twoFactorCodeInput{}
+	if err := c.BodyParser(&input); err != nil {
+		return handler.respondAuthError(c, fiber.StatusBadRequest, "invalid input")
+	}
+
+	handler.ensureDependencies()
+	user, err := handler.authService.FindByID(challenge.UserID)
+	if err != nil || !services.IsPasswordStateFingerprintMatch(challenge.PasswordState, user.PasswordHash) {
+		return handler.respondTwoFactorChallengeExpired(c)
+	}
+
+	result, err := handler.twoFactorService.VerifyLogin(&user, input.Code, now)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrTwoFactorCodeInvalid):
+			handler.twoFactorLimiter.addFailure(limiterKey, now, twoFactorAttemptsWindow)
+			return handler.respondAuthError(c, fiber.StatusUnauthorized, "invalid two factor code")
+		case errors.Is(err, services.ErrTwoFactorNotEnabled):
+			return handler.respondTwoFactorChallengeExpired(c)
+		default:
+			return apiError(c, fiber.StatusInternalServerError, "failed to verify two factor code")
+		}
+	}
+
+	handler.twoFactorLimiter.reset(limiterKey)
+	handler.clearTwoFactorChallengeCookie(c)
+
+	if result.UsedRecoveryCode && user.MustChangePassword {
+		return handler.renderForcedPasswordChangeRecoveryCode(c, &user, result.RecoveryCode)
+	}
+	if result.UsedRecoveryCode {
+		if err := handler.setAuthCookie(c, &user, challenge.RememberMe); err != nil {
+			return apiError(c, fiber.StatusInternalServerError, "failed to create session")
+		}
+		return handler.renderRecoveryCodeResponse(c, &user, result.RecoveryCode, fiber.StatusOK)
+	}
+	return handler.completeLogin(c, &user, challenge.RememberMe)
+}
+
+// renderForcedPasswordChangeRecoveryCode shows the code that replaced the
+// used recovery code before the forced password change. No session is
+// created, so the page is rendered directly and continues to the reset form.
+func (handler *Handler) renderForcedPasswordChangeRecoveryCode(c *fiber.Ctx, user *models.User, recoveryCode string) error {
+	if err := handler.setForcedPasswordChangeCookie(c, user); err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to create reset token")
+	}
+	if acceptsJSON(c) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":         "password change required",
+			"recovery_code": recoveryCode,
+		})
+	}
+
+	return handler.render(c, "recovery_code", fiber.Map{
+		"Title":          localizedPageTitle(currentMessages(c), "meta.title.recovery_code", "Ovumcy | Recovery Code"),
+		"RecoveryCode":   recoveryCode,
+		"ContinuePath":   buildResetPasswordPath(),
+		"HideNavigation": true,
+	})
+}
+
+func (handler *Handler) respondTwoFactorChallengeExpired(c *fiber.Ctx) error {
+	handler.clearTwoFactorChallengeCookie(c)
+	if acceptsJSON(c) || isHTMX(c) {
+		return apiError(c, fiber.StatusUnauthorized, "two factor session expired")
+	}
+	handler.setFlashCookie(c, FlashPayload{AuthError: "two factor session expired"})
+	return c.Redirect("/login", fiber.StatusSeeOther)
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+const calendarFeedHistory = 12 // months of logged periods in the feed
+
+// CalendarFeed serves the iCalendar subscription for a feed token. It is
+// public by design: calendar apps cannot sign in, so the secret token in
+// the URL is the only credential. Unknown tokens get a plain 404.
+func (handler *Handler) CalendarFeed(c *fiber.Ctx) error {
+	handler.ensureDependencies()
+	feed, err := handler.calendarFeedService.Resolve(c.Params("token"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusNotFound)
+	}
+	user, err := handler.repositories.Users.FindByID(feed.UserID)
+	if err != nil || !services.IsOwnerUser(&user) {
+		return c.SendStatus(fiber.StatusNotFound)
+	}
+
+	now := time.Now().In(handler.location)
+	stats, logs, err := handler.buildCycleStatsForRange(&user, now.AddDate(0, -calendarFeedHistory, 0), now, now)
+	if err != nil {
+		return c.SendStatus(fiber.StatusInternalServerError)
+	}
+
+	events := services.BuildCalendarFeedEvents(feed, logs, stats, handler.location)
+	titles := handler.calendarFeedTitles(feed.Language, feed.NeutralTitles)
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `inline; filename="ovumcy.ics"`)
+	c.Set(fiber.HeaderCacheControl, "private, no-cache")
+	return c.SendString(services.RenderCalendarFeedICS(feed, events, titles, now))
+}
+
+func (handler *Handler) calendarFeedTitles(language string, neutral bool) services.CalendarFeedTitles {
+	messages := map[string]string{}
+	if handler.i18n != nil {
+		messages = handler.i18n.Messages(handler.i18n.NormalizeLanguage(language))
+	}
+	if neutral {
+		title := translateMessage(messages, "calendar_feed.neutral_title")
+		return services.CalendarFeedTitles{
+			Calendar:        translateMessage(messages, "calendar_feed.neutral_calendar"),
+			Period:          title,
+			PredictedPeriod: title,
+			FertileWindow:   title,
+			Ovulation:       title,
+		}
+	}
+	return services.CalendarFeedTitles{
+		Calendar:        translateMessage(messages, "calendar_feed.calendar"),
+		Period:          translateMessage(messages, "calendar_feed.period"),
+		PredictedPeriod: translateMessage(messages, "calendar_feed.predicted_period"),
+		FertileWindow:   translateMessage(messages, "calendar_feed.fertile_window"),
+		Ovulation:       translateMessage(messages, "calendar_feed.ovulation"),
+	}
+}
@@ -16,5 +16,11 @@ var (
 
 func (handler *Handler) deleteDayAndRefreshLastPeriod(userID uint, day time.Time) error {
 	handler.ensureDependencies()
+	if err := handler.medicationService.DeleteDayIntakes(userID, day, handler.location); err != nil {
+		return errDeleteDayFailed
+	}
+	if err := handler.metricService.DeleteDayValues(userID, day, handler.location); err != nil {
+		return errDeleteDayFailed
+	}
 	return handler.dayService.DeleteDayAndRefreshLastPeriod(userID, day, handler.location)
 }
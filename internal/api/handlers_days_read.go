@@ -20,7 +20,12 @@ func (handler *Handler) GetDays(c *fiber.Ctx) error {
 		return apiError(c, fiber.StatusBadRequest, "invalid range")
 	}
 
-	logs, err := handler.fetchLogsForUser(user.ID, from, to)
+	dataOwner, err := handler.resolveDataOwner(user)
+	if err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to fetch logs")
+	}
+
+	logs, err := handler.fetchLogsForUser(dataOwner.ID, from, to)
 	if err != nil {
 		return apiError(c, fiber.StatusInternalServerError, "failed to fetch logs")
 	}
@@ -41,7 +46,12 @@ func (handler *Handler) GetDay(c *fiber.Ctx) error {
 		return apiError(c, fiber.StatusBadRequest, "invalid date")
 	}
 
-	logEntry, err := handler.fetchLogByDate(user.ID, day)
+	dataOwner, err := handler.resolveDataOwner(user)
+	if err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to fetch day")
+	}
+
+	logEntry, err := handler.fetchLogByDate(dataOwner.ID, day)
 	if err != nil {
 		return apiError(c, fiber.StatusInternalServerError, "failed to fetch day")
 	}
@@ -2,8 +2,10 @@ package api
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/models"
 	"github.com/terraincognita07/ovumcy/internal/services"
 )
 
@@ -28,16 +30,113 @@ func (handler *Handler) UpsertDay(c *fiber.Ctx) error {
 		return apiError(c, fiber.StatusBadRequest, "invalid symptom ids")
 	}
 	handler.ensureDependencies()
-	entry, err := handler.dayService.UpsertDayEntryWithAutoFill(user.ID, day, services.DayEntryInput{
-		IsPeriod:   payload.IsPeriod,
-		Flow:       payload.Flow,
-		Notes:      payload.Notes,
-		SymptomIDs: cleanIDs,
-	}, handler.location)
+	var medicationIDs []uint
+	if payload.MedicationIDs != nil {
+		medicationIDs, err = handler.medicationService.ValidateMedicationIDs(user.ID, *payload.MedicationIDs)
+		if err != nil {
+			return apiError(c, fiber.StatusBadRequest, "invalid medication ids")
+		}
+	}
+	var metricValues map[uint]float64
+	if payload.Metrics != nil {
+		metricValues, err = handler.metricService.NormalizeDayValues(user.ID, *payload.Metrics)
+		if err != nil {
+			switch {
+			case errors.Is(err, services.ErrInvalidMetricID):
+				return apiError(c, fiber.StatusBadRequest, "invalid metric ids")
+			case errors.Is(err, services.ErrMetricValueInvalid):
+				return apiError(c, fiber.StatusBadRequest, "invalid metric value")
+			default:
+				return apiError(c, fiber.StatusInternalServerError, "failed to load metrics")
+			}
+		}
+	}
+	input := services.DayEntryInput{
+		IsPeriod:          payload.IsPeriod,
+		Flow:              payload.Flow,
+		Notes:             payload.Notes,
+		SymptomIDs:        cleanIDs,
+		SymptomSeverities: payload.SymptomSeverities,
+	}
+	if payload.BBT != nil {
+		input.TemperatureSet = true
+		input.Temperature = *payload.BBT
+		input.TemperatureUnit = payload.BBTUnit
+		if strings.TrimSpace(input.TemperatureUnit) == "" {
+			input.TemperatureUnit = user.TemperatureUnit
+		}
+		input.TemperatureTime = payload.BBTTime
+		input.TemperatureDisturbed = payload.BBTDisturbed
+	}
+	if payload.Mucus != nil || payload.CervixPosition != nil || payload.CervixFirmness != nil {
+		input.CervicalSet = true
+		if payload.Mucus != nil {
+			input.Mucus = *payload.Mucus
+		}
+		if payload.CervixPosition != nil {
+			input.CervixPosition = *payload.CervixPosition
+		}
+		if payload.CervixFirmness != nil {
+			input.CervixFirmness = *payload.CervixFirmness
+		}
+	}
+	if payload.LHTest != nil || payload.PregnancyTest != nil || payload.TestBrand != nil {
+		input.TestsSet = true
+		if payload.LHTest != nil {
+			input.LHTest = *payload.LHTest
+		}
+		if payload.PregnancyTest != nil {
+			input.PregnancyTest = *payload.PregnancyTest
+		}
+		if payload.TestBrand != nil {
+			input.TestBrand = *payload.TestBrand
+		}
+	}
+	if payload.PillTaken != nil {
+		input.PillTakenSet = true
+		input.PillTaken = *payload.PillTaken
+	}
+	// Intimacy fields are ignored until the owner turns tracking on, so
+	// stored entries are kept as they are.
+	if payload.Intimacy != nil && services.IntimacyVisibleForViewer(user) {
+		input.IntimacySet = true
+		input.Intimacy = *payload.Intimacy
+		input.IntimacyProtection = payload.IntimacyProtection
+		input.IntimacyMethods = payload.IntimacyMethods
+	}
+	if payload.CycleStartOverride != nil || payload.CycleExclusion != nil {
+		input.CycleOverrideSet = true
+		if payload.CycleStartOverride != nil {
+			input.CycleStartOverride = *payload.CycleStartOverride
+		}
+		if payload.CycleExclusion != nil {
+			input.CycleExclusion = *payload.CycleExclusion
+		}
+	}
+
+	entry, err := handler.dayService.UpsertDayEntryWithAutoFill(user.ID, day, input, handler.location)
 	if err != nil {
 		switch {
 		case errors.Is(err, services.ErrInvalidDayFlow):
 			return apiError(c, fiber.StatusBadRequest, "invalid flow value")
+		case errors.Is(err, services.ErrInvalidDaySymptomSeverity):
+			return apiError(c, fiber.StatusBadRequest, "invalid symptom severity")
+		case errors.Is(err, services.ErrInvalidDayTemperature):
+			return apiError(c, fiber.StatusBadRequest, "invalid temperature value")
+		case errors.Is(err, services.ErrInvalidDayTemperatureTime):
+			return apiError(c, fiber.StatusBadRequest, "invalid temperature time")
+		case errors.Is(err, services.ErrInvalidDayMucus):
+			return apiError(c, fiber.StatusBadRequest, "invalid mucus value")
+		case errors.Is(err, services.ErrInvalidDayCervix):
+			return apiError(c, fiber.StatusBadRequest, "invalid cervix value")
+		case errors.Is(err, services.ErrInvalidDayLHTest):
+			return apiError(c, fiber.StatusBadRequest, "invalid lh test value")
+		case errors.Is(err, services.ErrInvalidDayPregnancyTest):
+			return apiError(c, fiber.StatusBadRequest, "invalid pregnancy test value")
+		case errors.Is(err, services.ErrInvalidDayIntimacy):
+			return apiError(c, fiber.StatusBadRequest, "invalid intimacy value")
+		case errors.Is(err, services.ErrInvalidDayCycleOverride):
+			return apiError(c, fiber.StatusBadRequest, "invalid cycle override value")
 		case errors.Is(err, services.ErrDayAutoFillLoadFailed), errors.Is(err, services.ErrDayAutoFillCheckFailed):
 			return apiError(c, fiber.StatusInternalServerError, "failed to load day")
 		case errors.Is(err, services.ErrDayAutoFillApplyFailed):
@@ -49,6 +148,22 @@ func (handler *Handler) UpsertDay(c *fiber.Ctx) error {
 		}
 	}
 
+	if payload.MedicationIDs != nil {
+		if err := handler.medicationService.ReplaceDayIntakes(user.ID, day, medicationIDs, handler.location); err != nil {
+			return apiError(c, fiber.StatusInternalServerError, "failed to save medications")
+		}
+	}
+	if payload.Metrics != nil {
+		if err := handler.metricService.ReplaceDayValues(user.ID, day, metricValues, handler.location); err != nil {
+			return apiError(c, fiber.StatusInternalServerError, "failed to save metrics")
+		}
+	}
+	if services.IsMenstrualDay(entry) || entry.CycleStartOverride == models.CycleStartOverrideStart {
+		if err := handler.capturePredictionSnapshotForDay(user, &day); err != nil {
+			return apiError(c, fiber.StatusInternalServerError, "failed to save prediction")
+		}
+	}
+
 	if isHTMX(c) {
 		c.Set("HX-Trigger", "calendar-day-updated")
 		return handler.sendDaySaveStatus(c)
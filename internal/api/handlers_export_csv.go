@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/terraincognita07/ovumcy/internal/services"
 )
 
 func (handler *Handler) ExportCSV(c *fiber.Ctx) error {
@@ -20,11 +19,15 @@ func (handler *Handler) ExportCSV(c *fiber.Ctx) error {
 	if err != nil {
 		return apiError(c, fiber.StatusInternalServerError, "failed to fetch logs")
 	}
+	headers, err := handler.exportService.BuildCSVHeaders(user.ID)
+	if err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to fetch metrics")
+	}
 	now := time.Now().In(handler.location)
 
 	var output bytes.Buffer
 	writer := csv.NewWriter(&output)
-	if err := writer.Write(services.ExportCSVHeaders); err != nil {
+	if err := writer.Write(headers); err != nil {
 		return apiError(c, fiber.StatusInternalServerError, "failed to build export")
 	}
 
@@ -18,11 +18,21 @@ func (handler *Handler) ExportJSON(c *fiber.Ctx) error {
 	if err != nil {
 		return apiError(c, fiber.StatusInternalServerError, "failed to fetch logs")
 	}
+	customSymptoms, err := handler.exportService.BuildCustomSymptoms(user.ID)
+	if err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to fetch symptoms")
+	}
+	customMetrics, err := handler.exportService.BuildCustomMetrics(user.ID)
+	if err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to fetch metrics")
+	}
 	now := time.Now().In(handler.location)
 
 	payload := fiber.Map{
-		"exported_at": now.Format(time.RFC3339),
-		"entries":     entries,
+		"exported_at":     now.Format(time.RFC3339),
+		"custom_symptoms": customSymptoms,
+		"custom_metrics":  customMetrics,
+		"entries":         entries,
 	}
 
 	serialized, err := json.MarshalIndent(payload, "", "  ")
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+// ImportData restores entries from an export file. The ":format" path
+// segment picks the parser ("json" for Ovumcy's own export, or clue, flo,
+// drip and csv). The file is sent either as the raw request body or as a
+// multipart "file" field; "mode" and "dry_run" come from the query string
+// or form fields.
+func (handler *Handler) ImportData(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	format, err := services.LookupImportFormat(c.Params("format"))
+	if err != nil {
+		return apiError(c, fiber.StatusBadRequest, "unsupported import format")
+	}
+	mode, err := services.ParseImportMode(importRequestValue(c, "mode"))
+	if err != nil {
+		return apiError(c, fiber.StatusBadRequest, "invalid import mode")
+	}
+	dryRun, _ := strconv.ParseBool(importRequestValue(c, "dry_run"))
+
+	raw, err := readImportDocument(c)
+	if err != nil {
+		return apiError(c, fiber.StatusBadRequest, "invalid import payload")
+	}
+
+	report, err := handler.runImport(user, format, raw, mode, dryRun)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrImportEntryInvalid):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":  "invalid import entry",
+				"detail": err.Error(),
+			})
+		case errors.Is(err, services.ErrImportPayloadInvalid):
+			return apiError(c, fiber.StatusBadRequest, "invalid import payload")
+		default:
+			return apiError(c, fiber.StatusInternalServerError, "failed to import data")
+		}
+	}
+
+	return c.JSON(fiber.Map{"ok": true, "report": report})
+}
+
+func (handler *Handler) runImport(user *models.User, format services.ImportFormat, raw []byte, mode services.ImportMode, dryRun bool) (services.ImportReport, error) {
+	payload, err := format.Parse(raw)
+	if err != nil {
+		return services.ImportReport{}, err
+	}
+
+	handler.ensureDependencies()
+	report, err := handler.importService.Import(user.ID, payload, mode, dryRun, handler.location)
+	if err != nil || dryRun {
+		return report, err
+	}
+	return report, handler.capturePredictionSnapshot(user)
+}
+
+func importRequestValue(c *fiber.Ctx, key string) string {
+	if value := strings.TrimSpace(c.Query(key)); value != "" {
+		return value
+	}
+	if strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+		return strings.TrimSpace(c.FormValue(key))
+	}
+	return ""
+}
+
+func readImportDocument(c *fiber.Ctx) ([]byte, error) {
+	if !strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+		return c.Body(), nil
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
@@ -28,5 +28,8 @@ func (handler *Handler) OnboardingComplete(c *fiber.Ctx) error {
 
 	user.OnboardingCompleted = true
 	user.LastPeriodStart = &startDay
+	if err := handler.capturePredictionSnapshot(user); err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to finish onboarding")
+	}
 	return redirectOrJSON(c, "/dashboard")
 }
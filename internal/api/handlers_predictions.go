@@ -0,0 +1,47 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func (handler *Handler) GetPredictions(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	cycles := 0
+	if raw := strings.TrimSpace(c.Query("cycles")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return apiError(c, fiber.StatusBadRequest, "invalid prediction cycles")
+		}
+		cycles = parsed
+	}
+	cycles, valid := services.NormalizePredictionCycles(cycles)
+	if !valid {
+		return apiError(c, fiber.StatusBadRequest, "invalid prediction cycles")
+	}
+
+	dataOwner, err := handler.resolveDataOwner(user)
+	if err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to fetch predictions")
+	}
+
+	now := time.Now().In(handler.location)
+	stats, _, err := handler.buildCycleStatsForRange(dataOwner, now.AddDate(-2, 0, 0), now, now)
+	if err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to fetch predictions")
+	}
+
+	predictions := services.BuildCyclePredictions(stats, cycles, handler.location)
+	return c.JSON(fiber.Map{
+		"cycles":      cycles,
+		"predictions": services.SanitizeCyclePredictionsForViewer(user, predictions),
+	})
+}
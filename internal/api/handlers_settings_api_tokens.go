@@ -0,0 +1,96 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func (handler *Handler) CreateAPIToken(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	input := apiTokenCreateInput{}
+	if err := c.BodyParser(&input); err != nil {
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, "api token name required")
+	}
+
+	handler.ensureDependencies()
+	rawToken, token, err := handler.apiTokenService.Create(user, input.Name, input.Scope, time.Now().In(handler.location))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrAPITokenOwnerRequired):
+			return apiError(c, fiber.StatusForbidden, "owner access required")
+		case errors.Is(err, services.ErrAPITokenNameInvalid):
+			return handler.respondSettingsError(c, fiber.StatusBadRequest, "api token name required")
+		case errors.Is(err, services.ErrAPITokenScopeInvalid):
+			return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid api token scope")
+		default:
+			return apiError(c, fiber.StatusInternalServerError, "failed to create api token")
+		}
+	}
+
+	if acceptsJSON(c) {
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+			"ok":    true,
+			"id":    token.ID,
+			"name":  token.Name,
+			"scope": token.Scope,
+			"token": rawToken,
+		})
+	}
+
+	data, err := handler.buildSettingsViewData(c, user, FlashPayload{})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).SendString("failed to load settings")
+	}
+	data["SuccessKey"] = "settings.success.api_token_created"
+	data["GeneratedAPIToken"] = rawToken
+	return handler.render(c, "settings", data)
+}
+
+func (handler *Handler) RevokeAPIToken(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	tokenID, err := parseSettingsResourceID(c.Params("id"))
+	if err != nil {
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, "api token not found")
+	}
+
+	handler.ensureDependencies()
+	if err := handler.apiTokenService.Revoke(user.ID, tokenID); err != nil {
+		if errors.Is(err, services.ErrAPITokenNotFound) {
+			return handler.respondSettingsError(c, fiber.StatusNotFound, "api token not found")
+		}
+		return apiError(c, fiber.StatusInternalServerError, "failed to revoke api token")
+	}
+
+	handler.setFlashCookie(c, FlashPayload{SettingsSuccess: "api_token_revoked"})
+	return redirectOrJSON(c, "/settings")
+}
+
+func buildAPITokenViews(tokens []models.APIToken, language string, location *time.Location) []APITokenView {
+	views := make([]APITokenView, 0, len(tokens))
+	for _, token := range tokens {
+		view := APITokenView{
+			ID:        token.ID,
+			Name:      token.Name,
+			ReadWrite: token.Scope == models.APITokenScopeReadWrite,
+			CreatedAt: localizedDateDisplay(language, token.CreatedAt.In(location)),
+		}
+		if token.LastUsedAt != nil {
+			lastUsed := token.LastUsedAt.In(location)
+			view.LastUsed = localizedDateDisplay(language, lastUsed) + " " + lastUsed.Format("15:04")
+		}
+		views = append(views, view)
+	}
+	return views
+}
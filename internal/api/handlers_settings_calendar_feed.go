@@ -0,0 +1,137 @@
+package api
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+// EnableCalendarFeed creates the owner's calendar subscription or replaces
+// its secret URL. The URL is shown once, like API tokens.
+func (handler *Handler) EnableCalendarFeed(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	options, err := parseCalendarFeedOptions(c)
+	if err != nil {
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid calendar feed input")
+	}
+	options.Language = currentLanguage(c)
+
+	handler.ensureDependencies()
+	rawToken, feed, err := handler.calendarFeedService.Enable(user, options, time.Now().In(handler.location))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrCalendarFeedOwnerRequired):
+			return apiError(c, fiber.StatusForbidden, "owner access required")
+		case errors.Is(err, services.ErrCalendarFeedCyclesInvalid):
+			return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid calendar feed input")
+		default:
+			return apiError(c, fiber.StatusInternalServerError, "failed to create calendar feed")
+		}
+	}
+
+	feedURL := buildCalendarFeedURL(c, rawToken)
+	if acceptsJSON(c) {
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+			"ok":                true,
+			"url":               feedURL,
+			"cycles":            feed.Cycles,
+			"include_fertility": feed.IncludeFertility,
+			"include_ovulation": feed.IncludeOvulation,
+			"neutral_titles":    feed.NeutralTitles,
+		})
+	}
+
+	data, err := handler.buildSettingsViewData(c, user, FlashPayload{})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).SendString("failed to load settings")
+	}
+	data["SuccessKey"] = "settings.success.calendar_feed_created"
+	data["GeneratedCalendarFeedURL"] = feedURL
+	return handler.render(c, "settings", data)
+}
+
+func (handler *Handler) UpdateCalendarFeed(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	options, err := parseCalendarFeedOptions(c)
+	if err != nil {
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid calendar feed input")
+	}
+	options.Language = currentLanguage(c)
+
+	handler.ensureDependencies()
+	if _, err := handler.calendarFeedService.UpdateOptions(user.ID, options, time.Now().In(handler.location)); err != nil {
+		switch {
+		case errors.Is(err, services.ErrCalendarFeedNotFound):
+			return handler.respondSettingsError(c, fiber.StatusNotFound, "calendar feed not found")
+		case errors.Is(err, services.ErrCalendarFeedCyclesInvalid):
+			return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid calendar feed input")
+		default:
+			return apiError(c, fiber.StatusInternalServerError, "failed to update calendar feed")
+		}
+	}
+
+	handler.setFlashCookie(c, FlashPayload{SettingsSuccess: "calendar_feed_updated"})
+	return redirectOrJSON(c, "/settings")
+}
+
+func (handler *Handler) DisableCalendarFeed(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	handler.ensureDependencies()
+	if err := handler.calendarFeedService.Disable(user.ID); err != nil {
+		if errors.Is(err, services.ErrCalendarFeedNotFound) {
+			return handler.respondSettingsError(c, fiber.StatusNotFound, "calendar feed not found")
+		}
+		return apiError(c, fiber.StatusInternalServerError, "failed to disable calendar feed")
+	}
+
+	handler.setFlashCookie(c, FlashPayload{SettingsSuccess: "calendar_feed_disabled"})
+	return redirectOrJSON(c, "/settings")
+}
+
+func parseCalendarFeedOptions(c *fiber.Ctx) (services.CalendarFeedOptions, error) {
+	input := calendarFeedInput{}
+	if strings.Contains(strings.ToLower(c.Get("Content-Type")), "application/json") {
+		if err := c.BodyParser(&input); err != nil {
+			return services.CalendarFeedOptions{}, err
+		}
+	} else {
+		if raw := strings.TrimSpace(c.FormValue("cycles")); raw != "" {
+			cycles, err := strconv.Atoi(raw)
+			if err != nil {
+				return services.CalendarFeedOptions{}, err
+			}
+			input.Cycles = cycles
+		}
+		input.IncludeFertility = parseBoolValue(c.FormValue("include_fertility"))
+		input.IncludeOvulation = parseBoolValue(c.FormValue("include_ovulation"))
+		input.NeutralTitles = parseBoolValue(c.FormValue("neutral_titles"))
+	}
+
+	return services.CalendarFeedOptions{
+		Cycles:           input.Cycles,
+		IncludeFertility: input.IncludeFertility,
+		IncludeOvulation: input.IncludeOvulation,
+		NeutralTitles:    input.NeutralTitles,
+	}, nil
+}
+
+func buildCalendarFeedURL(c *fiber.Ctx, rawToken string) string {
+	return strings.TrimRight(c.BaseURL(), "/") + "/calendar/feed/" + url.PathEscape(rawToken) + ".ics"
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+// UpdateContraception saves the owner's hormonal contraception profile.
+// While it is active, ovulation and fertility predictions are suppressed.
+func (handler *Handler) UpdateContraception(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	input := contraceptionInput{}
+	if strings.Contains(strings.ToLower(c.Get("Content-Type")), "application/json") {
+		if err := c.BodyParser(&input); err != nil {
+			return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid input")
+		}
+	} else {
+		input.Method = c.FormValue("method")
+		input.Start = c.FormValue("start")
+		if input.Method != "" {
+			packLength, err := strconv.Atoi(strings.TrimSpace(c.FormValue("pack_length")))
+			if err != nil {
+				return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid pack length")
+			}
+			placeboDays, err := strconv.Atoi(strings.TrimSpace(c.FormValue("placebo_days")))
+			if err != nil {
+				return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid pack length")
+			}
+			input.PackLength = packLength
+			input.PlaceboDays = placeboDays
+		}
+	}
+
+	handler.ensureDependencies()
+	profile, err := handler.settingsService.ValidateContraception(services.ContraceptionValidationInput{
+		Method:      input.Method,
+		PackLength:  input.PackLength,
+		PlaceboDays: input.PlaceboDays,
+		StartRaw:    input.Start,
+	}, time.Now().In(handler.location), handler.location)
+	if err != nil {
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, contraceptionErrorMessage(err))
+	}
+
+	if err := handler.settingsService.SaveContraception(user.ID, profile); err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to update contraception")
+	}
+	handler.settingsService.ApplyContraceptionSettings(user, profile)
+
+	handler.setFlashCookie(c, FlashPayload{SettingsSuccess: "contraception_updated"})
+	return redirectOrJSON(c, "/settings")
+}
+
+func contraceptionErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, services.ErrSettingsContraceptionMethodInvalid):
+		return "invalid contraception method"
+	case errors.Is(err, services.ErrSettingsContraceptionPackInvalid):
+		return "invalid pack length"
+	case errors.Is(err, services.ErrSettingsContraceptionStartRequired):
+		return "contraception start required"
+	case errors.Is(err, services.ErrSettingsContraceptionStartInvalid):
+		return "invalid contraception start"
+	default:
+		return "invalid input"
+	}
+}
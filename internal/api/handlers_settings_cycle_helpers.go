@@ -37,6 +37,7 @@ func (handler *Handler) parseCycleSettingsInput(c *fiber.Ctx) (services.CycleSet
 			AutoPeriodFill:     parseBoolValue(c.FormValue("auto_period_fill")),
 			LastPeriodStart:    strings.TrimSpace(c.FormValue("last_period_start")),
 			LastPeriodStartSet: c.Request().PostArgs().Has("last_period_start"),
+			TemperatureUnit:    c.FormValue("temperature_unit"),
 		}
 	}
 
@@ -47,6 +48,7 @@ func (handler *Handler) parseCycleSettingsInput(c *fiber.Ctx) (services.CycleSet
 		AutoPeriodFill:     input.AutoPeriodFill,
 		LastPeriodStartRaw: input.LastPeriodStart,
 		LastPeriodStartSet: input.LastPeriodStartSet,
+		TemperatureUnit:    input.TemperatureUnit,
 	}, time.Now().In(handler.location), handler.location)
 	if err != nil {
 		switch {
@@ -24,6 +24,17 @@ func (handler *Handler) ClearAllData(c *fiber.Ctx) error {
 	user.PeriodLength = models.DefaultPeriodLength
 	user.AutoPeriodFill = true
 	user.LastPeriodStart = nil
+	user.CycleMode = models.CycleModeCycle
+	user.PregnancyStart = nil
+	user.DueDate = nil
+	user.PostpartumStart = nil
+	user.Contraception = models.ContraceptionProfile{
+		PackLength:  models.DefaultPackLength,
+		PlaceboDays: models.DefaultPlaceboDays,
+	}
+	user.IntimacyTracking = false
+	user.PredictionAlgorithm = models.PredictionAlgorithmMedian
+	user.LutealPhaseLength = 0
 
 	if acceptsJSON(c) {
 		return c.JSON(fiber.Map{"ok": true})
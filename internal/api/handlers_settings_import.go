@@ -0,0 +1,113 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+// PreviewImport parses an uploaded file and runs the import as a dry run so
+// the owner can review created days, updates and conflicts first. The
+// parsed document is handed back to the page and posted to CommitImport
+// unchanged, so the upload is only parsed once.
+func (handler *Handler) PreviewImport(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	format, err := services.LookupImportFormat(c.FormValue("format"))
+	if err != nil {
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, "unsupported import format")
+	}
+	mode, err := services.ParseImportMode(c.FormValue("mode"))
+	if err != nil {
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid import mode")
+	}
+	raw, err := readImportDocument(c)
+	if err != nil {
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid import payload")
+	}
+
+	payload, err := format.Parse(raw)
+	if err != nil {
+		return handler.respondImportError(c, err)
+	}
+	handler.ensureDependencies()
+	report, err := handler.importService.Import(user.ID, payload, mode, true, handler.location)
+	if err != nil {
+		return handler.respondImportError(c, err)
+	}
+
+	if acceptsJSON(c) {
+		return c.JSON(fiber.Map{"ok": true, "report": report, "document": payload})
+	}
+
+	document, err := json.Marshal(payload)
+	if err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to import data")
+	}
+	data, err := handler.buildSettingsViewData(c, user, FlashPayload{})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).SendString("failed to load settings")
+	}
+	data["ImportPreview"] = ImportPreviewView{
+		Format:        format.Name(),
+		Mode:          string(mode),
+		Document:      string(document),
+		Report:        report,
+		ConflictDates: importConflictDates(report),
+	}
+	return handler.render(c, "settings", data)
+}
+
+// CommitImport applies a document previously returned by PreviewImport.
+func (handler *Handler) CommitImport(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	mode, err := services.ParseImportMode(c.FormValue("mode"))
+	if err != nil {
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid import mode")
+	}
+	payload, err := services.DecodeImportJSON([]byte(c.FormValue("document")))
+	if err != nil {
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid import payload")
+	}
+
+	handler.ensureDependencies()
+	if _, err := handler.importService.Import(user.ID, payload, mode, false, handler.location); err != nil {
+		return handler.respondImportError(c, err)
+	}
+	if err := handler.capturePredictionSnapshot(user); err != nil {
+		return handler.respondImportError(c, err)
+	}
+
+	handler.setFlashCookie(c, FlashPayload{SettingsSuccess: "import_completed"})
+	return redirectOrJSON(c, "/settings")
+}
+
+func (handler *Handler) respondImportError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrImportEntryInvalid):
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid import entry")
+	case errors.Is(err, services.ErrImportPayloadInvalid):
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid import payload")
+	default:
+		return apiError(c, fiber.StatusInternalServerError, "failed to import data")
+	}
+}
+
+func importConflictDates(report services.ImportReport) []string {
+	dates := make([]string, 0, report.Conflicts)
+	for _, change := range report.Changes {
+		if change.Conflict {
+			dates = append(dates, change.Date)
+		}
+	}
+	return dates
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// UpdateIntimacyTracking shows or hides intimacy entries for the owner.
+// Partners never see them either way.
+func (handler *Handler) UpdateIntimacyTracking(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	input := intimacyTrackingInput{}
+	if strings.Contains(strings.ToLower(c.Get("Content-Type")), "application/json") {
+		if err := c.BodyParser(&input); err != nil {
+			return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid input")
+		}
+	} else {
+		input.Enabled = parseBoolValue(c.FormValue("enabled"))
+	}
+
+	handler.ensureDependencies()
+	if err := handler.settingsService.SaveIntimacyTracking(user.ID, input.Enabled); err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to update intimacy tracking")
+	}
+	user.IntimacyTracking = input.Enabled
+
+	handler.setFlashCookie(c, FlashPayload{SettingsSuccess: "intimacy_tracking_updated"})
+	return redirectOrJSON(c, "/settings")
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+// UpdateLutealPhase sets the owner's luteal phase length by hand, or clears
+// it so the length is learned from confirmed ovulations again.
+func (handler *Handler) UpdateLutealPhase(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	input := lutealPhaseInput{}
+	if strings.Contains(strings.ToLower(c.Get("Content-Type")), "application/json") {
+		if err := c.BodyParser(&input); err != nil {
+			return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid input")
+		}
+	} else {
+		input.LutealPhaseLength = c.FormValue("luteal_phase_length")
+	}
+
+	handler.ensureDependencies()
+	length, err := handler.settingsService.SaveLutealPhaseLength(user.ID, input.LutealPhaseLength)
+	if err != nil {
+		if errors.Is(err, services.ErrSettingsLutealPhaseLengthInvalid) {
+			return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid luteal phase length")
+		}
+		return apiError(c, fiber.StatusInternalServerError, "failed to update luteal phase length")
+	}
+	user.LutealPhaseLength = length
+
+	handler.setFlashCookie(c, FlashPayload{SettingsSuccess: "luteal_phase_updated"})
+	return redirectOrJSON(c, "/settings")
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func (handler *Handler) GetMedications(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	handler.ensureDependencies()
+	medications, err := handler.medicationService.ListMedications(user.ID)
+	if err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to fetch medications")
+	}
+	return c.JSON(medications)
+}
+
+// CreateMedication adds an entry to the owner's medication catalog.
+func (handler *Handler) CreateMedication(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	input := medicationInput{}
+	if strings.Contains(strings.ToLower(c.Get("Content-Type")), "application/json") {
+		if err := c.BodyParser(&input); err != nil {
+			return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid input")
+		}
+	} else {
+		input.Name = c.FormValue("name")
+		input.Unit = c.FormValue("unit")
+		input.Schedule = c.FormValue("schedule")
+		if raw := strings.ReplaceAll(strings.TrimSpace(c.FormValue("dose")), ",", "."); raw != "" {
+			dose, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid medication dose")
+			}
+			input.Dose = dose
+		}
+	}
+
+	handler.ensureDependencies()
+	medication, err := handler.medicationService.CreateMedicationForUser(user.ID, services.MedicationInput{
+		Name:     input.Name,
+		Dose:     input.Dose,
+		Unit:     input.Unit,
+		Schedule: input.Schedule,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrCreateMedicationFailed) {
+			return apiError(c, fiber.StatusInternalServerError, "failed to create medication")
+		}
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, medicationErrorMessage(err))
+	}
+
+	if acceptsJSON(c) {
+		return c.Status(fiber.StatusCreated).JSON(medication)
+	}
+	handler.setFlashCookie(c, FlashPayload{SettingsSuccess: "medication_created"})
+	return redirectOrJSON(c, "/settings")
+}
+
+// DeleteMedication removes a catalog entry together with its intakes.
+func (handler *Handler) DeleteMedication(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	medicationID, err := parseSettingsResourceID(c.Params("id"))
+	if err != nil {
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, "medication not found")
+	}
+
+	handler.ensureDependencies()
+	if err := handler.medicationService.DeleteMedicationForUser(user.ID, medicationID); err != nil {
+		if errors.Is(err, services.ErrMedicationNotFound) {
+			return handler.respondSettingsError(c, fiber.StatusNotFound, "medication not found")
+		}
+		return apiError(c, fiber.StatusInternalServerError, "failed to delete medication")
+	}
+
+	handler.setFlashCookie(c, FlashPayload{SettingsSuccess: "medication_deleted"})
+	return redirectOrJSON(c, "/settings")
+}
+
+func medicationErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, services.ErrMedicationNameInvalid):
+		return "invalid medication name"
+	case errors.Is(err, services.ErrMedicationDoseInvalid):
+		return "invalid medication dose"
+	case errors.Is(err, services.ErrMedicationUnitInvalid):
+		return "invalid medication unit"
+	case errors.Is(err, services.ErrMedicationScheduleInvalid):
+		return "invalid medication schedule"
+	default:
+		return "invalid input"
+	}
+}
+
+// fetchDayMedicationsForViewer loads the catalog and the intakes of day for
+// the day form. Partners never see medication data.
+func (handler *Handler) fetchDayMedicationsForViewer(user *models.User, day time.Time) ([]models.Medication, map[uint]bool, error) {
+	if !isOwnerUser(user) {
+		return []models.Medication{}, map[uint]bool{}, nil
+	}
+
+	handler.ensureDependencies()
+	medications, err := handler.medicationService.ListMedications(user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	intakes, err := handler.medicationService.FetchIntakesForDay(user.ID, day, handler.location)
+	if err != nil {
+		return nil, nil, err
+	}
+	return medications, services.MedicationIntakeIDSet(intakes), nil
+}
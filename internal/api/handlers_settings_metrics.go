@@ -0,0 +1,145 @@
+package api
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func (handler *Handler) GetMetrics(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	handler.ensureDependencies()
+	metrics, err := handler.metricService.ListMetricTypes(user.ID)
+	if err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to fetch metrics")
+	}
+	return c.JSON(metrics)
+}
+
+// CreateMetric adds a custom metric to the owner's catalog.
+func (handler *Handler) CreateMetric(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	input := metricInput{}
+	if strings.Contains(strings.ToLower(c.Get("Content-Type")), "application/json") {
+		if err := c.BodyParser(&input); err != nil {
+			return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid input")
+		}
+	} else {
+		input.Name = c.FormValue("name")
+		input.Unit = c.FormValue("unit")
+		input.Type = c.FormValue("type")
+		var err error
+		if input.Min, err = parseOptionalMetricBound(c.FormValue("min")); err != nil {
+			return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid metric range")
+		}
+		if input.Max, err = parseOptionalMetricBound(c.FormValue("max")); err != nil {
+			return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid metric range")
+		}
+	}
+
+	handler.ensureDependencies()
+	metric, err := handler.metricService.CreateMetricTypeForUser(user.ID, services.MetricTypeInput{
+		Name:     input.Name,
+		Unit:     input.Unit,
+		Kind:     input.Type,
+		MinValue: input.Min,
+		MaxValue: input.Max,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrCreateMetricFailed) {
+			return apiError(c, fiber.StatusInternalServerError, "failed to create metric")
+		}
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, metricErrorMessage(err))
+	}
+
+	if acceptsJSON(c) {
+		return c.Status(fiber.StatusCreated).JSON(metric)
+	}
+	handler.setFlashCookie(c, FlashPayload{SettingsSuccess: "metric_created"})
+	return redirectOrJSON(c, "/settings")
+}
+
+// DeleteMetric removes a custom metric together with its values.
+func (handler *Handler) DeleteMetric(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	metricID, err := parseSettingsResourceID(c.Params("id"))
+	if err != nil {
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, "metric not found")
+	}
+
+	handler.ensureDependencies()
+	if err := handler.metricService.DeleteMetricTypeForUser(user.ID, metricID); err != nil {
+		if errors.Is(err, services.ErrMetricNotFound) {
+			return handler.respondSettingsError(c, fiber.StatusNotFound, "metric not found")
+		}
+		return apiError(c, fiber.StatusInternalServerError, "failed to delete metric")
+	}
+
+	handler.setFlashCookie(c, FlashPayload{SettingsSuccess: "metric_deleted"})
+	return redirectOrJSON(c, "/settings")
+}
+
+// parseOptionalMetricBound accepts both decimal separators; an empty value
+// leaves the bound open.
+func parseOptionalMetricBound(raw string) (*float64, error) {
+	normalized := strings.ReplaceAll(strings.TrimSpace(raw), ",", ".")
+	if normalized == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseFloat(normalized, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+func metricErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, services.ErrMetricNameInvalid):
+		return "invalid metric name"
+	case errors.Is(err, services.ErrMetricUnitInvalid):
+		return "invalid metric unit"
+	case errors.Is(err, services.ErrMetricKindInvalid):
+		return "invalid metric type"
+	case errors.Is(err, services.ErrMetricRangeInvalid):
+		return "invalid metric range"
+	default:
+		return "invalid input"
+	}
+}
+
+// fetchDayMetricsForViewer loads the metric catalog and the values of day
+// for the day form. Partners never see metric data.
+func (handler *Handler) fetchDayMetricsForViewer(user *models.User, day time.Time) ([]models.MetricType, map[uint]string, error) {
+	if !isOwnerUser(user) {
+		return []models.MetricType{}, map[uint]string{}, nil
+	}
+
+	handler.ensureDependencies()
+	metrics, err := handler.metricService.ListMetricTypes(user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	values, err := handler.metricService.FetchValuesForDay(user.ID, day, handler.location)
+	if err != nil {
+		return nil, nil, err
+	}
+	return metrics, services.MetricValueInputs(values), nil
+}
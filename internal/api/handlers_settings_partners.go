@@ -0,0 +1,168 @@
+package api
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func (handler *Handler) CreatePartnerInvite(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	handler.ensureDependencies()
+	rawToken, invite, err := handler.partnerService.CreateInvite(user, time.Now().In(handler.location))
+	if err != nil {
+		if errors.Is(err, services.ErrPartnerInviteOwnerRequired) {
+			return apiError(c, fiber.StatusForbidden, "owner access required")
+		}
+		return apiError(c, fiber.StatusInternalServerError, "failed to create partner invite")
+	}
+
+	inviteURL := buildPartnerInviteURL(c, rawToken)
+	if acceptsJSON(c) {
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+			"ok":         true,
+			"invite_url": inviteURL,
+			"expires_at": invite.ExpiresAt,
+		})
+	}
+
+	data, err := handler.buildSettingsViewData(c, user, FlashPayload{})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).SendString("failed to load settings")
+	}
+	data["SuccessKey"] = "settings.success.partner_invite_created"
+	data["GeneratedPartnerInviteURL"] = inviteURL
+	return handler.render(c, "settings", data)
+}
+
+func (handler *Handler) RevokePartnerInvite(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	inviteID, err := parseSettingsResourceID(c.Params("id"))
+	if err != nil {
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, "partner invite not found")
+	}
+
+	handler.ensureDependencies()
+	if err := handler.partnerService.RevokeInvite(user.ID, inviteID); err != nil {
+		if errors.Is(err, services.ErrPartnerInviteNotFound) {
+			return handler.respondSettingsError(c, fiber.StatusNotFound, "partner invite not found")
+		}
+		return apiError(c, fiber.StatusInternalServerError, "failed to revoke partner invite")
+	}
+
+	handler.setFlashCookie(c, FlashPayload{SettingsSuccess: "partner_invite_revoked"})
+	return redirectOrJSON(c, "/settings")
+}
+
+func (handler *Handler) RevokePartner(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	partnerID, err := parseSettingsResourceID(c.Params("id"))
+	if err != nil {
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, "partner not found")
+	}
+
+	handler.ensureDependencies()
+	if err := handler.partnerService.RevokePartner(user.ID, partnerID); err != nil {
+		if errors.Is(err, services.ErrPartnerNotFound) {
+			return handler.respondSettingsError(c, fiber.StatusNotFound, "partner not found")
+		}
+		return apiError(c, fiber.StatusInternalServerError, "failed to revoke partner")
+	}
+
+	handler.setFlashCookie(c, FlashPayload{SettingsSuccess: "partner_revoked"})
+	return redirectOrJSON(c, "/settings")
+}
+
+func (handler *Handler) UpdatePartnerSharing(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	partnerID, err := parseSettingsResourceID(c.Params("id"))
+	if err != nil {
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, "partner not found")
+	}
+
+	input, err := parsePartnerSharingInput(c)
+	if err != nil {
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid partner sharing input")
+	}
+
+	ownerSymptoms, err := handler.fetchSymptoms(user.ID)
+	if err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to update partner sharing")
+	}
+
+	handler.ensureDependencies()
+	policy := models.PartnerSharingPolicy{
+		HidePeriodDays:    !input.SharePeriodDays,
+		HideFlow:          !input.ShareFlow,
+		HidePredictions:   !input.SharePredictions,
+		HideFertileWindow: !input.ShareFertileWindow,
+		ShareNotes:        input.ShareNotes,
+		SharedSymptomIDs:  input.SharedSymptomIDs,
+	}
+	if err := handler.partnerService.UpdateSharingPolicy(user.ID, partnerID, policy, ownerSymptoms); err != nil {
+		if errors.Is(err, services.ErrPartnerNotFound) {
+			return handler.respondSettingsError(c, fiber.StatusNotFound, "partner not found")
+		}
+		return apiError(c, fiber.StatusInternalServerError, "failed to update partner sharing")
+	}
+
+	handler.setFlashCookie(c, FlashPayload{SettingsSuccess: "partner_sharing_updated"})
+	return redirectOrJSON(c, "/settings")
+}
+
+func parsePartnerSharingInput(c *fiber.Ctx) (partnerSharingInput, error) {
+	input := partnerSharingInput{}
+	if strings.Contains(strings.ToLower(c.Get("Content-Type")), "application/json") {
+		if err := c.BodyParser(&input); err != nil {
+			return partnerSharingInput{}, err
+		}
+		return input, nil
+	}
+
+	input.SharePeriodDays = parseBoolValue(c.FormValue("share_period_days"))
+	input.ShareFlow = parseBoolValue(c.FormValue("share_flow"))
+	input.SharePredictions = parseBoolValue(c.FormValue("share_predictions"))
+	input.ShareFertileWindow = parseBoolValue(c.FormValue("share_fertile_window"))
+	input.ShareNotes = parseBoolValue(c.FormValue("share_notes"))
+	for _, value := range c.Context().PostArgs().PeekMulti("shared_symptom_ids") {
+		parsed, err := strconv.ParseUint(string(value), 10, 64)
+		if err == nil {
+			input.SharedSymptomIDs = append(input.SharedSymptomIDs, uint(parsed))
+		}
+	}
+	return input, nil
+}
+
+func buildPartnerInviteURL(c *fiber.Ctx, rawToken string) string {
+	return strings.TrimRight(c.BaseURL(), "/") + "/register?invite=" + url.QueryEscape(rawToken)
+}
+
+func parseSettingsResourceID(raw string) (uint, error) {
+	parsed, err := strconv.ParseUint(strings.TrimSpace(raw), 10, 64)
+	if err != nil || parsed == 0 {
+		return 0, errors.New("invalid id")
+	}
+	return uint(parsed), nil
+}
@@ -46,6 +46,14 @@ func (handler *Handler) ChangePassword(c *fiber.Ctx) error {
 		}
 	}
 
+	keepSessionID := uint(0)
+	if session, ok := currentSession(c); ok {
+		keepSessionID = session.ID
+	}
+	if err := handler.sessionService.RevokeOthers(user.ID, keepSessionID); err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to update password")
+	}
+
 	if acceptsJSON(c) {
 		return c.JSON(fiber.Map{"ok": true})
 	}
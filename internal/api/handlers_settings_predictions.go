@@ -0,0 +1,40 @@
+package api
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+// UpdatePredictionAlgorithm picks the algorithm that projects the owner's
+// next cycles.
+func (handler *Handler) UpdatePredictionAlgorithm(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	input := predictionAlgorithmInput{}
+	if strings.Contains(strings.ToLower(c.Get("Content-Type")), "application/json") {
+		if err := c.BodyParser(&input); err != nil {
+			return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid input")
+		}
+	} else {
+		input.Algorithm = c.FormValue("algorithm")
+	}
+
+	handler.ensureDependencies()
+	algorithm, err := handler.settingsService.SavePredictionAlgorithm(user.ID, input.Algorithm)
+	if err != nil {
+		if errors.Is(err, services.ErrSettingsPredictionAlgorithmInvalid) {
+			return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid prediction algorithm")
+		}
+		return apiError(c, fiber.StatusInternalServerError, "failed to update prediction algorithm")
+	}
+	user.PredictionAlgorithm = algorithm
+
+	handler.setFlashCookie(c, FlashPayload{SettingsSuccess: "prediction_algorithm_updated"})
+	return redirectOrJSON(c, "/settings")
+}
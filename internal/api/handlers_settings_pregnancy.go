@@ -0,0 +1,67 @@
+package api
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+// UpdatePregnancyMode switches the owner between regular cycles, pregnancy
+// and postpartum. Predictions are paused outside regular cycles.
+func (handler *Handler) UpdatePregnancyMode(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	input := pregnancyModeInput{}
+	if strings.Contains(strings.ToLower(c.Get("Content-Type")), "application/json") {
+		if err := c.BodyParser(&input); err != nil {
+			return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid input")
+		}
+	} else {
+		input.Mode = c.FormValue("mode")
+		input.PregnancyStart = c.FormValue("pregnancy_start")
+		input.DueDate = c.FormValue("due_date")
+		input.PostpartumStart = c.FormValue("postpartum_start")
+	}
+
+	handler.ensureDependencies()
+	update, err := handler.settingsService.ValidatePregnancyMode(services.PregnancyModeValidationInput{
+		Mode:               input.Mode,
+		PregnancyStartRaw:  input.PregnancyStart,
+		DueDateRaw:         input.DueDate,
+		PostpartumStartRaw: input.PostpartumStart,
+	}, time.Now().In(handler.location), handler.location)
+	if err != nil {
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, pregnancyModeErrorMessage(err))
+	}
+
+	if err := handler.settingsService.SavePregnancyMode(user.ID, update); err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to update pregnancy mode")
+	}
+	handler.settingsService.ApplyPregnancyModeSettings(user, update)
+
+	handler.setFlashCookie(c, FlashPayload{SettingsSuccess: "pregnancy_mode_updated"})
+	return redirectOrJSON(c, "/settings")
+}
+
+func pregnancyModeErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, services.ErrSettingsCycleModeInvalid):
+		return "invalid cycle mode"
+	case errors.Is(err, services.ErrSettingsPregnancyDateRequired):
+		return "pregnancy date required"
+	case errors.Is(err, services.ErrSettingsPregnancyDateInvalid):
+		return "invalid pregnancy date"
+	case errors.Is(err, services.ErrSettingsPostpartumDateRequired):
+		return "postpartum date required"
+	case errors.Is(err, services.ErrSettingsPostpartumDateInvalid):
+		return "invalid postpartum date"
+	default:
+		return "invalid input"
+	}
+}
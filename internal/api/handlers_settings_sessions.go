@@ -0,0 +1,65 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func (handler *Handler) RevokeSession(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	sessionID, err := parseSettingsResourceID(c.Params("id"))
+	if err != nil {
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, "session not found")
+	}
+
+	handler.ensureDependencies()
+	if err := handler.sessionService.Revoke(user.ID, sessionID); err != nil {
+		if errors.Is(err, services.ErrSessionNotFound) {
+			return handler.respondSettingsError(c, fiber.StatusNotFound, "session not found")
+		}
+		return apiError(c, fiber.StatusInternalServerError, "failed to revoke session")
+	}
+
+	if session, ok := currentSession(c); ok && session.ID == sessionID {
+		return handler.respondSignedOut(c)
+	}
+
+	handler.setFlashCookie(c, FlashPayload{SettingsSuccess: "session_revoked"})
+	return redirectOrJSON(c, "/settings")
+}
+
+func (handler *Handler) RevokeAllSessions(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	handler.ensureDependencies()
+	if err := handler.sessionService.RevokeOthers(user.ID, 0); err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to revoke sessions")
+	}
+	return handler.respondSignedOut(c)
+}
+
+func buildSessionViews(sessions []models.AuthSession, currentSessionID uint, language string, location *time.Location) []SessionView {
+	views := make([]SessionView, 0, len(sessions))
+	for _, session := range sessions {
+		lastSeen := session.LastSeenAt.In(location)
+		views = append(views, SessionView{
+			ID:        session.ID,
+			UserAgent: session.UserAgent,
+			IPAddress: session.IPAddress,
+			LastSeen:  localizedDateDisplay(language, lastSeen) + " " + lastSeen.Format("15:04"),
+			Current:   session.ID == currentSessionID,
+		})
+	}
+	return views
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func (handler *Handler) StartTwoFactorSetup(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	handler.ensureDependencies()
+	enrollment, err := handler.twoFactorService.BeginEnrollment(user)
+	if err != nil {
+		if errors.Is(err, services.ErrTwoFactorAlreadyEnabled) {
+			return handler.respondSettingsError(c, fiber.StatusConflict, "two factor already enabled")
+		}
+		return apiError(c, fiber.StatusInternalServerError, "failed to start two factor setup")
+	}
+
+	if acceptsJSON(c) {
+		return c.JSON(fiber.Map{
+			"ok":          true,
+			"secret":      enrollment.Secret,
+			"otpauth_uri": enrollment.URI,
+		})
+	}
+	return redirectOrJSON(c, "/settings#settings-two-factor")
+}
+
+func (handler *Handler) EnableTwoFactor(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	input := twoFactorCodeInput{}
+	if err := c.BodyParser(&input); err != nil || strings.TrimSpace(input.Code) == "" {
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid two factor code")
+	}
+
+	handler.ensureDependencies()
+	if err := handler.twoFactorService.ConfirmEnrollment(user, input.Code, time.Now()); err != nil {
+		switch {
+		case errors.Is(err, services.ErrTwoFactorCodeInvalid):
+			return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid two factor code")
+		case errors.Is(err, services.ErrTwoFactorNotPending):
+			return handler.respondSettingsError(c, fiber.StatusBadRequest, "two factor setup required")
+		case errors.Is(err, services.ErrTwoFactorAlreadyEnabled):
+			return handler.respondSettingsError(c, fiber.StatusConflict, "two factor already enabled")
+		default:
+			return apiError(c, fiber.StatusInternalServerError, "failed to enable two factor")
+		}
+	}
+
+	handler.setFlashCookie(c, FlashPayload{SettingsSuccess: "two_factor_enabled"})
+	return redirectOrJSON(c, "/settings")
+}
+
+func (handler *Handler) DisableTwoFactor(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	input := twoFactorDisableInput{}
+	if err := c.BodyParser(&input); err != nil || strings.TrimSpace(input.Password) == "" {
+		return handler.respondSettingsError(c, fiber.StatusBadRequest, "invalid password")
+	}
+
+	handler.ensureDependencies()
+	if err := handler.twoFactorService.Disable(user, input.Password); err != nil {
+		switch {
+		case errors.Is(err, services.ErrTwoFactorPasswordInvalid):
+			return handler.respondSettingsError(c, fiber.StatusUnauthorized, "invalid password")
+		case errors.Is(err, services.ErrTwoFactorNotEnabled):
+			return handler.respondSettingsError(c, fiber.StatusBadRequest, "two factor not enabled")
+		default:
+			return apiError(c, fiber.StatusInternalServerError, "failed to disable two factor")
+		}
+	}
+
+	handler.setFlashCookie(c, FlashPayload{SettingsSuccess: "two_factor_disabled"})
+	return redirectOrJSON(c, "/settings")
+}
+
+func (handler *Handler) buildTwoFactorSettingsView(user *models.User) TwoFactorSettingsView {
+	handler.ensureDependencies()
+	view := TwoFactorSettingsView{Enabled: services.IsTwoFactorEnabled(user)}
+	if enrollment, pending := handler.twoFactorService.PendingEnrollment(user); pending {
+		view.Pending = true
+		view.Secret = enrollment.Secret
+		view.URI = enrollment.URI
+	}
+	return view
+}
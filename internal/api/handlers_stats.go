@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/services"
 )
 
 func (handler *Handler) GetStatsOverview(c *fiber.Ctx) error {
@@ -12,11 +13,53 @@ func (handler *Handler) GetStatsOverview(c *fiber.Ctx) error {
 		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
 	}
 
+	dataOwner, err := handler.resolveDataOwner(user)
+	if err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to fetch stats")
+	}
+
 	now := time.Now().In(handler.location)
-	stats, _, err := handler.buildCycleStatsForRange(user, now.AddDate(-2, 0, 0), now, now)
+	stats, _, err := handler.buildCycleStatsForRange(dataOwner, now.AddDate(-2, 0, 0), now, now)
 	if err != nil {
 		return apiError(c, fiber.StatusInternalServerError, "failed to fetch stats")
 	}
 
-	return c.JSON(stats)
+	return c.JSON(services.SanitizeCycleStatsForViewer(user, stats))
+}
+
+// GetPredictionAccuracy backtests each prediction algorithm on the owner's
+// completed cycles and reports its mean absolute error in days.
+func (handler *Handler) GetPredictionAccuracy(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	handler.ensureDependencies()
+	accuracy, err := handler.statsService.BuildPredictionAccuracy(user, time.Now().In(handler.location), handler.location)
+	if err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to fetch stats")
+	}
+
+	return c.JSON(fiber.Map{
+		"algorithm":     services.PredictorForUser(user).Name(),
+		"most_accurate": services.MostAccuratePredictionAlgorithm(accuracy),
+		"algorithms":    accuracy,
+	})
+}
+
+// GetPredictionHistory compares the owner's saved predictions with the cycle
+// starts that followed them.
+func (handler *Handler) GetPredictionHistory(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	handler.ensureDependencies()
+	history, err := handler.predictionHistory.BuildHistory(user, handler.location)
+	if err != nil {
+		return apiError(c, fiber.StatusInternalServerError, "failed to fetch stats")
+	}
+	return c.JSON(history)
 }
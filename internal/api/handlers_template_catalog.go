@@ -6,6 +6,7 @@ var pageTemplates = []string{
 	"recovery_code",
 	"forgot_password",
 	"reset_password",
+	"two_factor",
 	"onboarding",
 	"dashboard",
 	"calendar",
@@ -6,6 +6,8 @@ import (
 	"html/template"
 	"math"
 	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/services"
 )
 
 func formatTemplateDate(value time.Time, layout string) string {
@@ -32,6 +34,15 @@ func formatTemplateFloat(value float64) string {
 	return fmt.Sprintf("%.1f", rounded)
 }
 
+// formatTemplateTemperature renders a stored Celsius reading in unit with
+// two decimals, or an empty string when there is no reading.
+func formatTemplateTemperature(celsius float64, unit string) string {
+	if celsius <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.2f", services.TemperatureInUnit(celsius, unit))
+}
+
 func templateToJSON(value any) template.JS {
 	serialized, err := json.Marshal(value)
 	if err != nil {
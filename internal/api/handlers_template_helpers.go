@@ -9,6 +9,7 @@ func newTemplateFuncMap() template.FuncMap {
 		"formatDate":          formatTemplateDate,
 		"formatLocalizedDate": formatTemplateLocalizedDate,
 		"formatFloat":         formatTemplateFloat,
+		"formatTemperature":   formatTemplateTemperature,
 		"t":                   templateTranslate,
 		"phaseLabel":          templatePhaseLabel,
 		"phaseIcon":           templatePhaseIcon,
@@ -20,6 +21,9 @@ func newTemplateFuncMap() template.FuncMap {
 		"hasDisplayName":      templateHasDisplayName,
 		"isActiveRoute":       isActiveTemplateRoute,
 		"hasSymptom":          hasTemplateSymptom,
+		"hasValue":            hasTemplateValue,
+		"symptomSeverity":     templateSymptomSeverity,
+		"metricRange":         templateMetricRange,
 		"toJSON":              templateToJSON,
 		"dict":                templateDict,
 	}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
 )
 
 func templateUserIdentity(user *models.User) string {
@@ -51,6 +52,23 @@ func hasTemplateSymptom(set map[uint]bool, id uint) bool {
 	return set[id]
 }
 
+func hasTemplateValue(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+func templateSymptomSeverity(severities map[uint]int, id uint) int {
+	return services.SymptomSeverity(models.DailyLog{SymptomSeverities: severities}, id)
+}
+
+func templateMetricRange(metric models.MetricType) string {
+	return services.MetricRangeLabel(metric)
+}
+
 func templateDict(values ...any) (map[string]any, error) {
 	if len(values)%2 != 0 {
 		return nil, fmt.Errorf("dict requires key-value pairs")
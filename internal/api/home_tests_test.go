@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func TestUpsertDayStoresHomeTests(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "lh-upsert@example.com", "StrongPass1", true)
+	authCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+	handler := &Handler{db: database, location: time.UTC}
+
+	form := url.Values{
+		"flow":           {models.FlowNone},
+		"lh_test":        {"peak"},
+		"pregnancy_test": {""},
+		"test_brand":     {"Clearblue"},
+	}
+	request := httptest.NewRequest(http.MethodPost, "/api/days/2026-02-19", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Cookie", authCookie)
+	response, err := app.Test(request, -1)
+	if err != nil {
+		t.Fatalf("upsert request failed: %v", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+	entry := loadDayLogForTest(t, handler, user.ID, "2026-02-19")
+	if entry.LHTest != models.LHTestPeak || entry.PregnancyTest != "" || entry.TestBrand != "Clearblue" {
+		t.Fatalf("expected stored test results, got %#v", entry)
+	}
+
+	response = postDayJSONForTest(t, app, authCookie, "2026-02-19", map[string]any{"flow": models.FlowNone, "notes": "kept"})
+	response.Body.Close()
+	entry = loadDayLogForTest(t, handler, user.ID, "2026-02-19")
+	if entry.LHTest != models.LHTestPeak || entry.Notes != "kept" {
+		t.Fatalf("expected save without test fields to keep them, got %#v", entry)
+	}
+
+	for _, testCase := range []struct {
+		payload map[string]any
+		message string
+	}{
+		{payload: map[string]any{"lh_test": "maybe"}, message: "invalid lh test value"},
+		{payload: map[string]any{"pregnancy_test": "peak"}, message: "invalid pregnancy test value"},
+	} {
+		testCase.payload["flow"] = models.FlowNone
+		response = postDayJSONForTest(t, app, authCookie, "2026-02-19", testCase.payload)
+		if response.StatusCode != http.StatusBadRequest {
+			t.Fatalf("expected status 400 for %#v, got %d", testCase.payload, response.StatusCode)
+		}
+		if message := readAPIError(t, response.Body); message != testCase.message {
+			t.Fatalf("expected %q, got %q", testCase.message, message)
+		}
+		response.Body.Close()
+	}
+}
+
+func TestDashboardPromptsPregnancyModeAfterPositiveTest(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "pregnancy-prompt@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+
+	body := smokeGET(t, app, ownerCookie, "/dashboard", http.StatusOK)
+	if strings.Contains(body, "data-pregnancy-prompt") {
+		t.Fatal("expected no pregnancy prompt without a positive test")
+	}
+
+	today := services.DateAtLocation(time.Now().UTC(), time.UTC)
+	response := postDayJSONForTest(t, app, ownerCookie, today.Format("2006-01-02"), map[string]any{
+		"flow":           models.FlowNone,
+		"pregnancy_test": "faint",
+		"test_brand":     "First Response",
+	})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+
+	body = smokeGET(t, app, ownerCookie, "/dashboard", http.StatusOK)
+	if !strings.Contains(body, "data-pregnancy-prompt") || !strings.Contains(body, "Positive pregnancy test") {
+		t.Fatal("expected pregnancy prompt after a faint positive test")
+	}
+
+	partner := createLinkedPartnerForTest(t, app, database, ownerCookie, "pregnancy-partner@example.com")
+	partnerCookie := loginAndExtractAuthCookie(t, app, partner.Email, "StrongPass1")
+	body = smokeGET(t, app, partnerCookie, "/dashboard", http.StatusOK)
+	if strings.Contains(body, "data-pregnancy-prompt") {
+		t.Fatal("expected no pregnancy prompt for partner")
+	}
+	body = smokeGET(t, app, partnerCookie, "/api/days?from="+today.Format("2006-01-02")+"&to="+today.Format("2006-01-02"), http.StatusOK)
+	logs := []models.DailyLog{}
+	if err := json.Unmarshal([]byte(body), &logs); err != nil {
+		t.Fatalf("decode partner days: %v", err)
+	}
+	if len(logs) != 1 || logs[0].PregnancyTest != "" || logs[0].TestBrand != "" {
+		t.Fatalf("expected test results hidden from partner, got %#v", logs)
+	}
+}
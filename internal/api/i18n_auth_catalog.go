@@ -16,6 +16,15 @@ var authErrorKeys = map[string]string{
 	"too_many_forgot_password_attempts":               "auth.error.too_many_forgot_password_attempts",
 	"too many forgot password attempts":               "auth.error.too_many_forgot_password_attempts",
 	"invalid reset token":                             "auth.error.invalid_reset_token",
+	"invalid partner invite":                          "auth.error.invalid_partner_invite",
+	"registration invite required":                    "auth.error.registration_invite_required",
+	"invalid registration invite":                     "auth.error.invalid_registration_invite",
+	"invalid two factor code":                         "auth.error.invalid_two_factor_code",
+	"too many two factor attempts":                    "auth.error.too_many_two_factor_attempts",
+	"two factor session expired":                      "auth.error.two_factor_session_expired",
+	"two factor setup required":                       "settings.error.two_factor_setup_required",
+	"two factor already enabled":                      "settings.error.two_factor_already_enabled",
+	"two factor not enabled":                          "settings.error.two_factor_not_enabled",
 	"invalid current password":                        "settings.error.invalid_current_password",
 	"new password must differ":                        "settings.error.password_unchanged",
 	"invalid settings input":                          "settings.error.invalid_input",
@@ -23,7 +32,53 @@ var authErrorKeys = map[string]string{
 	"display name too long":                           "settings.error.display_name_too_long",
 	"invalid cycle start date":                        "settings.error.invalid_last_period_start",
 	"invalid password":                                "settings.error.invalid_password",
+	"partner invite not found":                        "settings.error.partner_invite_not_found",
+	"partner not found":                               "settings.error.partner_not_found",
+	"invalid partner sharing input":                   "settings.error.partner_sharing_invalid",
+	"session not found":                               "settings.error.session_not_found",
+	"api token name required":                         "settings.error.api_token_name_required",
+	"invalid api token scope":                         "settings.error.api_token_scope_invalid",
+	"api token not found":                             "settings.error.api_token_not_found",
+	"invalid calendar feed input":                     "settings.error.calendar_feed_invalid",
+	"calendar feed not found":                         "settings.error.calendar_feed_not_found",
+	"invalid cycle mode":                              "settings.error.cycle_mode_invalid",
+	"pregnancy date required":                         "settings.error.pregnancy_date_required",
+	"invalid pregnancy date":                          "settings.error.pregnancy_date_invalid",
+	"postpartum date required":                        "settings.error.postpartum_date_required",
+	"invalid postpartum date":                         "settings.error.postpartum_date_invalid",
+	"invalid contraception method":                    "settings.error.contraception_method_invalid",
+	"invalid pack length":                             "settings.error.contraception_pack_invalid",
+	"contraception start required":                    "settings.error.contraception_start_required",
+	"invalid contraception start":                     "settings.error.contraception_start_invalid",
+	"invalid prediction algorithm":                    "settings.error.prediction_algorithm_invalid",
+	"invalid luteal phase length":                     "settings.error.luteal_phase_invalid",
+	"invalid medication name":                         "settings.error.medication_name_invalid",
+	"invalid medication dose":                         "settings.error.medication_dose_invalid",
+	"invalid medication unit":                         "settings.error.medication_unit_invalid",
+	"invalid medication schedule":                     "settings.error.medication_schedule_invalid",
+	"medication not found":                            "settings.error.medication_not_found",
+	"invalid metric name":                             "settings.error.metric_name_invalid",
+	"invalid metric unit":                             "settings.error.metric_unit_invalid",
+	"invalid metric type":                             "settings.error.metric_type_invalid",
+	"invalid metric range":                            "settings.error.metric_range_invalid",
+	"metric not found":                                "settings.error.metric_not_found",
+	"unsupported import format":                       "settings.error.import_format_unsupported",
+	"invalid import mode":                             "settings.error.import_mode_invalid",
+	"invalid import payload":                          "settings.error.import_payload_invalid",
+	"invalid import entry":                            "settings.error.import_entry_invalid",
 	"period flow is required":                         "calendar.error.period_flow_required",
+	"invalid temperature value":                       "calendar.error.temperature_invalid",
+	"invalid temperature time":                        "calendar.error.temperature_time_invalid",
+	"invalid mucus value":                             "calendar.error.mucus_invalid",
+	"invalid cervix value":                            "calendar.error.cervix_invalid",
+	"invalid lh test value":                           "calendar.error.lh_test_invalid",
+	"invalid pregnancy test value":                    "calendar.error.pregnancy_test_invalid",
+	"invalid medication ids":                          "calendar.error.medication_ids_invalid",
+	"invalid intimacy value":                          "calendar.error.intimacy_invalid",
+	"invalid cycle override value":                    "calendar.error.cycle_override_invalid",
+	"invalid metric ids":                              "calendar.error.metric_ids_invalid",
+	"invalid metric value":                            "calendar.error.metric_value_invalid",
+	"invalid symptom severity":                        "calendar.error.symptom_severity_invalid",
 	"date is required":                                "onboarding.error.date_required",
 	"invalid last period start":                       "onboarding.error.invalid_last_period_start",
 	"last period start must be within last 60 days":   "onboarding.error.last_period_range",
@@ -16,6 +16,44 @@ func settingsStatusTranslationKey(status string) string {
 		return "settings.success.profile_name_cleared"
 	case "data_cleared":
 		return "settings.success.data_cleared"
+	case "partner_invite_revoked":
+		return "settings.success.partner_invite_revoked"
+	case "partner_revoked":
+		return "settings.success.partner_revoked"
+	case "partner_sharing_updated":
+		return "settings.success.partner_sharing_updated"
+	case "session_revoked":
+		return "settings.success.session_revoked"
+	case "two_factor_enabled":
+		return "settings.success.two_factor_enabled"
+	case "two_factor_disabled":
+		return "settings.success.two_factor_disabled"
+	case "api_token_revoked":
+		return "settings.success.api_token_revoked"
+	case "calendar_feed_updated":
+		return "settings.success.calendar_feed_updated"
+	case "calendar_feed_disabled":
+		return "settings.success.calendar_feed_disabled"
+	case "pregnancy_mode_updated":
+		return "settings.success.pregnancy_mode_updated"
+	case "contraception_updated":
+		return "settings.success.contraception_updated"
+	case "prediction_algorithm_updated":
+		return "settings.success.prediction_algorithm_updated"
+	case "luteal_phase_updated":
+		return "settings.success.luteal_phase_updated"
+	case "medication_created":
+		return "settings.success.medication_created"
+	case "medication_deleted":
+		return "settings.success.medication_deleted"
+	case "intimacy_tracking_updated":
+		return "settings.success.intimacy_tracking_updated"
+	case "metric_created":
+		return "settings.success.metric_created"
+	case "metric_deleted":
+		return "settings.success.metric_deleted"
+	case "import_completed":
+		return "settings.success.import_completed"
 	default:
 		return ""
 	}
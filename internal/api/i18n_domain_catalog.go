@@ -25,6 +25,8 @@ func phaseTranslationKey(phase string) string {
 
 func flowTranslationKey(flow string) string {
 	switch strings.ToLower(strings.TrimSpace(flow)) {
+	case models.FlowSpotting:
+		return "dashboard.flow.spotting"
 	case models.FlowLight:
 		return "dashboard.flow.light"
 	case models.FlowMedium:
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func postImportPreviewForTest(t *testing.T, app *fiber.App, authCookie string, format string, mode string, file string, acceptJSON bool) *http.Response {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	_ = writer.WriteField("format", format)
+	_ = writer.WriteField("mode", mode)
+	part, err := writer.CreateFormFile("file", "export."+format)
+	if err != nil {
+		t.Fatalf("create form file: %v", err)
+	}
+	if _, err := part.Write([]byte(file)); err != nil {
+		t.Fatalf("write form file: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close multipart writer: %v", err)
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/api/settings/import/preview", body)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	request.Header.Set("Cookie", authCookie)
+	if acceptJSON {
+		request.Header.Set("Accept", fiber.MIMEApplicationJSON)
+	}
+
+	response, err := app.Test(request, -1)
+	if err != nil {
+		t.Fatalf("import preview request failed: %v", err)
+	}
+	return response
+}
+
+func TestImportDataParsesForeignFormats(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "import-clue@example.com", "StrongPass1", true)
+	authCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+
+	clueExport := []byte(`[
+		{"date":"2026-02-10","type":"period","value":{"option":"heavy"}},
+		{"date":"2026-02-10","type":"pain","value":[{"option":"cramps"}]},
+		{"date":"2026-02-10","type":"mind","value":{"option":"brainFog"}}
+	]`)
+	response := postImportFormatForTest(t, app, authCookie, "clue", "", clueExport)
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+	report := readImportReportForTest(t, response.Body)
+	if report.Created != 1 || len(report.SymptomsCreated) != 1 || report.SymptomsCreated[0] != "Brain fog" {
+		t.Fatalf("unexpected import report: %#v", report)
+	}
+
+	var imported models.DailyLog
+	if err := database.Where("user_id = ?", user.ID).First(&imported).Error; err != nil {
+		t.Fatalf("load imported log: %v", err)
+	}
+	if !imported.IsPeriod || imported.Flow != models.FlowHeavy || len(imported.SymptomIDs) != 2 {
+		t.Fatalf("unexpected imported log: %#v", imported)
+	}
+
+	unsupported := postImportFormatForTest(t, app, authCookie, "xml", "", clueExport)
+	defer unsupported.Body.Close()
+	if unsupported.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for unknown format, got %d", unsupported.StatusCode)
+	}
+	if errorValue := readAPIError(t, unsupported.Body); errorValue != "unsupported import format" {
+		t.Fatalf("expected unsupported import format error, got %q", errorValue)
+	}
+}
+
+func TestSettingsImportPreviewThenCommit(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "import-preview@example.com", "StrongPass1", true)
+	authCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+
+	existing := models.DailyLog{
+		UserID:     user.ID,
+		Date:       mustParseDayForImportTest(t, "2026-02-10"),
+		IsPeriod:   true,
+		Flow:       models.FlowLight,
+		SymptomIDs: []uint{},
+		Notes:      "mine",
+	}
+	if err := database.Create(&existing).Error; err != nil {
+		t.Fatalf("create daily log: %v", err)
+	}
+
+	file := "date,flow,notes\n2026-02-10,heavy,theirs\n2026-02-11,medium,\n"
+
+	page := postImportPreviewForTest(t, app, authCookie, "csv", "overwrite", file, false)
+	defer page.Body.Close()
+	if page.StatusCode != http.StatusOK {
+		t.Fatalf("expected preview page status 200, got %d", page.StatusCode)
+	}
+	rendered, err := io.ReadAll(page.Body)
+	if err != nil {
+		t.Fatalf("read preview page: %v", err)
+	}
+	html := string(rendered)
+	if !strings.Contains(html, "data-import-preview") || !strings.Contains(html, "data-import-conflicts") || !strings.Contains(html, "2026-02-10") {
+		t.Fatalf("expected preview with conflict report in settings page")
+	}
+	if !strings.Contains(html, `action="/api/settings/import/commit"`) {
+		t.Fatalf("expected commit form in preview")
+	}
+
+	preview := postImportPreviewForTest(t, app, authCookie, "csv", "overwrite", file, true)
+	defer preview.Body.Close()
+	if preview.StatusCode != http.StatusOK {
+		t.Fatalf("expected preview status 200, got %d", preview.StatusCode)
+	}
+	previewPayload := struct {
+		Report   services.ImportReport      `json:"report"`
+		Document services.ImportJSONPayload `json:"document"`
+	}{}
+	if err := json.NewDecoder(preview.Body).Decode(&previewPayload); err != nil {
+		t.Fatalf("decode preview: %v", err)
+	}
+	if !previewPayload.Report.DryRun || previewPayload.Report.Created != 1 || previewPayload.Report.Updated != 1 || previewPayload.Report.Conflicts != 1 {
+		t.Fatalf("unexpected preview report: %#v", previewPayload.Report)
+	}
+
+	var count int64
+	database.Model(&models.DailyLog{}).Where("user_id = ?", user.ID).Count(&count)
+	if count != 1 {
+		t.Fatalf("expected preview to write nothing, found %d logs", count)
+	}
+
+	document, err := json.Marshal(previewPayload.Document)
+	if err != nil {
+		t.Fatalf("encode document: %v", err)
+	}
+	commit := postSessionFormForTest(t, app, authCookie, "/api/settings/import/commit", url.Values{
+		"mode":     {"overwrite"},
+		"document": {string(document)},
+	})
+	defer commit.Body.Close()
+	if commit.StatusCode != http.StatusOK {
+		t.Fatalf("expected commit status 200, got %d", commit.StatusCode)
+	}
+
+	var updated models.DailyLog
+	if err := database.First(&updated, existing.ID).Error; err != nil {
+		t.Fatalf("load updated log: %v", err)
+	}
+	if updated.Flow != models.FlowHeavy || updated.Notes != "theirs" {
+		t.Fatalf("expected overwrite to replace the day, got %#v", updated)
+	}
+	database.Model(&models.DailyLog{}).Where("user_id = ?", user.ID).Count(&count)
+	if count != 2 {
+		t.Fatalf("expected two logs after commit, found %d", count)
+	}
+}
+
+func TestSettingsImportPreviewRejectsUnreadableFile(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "import-preview-invalid@example.com", "StrongPass1", true)
+	authCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+
+	response := postImportPreviewForTest(t, app, authCookie, "flo", "merge", "date,flow\n", false)
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusSeeOther {
+		t.Fatalf("expected redirect back to settings, got %d", response.StatusCode)
+	}
+	if location := response.Header.Get("Location"); location != "/settings" {
+		t.Fatalf("expected redirect to /settings, got %q", location)
+	}
+	if flash := responseCookie(response.Cookies(), flashCookieName); flash == nil {
+		t.Fatal("expected flash cookie with import error")
+	}
+}
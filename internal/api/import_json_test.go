@@ -0,0 +1,176 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func postImportForTest(t *testing.T, app *fiber.App, authCookie string, query string, body []byte) *http.Response {
+	t.Helper()
+	return postImportFormatForTest(t, app, authCookie, "json", query, body)
+}
+
+func postImportFormatForTest(t *testing.T, app *fiber.App, authCookie string, format string, query string, body []byte) *http.Response {
+	t.Helper()
+
+	request := httptest.NewRequest(http.MethodPost, "/api/import/"+format+query, bytes.NewReader(body))
+	request.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+	request.Header.Set("Accept", fiber.MIMEApplicationJSON)
+	request.Header.Set("Cookie", authCookie)
+
+	response, err := app.Test(request, -1)
+	if err != nil {
+		t.Fatalf("import request failed: %v", err)
+	}
+	return response
+}
+
+func readImportReportForTest(t *testing.T, body io.Reader) services.ImportReport {
+	t.Helper()
+
+	payload := struct {
+		Report services.ImportReport `json:"report"`
+	}{}
+	if err := json.NewDecoder(body).Decode(&payload); err != nil {
+		t.Fatalf("decode import response: %v", err)
+	}
+	return payload.Report
+}
+
+func TestImportJSONRestoresOwnExport(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "import-roundtrip@example.com", "StrongPass1", true)
+	authCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+
+	custom := models.SymptomType{UserID: user.ID, Name: "Dizziness", Icon: "🌀", Color: "#123456"}
+	if err := database.Create(&custom).Error; err != nil {
+		t.Fatalf("create custom symptom: %v", err)
+	}
+	handler := &Handler{db: database}
+	if _, err := handler.fetchSymptoms(user.ID); err != nil {
+		t.Fatalf("seed builtin symptoms: %v", err)
+	}
+	var cramps models.SymptomType
+	if err := database.Where("user_id = ? AND name = ?", user.ID, "Cramps").First(&cramps).Error; err != nil {
+		t.Fatalf("load cramps symptom: %v", err)
+	}
+	log := models.DailyLog{
+		UserID:     user.ID,
+		Date:       mustParseDayForImportTest(t, "2026-02-10"),
+		IsPeriod:   true,
+		Flow:       models.FlowHeavy,
+		SymptomIDs: []uint{cramps.ID, custom.ID},
+		Notes:      "first day",
+	}
+	if err := database.Create(&log).Error; err != nil {
+		t.Fatalf("create daily log: %v", err)
+	}
+
+	exported := []byte(smokeGET(t, app, authCookie, "/api/export/json", http.StatusOK))
+
+	if err := database.Where("user_id = ?", user.ID).Delete(&models.DailyLog{}).Error; err != nil {
+		t.Fatalf("clear logs: %v", err)
+	}
+	if err := database.Delete(&custom).Error; err != nil {
+		t.Fatalf("delete custom symptom: %v", err)
+	}
+
+	dryRun := postImportForTest(t, app, authCookie, "?dry_run=true", exported)
+	defer dryRun.Body.Close()
+	if dryRun.StatusCode != http.StatusOK {
+		t.Fatalf("expected dry run status 200, got %d", dryRun.StatusCode)
+	}
+	dryReport := readImportReportForTest(t, dryRun.Body)
+	if !dryReport.DryRun || dryReport.Created != 1 || len(dryReport.SymptomsCreated) != 1 {
+		t.Fatalf("unexpected dry run report: %#v", dryReport)
+	}
+	var count int64
+	database.Model(&models.DailyLog{}).Where("user_id = ?", user.ID).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected dry run to write nothing, found %d logs", count)
+	}
+
+	response := postImportForTest(t, app, authCookie, "?mode=merge", exported)
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected import status 200, got %d", response.StatusCode)
+	}
+	if report := readImportReportForTest(t, response.Body); report.Created != 1 {
+		t.Fatalf("unexpected import report: %#v", report)
+	}
+
+	var restoredSymptom models.SymptomType
+	if err := database.Where("user_id = ? AND name = ?", user.ID, "Dizziness").First(&restoredSymptom).Error; err != nil {
+		t.Fatalf("expected custom symptom to be restored: %v", err)
+	}
+	if restoredSymptom.Icon != "🌀" || restoredSymptom.Color != "#123456" {
+		t.Fatalf("expected custom symptom definition to be restored, got %#v", restoredSymptom)
+	}
+
+	var restored models.DailyLog
+	if err := database.Where("user_id = ?", user.ID).First(&restored).Error; err != nil {
+		t.Fatalf("load restored log: %v", err)
+	}
+	if !restored.IsPeriod || restored.Flow != models.FlowHeavy || restored.Notes != "first day" || len(restored.SymptomIDs) != 2 {
+		t.Fatalf("unexpected restored log: %#v", restored)
+	}
+
+	var refreshed models.User
+	if err := database.First(&refreshed, user.ID).Error; err != nil {
+		t.Fatalf("load user: %v", err)
+	}
+	if refreshed.LastPeriodStart == nil {
+		t.Fatal("expected last period start to be refreshed after import")
+	}
+}
+
+func TestImportJSONRejectsInvalidRequests(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "import-invalid@example.com", "StrongPass1", true)
+	authCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+
+	testCases := []struct {
+		name      string
+		query     string
+		body      string
+		wantError string
+	}{
+		{name: "mode", query: "?mode=replace", body: `{"entries": []}`, wantError: "invalid import mode"},
+		{name: "payload", body: `not json`, wantError: "invalid import payload"},
+		{name: "entry", body: `{"entries": [{"date": "2026-13-40", "period": false, "flow": "none"}]}`, wantError: "invalid import entry"},
+	}
+
+	for _, testCase := range testCases {
+		response := postImportForTest(t, app, authCookie, testCase.query, []byte(testCase.body))
+		if response.StatusCode != http.StatusBadRequest {
+			t.Fatalf("%s: expected status 400, got %d", testCase.name, response.StatusCode)
+		}
+		if errorValue := readAPIError(t, response.Body); errorValue != testCase.wantError {
+			t.Fatalf("%s: expected %q, got %q", testCase.name, testCase.wantError, errorValue)
+		}
+		response.Body.Close()
+	}
+}
+
+func mustParseDayForImportTest(t *testing.T, raw string) time.Time {
+	t.Helper()
+
+	parsed, err := parseDayParam(raw, time.UTC)
+	if err != nil {
+		t.Fatalf("parse day: %v", err)
+	}
+	return parsed
+}
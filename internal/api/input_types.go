@@ -1,17 +1,40 @@
 package api
 
 type credentialsInput struct {
-	Email           string `json:"email" form:"email"`
-	Password        string `json:"password" form:"password"`
-	ConfirmPassword string `json:"confirm_password" form:"confirm_password"`
-	RememberMe      bool   `json:"remember_me" form:"remember_me"`
+	Email             string `json:"email" form:"email"`
+	Password          string `json:"password" form:"password"`
+	ConfirmPassword   string `json:"confirm_password" form:"confirm_password"`
+	RememberMe        bool   `json:"remember_me" form:"remember_me"`
+	InviteToken       string `json:"invite" form:"invite"`
+	RegistrationToken string `json:"registration_token" form:"registration_token"`
 }
 
 type dayPayload struct {
-	IsPeriod   bool   `json:"is_period"`
-	Flow       string `json:"flow"`
-	SymptomIDs []uint `json:"symptom_ids"`
-	Notes      string `json:"notes"`
+	IsPeriod          bool              `json:"is_period"`
+	Flow              string            `json:"flow"`
+	SymptomIDs        []uint            `json:"symptom_ids"`
+	SymptomSeverities map[uint]int      `json:"symptom_severities"`
+	Notes             string            `json:"notes"`
+	BBT               *float64          `json:"bbt"`
+	BBTUnit           string            `json:"bbt_unit"`
+	BBTTime           string            `json:"bbt_time"`
+	BBTDisturbed      bool              `json:"bbt_disturbed"`
+	Mucus             *string           `json:"mucus"`
+	CervixPosition    *string           `json:"cervix_position"`
+	CervixFirmness    *string           `json:"cervix_firmness"`
+	LHTest            *string           `json:"lh_test"`
+	PregnancyTest     *string           `json:"pregnancy_test"`
+	TestBrand         *string           `json:"test_brand"`
+	PillTaken         *bool             `json:"pill_taken"`
+	MedicationIDs     *[]uint           `json:"medication_ids"`
+	Metrics           *map[uint]float64 `json:"metrics"`
+
+	Intimacy           *bool    `json:"intimacy"`
+	IntimacyProtection string   `json:"intimacy_protection"`
+	IntimacyMethods    []string `json:"intimacy_methods"`
+
+	CycleStartOverride *string `json:"cycle_start_override"`
+	CycleExclusion     *string `json:"cycle_exclusion"`
 }
 
 type symptomPayload struct {
@@ -24,6 +47,14 @@ type forgotPasswordInput struct {
 	RecoveryCode string `json:"recovery_code" form:"recovery_code"`
 }
 
+type twoFactorCodeInput struct {
+	Code string `json:"code" form:"code"`
+}
+
+type twoFactorDisableInput struct {
+	Password string `json:"password" form:"password"`
+}
+
 type resetPasswordInput struct {
 	Password        string `json:"password" form:"password"`
 	ConfirmPassword string `json:"confirm_password" form:"confirm_password"`
@@ -41,6 +72,7 @@ type cycleSettingsInput struct {
 	AutoPeriodFill     bool   `json:"auto_period_fill" form:"auto_period_fill"`
 	LastPeriodStart    string `json:"last_period_start" form:"last_period_start"`
 	LastPeriodStartSet bool   `json:"-" form:"-"`
+	TemperatureUnit    string `json:"temperature_unit" form:"temperature_unit"`
 }
 
 type profileSettingsInput struct {
@@ -50,3 +82,65 @@ type profileSettingsInput struct {
 type deleteAccountInput struct {
 	Password string `json:"password" form:"password"`
 }
+
+type partnerSharingInput struct {
+	SharePeriodDays    bool   `json:"share_period_days" form:"share_period_days"`
+	ShareFlow          bool   `json:"share_flow" form:"share_flow"`
+	SharePredictions   bool   `json:"share_predictions" form:"share_predictions"`
+	ShareFertileWindow bool   `json:"share_fertile_window" form:"share_fertile_window"`
+	ShareNotes         bool   `json:"share_notes" form:"share_notes"`
+	SharedSymptomIDs   []uint `json:"shared_symptom_ids" form:"-"`
+}
+
+type calendarFeedInput struct {
+	Cycles           int  `json:"cycles" form:"cycles"`
+	IncludeFertility bool `json:"include_fertility" form:"include_fertility"`
+	IncludeOvulation bool `json:"include_ovulation" form:"include_ovulation"`
+	NeutralTitles    bool `json:"neutral_titles" form:"neutral_titles"`
+}
+
+type pregnancyModeInput struct {
+	Mode            string `json:"mode" form:"mode"`
+	PregnancyStart  string `json:"pregnancy_start" form:"pregnancy_start"`
+	DueDate         string `json:"due_date" form:"due_date"`
+	PostpartumStart string `json:"postpartum_start" form:"postpartum_start"`
+}
+
+type contraceptionInput struct {
+	Method      string `json:"method" form:"method"`
+	PackLength  int    `json:"pack_length" form:"pack_length"`
+	PlaceboDays int    `json:"placebo_days" form:"placebo_days"`
+	Start       string `json:"start" form:"start"`
+}
+
+type predictionAlgorithmInput struct {
+	Algorithm string `json:"algorithm" form:"algorithm"`
+}
+
+type lutealPhaseInput struct {
+	LutealPhaseLength string `json:"luteal_phase_length" form:"luteal_phase_length"`
+}
+
+type medicationInput struct {
+	Name     string  `json:"name" form:"name"`
+	Dose     float64 `json:"dose" form:"dose"`
+	Unit     string  `json:"unit" form:"unit"`
+	Schedule string  `json:"schedule" form:"schedule"`
+}
+
+type intimacyTrackingInput struct {
+	Enabled bool `json:"enabled" form:"enabled"`
+}
+
+type metricInput struct {
+	Name string   `json:"name" form:"name"`
+	Unit string   `json:"unit" form:"unit"`
+	Type string   `json:"type" form:"type"`
+	Min  *float64 `json:"min" form:"min"`
+	Max  *float64 `json:"max" form:"max"`
+}
+
+type apiTokenCreateInput struct {
+	Name  string `json:"name" form:"name"`
+	Scope string `json:"scope" form:"scope"`
+}
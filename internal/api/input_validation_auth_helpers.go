@@ -20,6 +20,8 @@ func parseCredentials(c *fiber.Ctx) (credentialsInput, error) {
 	credentials.Email = email
 	credentials.Password = password
 	credentials.ConfirmPassword = strings.TrimSpace(credentials.ConfirmPassword)
+	credentials.InviteToken = strings.TrimSpace(credentials.InviteToken)
+	credentials.RegistrationToken = strings.TrimSpace(credentials.RegistrationToken)
 	credentials.RememberMe = credentials.RememberMe || parseBoolValue(c.FormValue("remember_me"))
 
 	return credentials, nil
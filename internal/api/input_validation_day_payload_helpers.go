@@ -21,6 +21,101 @@ func parseDayPayload(c *fiber.Ctx) (dayPayload, error) {
 		payload.Flow = strings.ToLower(strings.TrimSpace(c.FormValue("flow")))
 		payload.Notes = strings.TrimSpace(c.FormValue("notes"))
 
+		if c.Context().PostArgs().Has("bbt") {
+			bbt, err := parseTemperatureValue(c.FormValue("bbt"))
+			if err != nil {
+				return payload, err
+			}
+			payload.BBT = &bbt
+			payload.BBTUnit = c.FormValue("bbt_unit")
+			payload.BBTTime = strings.TrimSpace(c.FormValue("bbt_time"))
+			payload.BBTDisturbed = parseBoolValue(c.FormValue("bbt_disturbed"))
+		}
+
+		if c.Context().PostArgs().Has("mucus") {
+			mucus := c.FormValue("mucus")
+			cervixPosition := c.FormValue("cervix_position")
+			cervixFirmness := c.FormValue("cervix_firmness")
+			payload.Mucus = &mucus
+			payload.CervixPosition = &cervixPosition
+			payload.CervixFirmness = &cervixFirmness
+		}
+
+		if c.Context().PostArgs().Has("lh_test") {
+			lhTest := c.FormValue("lh_test")
+			pregnancyTest := c.FormValue("pregnancy_test")
+			testBrand := c.FormValue("test_brand")
+			payload.LHTest = &lhTest
+			payload.PregnancyTest = &pregnancyTest
+			payload.TestBrand = &testBrand
+		}
+
+		// The form sends a hidden "false" before the checkbox so that an
+		// unchecked box still clears the stored value.
+		if pillRaw := c.Context().PostArgs().PeekMulti("pill_taken"); len(pillRaw) > 0 {
+			pillTaken := false
+			for _, value := range pillRaw {
+				pillTaken = pillTaken || parseBoolValue(string(value))
+			}
+			payload.PillTaken = &pillTaken
+		}
+
+		// Intimacy uses the same hidden "false" as the pill checkbox; the
+		// method list only counts when the box is ticked.
+		if intimacyRaw := c.Context().PostArgs().PeekMulti("intimacy"); len(intimacyRaw) > 0 {
+			intimacy := false
+			for _, value := range intimacyRaw {
+				intimacy = intimacy || parseBoolValue(string(value))
+			}
+			payload.Intimacy = &intimacy
+			payload.IntimacyProtection = c.FormValue("intimacy_protection")
+			for _, value := range c.Context().PostArgs().PeekMulti("intimacy_methods") {
+				payload.IntimacyMethods = append(payload.IntimacyMethods, string(value))
+			}
+		}
+
+		if c.Context().PostArgs().Has("cycle_start_override") {
+			cycleStartOverride := c.FormValue("cycle_start_override")
+			cycleExclusion := c.FormValue("cycle_exclusion")
+			payload.CycleStartOverride = &cycleStartOverride
+			payload.CycleExclusion = &cycleExclusion
+		}
+
+		// The medication list also starts with a hidden empty value, so a
+		// form with every box unchecked clears the day's intakes.
+		if medicationRaw := c.Context().PostArgs().PeekMulti("medication_ids"); len(medicationRaw) > 0 {
+			medicationIDs := make([]uint, 0, len(medicationRaw))
+			for _, value := range medicationRaw {
+				parsed, err := strconv.ParseUint(strings.TrimSpace(string(value)), 10, 64)
+				if err == nil {
+					medicationIDs = append(medicationIDs, uint(parsed))
+				}
+			}
+			payload.MedicationIDs = &medicationIDs
+		}
+
+		// Every metric in the form posts its id in metric_ids and its value in
+		// metric_value_<id>; an empty value clears the metric for the day.
+		if metricRaw := c.Context().PostArgs().PeekMulti("metric_ids"); len(metricRaw) > 0 {
+			metrics := make(map[uint]float64, len(metricRaw))
+			for _, value := range metricRaw {
+				id, err := strconv.ParseUint(strings.TrimSpace(string(value)), 10, 64)
+				if err != nil {
+					continue
+				}
+				raw := strings.ReplaceAll(strings.TrimSpace(c.FormValue("metric_value_"+strconv.FormatUint(id, 10))), ",", ".")
+				if raw == "" {
+					continue
+				}
+				parsed, err := strconv.ParseFloat(raw, 64)
+				if err != nil {
+					return payload, err
+				}
+				metrics[uint(id)] = parsed
+			}
+			payload.Metrics = &metrics
+		}
+
 		symptomRaw := c.Context().PostArgs().PeekMulti("symptom_ids")
 		for _, value := range symptomRaw {
 			parsed, err := strconv.ParseUint(string(value), 10, 64)
@@ -28,6 +123,22 @@ func parseDayPayload(c *fiber.Ctx) (dayPayload, error) {
 				payload.SymptomIDs = append(payload.SymptomIDs, uint(parsed))
 			}
 		}
+
+		// Each checked symptom carries its severity in symptom_severity_<id>.
+		for _, id := range payload.SymptomIDs {
+			raw := strings.TrimSpace(c.FormValue("symptom_severity_" + strconv.FormatUint(uint64(id), 10)))
+			if raw == "" {
+				continue
+			}
+			severity, err := strconv.Atoi(raw)
+			if err != nil {
+				return payload, err
+			}
+			if payload.SymptomSeverities == nil {
+				payload.SymptomSeverities = make(map[uint]int, len(payload.SymptomIDs))
+			}
+			payload.SymptomSeverities[id] = severity
+		}
 	}
 
 	payload.Flow = strings.ToLower(strings.TrimSpace(payload.Flow))
@@ -39,6 +150,16 @@ func parseDayPayload(c *fiber.Ctx) (dayPayload, error) {
 	return payload, nil
 }
 
+// parseTemperatureValue accepts both decimal separators; an empty value
+// clears the reading.
+func parseTemperatureValue(raw string) (float64, error) {
+	normalized := strings.ReplaceAll(strings.TrimSpace(raw), ",", ".")
+	if normalized == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(normalized, 64)
+}
+
 func parseBoolValue(value string) bool {
 	normalized := strings.ToLower(strings.TrimSpace(value))
 	return normalized == "1" || normalized == "true" || normalized == "on" || normalized == "yes"
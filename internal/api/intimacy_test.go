@@ -0,0 +1,144 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func TestIntimacyIsIgnoredUntilTrackingIsEnabled(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "intimacy-disabled@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+
+	today := services.DateAtLocation(time.Now().UTC(), time.UTC).Format("2006-01-02")
+	if body := smokeGET(t, app, ownerCookie, "/dashboard", http.StatusOK); strings.Contains(body, `name="intimacy_protection"`) {
+		t.Fatal("expected no intimacy fields before tracking is enabled")
+	}
+
+	response := postDayJSONForTest(t, app, ownerCookie, today, map[string]any{
+		"is_period":           false,
+		"notes":               "kept",
+		"intimacy":            true,
+		"intimacy_protection": models.IntimacyUnprotected,
+	})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+
+	stored := models.DailyLog{}
+	if err := database.Where("user_id = ?", owner.ID).First(&stored).Error; err != nil {
+		t.Fatalf("load day: %v", err)
+	}
+	if stored.Intimacy || stored.IntimacyProtection != "" {
+		t.Fatalf("expected intimacy to be ignored while tracking is off, got %#v", stored)
+	}
+}
+
+func TestIntimacyTrackingStoresEntriesAndHidesThemFromPartners(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "intimacy@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+
+	response := postSessionFormForTest(t, app, ownerCookie, "/api/settings/intimacy", url.Values{"enabled": {"true"}})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+	if body := smokeGET(t, app, ownerCookie, "/settings", http.StatusOK); !strings.Contains(body, `id="settings-intimacy"`) {
+		t.Fatal("expected the intimacy section on the settings page")
+	}
+
+	today := services.DateAtLocation(time.Now().UTC(), time.UTC)
+	for _, daysAgo := range []int{68, 67, 40, 39, 12, 11} {
+		entry := models.DailyLog{UserID: owner.ID, Date: today.AddDate(0, 0, -daysAgo), IsPeriod: true, Flow: models.FlowMedium}
+		if err := database.Create(&entry).Error; err != nil {
+			t.Fatalf("create period log: %v", err)
+		}
+	}
+
+	response = postDayJSONForTest(t, app, ownerCookie, today.AddDate(0, 0, -1).Format("2006-01-02"), map[string]any{
+		"is_period":        false,
+		"intimacy":         true,
+		"intimacy_methods": []string{"IUD", "condom"},
+	})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+	response = postSessionFormForTest(t, app, ownerCookie, "/api/days/"+today.Format("2006-01-02"), url.Values{
+		"is_period":           {"false"},
+		"intimacy":            {"false", "true"},
+		"intimacy_protection": {models.IntimacyUnprotected},
+	})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+
+	protected := models.DailyLog{}
+	if err := database.Where("user_id = ? AND intimacy_protection = ?", owner.ID, models.IntimacyProtected).First(&protected).Error; err != nil {
+		t.Fatalf("load protected day: %v", err)
+	}
+	if strings.Join(protected.IntimacyMethods, ",") != "condom,iud" {
+		t.Fatalf("expected normalized methods, got %#v", protected.IntimacyMethods)
+	}
+
+	response = postDayJSONForTest(t, app, ownerCookie, today.Format("2006-01-02"), map[string]any{
+		"is_period":           false,
+		"intimacy":            true,
+		"intimacy_protection": models.IntimacyUnprotected,
+		"intimacy_methods":    []string{"condom"},
+	})
+	if response.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", response.StatusCode)
+	}
+	if message := readAPIError(t, response.Body); message != "invalid intimacy value" {
+		t.Fatalf("expected intimacy error, got %q", message)
+	}
+	response.Body.Close()
+
+	body := smokeGET(t, app, ownerCookie, "/dashboard", http.StatusOK)
+	if !strings.Contains(body, "data-unprotected-fertile") {
+		t.Fatal("expected the unprotected fertile insight on the dashboard")
+	}
+	calendarBody := smokeGET(t, app, ownerCookie, "/calendar?month="+today.Format("2006-01"), http.StatusOK)
+	if !strings.Contains(calendarBody, `data-intimacy="unprotected"`) {
+		t.Fatal("expected the unprotected intimacy marker on the calendar")
+	}
+	if body := smokeGET(t, app, ownerCookie, "/api/days?from="+today.AddDate(0, 0, -1).Format("2006-01-02")+"&to="+today.Format("2006-01-02"), http.StatusOK); !strings.Contains(body, `"Intimacy":true`) {
+		t.Fatalf("expected intimacy in the owner's days, got %s", body)
+	}
+
+	partner := createLinkedPartnerForTest(t, app, database, ownerCookie, "intimacy-partner@example.com")
+	partnerCookie := loginAndExtractAuthCookie(t, app, partner.Email, "StrongPass1")
+	body = smokeGET(t, app, partnerCookie, "/api/days?from="+today.AddDate(0, 0, -1).Format("2006-01-02")+"&to="+today.Format("2006-01-02"), http.StatusOK)
+	if strings.Contains(body, `"Intimacy":true`) || strings.Contains(body, `"IntimacyProtection":"unprotected"`) {
+		t.Fatalf("expected intimacy to be stripped for partners, got %s", body)
+	}
+	calendarBody = smokeGET(t, app, partnerCookie, "/calendar?month="+today.Format("2006-01"), http.StatusOK)
+	if strings.Contains(calendarBody, "data-intimacy") {
+		t.Fatal("expected no intimacy markers on the partner calendar")
+	}
+
+	response = postSessionFormForTest(t, app, ownerCookie, "/api/settings/intimacy", url.Values{})
+	response.Body.Close()
+	calendarBody = smokeGET(t, app, ownerCookie, "/calendar?month="+today.Format("2006-01"), http.StatusOK)
+	if strings.Contains(calendarBody, "data-intimacy") {
+		t.Fatal("expected intimacy markers to be hidden once tracking is off")
+	}
+	kept := models.DailyLog{}
+	if err := database.First(&kept, protected.ID).Error; err != nil || !kept.Intimacy {
+		t.Fatalf("expected entries to be kept when tracking is off, got %#v (%v)", kept, err)
+	}
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func TestLutealPhaseIsLearnedFromLHTestsAndCanBeSetManually(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "luteal-phase@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+
+	today := services.DateAtLocation(time.Now().UTC(), time.UTC)
+	for index, daysAgo := range []int{86, 58, 30, 2} {
+		start := today.AddDate(0, 0, -daysAgo)
+		entries := []models.DailyLog{{UserID: owner.ID, Date: start, IsPeriod: true, Flow: models.FlowMedium}}
+		if index < 2 {
+			entries = append(entries, models.DailyLog{UserID: owner.ID, Date: start.AddDate(0, 0, 14), LHTest: models.LHTestPositive})
+		}
+		if err := database.Create(&entries).Error; err != nil {
+			t.Fatalf("create logs: %v", err)
+		}
+	}
+
+	body := smokeGET(t, app, ownerCookie, "/api/stats/overview", http.StatusOK)
+	if !strings.Contains(body, `"luteal_phase_length":12,"luteal_phase_personalised":true`) {
+		t.Fatalf("expected a learned 12-day luteal phase, got %s", body)
+	}
+	if body := smokeGET(t, app, ownerCookie, "/dashboard", http.StatusOK); !strings.Contains(body, `data-luteal-personalised="12"`) {
+		t.Fatal("expected the personalised indicator on the dashboard")
+	}
+	if body := smokeGET(t, app, ownerCookie, "/settings", http.StatusOK); !strings.Contains(body, `data-luteal-source="learned"`) {
+		t.Fatal("expected the learned luteal phase on the settings page")
+	}
+
+	response := postSessionFormForTest(t, app, ownerCookie, "/api/settings/luteal-phase", url.Values{"luteal_phase_length": {"10"}})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+	body = smokeGET(t, app, ownerCookie, "/api/stats/overview", http.StatusOK)
+	if !strings.Contains(body, `"luteal_phase_length":10,"luteal_phase_personalised":true`) {
+		t.Fatalf("expected the manual luteal phase, got %s", body)
+	}
+
+	response = postSessionFormForTest(t, app, ownerCookie, "/api/settings/luteal-phase", url.Values{"luteal_phase_length": {"25"}})
+	if response.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", response.StatusCode)
+	}
+	if message := readAPIError(t, response.Body); message != "invalid luteal phase length" {
+		t.Fatalf("expected %q, got %q", "invalid luteal phase length", message)
+	}
+	response.Body.Close()
+
+	response = postSessionFormForTest(t, app, ownerCookie, "/api/settings/luteal-phase", url.Values{"luteal_phase_length": {""}})
+	response.Body.Close()
+	stored := models.User{}
+	if err := database.First(&stored, owner.ID).Error; err != nil || stored.LutealPhaseLength != 0 {
+		t.Fatalf("expected the manual length to be cleared, got %d (%v)", stored.LutealPhaseLength, err)
+	}
+
+	partner := createLinkedPartnerForTest(t, app, database, ownerCookie, "luteal-phase-partner@example.com")
+	partnerCookie := loginAndExtractAuthCookie(t, app, partner.Email, "StrongPass1")
+	response = postSessionFormForTest(t, app, partnerCookie, "/api/settings/luteal-phase", url.Values{"luteal_phase_length": {"12"}})
+	response.Body.Close()
+	if response.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected status 403 for partners, got %d", response.StatusCode)
+	}
+}
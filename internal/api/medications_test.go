@@ -0,0 +1,143 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func TestMedicationIntakesFlowIntoDayFormStatsAndExport(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "medications@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+
+	response := postSessionFormForTest(t, app, ownerCookie, "/api/settings/medications", url.Values{
+		"name":     {"Ibuprofen"},
+		"dose":     {"400"},
+		"unit":     {"mg"},
+		"schedule": {"as_needed"},
+	})
+	response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", response.StatusCode)
+	}
+	medication := models.Medication{}
+	if err := database.Where("user_id = ?", owner.ID).First(&medication).Error; err != nil {
+		t.Fatalf("load medication: %v", err)
+	}
+	if body := smokeGET(t, app, ownerCookie, "/settings", http.StatusOK); !strings.Contains(body, fmt.Sprintf(`data-medication-id="%d"`, medication.ID)) {
+		t.Fatal("expected medication listed on the settings page")
+	}
+
+	smokeGET(t, app, ownerCookie, "/api/symptoms", http.StatusOK)
+	cramps := models.SymptomType{}
+	if err := database.Where("user_id = ? AND name = ?", owner.ID, "Cramps").First(&cramps).Error; err != nil {
+		t.Fatalf("load cramps symptom: %v", err)
+	}
+
+	today := services.DateAtLocation(time.Now().UTC(), time.UTC).Format("2006-01-02")
+	form := url.Values{
+		"is_period":      {"true"},
+		"flow":           {models.FlowMedium},
+		"symptom_ids":    {fmt.Sprint(cramps.ID)},
+		"medication_ids": {"", fmt.Sprint(medication.ID)},
+	}
+	response = postSessionFormForTest(t, app, ownerCookie, "/api/days/"+today, form)
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+
+	intakes := make([]models.MedicationIntake, 0)
+	if err := database.Where("user_id = ?", owner.ID).Find(&intakes).Error; err != nil {
+		t.Fatalf("load intakes: %v", err)
+	}
+	if len(intakes) != 1 || intakes[0].MedicationID != medication.ID || intakes[0].Dose != 400 || intakes[0].Unit != "mg" {
+		t.Fatalf("expected one intake with the catalog dose, got %#v", intakes)
+	}
+
+	body := smokeGET(t, app, ownerCookie, "/dashboard", http.StatusOK)
+	if !strings.Contains(body, fmt.Sprintf(`name="medication_ids" value="%d" class="choice-input" checked`, medication.ID)) {
+		t.Fatal("expected the medication to be checked in today's form")
+	}
+	body = smokeGET(t, app, ownerCookie, "/stats", http.StatusOK)
+	if !strings.Contains(body, fmt.Sprintf(`data-medication-correlation="%d"`, medication.ID)) || !strings.Contains(body, "100% of intake days") {
+		t.Fatal("expected the medication correlation on the stats page")
+	}
+	if body := smokeGET(t, app, ownerCookie, "/api/export/csv", http.StatusOK); !strings.Contains(body, "Ibuprofen 400 mg") {
+		t.Fatalf("expected the intake in the csv export, got %s", body)
+	}
+	if body := smokeGET(t, app, ownerCookie, "/api/export/json", http.StatusOK); !strings.Contains(body, `"name": "Ibuprofen"`) {
+		t.Fatalf("expected the intake in the json export, got %s", body)
+	}
+
+	partner := createLinkedPartnerForTest(t, app, database, ownerCookie, "medications-partner@example.com")
+	partnerCookie := loginAndExtractAuthCookie(t, app, partner.Email, "StrongPass1")
+	if body := smokeGET(t, app, partnerCookie, "/dashboard", http.StatusOK); strings.Contains(body, "data-medication-fields") {
+		t.Fatal("expected no medication fields for partner")
+	}
+	if body := smokeGET(t, app, partnerCookie, "/stats", http.StatusOK); strings.Contains(body, "medication-correlation-section") {
+		t.Fatal("expected no medication stats for partner")
+	}
+
+	response = postSessionFormForTest(t, app, ownerCookie, "/api/days/"+today, url.Values{"flow": {models.FlowNone}, "medication_ids": {""}})
+	response.Body.Close()
+	var remaining int64
+	if err := database.Model(&models.MedicationIntake{}).Where("user_id = ?", owner.ID).Count(&remaining).Error; err != nil {
+		t.Fatalf("count intakes: %v", err)
+	}
+	if remaining != 0 {
+		t.Fatalf("expected unchecked medications to clear the intake, got %d", remaining)
+	}
+}
+
+func TestMedicationRejectsInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "medications-invalid@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+
+	for _, testCase := range []struct {
+		form    url.Values
+		message string
+	}{
+		{form: url.Values{"name": {""}}, message: "invalid medication name"},
+		{form: url.Values{"name": {"Iron"}, "dose": {"lots"}}, message: "invalid medication dose"},
+		{form: url.Values{"name": {"Iron"}, "unit": {"spoons"}}, message: "invalid medication unit"},
+		{form: url.Values{"name": {"Iron"}, "schedule": {"hourly"}}, message: "invalid medication schedule"},
+	} {
+		response := postSessionFormForTest(t, app, ownerCookie, "/api/settings/medications", testCase.form)
+		if response.StatusCode != http.StatusBadRequest {
+			t.Fatalf("expected status 400 for %v, got %d", testCase.form, response.StatusCode)
+		}
+		if message := readAPIError(t, response.Body); message != testCase.message {
+			t.Fatalf("expected %q, got %q", testCase.message, message)
+		}
+		response.Body.Close()
+	}
+
+	today := time.Now().UTC().Format("2006-01-02")
+	response := postDayJSONForTest(t, app, ownerCookie, today, map[string]any{"flow": models.FlowNone, "medication_ids": []uint{999}})
+	if response.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for unknown medication, got %d", response.StatusCode)
+	}
+	if message := readAPIError(t, response.Body); message != "invalid medication ids" {
+		t.Fatalf("expected invalid medication ids, got %q", message)
+	}
+	response.Body.Close()
+
+	response = postSessionFormForTest(t, app, ownerCookie, "/api/settings/medications/999/delete", url.Values{})
+	response.Body.Close()
+	if response.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404 for unknown medication, got %d", response.StatusCode)
+	}
+}
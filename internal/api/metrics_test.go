@@ -0,0 +1,173 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func TestCustomMetricsFlowIntoDayFormStatsAndExport(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "metrics@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+
+	response := postSessionFormForTest(t, app, ownerCookie, "/api/settings/metrics", url.Values{
+		"name": {"Weight"},
+		"unit": {"kg"},
+		"type": {"decimal"},
+		"min":  {"0"},
+	})
+	response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", response.StatusCode)
+	}
+	response = postSessionFormForTest(t, app, ownerCookie, "/api/settings/metrics", url.Values{"name": {"Energy"}, "type": {"scale"}})
+	response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", response.StatusCode)
+	}
+
+	weight := models.MetricType{}
+	if err := database.Where("user_id = ? AND name = ?", owner.ID, "Weight").First(&weight).Error; err != nil {
+		t.Fatalf("load weight metric: %v", err)
+	}
+	energy := models.MetricType{}
+	if err := database.Where("user_id = ? AND name = ?", owner.ID, "Energy").First(&energy).Error; err != nil {
+		t.Fatalf("load energy metric: %v", err)
+	}
+	if body := smokeGET(t, app, ownerCookie, "/settings", http.StatusOK); !strings.Contains(body, fmt.Sprintf(`data-metric-id="%d"`, energy.ID)) || !strings.Contains(body, "1–10") {
+		t.Fatal("expected the scale metric with its default range on the settings page")
+	}
+
+	today := services.DateAtLocation(time.Now().UTC(), time.UTC)
+	form := url.Values{
+		"is_period":  {"true"},
+		"flow":       {models.FlowMedium},
+		"metric_ids": {fmt.Sprint(weight.ID), fmt.Sprint(energy.ID)},
+		fmt.Sprintf("metric_value_%d", weight.ID): {"62,4"},
+		fmt.Sprintf("metric_value_%d", energy.ID): {"7"},
+	}
+	response = postSessionFormForTest(t, app, ownerCookie, "/api/days/"+today.Format("2006-01-02"), form)
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+
+	yesterday := today.AddDate(0, 0, -1).Format("2006-01-02")
+	response = postDayJSONForTest(t, app, ownerCookie, yesterday, map[string]any{
+		"is_period": true,
+		"flow":      models.FlowLight,
+		"metrics":   map[string]float64{fmt.Sprint(weight.ID): 62.2},
+	})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+
+	body := smokeGET(t, app, ownerCookie, "/dashboard", http.StatusOK)
+	if !strings.Contains(body, fmt.Sprintf(`name="metric_value_%d"`, weight.ID)) || !strings.Contains(body, `value="62.4"`) {
+		t.Fatal("expected today's weight in the day form")
+	}
+
+	body = smokeGET(t, app, ownerCookie, "/stats", http.StatusOK)
+	if !strings.Contains(body, fmt.Sprintf(`data-metric-series="%d"`, weight.ID)) || !strings.Contains(body, "Average 62.3 · 2 days") {
+		t.Fatal("expected the weight chart on the stats page")
+	}
+
+	body = smokeGET(t, app, ownerCookie, "/api/export/csv", http.StatusOK)
+	if !strings.Contains(body, "Energy,Weight (kg)") || !strings.Contains(body, "7,62.4") {
+		t.Fatalf("expected one column per metric in the csv export, got %s", body)
+	}
+	if body := smokeGET(t, app, ownerCookie, "/api/export/json", http.StatusOK); !strings.Contains(body, `"Weight": 62.2`) || !strings.Contains(body, `"custom_metrics"`) {
+		t.Fatalf("expected metrics in the json export, got %s", body)
+	}
+
+	partner := createLinkedPartnerForTest(t, app, database, ownerCookie, "metrics-partner@example.com")
+	partnerCookie := loginAndExtractAuthCookie(t, app, partner.Email, "StrongPass1")
+	if body := smokeGET(t, app, partnerCookie, "/dashboard", http.StatusOK); strings.Contains(body, "data-metric-fields") {
+		t.Fatal("expected no metric fields for partner")
+	}
+	if body := smokeGET(t, app, partnerCookie, "/stats", http.StatusOK); strings.Contains(body, "metrics-section") {
+		t.Fatal("expected no metric stats for partner")
+	}
+
+	response = postSessionFormForTest(t, app, ownerCookie, "/api/days/"+today.Format("2006-01-02"), url.Values{
+		"flow":       {models.FlowNone},
+		"metric_ids": {fmt.Sprint(weight.ID), fmt.Sprint(energy.ID)},
+	})
+	response.Body.Close()
+	var remaining int64
+	if err := database.Model(&models.MetricValue{}).Where("user_id = ?", owner.ID).Count(&remaining).Error; err != nil {
+		t.Fatalf("count metric values: %v", err)
+	}
+	if remaining != 1 {
+		t.Fatalf("expected empty inputs to clear today's values, got %d values left", remaining)
+	}
+}
+
+func TestCustomMetricsRejectInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "metrics-invalid@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+
+	for _, testCase := range []struct {
+		form    url.Values
+		message string
+	}{
+		{form: url.Values{"name": {""}}, message: "invalid metric name"},
+		{form: url.Values{"name": {"Mood"}, "type": {"text"}}, message: "invalid metric type"},
+		{form: url.Values{"name": {"Mood"}, "min": {"5"}, "max": {"1"}}, message: "invalid metric range"},
+		{form: url.Values{"name": {"Mood"}, "min": {"low"}}, message: "invalid metric range"},
+	} {
+		response := postSessionFormForTest(t, app, ownerCookie, "/api/settings/metrics", testCase.form)
+		if response.StatusCode != http.StatusBadRequest {
+			t.Fatalf("expected status 400 for %v, got %d", testCase.form, response.StatusCode)
+		}
+		if message := readAPIError(t, response.Body); message != testCase.message {
+			t.Fatalf("expected %q, got %q", testCase.message, message)
+		}
+		response.Body.Close()
+	}
+
+	response := postSessionFormForTest(t, app, ownerCookie, "/api/settings/metrics", url.Values{"name": {"Mood"}, "type": {"scale"}, "min": {"1"}, "max": {"5"}})
+	response.Body.Close()
+	mood := models.MetricType{}
+	if err := database.Where("user_id = ?", owner.ID).First(&mood).Error; err != nil {
+		t.Fatalf("load mood metric: %v", err)
+	}
+
+	today := time.Now().UTC().Format("2006-01-02")
+	for _, testCase := range []struct {
+		metrics map[string]float64
+		message string
+	}{
+		{metrics: map[string]float64{fmt.Sprint(mood.ID): 6}, message: "invalid metric value"},
+		{metrics: map[string]float64{fmt.Sprint(mood.ID): 2.5}, message: "invalid metric value"},
+		{metrics: map[string]float64{"999": 1}, message: "invalid metric ids"},
+	} {
+		response := postDayJSONForTest(t, app, ownerCookie, today, map[string]any{"flow": models.FlowNone, "metrics": testCase.metrics})
+		if response.StatusCode != http.StatusBadRequest {
+			t.Fatalf("expected status 400 for %v, got %d", testCase.metrics, response.StatusCode)
+		}
+		if message := readAPIError(t, response.Body); message != testCase.message {
+			t.Fatalf("expected %q, got %q", testCase.message, message)
+		}
+		response.Body.Close()
+	}
+
+	response = postSessionFormForTest(t, app, ownerCookie, "/api/settings/metrics/999/delete", url.Values{})
+	response.Body.Close()
+	if response.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404 for unknown metric, got %d", response.StatusCode)
+	}
+}
@@ -11,7 +11,9 @@ const (
 	flashCookieName         = "ovumcy_flash"
 	recoveryCodeCookieName  = "ovumcy_recovery_code"
 	resetPasswordCookieName = "ovumcy_reset_password"
+	twoFactorCookieName     = "ovumcy_two_factor"
 	contextUserKey          = "current_user"
+	contextSessionKey       = "current_session"
 	contextLanguageKey      = "current_language"
 	contextMessagesKey      = "current_messages"
 )
@@ -20,3 +22,8 @@ func currentUser(c *fiber.Ctx) (*models.User, bool) {
 	user, ok := c.Locals(contextUserKey).(*models.User)
 	return user, ok
 }
+
+func currentSession(c *fiber.Ctx) (*models.AuthSession, bool) {
+	session, ok := c.Locals(contextSessionKey).(*models.AuthSession)
+	return session, ok
+}
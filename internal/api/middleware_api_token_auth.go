@@ -0,0 +1,66 @@
+package api
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+// IsBearerTokenRequest reports whether an /api request carries an
+// Authorization: Bearer credential. Such requests are authenticated by
+// the API token alone, never by cookies, so they can skip cookie CSRF
+// checks; browsers cannot attach that header cross-site without CORS.
+func IsBearerTokenRequest(c *fiber.Ctx) bool {
+	if !strings.HasPrefix(c.Path(), "/api/") {
+		return false
+	}
+	_, ok := bearerToken(c)
+	return ok
+}
+
+func bearerToken(c *fiber.Ctx) (string, bool) {
+	header := strings.TrimSpace(c.Get(fiber.HeaderAuthorization))
+	scheme, value, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	value = strings.TrimSpace(value)
+	return value, value != ""
+}
+
+// isAPITokenPath limits bearer tokens to the data endpoints. Account
+// management under /api/auth and /api/settings keeps requiring a browser
+// session.
+func isAPITokenPath(path string) bool {
+	if !strings.HasPrefix(path, "/api/") {
+		return false
+	}
+	return !strings.HasPrefix(path, "/api/auth/") && !strings.HasPrefix(path, "/api/settings/")
+}
+
+func (handler *Handler) authenticateAPIToken(c *fiber.Ctx, rawToken string) error {
+	handler.ensureDependencies()
+	token, err := handler.apiTokenService.Resolve(rawToken, time.Now())
+	if err != nil {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+	if !isAPITokenPath(c.Path()) {
+		return apiError(c, fiber.StatusForbidden, "api token not allowed")
+	}
+	if !services.APITokenAllowsMethod(token, c.Method()) {
+		return apiError(c, fiber.StatusForbidden, "api token is read-only")
+	}
+
+	user, err := handler.authService.FindByID(token.UserID)
+	if err != nil || !services.IsOwnerUser(&user) {
+		return apiError(c, fiber.StatusUnauthorized, "unauthorized")
+	}
+
+	c.Locals(contextUserKey, &user)
+	if requiresOnboarding(&user) {
+		return apiError(c, fiber.StatusForbidden, "onboarding required")
+	}
+	return c.Next()
+}
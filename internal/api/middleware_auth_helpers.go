@@ -12,21 +12,30 @@ import (
 )
 
 type authClaims struct {
-	UserID uint   `json:"uid"`
-	Role   string `json:"role"`
+	UserID    uint   `json:"uid"`
+	Role      string `json:"role"`
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
 func (handler *Handler) authenticateRequest(c *fiber.Ctx) (*models.User, error) {
+	user, _, err := handler.authenticateSession(c)
+	return user, err
+}
+
+// authenticateSession validates the auth cookie and the server-side session
+// it points at. Tokens without a session ID, or whose session was revoked,
+// are rejected.
+func (handler *Handler) authenticateSession(c *fiber.Ctx) (*models.User, *models.AuthSession, error) {
 	rawToken := strings.TrimSpace(c.Cookies(authCookieName))
 	if rawToken == "" {
-		return nil, errors.New("missing auth cookie")
+		return nil, nil, errors.New("missing auth cookie")
 	}
 	tokenValue := rawToken
 	if strings.HasPrefix(rawToken, secureCookieVersion+".") {
 		decodedToken, err := handler.decodeSealedAuthCookieToken(rawToken)
 		if err != nil {
-			return nil, errors.New("invalid token")
+			return nil, nil, errors.New("invalid token")
 		}
 		tokenValue = decodedToken
 	}
@@ -39,18 +48,23 @@ func (handler *Handler) authenticateRequest(c *fiber.Ctx) (*models.User, error)
 		return handler.secretKey, nil
 	})
 	if err != nil || !token.Valid {
-		return nil, errors.New("invalid token")
+		return nil, nil, errors.New("invalid token")
 	}
 
 	if claims.ExpiresAt == nil || claims.ExpiresAt.Time.Before(time.Now()) {
-		return nil, errors.New("token expired")
+		return nil, nil, errors.New("token expired")
 	}
 
 	handler.ensureDependencies()
 	user, err := handler.authService.FindByID(claims.UserID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	session, err := handler.sessionService.Resolve(claims.SessionID, user.ID, time.Now())
+	if err != nil {
+		return nil, nil, errors.New("session revoked")
 	}
 
-	return &user, nil
+	return &user, &session, nil
 }
@@ -7,7 +7,11 @@ import (
 )
 
 func (handler *Handler) AuthRequired(c *fiber.Ctx) error {
-	user, err := handler.authenticateRequest(c)
+	if rawToken, ok := bearerToken(c); ok {
+		return handler.authenticateAPIToken(c, rawToken)
+	}
+
+	user, session, err := handler.authenticateSession(c)
 	if err != nil {
 		if strings.HasPrefix(c.Path(), "/api/") {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
@@ -16,6 +20,7 @@ func (handler *Handler) AuthRequired(c *fiber.Ctx) error {
 	}
 
 	c.Locals(contextUserKey, user)
+	c.Locals(contextSessionKey, session)
 	if requiresOnboarding(user) && !isOnboardingPath(c.Path()) {
 		if strings.HasPrefix(c.Path(), "/api/") {
 			if c.Path() == "/api/auth/logout" {
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/services"
 )
 
 func TestRedirectAuthenticatedUserIfPresentRedirectsAuthenticatedRequest(t *testing.T) {
@@ -16,7 +17,12 @@ func TestRedirectAuthenticatedUserIfPresentRedirectsAuthenticatedRequest(t *test
 	handler.secretKey = []byte("test-secret")
 	user := createDataAccessTestUser(t, database, "redirect-helper@example.com")
 
-	token, err := handler.buildToken(&user, time.Hour)
+	handler.ensureDependencies()
+	sessionToken, _, err := handler.sessionService.Start(user.ID, services.SessionClient{}, time.Hour, time.Now())
+	if err != nil {
+		t.Fatalf("start session: %v", err)
+	}
+	token, err := handler.buildToken(&user, sessionToken, time.Hour)
 	if err != nil {
 		t.Fatalf("buildToken returned error: %v", err)
 	}
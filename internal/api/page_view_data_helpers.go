@@ -11,7 +11,12 @@ import (
 func (handler *Handler) buildDashboardViewData(user *models.User, language string, messages map[string]string, now time.Time) (fiber.Map, string, error) {
 	today := dateAtLocation(now, handler.location)
 
-	stats, _, err := handler.buildCycleStatsForRange(user, today.AddDate(-2, 0, 0), today, now)
+	dataOwner, err := handler.resolveDataOwner(user)
+	if err != nil {
+		return nil, "failed to load logs", err
+	}
+
+	stats, logs, err := handler.buildCycleStatsForRange(dataOwner, today.AddDate(-2, 0, 0), today, now)
 	if err != nil {
 		return nil, "failed to load logs", err
 	}
@@ -20,8 +25,41 @@ func (handler *Handler) buildDashboardViewData(user *models.User, language strin
 	if err != nil {
 		return nil, "failed to load today log", err
 	}
+	medications, selectedMedicationID, err := handler.fetchDayMedicationsForViewer(user, today)
+	if err != nil {
+		return nil, "failed to load today log", err
+	}
+	metricTypes, metricValues, err := handler.fetchDayMetricsForViewer(user, today)
+	if err != nil {
+		return nil, "failed to load today log", err
+	}
+
+	// A positive pregnancy test since the last period prompts the owner to
+	// switch to pregnancy mode.
+	pregnancyTestDate := time.Time{}
+	var pregnancyStatus *services.PregnancyStatus
+	var pillPack *services.PillPack
+	if isOwnerUser(user) {
+		if status, ok := services.BuildPregnancyStatus(user, logs, now, handler.location); ok {
+			pregnancyStatus = &status
+		} else {
+			promptStart := services.PregnancyTestPromptStart(user, logs, now, handler.location)
+			pregnancyTestDate, _ = services.LatestPositivePregnancyTest(logs, promptStart, today, handler.location)
+		}
+		if pack, ok := services.BuildPillPack(user.Contraception, logs, now, handler.location); ok {
+			pillPack = &pack
+		}
+	}
+	// Unprotected intimacy during this cycle's fertile window is pointed
+	// out to owners who track intimacy.
+	unprotectedFertileDays := []time.Time{}
+	if services.IntimacyVisibleForViewer(user) {
+		unprotectedFertileDays = services.UnprotectedFertileDays(dataOwner, logs, stats, now, handler.location)
+	}
 
-	cycleContext := services.BuildDashboardCycleContext(user, stats, today, handler.location)
+	cycleContext := services.BuildDashboardCycleContext(dataOwner, stats, today, handler.location)
+	cycleContext = services.SanitizeDashboardCycleContextForViewer(user, cycleContext)
+	stats = services.SanitizeCycleStatsForViewer(user, stats)
 
 	data := fiber.Map{
 		"Title":                      localizedPageTitle(messages, "meta.title.dashboard", "Ovumcy | Dashboard"),
@@ -43,13 +81,31 @@ func (handler *Handler) buildDashboardViewData(user *models.User, language strin
 		"TodayHasData":               dayHasData(todayLog),
 		"Symptoms":                   symptoms,
 		"SelectedSymptomID":          symptomIDSet(todayLog.SymptomIDs),
+		"SelectedSymptomSeverity":    todayLog.SymptomSeverities,
+		"Medications":                medications,
+		"SelectedMedicationID":       selectedMedicationID,
+		"MetricTypes":                metricTypes,
+		"MetricValues":               metricValues,
+		"TemperatureUnit":            services.NormalizeTemperatureUnit(user.TemperatureUnit),
 		"IsOwner":                    isOwnerUser(user),
+		"PregnancyTestPositiveDate":  pregnancyTestDate,
+		"PregnancyStatus":            pregnancyStatus,
+		"PillPack":                   pillPack,
+		"ShowPillTaken":              handler.showPillTaken(user, today),
+		"ShowIntimacy":               services.IntimacyVisibleForViewer(user),
+		"IntimacyMethods":            services.IntimacyMethods(),
+		"UnprotectedFertileDays":     unprotectedFertileDays,
 	}
 	return data, "", nil
 }
 
 func (handler *Handler) buildDayEditorPartialData(user *models.User, language string, messages map[string]string, day time.Time, now time.Time) (fiber.Map, string, error) {
-	hasDayData, err := handler.dayHasDataForDate(user.ID, day)
+	dataOwner, err := handler.resolveDataOwner(user)
+	if err != nil {
+		return nil, "failed to load day state", err
+	}
+
+	hasDayData, err := handler.dayHasDataForDate(dataOwner.ID, day)
 	if err != nil {
 		return nil, "failed to load day state", err
 	}
@@ -58,18 +114,45 @@ func (handler *Handler) buildDayEditorPartialData(user *models.User, language st
 	if err != nil {
 		return nil, "failed to load day", err
 	}
+	medications, selectedMedicationID, err := handler.fetchDayMedicationsForViewer(user, day)
+	if err != nil {
+		return nil, "failed to load day", err
+	}
+	metricTypes, metricValues, err := handler.fetchDayMetricsForViewer(user, day)
+	if err != nil {
+		return nil, "failed to load day", err
+	}
 
 	payload := fiber.Map{
-		"Date":              day,
-		"DateString":        day.Format("2006-01-02"),
-		"DateLabel":         localizedDateLabel(language, day),
-		"IsFutureDate":      day.After(dateAtLocation(now.In(handler.location), handler.location)),
-		"NoDataLabel":       translateMessage(messages, "common.not_available"),
-		"Log":               logEntry,
-		"Symptoms":          symptoms,
-		"SelectedSymptomID": symptomIDSet(logEntry.SymptomIDs),
-		"HasDayData":        hasDayData,
-		"IsOwner":           isOwnerUser(user),
+		"Date":                    day,
+		"DateString":              day.Format("2006-01-02"),
+		"DateLabel":               localizedDateLabel(language, day),
+		"IsFutureDate":            day.After(dateAtLocation(now.In(handler.location), handler.location)),
+		"NoDataLabel":             translateMessage(messages, "common.not_available"),
+		"Log":                     logEntry,
+		"Symptoms":                symptoms,
+		"SelectedSymptomID":       symptomIDSet(logEntry.SymptomIDs),
+		"SelectedSymptomSeverity": logEntry.SymptomSeverities,
+		"Medications":             medications,
+		"SelectedMedicationID":    selectedMedicationID,
+		"MetricTypes":             metricTypes,
+		"MetricValues":            metricValues,
+		"HasDayData":              hasDayData,
+		"TemperatureUnit":         services.NormalizeTemperatureUnit(user.TemperatureUnit),
+		"IsOwner":                 isOwnerUser(user),
+		"ShowPillTaken":           handler.showPillTaken(user, day),
+		"ShowIntimacy":            services.IntimacyVisibleForViewer(user),
+		"IntimacyMethods":         services.IntimacyMethods(),
+		"CycleExclusionReasons":   services.CycleExclusionReasons(),
 	}
 	return payload, "", nil
 }
+
+// showPillTaken reports whether the day form offers the pill checkbox: for
+// owners taking a daily pill on days covered by their profile.
+func (handler *Handler) showPillTaken(user *models.User, day time.Time) bool {
+	if !isOwnerUser(user) || !services.ContraceptionTracksDailyPill(user.Contraception.Method) {
+		return false
+	}
+	return services.ContraceptionActive(user.Contraception, day, handler.location)
+}
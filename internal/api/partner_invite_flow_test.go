@@ -0,0 +1,237 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func createPartnerInviteForTest(t *testing.T, app *fiber.App, ownerCookie string) string {
+	t.Helper()
+
+	request := httptest.NewRequest(http.MethodPost, "/api/settings/partner-invites", nil)
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Cookie", ownerCookie)
+
+	response, err := app.Test(request, -1)
+	if err != nil {
+		t.Fatalf("create invite request failed: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusCreated {
+		t.Fatalf("expected create invite status 201, got %d", response.StatusCode)
+	}
+
+	payload := struct {
+		InviteURL string `json:"invite_url"`
+	}{}
+	if err := json.NewDecoder(response.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode invite response: %v", err)
+	}
+	parsed, err := url.Parse(payload.InviteURL)
+	if err != nil {
+		t.Fatalf("parse invite url: %v", err)
+	}
+	if parsed.Path != "/register" {
+		t.Fatalf("expected invite url to target /register, got %q", payload.InviteURL)
+	}
+	token := parsed.Query().Get("invite")
+	if token == "" {
+		t.Fatalf("expected invite token in url %q", payload.InviteURL)
+	}
+	return token
+}
+
+func registerPartnerWithInvite(t *testing.T, app *fiber.App, email string, token string) *http.Response {
+	t.Helper()
+
+	form := url.Values{
+		"email":            {email},
+		"password":         {"StrongPass1"},
+		"confirm_password": {"StrongPass1"},
+		"invite":           {token},
+	}
+	request := httptest.NewRequest(http.MethodPost, "/api/auth/register", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request, -1)
+	if err != nil {
+		t.Fatalf("partner register request failed: %v", err)
+	}
+	return response
+}
+
+func TestPartnerInviteRegistrationLinksPartnerToOwnerData(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "invite-owner@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+
+	logEntry := models.DailyLog{
+		UserID:     owner.ID,
+		Date:       time.Date(2026, time.February, 21, 0, 0, 0, 0, time.UTC),
+		IsPeriod:   true,
+		Flow:       models.FlowHeavy,
+		SymptomIDs: []uint{1},
+		Notes:      "owner-private-note",
+	}
+	if err := database.Create(&logEntry).Error; err != nil {
+		t.Fatalf("create owner log: %v", err)
+	}
+
+	token := createPartnerInviteForTest(t, app, ownerCookie)
+
+	registerPage := smokeGET(t, app, "", "/register?invite="+url.QueryEscape(token), http.StatusOK)
+	if !strings.Contains(registerPage, `name="invite" value="`+token+`"`) {
+		t.Fatalf("expected register page to carry invite token")
+	}
+
+	response := registerPartnerWithInvite(t, app, "invited-partner@example.com", token)
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		t.Fatalf("expected partner register status 201, got %d", response.StatusCode)
+	}
+
+	partner := models.User{}
+	if err := database.Where("email = ?", "invited-partner@example.com").First(&partner).Error; err != nil {
+		t.Fatalf("load partner: %v", err)
+	}
+	if partner.Role != models.RolePartner {
+		t.Fatalf("expected partner role, got %q", partner.Role)
+	}
+	if partner.LinkedOwnerID == nil || *partner.LinkedOwnerID != owner.ID {
+		t.Fatalf("expected partner linked to owner %d, got %v", owner.ID, partner.LinkedOwnerID)
+	}
+
+	var partnerSymptoms int64
+	if err := database.Model(&models.SymptomType{}).Where("user_id = ?", partner.ID).Count(&partnerSymptoms).Error; err != nil {
+		t.Fatalf("count partner symptoms: %v", err)
+	}
+	if partnerSymptoms != 0 {
+		t.Fatalf("expected no symptoms seeded for partner, got %d", partnerSymptoms)
+	}
+
+	partnerCookie := loginAndExtractAuthCookie(t, app, partner.Email, "StrongPass1")
+	body := smokeGET(t, app, partnerCookie, "/api/days?from=2026-02-01&to=2026-02-28", http.StatusOK)
+
+	logs := []models.DailyLog{}
+	if err := json.Unmarshal([]byte(body), &logs); err != nil {
+		t.Fatalf("decode partner days: %v", err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("expected partner to read 1 owner log, got %d", len(logs))
+	}
+	if logs[0].Flow != models.FlowHeavy || !logs[0].IsPeriod {
+		t.Fatalf("expected owner period data visible to partner, got %#v", logs[0])
+	}
+	if logs[0].Notes != "" || len(logs[0].SymptomIDs) != 0 {
+		t.Fatalf("expected owner private fields hidden from partner, got %#v", logs[0])
+	}
+
+	partnerInviteRequest := httptest.NewRequest(http.MethodPost, "/api/settings/partner-invites", nil)
+	partnerInviteRequest.Header.Set("Accept", "application/json")
+	partnerInviteRequest.Header.Set("Cookie", partnerCookie)
+	partnerInviteResponse, err := app.Test(partnerInviteRequest, -1)
+	if err != nil {
+		t.Fatalf("partner invite request failed: %v", err)
+	}
+	partnerInviteResponse.Body.Close()
+	if partnerInviteResponse.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected partner invite creation status 403, got %d", partnerInviteResponse.StatusCode)
+	}
+
+	settingsBody := smokeGET(t, app, ownerCookie, "/settings", http.StatusOK)
+	if !strings.Contains(settingsBody, "invited-partner@example.com") {
+		t.Fatalf("expected owner settings to list linked partner")
+	}
+}
+
+func TestPartnerInviteCannotBeReused(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "invite-reuse-owner@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+	token := createPartnerInviteForTest(t, app, ownerCookie)
+
+	first := registerPartnerWithInvite(t, app, "invite-reuse-first@example.com", token)
+	first.Body.Close()
+	if first.StatusCode != http.StatusCreated {
+		t.Fatalf("expected first partner register status 201, got %d", first.StatusCode)
+	}
+
+	second := registerPartnerWithInvite(t, app, "invite-reuse-second@example.com", token)
+	defer second.Body.Close()
+	if second.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected reused invite status 400, got %d", second.StatusCode)
+	}
+	if got := readAPIError(t, second.Body); got != "invalid partner invite" {
+		t.Fatalf("expected invalid partner invite error, got %q", got)
+	}
+}
+
+func TestPartnerInviteRejectsUnknownToken(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	response := registerPartnerWithInvite(t, app, "invite-unknown@example.com", "not-a-real-token")
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected unknown invite status 400, got %d", response.StatusCode)
+	}
+
+	var usersCount int64
+	if err := database.Model(&models.User{}).Count(&usersCount).Error; err != nil {
+		t.Fatalf("count users: %v", err)
+	}
+	if usersCount != 0 {
+		t.Fatalf("expected no account to be created, got %d", usersCount)
+	}
+}
+
+func TestOwnerRevokesPartnerAccess(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "revoke-owner@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+	token := createPartnerInviteForTest(t, app, ownerCookie)
+
+	response := registerPartnerWithInvite(t, app, "revoke-partner@example.com", token)
+	response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		t.Fatalf("expected partner register status 201, got %d", response.StatusCode)
+	}
+
+	partner := models.User{}
+	if err := database.Where("email = ?", "revoke-partner@example.com").First(&partner).Error; err != nil {
+		t.Fatalf("load partner: %v", err)
+	}
+	partnerCookie := loginAndExtractAuthCookie(t, app, partner.Email, "StrongPass1")
+
+	revokeRequest := httptest.NewRequest(http.MethodPost, "/api/settings/partners/"+strconv.FormatUint(uint64(partner.ID), 10)+"/revoke", nil)
+	revokeRequest.Header.Set("Cookie", ownerCookie)
+	revokeResponse, err := app.Test(revokeRequest, -1)
+	if err != nil {
+		t.Fatalf("revoke request failed: %v", err)
+	}
+	revokeResponse.Body.Close()
+	if revokeResponse.StatusCode != http.StatusSeeOther {
+		t.Fatalf("expected revoke status 303, got %d", revokeResponse.StatusCode)
+	}
+
+	smokeGET(t, app, partnerCookie, "/api/days?from=2026-02-01&to=2026-02-28", http.StatusUnauthorized)
+
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+	"gorm.io/gorm"
+)
+
+func createLinkedPartnerForTest(t *testing.T, app *fiber.App, database *gorm.DB, ownerCookie string, email string) models.User {
+	t.Helper()
+
+	token := createPartnerInviteForTest(t, app, ownerCookie)
+	response := registerPartnerWithInvite(t, app, email, token)
+	response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		t.Fatalf("expected partner register status 201, got %d", response.StatusCode)
+	}
+
+	partner := models.User{}
+	if err := database.Where("email = ?", email).First(&partner).Error; err != nil {
+		t.Fatalf("load partner: %v", err)
+	}
+	return partner
+}
+
+func postPartnerSharingForTest(t *testing.T, app *fiber.App, cookie string, partnerID uint, payload string) int {
+	t.Helper()
+
+	request := httptest.NewRequest(
+		http.MethodPost,
+		"/api/settings/partners/"+strconv.FormatUint(uint64(partnerID), 10)+"/sharing",
+		strings.NewReader(payload),
+	)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Cookie", cookie)
+
+	response, err := app.Test(request, -1)
+	if err != nil {
+		t.Fatalf("partner sharing request failed: %v", err)
+	}
+	defer response.Body.Close()
+	return response.StatusCode
+}
+
+func TestPartnerSharingPolicyControlsPartnerView(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "sharing-owner@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+
+	sharedSymptom := models.SymptomType{UserID: owner.ID, Name: "Cramps", Icon: "🩸", Color: "#FF4444", IsBuiltin: true}
+	privateSymptom := models.SymptomType{UserID: owner.ID, Name: "Acne", Icon: "🔴", Color: "#E74C3C", IsBuiltin: true}
+	if err := database.Create(&sharedSymptom).Error; err != nil {
+		t.Fatalf("create shared symptom: %v", err)
+	}
+	if err := database.Create(&privateSymptom).Error; err != nil {
+		t.Fatalf("create private symptom: %v", err)
+	}
+
+	logEntry := models.DailyLog{
+		UserID:     owner.ID,
+		Date:       time.Date(2026, time.February, 21, 0, 0, 0, 0, time.UTC),
+		IsPeriod:   true,
+		Flow:       models.FlowHeavy,
+		SymptomIDs: []uint{sharedSymptom.ID, privateSymptom.ID},
+		Notes:      "shared-note",
+	}
+	if err := database.Create(&logEntry).Error; err != nil {
+		t.Fatalf("create owner log: %v", err)
+	}
+
+	partner := createLinkedPartnerForTest(t, app, database, ownerCookie, "sharing-partner@example.com")
+
+	payload := `{"share_period_days":true,"share_flow":false,"share_predictions":false,"share_fertile_window":false,"share_notes":true,"shared_symptom_ids":[` +
+		strconv.FormatUint(uint64(sharedSymptom.ID), 10) + `,999999]}`
+	if status := postPartnerSharingForTest(t, app, ownerCookie, partner.ID, payload); status != http.StatusOK {
+		t.Fatalf("expected sharing update status 200, got %d", status)
+	}
+
+	stored := models.User{}
+	if err := database.First(&stored, partner.ID).Error; err != nil {
+		t.Fatalf("reload partner: %v", err)
+	}
+	if !stored.SharingPolicy.HideFlow || !stored.SharingPolicy.HidePredictions || stored.SharingPolicy.HidePeriodDays {
+		t.Fatalf("expected stored policy toggles, got %#v", stored.SharingPolicy)
+	}
+	if len(stored.SharingPolicy.SharedSymptomIDs) != 1 || stored.SharingPolicy.SharedSymptomIDs[0] != sharedSymptom.ID {
+		t.Fatalf("expected only owner symptom to be stored, got %#v", stored.SharingPolicy.SharedSymptomIDs)
+	}
+
+	partnerCookie := loginAndExtractAuthCookie(t, app, partner.Email, "StrongPass1")
+	body := smokeGET(t, app, partnerCookie, "/api/days?from=2026-02-01&to=2026-02-28", http.StatusOK)
+	logs := []models.DailyLog{}
+	if err := json.Unmarshal([]byte(body), &logs); err != nil {
+		t.Fatalf("decode partner days: %v", err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("expected 1 shared log, got %d", len(logs))
+	}
+	if !logs[0].IsPeriod || logs[0].Flow != models.FlowNone {
+		t.Fatalf("expected period day without flow, got %#v", logs[0])
+	}
+	if logs[0].Notes != "shared-note" {
+		t.Fatalf("expected shared notes, got %q", logs[0].Notes)
+	}
+	if len(logs[0].SymptomIDs) != 1 || logs[0].SymptomIDs[0] != sharedSymptom.ID {
+		t.Fatalf("expected only shared symptom, got %#v", logs[0].SymptomIDs)
+	}
+
+	statsBody := smokeGET(t, app, partnerCookie, "/api/stats/overview", http.StatusOK)
+	stats := services.CycleStats{}
+	if err := json.Unmarshal([]byte(statsBody), &stats); err != nil {
+		t.Fatalf("decode partner stats: %v", err)
+	}
+	if !stats.NextPeriodStart.IsZero() || !stats.FertilityWindowStart.IsZero() || !stats.OvulationDate.IsZero() {
+		t.Fatalf("expected predictions hidden from partner, got %#v", stats)
+	}
+	if stats.LastPeriodStart.IsZero() {
+		t.Fatal("expected shared period history in partner stats")
+	}
+
+	predictionsBody := smokeGET(t, app, partnerCookie, "/api/predictions", http.StatusOK)
+	if !strings.Contains(predictionsBody, `"predictions":[]`) {
+		t.Fatalf("expected predictions hidden from partner, got %s", predictionsBody)
+	}
+
+	calendar := smokeGET(t, app, partnerCookie, "/calendar?month=2026-03", http.StatusOK)
+	if strings.Contains(calendar, "calendar-tag-predicted") || strings.Contains(calendar, "calendar-tag-fertile") {
+		t.Fatal("expected calendar to hide predicted and fertile days from partner")
+	}
+}
+
+func TestPartnerSharingPolicyUpdateIsOwnerScoped(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "sharing-scope-owner@example.com", "StrongPass1", true)
+	other := createOnboardingTestUser(t, database, "sharing-scope-other@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+	otherCookie := loginAndExtractAuthCookie(t, app, other.Email, "StrongPass1")
+
+	partner := createLinkedPartnerForTest(t, app, database, ownerCookie, "sharing-scope-partner@example.com")
+	partnerCookie := loginAndExtractAuthCookie(t, app, partner.Email, "StrongPass1")
+
+	if status := postPartnerSharingForTest(t, app, otherCookie, partner.ID, `{"share_notes":true}`); status != http.StatusNotFound {
+		t.Fatalf("expected foreign owner sharing update status 404, got %d", status)
+	}
+	if status := postPartnerSharingForTest(t, app, partnerCookie, partner.ID, `{"share_notes":true}`); status != http.StatusForbidden {
+		t.Fatalf("expected partner sharing update status 403, got %d", status)
+	}
+
+	stored := models.User{}
+	if err := database.First(&stored, partner.ID).Error; err != nil {
+		t.Fatalf("reload partner: %v", err)
+	}
+	if stored.SharingPolicy.ShareNotes {
+		t.Fatal("expected sharing policy to stay unchanged")
+	}
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func TestPredictionAlgorithmSettingAndAccuracyReport(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "prediction-algorithm@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+
+	today := services.DateAtLocation(time.Now().UTC(), time.UTC)
+	start := today.AddDate(0, 0, -(26 + 27 + 28 + 35 + 36 + 37 + 3))
+	for _, length := range []int{26, 27, 28, 35, 36, 37, 0} {
+		entry := models.DailyLog{UserID: owner.ID, Date: start, IsPeriod: true, Flow: models.FlowMedium}
+		if err := database.Create(&entry).Error; err != nil {
+			t.Fatalf("create period log: %v", err)
+		}
+		start = start.AddDate(0, 0, length)
+	}
+
+	body := smokeGET(t, app, ownerCookie, "/api/stats/overview", http.StatusOK)
+	if !strings.Contains(body, `"prediction_algorithm":"median"`) || !strings.Contains(body, `"predicted_cycle_length":32`) {
+		t.Fatalf("expected the median prediction by default, got %s", body)
+	}
+	body = smokeGET(t, app, ownerCookie, "/api/stats/prediction-accuracy", http.StatusOK)
+	if !strings.Contains(body, `"algorithm":"weighted_average","samples":4`) {
+		t.Fatalf("expected backtest results per algorithm, got %s", body)
+	}
+	settingsBody := smokeGET(t, app, ownerCookie, "/settings", http.StatusOK)
+	if !strings.Contains(settingsBody, `id="settings-predictions"`) || !strings.Contains(settingsBody, `data-prediction-algorithm="bayesian"`) {
+		t.Fatal("expected the prediction section with every algorithm on the settings page")
+	}
+
+	response := postSessionFormForTest(t, app, ownerCookie, "/api/settings/prediction-algorithm", url.Values{"algorithm": {"weighted_average"}})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+	body = smokeGET(t, app, ownerCookie, "/api/stats/overview", http.StatusOK)
+	if !strings.Contains(body, `"prediction_algorithm":"weighted_average"`) || !strings.Contains(body, `"predicted_cycle_length":34`) {
+		t.Fatalf("expected the weighted average prediction, got %s", body)
+	}
+
+	response = postSessionFormForTest(t, app, ownerCookie, "/api/settings/prediction-algorithm", url.Values{"algorithm": {"neural"}})
+	if response.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", response.StatusCode)
+	}
+	if message := readAPIError(t, response.Body); message != "invalid prediction algorithm" {
+		t.Fatalf("expected %q, got %q", "invalid prediction algorithm", message)
+	}
+	response.Body.Close()
+
+	partner := createLinkedPartnerForTest(t, app, database, ownerCookie, "prediction-algorithm-partner@example.com")
+	partnerCookie := loginAndExtractAuthCookie(t, app, partner.Email, "StrongPass1")
+	smokeGET(t, app, partnerCookie, "/api/stats/prediction-accuracy", http.StatusForbidden)
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func TestLoggingPeriodStartSnapshotsPredictionAndReportsAccuracy(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "prediction-history@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+
+	today := services.DateAtLocation(time.Now().UTC(), time.UTC)
+	for _, daysAgo := range []int{84, 83, 56, 55, 28} {
+		entry := models.DailyLog{UserID: owner.ID, Date: today.AddDate(0, 0, -daysAgo), IsPeriod: true, Flow: models.FlowMedium}
+		if err := database.Create(&entry).Error; err != nil {
+			t.Fatalf("create period log: %v", err)
+		}
+	}
+	previous := models.PredictionSnapshot{
+		UserID:               owner.ID,
+		CycleStart:           today.AddDate(0, 0, -28),
+		PredictedPeriodStart: today.AddDate(0, 0, -3),
+		Algorithm:            models.PredictionAlgorithmMedian,
+	}
+	if err := database.Create(&previous).Error; err != nil {
+		t.Fatalf("create snapshot: %v", err)
+	}
+
+	response := postDayJSONForTest(t, app, ownerCookie, today.Format("2006-01-02"), map[string]any{
+		"is_period": true,
+		"flow":      models.FlowMedium,
+	})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+
+	snapshot := models.PredictionSnapshot{}
+	if err := database.Where("user_id = ? AND cycle_start = ?", owner.ID, today).First(&snapshot).Error; err != nil {
+		t.Fatalf("expected a snapshot for the new cycle: %v", err)
+	}
+	if !snapshot.PredictedPeriodStart.After(today) || snapshot.PredictedOvulation == nil {
+		t.Fatalf("expected future predictions in the snapshot, got %#v", snapshot)
+	}
+
+	response = postDayJSONForTest(t, app, ownerCookie, today.Format("2006-01-02"), map[string]any{
+		"is_period": true,
+		"flow":      models.FlowHeavy,
+	})
+	response.Body.Close()
+	var count int64
+	if err := database.Model(&models.PredictionSnapshot{}).Where("user_id = ?", owner.ID).Count(&count).Error; err != nil || count != 2 {
+		t.Fatalf("expected one snapshot per cycle, got %d (%v)", count, err)
+	}
+
+	body := smokeGET(t, app, ownerCookie, "/api/stats/prediction-history", http.StatusOK)
+	if !strings.Contains(body, `"error_days":3`) || !strings.Contains(body, `"accuracy_score":0`) || !strings.Contains(body, `"scored_cycles":1`) {
+		t.Fatalf("expected the finished cycle to be scored, got %s", body)
+	}
+
+	statsBody := smokeGET(t, app, ownerCookie, "/stats", http.StatusOK)
+	if !strings.Contains(statsBody, `id="prediction-history-section"`) || !strings.Contains(statsBody, `data-prediction-error="3"`) {
+		t.Fatal("expected the prediction history on the stats page")
+	}
+
+	partner := createLinkedPartnerForTest(t, app, database, ownerCookie, "prediction-history-partner@example.com")
+	partnerCookie := loginAndExtractAuthCookie(t, app, partner.Email, "StrongPass1")
+	smokeGET(t, app, partnerCookie, "/api/stats/prediction-history", http.StatusForbidden)
+	if body := smokeGET(t, app, partnerCookie, "/stats", http.StatusOK); strings.Contains(body, "prediction-history-section") {
+		t.Fatal("expected no prediction history for partners")
+	}
+}
+
+func TestSavingNonStartPeriodDaysDoesNotSnapshotPrediction(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "prediction-history-mid-cycle@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+
+	today := services.DateAtLocation(time.Now().UTC(), time.UTC)
+	for _, daysAgo := range []int{56, 55, 28, 27} {
+		entry := models.DailyLog{UserID: owner.ID, Date: today.AddDate(0, 0, -daysAgo), IsPeriod: true, Flow: models.FlowMedium}
+		if err := database.Create(&entry).Error; err != nil {
+			t.Fatalf("create period log: %v", err)
+		}
+	}
+
+	for _, request := range []struct {
+		day  time.Time
+		flow string
+	}{
+		{day: today.AddDate(0, 0, -26), flow: models.FlowLight},
+		{day: today.AddDate(0, 0, -60), flow: models.FlowMedium},
+		{day: today.AddDate(0, 0, -2), flow: models.FlowSpotting},
+	} {
+		response := postDayJSONForTest(t, app, ownerCookie, request.day.Format("2006-01-02"), map[string]any{
+			"is_period": true,
+			"flow":      request.flow,
+		})
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", response.StatusCode)
+		}
+	}
+
+	var count int64
+	if err := database.Model(&models.PredictionSnapshot{}).Where("user_id = ?", owner.ID).Count(&count).Error; err != nil || count != 0 {
+		t.Fatalf("expected no snapshot for days that do not start the current cycle, got %d (%v)", count, err)
+	}
+}
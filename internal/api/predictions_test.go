@@ -0,0 +1,86 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func TestGetPredictionsProjectsRequestedCycles(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "predictions-owner@example.com", "StrongPass1", true)
+	authCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+
+	today := time.Now().UTC()
+	for _, daysAgo := range []int{62, 61, 34, 33, 5, 4} {
+		day := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -daysAgo)
+		entry := models.DailyLog{UserID: user.ID, Date: day, IsPeriod: true, Flow: models.FlowMedium}
+		if err := database.Create(&entry).Error; err != nil {
+			t.Fatalf("create period log: %v", err)
+		}
+	}
+
+	body := smokeGET(t, app, authCookie, "/api/predictions?cycles=4", http.StatusOK)
+	payload := struct {
+		Cycles      int                        `json:"cycles"`
+		Predictions []services.CyclePrediction `json:"predictions"`
+	}{}
+	if err := json.Unmarshal([]byte(body), &payload); err != nil {
+		t.Fatalf("decode predictions: %v", err)
+	}
+	if payload.Cycles != 4 || len(payload.Predictions) != 4 {
+		t.Fatalf("expected 4 predicted cycles, got %s", body)
+	}
+	for index := 1; index < len(payload.Predictions); index++ {
+		previous, current := payload.Predictions[index-1], payload.Predictions[index]
+		if !current.PeriodStart.After(previous.PeriodStart) {
+			t.Fatalf("expected predictions in chronological order, got %s", body)
+		}
+		if current.UncertaintyDays < previous.UncertaintyDays {
+			t.Fatalf("expected uncertainty to widen with distance, got %s", body)
+		}
+	}
+
+	defaultBody := smokeGET(t, app, authCookie, "/api/predictions", http.StatusOK)
+	if err := json.Unmarshal([]byte(defaultBody), &payload); err != nil {
+		t.Fatalf("decode default predictions: %v", err)
+	}
+	if payload.Cycles != services.DefaultPredictionCycles || len(payload.Predictions) != services.DefaultPredictionCycles {
+		t.Fatalf("expected default horizon of %d cycles, got %s", services.DefaultPredictionCycles, defaultBody)
+	}
+
+	for _, query := range []string{"2", "13", "many"} {
+		invalid := smokeGET(t, app, authCookie, "/api/predictions?cycles="+query, http.StatusBadRequest)
+		if got := readAPIError(t, strings.NewReader(invalid)); got != "invalid prediction cycles" {
+			t.Fatalf("expected invalid prediction cycles for %q, got %q", query, got)
+		}
+	}
+}
+
+func TestCalendarDrawsPredictionRangeOnFutureMonths(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "predictions-calendar@example.com", "StrongPass1", true)
+	authCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+
+	today := time.Now().UTC()
+	start := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -3)
+	entry := models.DailyLog{UserID: user.ID, Date: start, IsPeriod: true, Flow: models.FlowMedium}
+	if err := database.Create(&entry).Error; err != nil {
+		t.Fatalf("create period log: %v", err)
+	}
+
+	month := start.AddDate(0, 4, 0).Format("2006-01")
+	body := smokeGET(t, app, authCookie, "/calendar?month="+month, http.StatusOK)
+	if !strings.Contains(body, "calendar-tag-predicted-range") {
+		t.Fatalf("expected prediction range markers on %s", month)
+	}
+}
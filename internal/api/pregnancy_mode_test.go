@@ -0,0 +1,113 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func TestPregnancyModePausesPredictions(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "pregnancy-mode@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+
+	today := services.DateAtLocation(time.Now().UTC(), time.UTC)
+	for _, daysAgo := range []int{90, 89, 62, 61, 34, 33} {
+		entry := models.DailyLog{UserID: owner.ID, Date: today.AddDate(0, 0, -daysAgo), IsPeriod: true, Flow: models.FlowMedium}
+		if err := database.Create(&entry).Error; err != nil {
+			t.Fatalf("create period log: %v", err)
+		}
+	}
+	if body := smokeGET(t, app, ownerCookie, "/api/predictions", http.StatusOK); strings.Contains(body, `"predictions":[]`) {
+		t.Fatalf("expected predictions before pregnancy mode, got %s", body)
+	}
+
+	pregnancyStart := today.AddDate(0, 0, -34).Format("2006-01-02")
+	response := postSessionFormForTest(t, app, ownerCookie, "/api/settings/pregnancy-mode", url.Values{
+		"mode":            {"pregnancy"},
+		"pregnancy_start": {pregnancyStart},
+	})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+
+	stored := models.User{}
+	if err := database.First(&stored, owner.ID).Error; err != nil {
+		t.Fatalf("load owner: %v", err)
+	}
+	if stored.CycleMode != models.CycleModePregnancy || stored.PregnancyStart == nil || stored.DueDate == nil {
+		t.Fatalf("expected pregnancy mode with dates stored, got %#v", stored)
+	}
+	if got := services.DateAtLocation(*stored.DueDate, time.UTC); !got.Equal(today.AddDate(0, 0, 246)) {
+		t.Fatalf("expected due date 280 days after the start, got %s", got)
+	}
+
+	if body := smokeGET(t, app, ownerCookie, "/api/predictions", http.StatusOK); !strings.Contains(body, `"predictions":[]`) {
+		t.Fatalf("expected no predictions in pregnancy mode, got %s", body)
+	}
+	body := smokeGET(t, app, ownerCookie, "/dashboard", http.StatusOK)
+	if !strings.Contains(body, `data-pregnancy-status="pregnancy"`) || !strings.Contains(body, "4 wk 6 d") {
+		t.Fatal("expected gestational age on the dashboard")
+	}
+	if strings.Contains(body, "Cycle data may be outdated") || strings.Contains(body, "data-pregnancy-prompt") {
+		t.Fatal("expected no stale warning or pregnancy prompt in pregnancy mode")
+	}
+	settingsBody := smokeGET(t, app, ownerCookie, "/settings", http.StatusOK)
+	if !strings.Contains(settingsBody, `id="settings-pregnancy"`) || !strings.Contains(settingsBody, `value="`+pregnancyStart+`"`) {
+		t.Fatal("expected pregnancy settings with the stored start date")
+	}
+
+	partner := createLinkedPartnerForTest(t, app, database, ownerCookie, "pregnancy-mode-partner@example.com")
+	partnerCookie := loginAndExtractAuthCookie(t, app, partner.Email, "StrongPass1")
+	body = smokeGET(t, app, partnerCookie, "/dashboard", http.StatusOK)
+	if !strings.Contains(body, `data-pregnancy-status="paused"`) || strings.Contains(body, "4 wk 6 d") {
+		t.Fatal("expected partner to see paused predictions without pregnancy details")
+	}
+
+	response = postSessionFormForTest(t, app, ownerCookie, "/api/settings/pregnancy-mode", url.Values{"mode": {"cycle"}})
+	response.Body.Close()
+	stored = models.User{}
+	if err := database.First(&stored, owner.ID).Error; err != nil {
+		t.Fatalf("load owner: %v", err)
+	}
+	if stored.CycleMode != models.CycleModeCycle || stored.PregnancyStart != nil || stored.DueDate != nil {
+		t.Fatalf("expected cycle mode without pregnancy dates, got %#v", stored)
+	}
+}
+
+func TestPregnancyModeRejectsInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "pregnancy-mode-invalid@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format("2006-01-02")
+
+	for _, testCase := range []struct {
+		form    url.Values
+		message string
+	}{
+		{form: url.Values{"mode": {"trying"}}, message: "invalid cycle mode"},
+		{form: url.Values{"mode": {"pregnancy"}}, message: "pregnancy date required"},
+		{form: url.Values{"mode": {"pregnancy"}, "pregnancy_start": {tomorrow}}, message: "invalid pregnancy date"},
+		{form: url.Values{"mode": {"postpartum"}}, message: "postpartum date required"},
+		{form: url.Values{"mode": {"postpartum"}, "postpartum_start": {tomorrow}}, message: "invalid postpartum date"},
+	} {
+		response := postSessionFormForTest(t, app, ownerCookie, "/api/settings/pregnancy-mode", testCase.form)
+		if response.StatusCode != http.StatusBadRequest {
+			t.Fatalf("expected status 400 for %v, got %d", testCase.form, response.StatusCode)
+		}
+		if message := readAPIError(t, response.Body); message != testCase.message {
+			t.Fatalf("expected %q, got %q", testCase.message, message)
+		}
+		response.Body.Close()
+	}
+}
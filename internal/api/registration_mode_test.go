@@ -0,0 +1,193 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/db"
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+	"gorm.io/gorm"
+)
+
+func postRegistrationForTest(t *testing.T, app *fiber.App, email string, extra url.Values, acceptJSON bool) *http.Response {
+	t.Helper()
+
+	form := url.Values{
+		"email":            {email},
+		"password":         {"StrongPass1"},
+		"confirm_password": {"StrongPass1"},
+	}
+	for key, values := range extra {
+		form[key] = values
+	}
+	request := httptest.NewRequest(http.MethodPost, "/api/auth/register", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if acceptJSON {
+		request.Header.Set("Accept", "application/json")
+	}
+
+	response, err := app.Test(request, -1)
+	if err != nil {
+		t.Fatalf("register request failed: %v", err)
+	}
+	return response
+}
+
+func createRegistrationInviteForTest(t *testing.T, database *gorm.DB) string {
+	t.Helper()
+
+	repositories := db.NewRepositories(database)
+	service := services.NewRegistrationService(services.RegistrationModeInvite, repositories.Users, repositories.RegistrationInvites)
+	rawToken, _, err := service.CreateInvite(time.Now(), time.Hour)
+	if err != nil {
+		t.Fatalf("create registration invite: %v", err)
+	}
+	return rawToken
+}
+
+func countUsersForTest(t *testing.T, database *gorm.DB) int64 {
+	t.Helper()
+
+	var count int64
+	if err := database.Model(&models.User{}).Count(&count).Error; err != nil {
+		t.Fatalf("count users: %v", err)
+	}
+	return count
+}
+
+func TestRegistrationModeFirstUserOnlyClosesSignUpAfterFirstOwner(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestAppWithRegistrationMode(t, services.RegistrationModeFirstUserOnly)
+
+	registerPage := smokeGET(t, app, "", "/register", http.StatusOK)
+	if !strings.Contains(registerPage, `action="/api/auth/register"`) {
+		t.Fatal("expected register form before the first owner exists")
+	}
+
+	first := postRegistrationForTest(t, app, "first-owner@example.com", nil, true)
+	first.Body.Close()
+	if first.StatusCode != http.StatusCreated {
+		t.Fatalf("expected first owner register status 201, got %d", first.StatusCode)
+	}
+
+	second := postRegistrationForTest(t, app, "second-owner@example.com", nil, true)
+	defer second.Body.Close()
+	if second.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected second register status 403, got %d", second.StatusCode)
+	}
+	if message := readAPIError(t, second.Body); message != "registration disabled" {
+		t.Fatalf("expected registration disabled error, got %q", message)
+	}
+	if count := countUsersForTest(t, database); count != 1 {
+		t.Fatalf("expected only the first owner to exist, got %d users", count)
+	}
+
+	formResponse := postRegistrationForTest(t, app, "third-owner@example.com", nil, false)
+	formResponse.Body.Close()
+	if formResponse.StatusCode != http.StatusSeeOther {
+		t.Fatalf("expected form register redirect status 303, got %d", formResponse.StatusCode)
+	}
+	if location := formResponse.Header.Get("Location"); !strings.Contains(location, "error=registration+disabled") {
+		t.Fatalf("expected redirect with registration disabled error, got %q", location)
+	}
+
+	closedPage := smokeGET(t, app, "", "/register", http.StatusOK)
+	if strings.Contains(closedPage, `action="/api/auth/register"`) {
+		t.Fatal("expected register form to be hidden after the first owner")
+	}
+	loginPage := smokeGET(t, app, "", "/login", http.StatusOK)
+	if strings.Contains(loginPage, `href="/register"`) {
+		t.Fatal("expected login page to hide the sign-up link")
+	}
+}
+
+func TestRegistrationModeInviteRequiresRegistrationToken(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestAppWithRegistrationMode(t, services.RegistrationModeInvite)
+	createOnboardingTestUser(t, database, "invite-mode-owner@example.com", "StrongPass1", true)
+
+	missing := postRegistrationForTest(t, app, "no-token@example.com", nil, true)
+	defer missing.Body.Close()
+	if missing.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected missing token status 403, got %d", missing.StatusCode)
+	}
+	if message := readAPIError(t, missing.Body); message != "registration invite required" {
+		t.Fatalf("expected registration invite required error, got %q", message)
+	}
+
+	invalid := postRegistrationForTest(t, app, "bad-token@example.com", url.Values{"registration_token": {"not-a-token"}}, true)
+	defer invalid.Body.Close()
+	if invalid.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected invalid token status 400, got %d", invalid.StatusCode)
+	}
+
+	token := createRegistrationInviteForTest(t, database)
+	registerPage := smokeGET(t, app, "", "/register?registration_token="+url.QueryEscape(token), http.StatusOK)
+	if !strings.Contains(registerPage, `name="registration_token" value="`+token+`"`) {
+		t.Fatal("expected register page to carry the registration token")
+	}
+
+	accepted := postRegistrationForTest(t, app, "invited-owner@example.com", url.Values{"registration_token": {token}}, true)
+	accepted.Body.Close()
+	if accepted.StatusCode != http.StatusCreated {
+		t.Fatalf("expected invited register status 201, got %d", accepted.StatusCode)
+	}
+
+	invitedUser := models.User{}
+	if err := database.Where("email = ?", "invited-owner@example.com").First(&invitedUser).Error; err != nil {
+		t.Fatalf("load invited owner: %v", err)
+	}
+	if invitedUser.Role != models.RoleOwner {
+		t.Fatalf("expected invited user to be an owner, got %q", invitedUser.Role)
+	}
+
+	reused := postRegistrationForTest(t, app, "reused-token@example.com", url.Values{"registration_token": {token}}, true)
+	reused.Body.Close()
+	if reused.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected reused token status 400, got %d", reused.StatusCode)
+	}
+	if count := countUsersForTest(t, database); count != 2 {
+		t.Fatalf("expected 2 users after invite flow, got %d", count)
+	}
+}
+
+func TestRegistrationModeInviteStillAcceptsPartnerInvites(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestAppWithRegistrationMode(t, services.RegistrationModeInvite)
+	owner := createOnboardingTestUser(t, database, "invite-mode-partner-owner@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+
+	token := createPartnerInviteForTest(t, app, ownerCookie)
+	response := registerPartnerWithInvite(t, app, "invite-mode-partner@example.com", token)
+	response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		t.Fatalf("expected partner register status 201, got %d", response.StatusCode)
+	}
+}
+
+func TestRegistrationModeOpenKeepsPublicSignUp(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestAppWithRegistrationMode(t, services.RegistrationModeOpen)
+	createOnboardingTestUser(t, database, "open-mode-owner@example.com", "StrongPass1", true)
+
+	response := postRegistrationForTest(t, app, "open-mode-second@example.com", nil, true)
+	response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		t.Fatalf("expected open register status 201, got %d", response.StatusCode)
+	}
+
+	loginPage := smokeGET(t, app, "", "/login", http.StatusOK)
+	if !strings.Contains(loginPage, `href="/register"`) {
+		t.Fatal("expected login page to keep the sign-up link")
+	}
+}
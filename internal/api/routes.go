@@ -17,6 +17,7 @@ func registerPageRoutes(app *fiber.App, handler *Handler) {
 	app.Get("/recovery-code", handler.ShowRecoveryCodePage)
 	app.Get("/forgot-password", handler.ShowForgotPasswordPage)
 	app.Get("/reset-password", handler.ShowResetPasswordPage)
+	app.Get("/login/two-factor", handler.ShowTwoFactorPage)
 	app.Post("/logout", handler.AuthRequired, handler.Logout)
 	app.Get("/privacy", handler.ShowPrivacyPage)
 	app.Get("/onboarding", handler.AuthRequired, handler.ShowOnboarding)
@@ -27,6 +28,7 @@ func registerPageRoutes(app *fiber.App, handler *Handler) {
 	app.Get("/dashboard", handler.AuthRequired, handler.ShowDashboard)
 	app.Get("/calendar", handler.AuthRequired, handler.ShowCalendar)
 	app.Get("/calendar/day/:date", handler.AuthRequired, handler.CalendarDayPanel)
+	app.Get("/calendar/feed/:token.ics", handler.CalendarFeed)
 	app.Get("/stats", handler.AuthRequired, handler.ShowStats)
 	app.Get("/settings", handler.AuthRequired, handler.ShowSettings)
 	app.Post("/settings/cycle", handler.AuthRequired, handler.OwnerOnly, handler.UpdateCycleSettings)
@@ -40,6 +42,7 @@ func registerAPIRoutes(app *fiber.App, handler *Handler) {
 	auth.Post("/logout", handler.AuthRequired, handler.Logout)
 	auth.Post("/register", handler.Register)
 	auth.Post("/login", handler.Login)
+	auth.Post("/login/two-factor", handler.VerifyTwoFactorLogin)
 	auth.Post("/forgot-password", handler.ForgotPassword)
 	auth.Post("/reset-password", handler.ResetPassword)
 
@@ -58,18 +61,53 @@ func registerAPIRoutes(app *fiber.App, handler *Handler) {
 	symptoms.Post("", handler.OwnerOnly, handler.CreateSymptom)
 	symptoms.Delete("/:id", handler.OwnerOnly, handler.DeleteSymptom)
 
+	api.Get("/medications", handler.AuthRequired, handler.OwnerOnly, handler.GetMedications)
+	api.Get("/metrics", handler.AuthRequired, handler.OwnerOnly, handler.GetMetrics)
+
 	stats := api.Group("/stats", handler.AuthRequired)
 	stats.Get("/overview", handler.GetStatsOverview)
+	stats.Get("/prediction-accuracy", handler.OwnerOnly, handler.GetPredictionAccuracy)
+	stats.Get("/prediction-history", handler.OwnerOnly, handler.GetPredictionHistory)
+
+	api.Get("/predictions", handler.AuthRequired, handler.GetPredictions)
 
 	export := api.Group("/export", handler.AuthRequired, handler.OwnerOnly)
 	export.Get("/summary", handler.ExportSummary)
 	export.Get("/csv", handler.ExportCSV)
 	export.Get("/json", handler.ExportJSON)
 
+	importGroup := api.Group("/import", handler.AuthRequired, handler.OwnerOnly)
+	importGroup.Post("/:format", handler.ImportData)
+
 	settings := api.Group("/settings", handler.AuthRequired)
 	settings.Post("/profile", handler.UpdateProfile)
 	settings.Post("/change-password", handler.ChangePassword)
 	settings.Post("/regenerate-recovery-code", handler.RegenerateRecoveryCode)
+	settings.Post("/two-factor/setup", handler.StartTwoFactorSetup)
+	settings.Post("/two-factor/enable", handler.EnableTwoFactor)
+	settings.Post("/two-factor/disable", handler.DisableTwoFactor)
+	settings.Post("/partner-invites", handler.OwnerOnly, handler.CreatePartnerInvite)
+	settings.Post("/partner-invites/:id/revoke", handler.OwnerOnly, handler.RevokePartnerInvite)
+	settings.Post("/partners/:id/revoke", handler.OwnerOnly, handler.RevokePartner)
+	settings.Post("/partners/:id/sharing", handler.OwnerOnly, handler.UpdatePartnerSharing)
+	settings.Post("/api-tokens", handler.OwnerOnly, handler.CreateAPIToken)
+	settings.Post("/api-tokens/:id/revoke", handler.OwnerOnly, handler.RevokeAPIToken)
+	settings.Post("/calendar-feed", handler.OwnerOnly, handler.EnableCalendarFeed)
+	settings.Post("/calendar-feed/options", handler.OwnerOnly, handler.UpdateCalendarFeed)
+	settings.Post("/calendar-feed/disable", handler.OwnerOnly, handler.DisableCalendarFeed)
+	settings.Post("/pregnancy-mode", handler.OwnerOnly, handler.UpdatePregnancyMode)
+	settings.Post("/contraception", handler.OwnerOnly, handler.UpdateContraception)
+	settings.Post("/prediction-algorithm", handler.OwnerOnly, handler.UpdatePredictionAlgorithm)
+	settings.Post("/luteal-phase", handler.OwnerOnly, handler.UpdateLutealPhase)
+	settings.Post("/medications", handler.OwnerOnly, handler.CreateMedication)
+	settings.Post("/medications/:id/delete", handler.OwnerOnly, handler.DeleteMedication)
+	settings.Post("/intimacy", handler.OwnerOnly, handler.UpdateIntimacyTracking)
+	settings.Post("/metrics", handler.OwnerOnly, handler.CreateMetric)
+	settings.Post("/metrics/:id/delete", handler.OwnerOnly, handler.DeleteMetric)
+	settings.Post("/import/preview", handler.OwnerOnly, handler.PreviewImport)
+	settings.Post("/import/commit", handler.OwnerOnly, handler.CommitImport)
+	settings.Post("/sessions/revoke-all", handler.RevokeAllSessions)
+	settings.Post("/sessions/:id/revoke", handler.RevokeSession)
 	settings.Post("/clear-data", handler.OwnerOnly, handler.ClearAllData)
 	settings.Delete("/delete-account", handler.DeleteAccount)
 }
@@ -47,6 +47,15 @@ func (handler *Handler) buildSettingsViewData(c *fiber.Ctx, user *models.User, f
 	user.PeriodLength = periodLength
 	user.AutoPeriodFill = autoPeriodFill
 	user.LastPeriodStart = persisted.LastPeriodStart
+	user.TemperatureUnit = services.NormalizeTemperatureUnit(persisted.TemperatureUnit)
+	user.CycleMode = services.NormalizeCycleMode(persisted.CycleMode)
+	user.PregnancyStart = persisted.PregnancyStart
+	user.DueDate = persisted.DueDate
+	user.PostpartumStart = persisted.PostpartumStart
+	user.Contraception = persisted.Contraception
+	user.IntimacyTracking = persisted.IntimacyTracking
+	user.PredictionAlgorithm = services.NormalizePredictionAlgorithm(persisted.PredictionAlgorithm)
+	user.LutealPhaseLength = persisted.LutealPhaseLength
 
 	lastPeriodStart := ""
 	if persisted.LastPeriodStart != nil {
@@ -63,11 +72,31 @@ func (handler *Handler) buildSettingsViewData(c *fiber.Ctx, user *models.User, f
 		"CycleLength":            cycleLength,
 		"PeriodLength":           periodLength,
 		"AutoPeriodFill":         autoPeriodFill,
+		"TemperatureUnit":        user.TemperatureUnit,
 		"LastPeriodStart":        lastPeriodStart,
 		"TodayISO":               today.Format("2006-01-02"),
 		"CycleStartMinISO":       minCycleStart.Format("2006-01-02"),
+		"CycleMode":              user.CycleMode,
+		"PregnancyStart":         handler.optionalDateISO(persisted.PregnancyStart),
+		"DueDate":                handler.optionalDateISO(persisted.DueDate),
+		"PostpartumStart":        handler.optionalDateISO(persisted.PostpartumStart),
+		"Contraception":          persisted.Contraception,
+		"ContraceptionStart":     handler.optionalDateISO(persisted.Contraception.Start),
+		"IntimacyTracking":       persisted.IntimacyTracking,
+		"PredictionAlgorithm":    user.PredictionAlgorithm,
 	}
 
+	sessions, err := handler.sessionService.List(user.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	currentSessionID := uint(0)
+	if session, ok := currentSession(c); ok {
+		currentSessionID = session.ID
+	}
+	data["Sessions"] = buildSessionViews(sessions, currentSessionID, language, handler.location)
+	data["TwoFactor"] = handler.buildTwoFactorSettingsView(user)
+
 	if user.Role == models.RoleOwner {
 		summary, err := handler.exportService.BuildSummary(user.ID, nil, nil, handler.location)
 		if err != nil {
@@ -87,7 +116,88 @@ func (handler *Handler) buildSettingsViewData(c *fiber.Ctx, user *models.User, f
 		}
 		data["ExportDateFromDisplay"] = displayFrom
 		data["ExportDateToDisplay"] = displayTo
+
+		partners, err := handler.partnerService.ListPartners(user.ID)
+		if err != nil {
+			return nil, err
+		}
+		invites, err := handler.partnerService.ListActiveInvites(user.ID, time.Now().In(handler.location))
+		if err != nil {
+			return nil, err
+		}
+		shareableSymptoms, err := handler.fetchSymptoms(user.ID)
+		if err != nil {
+			return nil, err
+		}
+		data["Partners"] = buildPartnerSharingViews(partners)
+		data["PartnerShareableSymptoms"] = shareableSymptoms
+		data["PartnerInvites"] = invites
+
+		apiTokens, err := handler.apiTokenService.List(user.ID)
+		if err != nil {
+			return nil, err
+		}
+		data["APITokens"] = buildAPITokenViews(apiTokens, language, handler.location)
+
+		medications, err := handler.medicationService.ListMedications(user.ID)
+		if err != nil {
+			return nil, err
+		}
+		data["Medications"] = medications
+		data["MedicationUnits"] = services.MedicationUnits()
+
+		metrics, err := handler.metricService.ListMetricTypes(user.ID)
+		if err != nil {
+			return nil, err
+		}
+		data["MetricTypes"] = metrics
+		data["MetricKinds"] = services.MetricKinds()
+
+		accuracy, err := handler.statsService.BuildPredictionAccuracy(user, time.Now().In(handler.location), handler.location)
+		if err != nil {
+			return nil, err
+		}
+		data["PredictionAccuracy"] = accuracy
+		data["MostAccuratePredictionAlgorithm"] = services.MostAccuratePredictionAlgorithm(accuracy)
+
+		luteal, err := handler.statsService.BuildLutealPhase(user, handler.location)
+		if err != nil {
+			return nil, err
+		}
+		data["LutealPhase"] = luteal
+		data["LutealPhaseMin"] = services.MinLutealPhaseLength
+		data["LutealPhaseMax"] = services.MaxLutealPhaseLength
+
+		if feed, found := handler.calendarFeedService.Find(user.ID); found {
+			data["CalendarFeed"] = feed
+		}
+	} else {
+		linkedOwner, err := handler.partnerService.ResolveDataOwner(user)
+		if err != nil {
+			return nil, err
+		}
+		if linkedOwner.ID != user.ID {
+			data["LinkedOwner"] = linkedOwner
+		}
 	}
 
 	return data, nil
 }
+
+func (handler *Handler) optionalDateISO(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return dateAtLocation(*value, handler.location).Format("2006-01-02")
+}
+
+func buildPartnerSharingViews(partners []models.User) []PartnerSharingView {
+	views := make([]PartnerSharingView, 0, len(partners))
+	for _, partner := range partners {
+		views = append(views, PartnerSharingView{
+			Partner:         partner,
+			SharedSymptomID: symptomIDSet(partner.SharingPolicy.SharedSymptomIDs),
+		})
+	}
+	return views
+}
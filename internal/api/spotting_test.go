@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func TestSpottingFlowIsSavedWithoutStartingACycle(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "spotting-flow@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+
+	today := services.DateAtLocation(time.Now().UTC(), time.UTC)
+	periodStart := today.AddDate(0, 0, -20)
+	response := postDayJSONForTest(t, app, ownerCookie, periodStart.Format("2006-01-02"), map[string]any{
+		"is_period": true,
+		"flow":      models.FlowMedium,
+	})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+
+	spottingDay := today.AddDate(0, 0, -2)
+	response = postSessionFormForTest(t, app, ownerCookie, "/api/days/"+spottingDay.Format("2006-01-02"), url.Values{
+		"is_period": {"true"},
+		"flow":      {models.FlowSpotting},
+	})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+
+	var spottingDays int64
+	if err := database.Model(&models.DailyLog{}).Where("user_id = ? AND flow = ?", owner.ID, models.FlowSpotting).Count(&spottingDays).Error; err != nil || spottingDays != 1 {
+		t.Fatalf("expected one spotting day without auto-filled days, got %d (%v)", spottingDays, err)
+	}
+	refreshed := models.User{}
+	if err := database.First(&refreshed, owner.ID).Error; err != nil {
+		t.Fatalf("load user: %v", err)
+	}
+	if refreshed.LastPeriodStart == nil || services.DateAtLocation(*refreshed.LastPeriodStart, time.UTC).Format("2006-01-02") != periodStart.Format("2006-01-02") {
+		t.Fatalf("expected spotting not to start a cycle, got %v", refreshed.LastPeriodStart)
+	}
+
+	calendarBody := smokeGET(t, app, ownerCookie, "/calendar?month="+spottingDay.Format("2006-01"), http.StatusOK)
+	if !strings.Contains(calendarBody, "calendar-cell-spotting") || !strings.Contains(calendarBody, "data-spotting") {
+		t.Fatal("expected the spotting day to be drawn apart on the calendar")
+	}
+}
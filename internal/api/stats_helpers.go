@@ -11,3 +11,25 @@ func (handler *Handler) buildCycleStatsForRange(user *models.User, from time.Tim
 	handler.ensureDependencies()
 	return handler.statsService.BuildCycleStatsForRange(user, from, to, now, handler.location)
 }
+
+// capturePredictionSnapshot records the forecast for the owner's current
+// cycle once, right after a write that may have started that cycle.
+func (handler *Handler) capturePredictionSnapshot(user *models.User) error {
+	return handler.capturePredictionSnapshotForDay(user, nil)
+}
+
+// capturePredictionSnapshotForDay only captures when the saved day is the
+// start of the current cycle, so saving a later day of the cycle or a
+// backfilled one never snapshots a mid-cycle forecast.
+func (handler *Handler) capturePredictionSnapshotForDay(user *models.User, day *time.Time) error {
+	now := time.Now().In(handler.location)
+	stats, _, err := handler.buildCycleStatsForRange(user, now.AddDate(-2, 0, 0), now, now)
+	if err != nil {
+		return err
+	}
+	if day != nil && !sameCalendarDay(stats.LastPeriodStart.In(handler.location), day.In(handler.location)) {
+		return nil
+	}
+	_, err = handler.predictionHistory.Capture(user, stats, now, handler.location)
+	return err
+}
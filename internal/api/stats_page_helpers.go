@@ -1,14 +1,20 @@
 package api
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
 )
 
 const maxStatsTrendPoints = 12
 
+// maxStatsMetricPoints limits the over-time metric charts to the most recent
+// values.
+const maxStatsMetricPoints = 90
+
 func buildStatsChartData(messages map[string]string, lengths []int, baselineCycleLength int) fiber.Map {
 	chartPayload := fiber.Map{
 		"labels": buildCycleTrendLabels(messages, len(lengths)),
@@ -20,6 +26,159 @@ func buildStatsChartData(messages map[string]string, lengths []int, baselineCycl
 	return chartPayload
 }
 
+// buildStatsTemperatureChartData converts the current cycle's readings to
+// unit, labelled by cycle day, with the cover line as the chart baseline.
+func buildStatsTemperatureChartData(messages map[string]string, chart services.TemperatureChart, unit string) fiber.Map {
+	labelPattern := translateMessage(messages, "stats.temperature_day_label")
+	if labelPattern == "stats.temperature_day_label" {
+		labelPattern = "D%d"
+	}
+
+	labels := make([]string, 0, len(chart.Readings))
+	values := make([]float64, 0, len(chart.Readings))
+	for _, reading := range chart.Readings {
+		labels = append(labels, fmt.Sprintf(labelPattern, chart.CycleDay(reading)))
+		values = append(values, services.TemperatureInUnit(reading.Celsius, unit))
+	}
+
+	chartPayload := fiber.Map{
+		"labels": labels,
+		"values": values,
+	}
+	if chart.ShiftFound {
+		chartPayload["baseline"] = services.TemperatureInUnit(chart.Shift.CoverLine, unit)
+	}
+	return chartPayload
+}
+
+func (handler *Handler) buildStatsTemperatureView(user *models.User, stats services.CycleStats, logs []models.DailyLog, now time.Time, messages map[string]string) fiber.Map {
+	unit := services.NormalizeTemperatureUnit(user.TemperatureUnit)
+	unitLabel := "°C"
+	if unit == models.TemperatureUnitFahrenheit {
+		unitLabel = "°F"
+	}
+
+	chart := services.BuildTemperatureChart(stats, logs, now, handler.location)
+	return fiber.Map{
+		"TemperatureChartData": buildStatsTemperatureChartData(messages, chart, unit),
+		"HasTemperatureData":   len(chart.Readings) > 0,
+		"TemperatureUnitLabel": unitLabel,
+		"TemperatureConfirmed": chart.ShiftFound,
+		"TemperatureOvulation": chart.Shift.OvulationDate,
+		"TemperatureCoverLine": formatTemplateTemperature(chart.Shift.CoverLine, unit),
+	}
+}
+
+// buildStatsMedicationView correlates the owner's medication intakes with
+// the symptoms logged on the same cycle days.
+func (handler *Handler) buildStatsMedicationView(user *models.User, logs []models.DailyLog, from time.Time, now time.Time) (fiber.Map, error) {
+	handler.ensureDependencies()
+	medications, err := handler.medicationService.ListMedications(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	intakes, err := handler.medicationService.FetchIntakesForOptionalRange(user.ID, &from, &now, handler.location)
+	if err != nil {
+		return nil, err
+	}
+	symptoms, err := handler.fetchSymptoms(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	cycleStarts := services.DetectCycleStarts(services.MaskWithdrawalBleeding(user, logs, handler.location))
+	return fiber.Map{
+		"MedicationCorrelations": services.BuildMedicationCorrelations(medications, intakes, logs, symptoms, cycleStarts, handler.location),
+	}, nil
+}
+
+// buildStatsSymptomSeverityView averages the owner's symptom severities per
+// cycle phase.
+func (handler *Handler) buildStatsSymptomSeverityView(user *models.User, stats services.CycleStats, logs []models.DailyLog) (fiber.Map, error) {
+	symptoms, err := handler.fetchSymptoms(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	periodLength := user.PeriodLength
+	if periodLength <= 0 {
+		periodLength = models.DefaultPeriodLength
+	}
+	cycleStarts := services.DetectCycleStarts(services.MaskWithdrawalBleeding(user, logs, handler.location))
+	return fiber.Map{
+		"SymptomSeverityByPhase": services.BuildSymptomSeverityByPhase(logs, symptoms, cycleStarts, services.DashboardCycleReferenceLength(user, stats), periodLength, stats.LutealPhaseLength, handler.location),
+	}, nil
+}
+
+// buildStatsMetricView charts each custom metric over time and averaged by
+// cycle day.
+func (handler *Handler) buildStatsMetricView(user *models.User, logs []models.DailyLog, from time.Time, now time.Time, language string, messages map[string]string) (fiber.Map, error) {
+	handler.ensureDependencies()
+	metrics, err := handler.metricService.ListMetricTypes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	values, err := handler.metricService.FetchValuesForOptionalRange(user.ID, &from, &now, handler.location)
+	if err != nil {
+		return nil, err
+	}
+
+	cycleStarts := services.DetectCycleStarts(services.MaskWithdrawalBleeding(user, logs, handler.location))
+	series := services.BuildMetricSeries(metrics, values, cycleStarts, handler.location)
+	views := make([]fiber.Map, 0, len(series))
+	for _, item := range series {
+		decimals := 0
+		if item.Metric.Kind == models.MetricKindDecimal {
+			decimals = 1
+		}
+		views = append(views, fiber.Map{
+			"Metric":            item.Metric,
+			"Days":              len(item.Points),
+			"Average":           services.FormatMetricValue(item.Average),
+			"Decimals":          decimals,
+			"TimeChartData":     buildStatsMetricTimeChartData(language, item),
+			"CycleDayChartData": buildStatsMetricCycleDayChartData(messages, item),
+		})
+	}
+	return fiber.Map{"MetricSeries": views}, nil
+}
+
+func buildStatsMetricTimeChartData(language string, series services.MetricSeries) fiber.Map {
+	points := series.Points
+	if len(points) > maxStatsMetricPoints {
+		points = points[len(points)-maxStatsMetricPoints:]
+	}
+
+	labels := make([]string, 0, len(points))
+	values := make([]float64, 0, len(points))
+	for _, point := range points {
+		labels = append(labels, localizedDateShort(language, point.Date))
+		values = append(values, point.Value)
+	}
+	return fiber.Map{
+		"labels": labels,
+		"values": values,
+	}
+}
+
+func buildStatsMetricCycleDayChartData(messages map[string]string, series services.MetricSeries) fiber.Map {
+	labelPattern := translateMessage(messages, "stats.temperature_day_label")
+	if labelPattern == "stats.temperature_day_label" {
+		labelPattern = "D%d"
+	}
+
+	labels := make([]string, 0, len(series.CycleDays))
+	values := make([]float64, 0, len(series.CycleDays))
+	for _, day := range series.CycleDays {
+		labels = append(labels, fmt.Sprintf(labelPattern, day.CycleDay))
+		values = append(values, day.Average)
+	}
+	return fiber.Map{
+		"labels": labels,
+		"values": values,
+	}
+}
+
 func (handler *Handler) buildStatsTrendView(user *models.User, logs []models.DailyLog, now time.Time, messages map[string]string) (fiber.Map, int, int) {
 	handler.ensureDependencies()
 	lengths, baselineCycleLength := handler.statsService.BuildTrend(user, logs, now, handler.location, maxStatsTrendPoints)
@@ -37,10 +196,11 @@ func (handler *Handler) buildStatsSymptomCounts(user *models.User, language stri
 	symptomCounts := make([]SymptomCount, 0, len(frequencies))
 	for _, item := range frequencies {
 		symptomCounts = append(symptomCounts, SymptomCount{
-			Name:      item.Name,
-			Icon:      item.Icon,
-			Count:     item.Count,
-			TotalDays: item.TotalDays,
+			Name:            item.Name,
+			Icon:            item.Icon,
+			Count:           item.Count,
+			TotalDays:       item.TotalDays,
+			AverageSeverity: item.AverageSeverity,
 		})
 	}
 	localizeSymptomFrequencySummaries(language, symptomCounts)
@@ -48,14 +208,23 @@ func (handler *Handler) buildStatsSymptomCounts(user *models.User, language stri
 }
 
 func (handler *Handler) buildStatsPageData(user *models.User, language string, messages map[string]string, now time.Time) (fiber.Map, string, error) {
-	stats, logs, err := handler.buildCycleStatsForRange(user, now.AddDate(-2, 0, 0), now, now)
+	dataOwner, err := handler.resolveDataOwner(user)
 	if err != nil {
 		return nil, "failed to load stats", err
 	}
 
-	chartPayload, baselineCycleLength, trendPointCount := handler.buildStatsTrendView(user, logs, now, messages)
+	from := now.AddDate(-2, 0, 0)
+	stats, logs, err := handler.buildCycleStatsForRange(dataOwner, from, now, now)
+	if err != nil {
+		return nil, "failed to load stats", err
+	}
+
+	stats = services.SanitizeCycleStatsForViewer(user, stats)
+	sanitizeLogsForViewer(user, logs)
+
+	chartPayload, baselineCycleLength, trendPointCount := handler.buildStatsTrendView(dataOwner, logs, now, messages)
 	handler.ensureDependencies()
-	flags := handler.statsService.BuildFlags(user, logs, stats, now, handler.location, trendPointCount)
+	flags := handler.statsService.BuildFlags(dataOwner, logs, stats, now, handler.location, trendPointCount)
 	symptomCounts, symptomErrorMessage, err := handler.buildStatsSymptomCounts(user, language)
 	if err != nil {
 		return nil, symptomErrorMessage, err
@@ -75,5 +244,37 @@ func (handler *Handler) buildStatsPageData(user *models.User, language string, m
 		"SymptomCounts":        symptomCounts,
 		"IsOwner":              isOwnerUser(user),
 	}
+	if isOwnerUser(user) {
+		for key, value := range handler.buildStatsTemperatureView(user, stats, logs, now, messages) {
+			data[key] = value
+		}
+		medicationView, err := handler.buildStatsMedicationView(user, logs, from, now)
+		if err != nil {
+			return nil, "failed to load medication stats", err
+		}
+		for key, value := range medicationView {
+			data[key] = value
+		}
+		severityView, err := handler.buildStatsSymptomSeverityView(user, stats, logs)
+		if err != nil {
+			return nil, "failed to load symptom stats", err
+		}
+		for key, value := range severityView {
+			data[key] = value
+		}
+		metricView, err := handler.buildStatsMetricView(user, logs, from, now, language, messages)
+		if err != nil {
+			return nil, "failed to load metric stats", err
+		}
+		for key, value := range metricView {
+			data[key] = value
+		}
+		predictionHistory, err := handler.predictionHistory.BuildHistory(user, handler.location)
+		if err != nil {
+			return nil, "failed to load prediction history", err
+		}
+		data["PredictionHistory"] = predictionHistory
+		data["ExcludedCycles"] = services.ExcludedCycles(logs)
+	}
 	return data, "", nil
 }
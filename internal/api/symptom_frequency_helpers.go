@@ -12,10 +12,11 @@ func (handler *Handler) calculateSymptomFrequencies(userID uint, logs []models.D
 	result := make([]SymptomCount, 0, len(frequencies))
 	for _, item := range frequencies {
 		result = append(result, SymptomCount{
-			Name:      item.Name,
-			Icon:      item.Icon,
-			Count:     item.Count,
-			TotalDays: item.TotalDays,
+			Name:            item.Name,
+			Icon:            item.Icon,
+			Count:           item.Count,
+			TotalDays:       item.TotalDays,
+			AverageSeverity: item.AverageSeverity,
 		})
 	}
 	return result, nil
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func TestSymptomSeverityFlowsIntoDayFormStatsAndExport(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "severity@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+	handler := &Handler{db: database, location: time.UTC}
+
+	smokeGET(t, app, ownerCookie, "/api/symptoms", http.StatusOK)
+	cramps := models.SymptomType{}
+	if err := database.Where("user_id = ? AND name = ?", owner.ID, "Cramps").First(&cramps).Error; err != nil {
+		t.Fatalf("load cramps symptom: %v", err)
+	}
+	headache := models.SymptomType{}
+	if err := database.Where("user_id = ? AND name = ?", owner.ID, "Headache").First(&headache).Error; err != nil {
+		t.Fatalf("load headache symptom: %v", err)
+	}
+
+	today := services.DateAtLocation(time.Now().UTC(), time.UTC)
+	form := url.Values{
+		"is_period":   {"true"},
+		"flow":        {models.FlowMedium},
+		"symptom_ids": {fmt.Sprint(cramps.ID), fmt.Sprint(headache.ID)},
+		fmt.Sprintf("symptom_severity_%d", cramps.ID): {"3"},
+	}
+	response := postSessionFormForTest(t, app, ownerCookie, "/api/days/"+today.Format("2006-01-02"), form)
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+	entry := loadDayLogForTest(t, handler, owner.ID, today.Format("2006-01-02"))
+	if entry.SymptomSeverities[cramps.ID] != models.SymptomSeveritySevere || entry.SymptomSeverities[headache.ID] != models.DefaultSymptomSeverity {
+		t.Fatalf("expected severe cramps and a default headache severity, got %#v", entry.SymptomSeverities)
+	}
+
+	yesterday := today.AddDate(0, 0, -1).Format("2006-01-02")
+	response = postDayJSONForTest(t, app, ownerCookie, yesterday, map[string]any{
+		"is_period":          true,
+		"flow":               models.FlowLight,
+		"symptom_ids":        []uint{cramps.ID},
+		"symptom_severities": map[string]int{fmt.Sprint(cramps.ID): 1},
+	})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+	if body := smokeGET(t, app, ownerCookie, "/api/days?from="+yesterday+"&to="+yesterday, http.StatusOK); !strings.Contains(body, fmt.Sprintf(`"SymptomSeverities":{"%d":1}`, cramps.ID)) {
+		t.Fatalf("expected the severity in the days api, got %s", body)
+	}
+
+	body := smokeGET(t, app, ownerCookie, "/dashboard", http.StatusOK)
+	if !strings.Contains(body, fmt.Sprintf(`name="symptom_severity_%d"`, cramps.ID)) || !strings.Contains(body, `<option value="3" selected>Severe</option>`) {
+		t.Fatal("expected the stored severity selected in today's form")
+	}
+
+	body = smokeGET(t, app, ownerCookie, "/stats", http.StatusOK)
+	if !strings.Contains(body, "symptom-severity-section") || !strings.Contains(body, "avg severity 2/3") {
+		t.Fatal("expected average severity on the stats page")
+	}
+	if !strings.Contains(body, `data-severity-phase="menstrual"`) {
+		t.Fatal("expected severity by phase on the stats page")
+	}
+
+	if body := smokeGET(t, app, ownerCookie, "/api/export/csv", http.StatusOK); !strings.Contains(body, "Cramps: 3; Headache: 2") {
+		t.Fatalf("expected severities in the csv export, got %s", body)
+	}
+	if body := smokeGET(t, app, ownerCookie, "/api/export/json", http.StatusOK); !strings.Contains(body, `"Cramps": 3`) {
+		t.Fatalf("expected severities in the json export, got %s", body)
+	}
+
+	partner := createLinkedPartnerForTest(t, app, database, ownerCookie, "severity-partner@example.com")
+	partnerCookie := loginAndExtractAuthCookie(t, app, partner.Email, "StrongPass1")
+	if body := smokeGET(t, app, partnerCookie, "/stats", http.StatusOK); strings.Contains(body, "symptom-severity-section") {
+		t.Fatal("expected no severity stats for partner")
+	}
+}
+
+func TestSymptomSeverityRejectsOutOfScaleValues(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "severity-invalid@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+
+	smokeGET(t, app, ownerCookie, "/api/symptoms", http.StatusOK)
+	cramps := models.SymptomType{}
+	if err := database.Where("user_id = ? AND name = ?", owner.ID, "Cramps").First(&cramps).Error; err != nil {
+		t.Fatalf("load cramps symptom: %v", err)
+	}
+
+	today := time.Now().UTC().Format("2006-01-02")
+	response := postSessionFormForTest(t, app, ownerCookie, "/api/days/"+today, url.Values{
+		"is_period":   {"true"},
+		"flow":        {models.FlowMedium},
+		"symptom_ids": {fmt.Sprint(cramps.ID)},
+		fmt.Sprintf("symptom_severity_%d", cramps.ID): {"5"},
+	})
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", response.StatusCode)
+	}
+	if message := readAPIError(t, response.Body); message != "invalid symptom severity" {
+		t.Fatalf("expected invalid symptom severity, got %q", message)
+	}
+}
@@ -0,0 +1,215 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func postDayJSONForTest(t *testing.T, app *fiber.App, cookie string, day string, payload map[string]any) *http.Response {
+	t.Helper()
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	request := httptest.NewRequest(http.MethodPost, "/api/days/"+day, bytes.NewReader(body))
+	request.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+	request.Header.Set("Cookie", cookie)
+
+	response, err := app.Test(request, -1)
+	if err != nil {
+		t.Fatalf("upsert request failed: %v", err)
+	}
+	return response
+}
+
+func loadDayLogForTest(t *testing.T, handler *Handler, userID uint, raw string) models.DailyLog {
+	t.Helper()
+
+	day, err := parseDayParam(raw, time.UTC)
+	if err != nil {
+		t.Fatalf("parse day: %v", err)
+	}
+	entry, err := handler.fetchLogByDate(userID, day)
+	if err != nil {
+		t.Fatalf("load stored log: %v", err)
+	}
+	return entry
+}
+
+func TestUpsertDayStoresTemperatureInCelsius(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "bbt-upsert@example.com", "StrongPass1", true)
+	authCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+	handler := &Handler{db: database, location: time.UTC}
+
+	response := postDayJSONForTest(t, app, authCookie, "2026-02-19", map[string]any{
+		"is_period":     false,
+		"flow":          models.FlowNone,
+		"bbt":           98.6,
+		"bbt_unit":      "f",
+		"bbt_time":      "06:40",
+		"bbt_disturbed": true,
+	})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+	entry := loadDayLogForTest(t, handler, user.ID, "2026-02-19")
+	if entry.BBT != 37 || entry.BBTTime != "06:40" || !entry.BBTDisturbed {
+		t.Fatalf("expected 37 °C at 06:40 marked disturbed, got %#v", entry)
+	}
+
+	response = postDayJSONForTest(t, app, authCookie, "2026-02-19", map[string]any{
+		"is_period": false,
+		"flow":      models.FlowNone,
+		"notes":     "no temperature fields",
+	})
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+	entry = loadDayLogForTest(t, handler, user.ID, "2026-02-19")
+	if entry.BBT != 37 || entry.Notes != "no temperature fields" {
+		t.Fatalf("expected save without bbt fields to keep the reading, got %#v", entry)
+	}
+
+	for _, payload := range []map[string]any{
+		{"bbt": 45.1},
+		{"bbt": 36.5, "bbt_time": "7 am"},
+	} {
+		payload["flow"] = models.FlowNone
+		response = postDayJSONForTest(t, app, authCookie, "2026-02-19", payload)
+		if response.StatusCode != http.StatusBadRequest {
+			t.Fatalf("expected status 400 for %#v, got %d", payload, response.StatusCode)
+		}
+		if message := readAPIError(t, response.Body); !strings.HasPrefix(message, "invalid temperature") {
+			t.Fatalf("expected temperature error for %#v, got %q", payload, message)
+		}
+		response.Body.Close()
+	}
+}
+
+func TestUpsertDayFormUsesPreferredTemperatureUnit(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "bbt-form@example.com", "StrongPass1", true)
+	if err := database.Model(&models.User{}).Where("id = ?", user.ID).Update("temperature_unit", models.TemperatureUnitFahrenheit).Error; err != nil {
+		t.Fatalf("set temperature unit: %v", err)
+	}
+	authCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+
+	form := url.Values{"flow": {models.FlowNone}, "bbt": {"97,7"}, "bbt_time": {"07:15"}}
+	request := httptest.NewRequest(http.MethodPost, "/api/days/2026-02-20", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Cookie", authCookie)
+	response, err := app.Test(request, -1)
+	if err != nil {
+		t.Fatalf("upsert request failed: %v", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+
+	entry := loadDayLogForTest(t, &Handler{db: database, location: time.UTC}, user.ID, "2026-02-20")
+	if entry.BBT != 36.5 || entry.BBTTime != "07:15" {
+		t.Fatalf("expected 97.7 °F stored as 36.5 °C, got %#v", entry)
+	}
+}
+
+func TestStatsPageShowsTemperatureChartToOwnerOnly(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	owner := createOnboardingTestUser(t, database, "bbt-stats-owner@example.com", "StrongPass1", true)
+	ownerCookie := loginAndExtractAuthCookie(t, app, owner.Email, "StrongPass1")
+
+	today := time.Now().UTC()
+	cycleStart := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -16)
+	values := []float64{36.3, 36.4, 36.35, 36.3, 36.45, 36.4, 36.6, 36.65, 36.7}
+	for offset := 0; offset < 16; offset++ {
+		entry := models.DailyLog{UserID: owner.ID, Date: cycleStart.AddDate(0, 0, offset), IsPeriod: offset < 2, Flow: models.FlowNone}
+		if offset < 2 {
+			entry.Flow = models.FlowMedium
+		}
+		if index := offset - 5; index >= 0 && index < len(values) {
+			entry.BBT = values[index]
+		}
+		if err := database.Create(&entry).Error; err != nil {
+			t.Fatalf("create log: %v", err)
+		}
+	}
+
+	body := smokeGET(t, app, ownerCookie, "/stats", http.StatusOK)
+	for _, want := range []string{`id="temperature-chart"`, `data-decimals="2"`, "Cover line: 36.45 °C", "Ovulation confirmed by the 3-over-6 rule"} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("expected %q in stats page", want)
+		}
+	}
+
+	partner := createLinkedPartnerForTest(t, app, database, ownerCookie, "bbt-stats-partner@example.com")
+	partnerCookie := loginAndExtractAuthCookie(t, app, partner.Email, "StrongPass1")
+	if partnerBody := smokeGET(t, app, partnerCookie, "/stats", http.StatusOK); strings.Contains(partnerBody, "temperature-chart") {
+		t.Fatal("did not expect the temperature chart on the partner stats page")
+	}
+
+	from := cycleStart.Format("2006-01-02")
+	daysBody := smokeGET(t, app, partnerCookie, "/api/days?from="+from+"&to="+today.Format("2006-01-02"), http.StatusOK)
+	logs := []models.DailyLog{}
+	if err := json.Unmarshal([]byte(daysBody), &logs); err != nil {
+		t.Fatalf("decode partner days: %v", err)
+	}
+	for _, entry := range logs {
+		if entry.BBT != 0 || entry.BBTTime != "" {
+			t.Fatalf("expected temperatures hidden from partner, got %#v", entry)
+		}
+	}
+}
+
+func TestSettingsCycleUpdatePersistsTemperatureUnit(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "bbt-settings@example.com", "StrongPass1", true)
+	authCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+
+	form := url.Values{
+		"cycle_length":     {"28"},
+		"period_length":    {"5"},
+		"temperature_unit": {"f"},
+	}
+	response := postSessionFormForTest(t, app, authCookie, "/settings/cycle", form)
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+
+	persisted := models.User{}
+	if err := database.Select("temperature_unit").First(&persisted, user.ID).Error; err != nil {
+		t.Fatalf("load persisted user: %v", err)
+	}
+	if persisted.TemperatureUnit != models.TemperatureUnitFahrenheit {
+		t.Fatalf("expected temperature_unit=f, got %q", persisted.TemperatureUnit)
+	}
+
+	form.Set("temperature_unit", "kelvin")
+	response = postSessionFormForTest(t, app, authCookie, "/settings/cycle", form)
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for unknown unit, got %d", response.StatusCode)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/csrf"
 	"github.com/terraincognita07/ovumcy/internal/db"
 	"github.com/terraincognita07/ovumcy/internal/i18n"
+	"github.com/terraincognita07/ovumcy/internal/services"
 	"gorm.io/gorm"
 )
 
@@ -28,8 +29,20 @@ func newOnboardingTestAppWithCSRF(t *testing.T) (*fiber.App, *gorm.DB) {
 	return newOnboardingTestAppWithCookieSecureAndCSRF(t, false, true)
 }
 
+func newOnboardingTestAppWithRegistrationMode(t *testing.T, mode services.RegistrationMode) (*fiber.App, *gorm.DB) {
+	t.Helper()
+	return newConfiguredOnboardingTestApp(t, false, false, func(handler *Handler) {
+		handler.SetRegistrationMode(mode)
+	})
+}
+
 func newOnboardingTestAppWithCookieSecureAndCSRF(t *testing.T, cookieSecure bool, enableCSRF bool) (*fiber.App, *gorm.DB) {
 	t.Helper()
+	return newConfiguredOnboardingTestApp(t, cookieSecure, enableCSRF, nil)
+}
+
+func newConfiguredOnboardingTestApp(t *testing.T, cookieSecure bool, enableCSRF bool, configure func(*Handler)) (*fiber.App, *gorm.DB) {
+	t.Helper()
 
 	_, testFile, _, ok := runtime.Caller(0)
 	if !ok {
@@ -63,6 +76,9 @@ func newOnboardingTestAppWithCookieSecureAndCSRF(t *testing.T, cookieSecure bool
 	if err != nil {
 		t.Fatalf("init handler: %v", err)
 	}
+	if configure != nil {
+		configure(handler)
+	}
 
 	app := fiber.New()
 	app.Use(handler.LanguageMiddleware)
@@ -82,5 +98,6 @@ func testCSRFMiddlewareConfig(cookieSecure bool) csrf.Config {
 		CookieHTTPOnly: true,
 		CookieSecure:   cookieSecure,
 		ContextKey:     "csrf",
+		Next:           IsBearerTokenRequest,
 	}
 }
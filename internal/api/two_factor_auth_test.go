@@ -0,0 +1,348 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+	"gorm.io/gorm"
+)
+
+func enableTwoFactorForTest(t *testing.T, database *gorm.DB, userID uint) string {
+	t.Helper()
+
+	secret, err := services.GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("generate totp secret: %v", err)
+	}
+	if err := database.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]any{
+		"totp_secret":    secret,
+		"totp_enabled":   true,
+		"totp_last_step": 0,
+	}).Error; err != nil {
+		t.Fatalf("enable two factor: %v", err)
+	}
+	return secret
+}
+
+func currentTOTPCodeForTest(t *testing.T, secret string) string {
+	t.Helper()
+
+	code, err := services.GenerateTOTPCode(secret, services.TOTPTimeStep(time.Now()))
+	if err != nil {
+		t.Fatalf("generate totp code: %v", err)
+	}
+	return code
+}
+
+func loginForTwoFactorChallenge(t *testing.T, app *fiber.App, email string, password string) string {
+	t.Helper()
+
+	form := url.Values{"email": {email}, "password": {password}}
+	request := httptest.NewRequest(http.MethodPost, "/api/auth/login", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	response, err := app.Test(request, -1)
+	if err != nil {
+		t.Fatalf("login request failed: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusSeeOther {
+		t.Fatalf("expected login status 303, got %d", response.StatusCode)
+	}
+	if location := response.Header.Get("Location"); location != "/login/two-factor" {
+		t.Fatalf("expected redirect to /login/two-factor, got %q", location)
+	}
+	if authCookie := responseCookie(response.Cookies(), authCookieName); authCookie != nil && authCookie.Value != "" {
+		t.Fatal("did not expect auth cookie before the second factor")
+	}
+	challenge := responseCookie(response.Cookies(), twoFactorCookieName)
+	if challenge == nil || strings.TrimSpace(challenge.Value) == "" {
+		t.Fatal("expected two factor challenge cookie")
+	}
+	return challenge.Name + "=" + challenge.Value
+}
+
+func postTwoFactorCodeForTest(t *testing.T, app *fiber.App, challengeCookie string, code string, acceptJSON bool) *http.Response {
+	t.Helper()
+
+	form := url.Values{"code": {code}}
+	request := httptest.NewRequest(http.MethodPost, "/api/auth/login/two-factor", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if acceptJSON {
+		request.Header.Set("Accept", "application/json")
+	}
+	if challengeCookie != "" {
+		request.Header.Set("Cookie", challengeCookie)
+	}
+
+	response, err := app.Test(request, -1)
+	if err != nil {
+		t.Fatalf("two factor request failed: %v", err)
+	}
+	return response
+}
+
+func TestTwoFactorLoginRequiresAuthenticatorCode(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "totp-login@example.com", "StrongPass1", true)
+	secret := enableTwoFactorForTest(t, database, user.ID)
+
+	challengeCookie := loginForTwoFactorChallenge(t, app, user.Email, "StrongPass1")
+	body := smokeGET(t, app, challengeCookie, "/login/two-factor", http.StatusOK)
+	if !strings.Contains(body, `action="/api/auth/login/two-factor"`) {
+		t.Fatal("expected two factor page to render the code form")
+	}
+
+	wrong := postTwoFactorCodeForTest(t, app, challengeCookie, "000000", false)
+	wrong.Body.Close()
+	if wrong.StatusCode != http.StatusSeeOther || wrong.Header.Get("Location") != "/login/two-factor" {
+		t.Fatalf("expected wrong code to redirect back to /login/two-factor, got %d %q", wrong.StatusCode, wrong.Header.Get("Location"))
+	}
+
+	response := postTwoFactorCodeForTest(t, app, challengeCookie, currentTOTPCodeForTest(t, secret), false)
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusSeeOther || response.Header.Get("Location") != "/dashboard" {
+		t.Fatalf("expected successful code to redirect to /dashboard, got %d %q", response.StatusCode, response.Header.Get("Location"))
+	}
+	authCookie := responseCookie(response.Cookies(), authCookieName)
+	if authCookie == nil || authCookie.Value == "" {
+		t.Fatal("expected auth cookie after the second factor")
+	}
+	smokeGET(t, app, authCookie.Name+"="+authCookie.Value, "/dashboard", http.StatusOK)
+
+	replay := postTwoFactorCodeForTest(t, app, challengeCookie, currentTOTPCodeForTest(t, secret), true)
+	defer replay.Body.Close()
+	if replay.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected replayed code to be rejected, got %d", replay.StatusCode)
+	}
+}
+
+func TestTwoFactorLoginAcceptsRecoveryCodeAndRotatesIt(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "totp-recovery@example.com", "StrongPass1", true)
+	enableTwoFactorForTest(t, database, user.ID)
+	recoveryCode := mustSetRecoveryCodeForUser(t, database, user.ID)
+
+	var before models.User
+	if err := database.First(&before, user.ID).Error; err != nil {
+		t.Fatalf("load user: %v", err)
+	}
+
+	challengeCookie := loginForTwoFactorChallenge(t, app, user.Email, "StrongPass1")
+	response := postTwoFactorCodeForTest(t, app, challengeCookie, recoveryCode, true)
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected recovery code login status 200, got %d", response.StatusCode)
+	}
+
+	payload := map[string]any{}
+	if err := json.NewDecoder(response.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	newCode, _ := payload["recovery_code"].(string)
+	if newCode == "" || newCode == recoveryCode {
+		t.Fatalf("expected a fresh recovery code in response, got %q", newCode)
+	}
+
+	var after models.User
+	if err := database.First(&after, user.ID).Error; err != nil {
+		t.Fatalf("load user: %v", err)
+	}
+	if after.RecoveryCodeHash == before.RecoveryCodeHash {
+		t.Fatal("expected recovery code hash to rotate after use")
+	}
+	if responseCookie(response.Cookies(), authCookieName) == nil {
+		t.Fatal("expected auth cookie after recovery code login")
+	}
+}
+
+func TestTwoFactorRecoveryCodeLoginShowsNewCodeBeforeForcedPasswordChange(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "totp-recovery-forced@example.com", "StrongPass1", true)
+	enableTwoFactorForTest(t, database, user.ID)
+	if err := database.Model(&models.User{}).Where("id = ?", user.ID).Update("must_change_password", true).Error; err != nil {
+		t.Fatalf("force password change: %v", err)
+	}
+
+	recoveryCode := mustSetRecoveryCodeForUser(t, database, user.ID)
+	challengeCookie := loginForTwoFactorChallenge(t, app, user.Email, "StrongPass1")
+	response := postTwoFactorCodeForTest(t, app, challengeCookie, recoveryCode, false)
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected the recovery code page, got %d %q", response.StatusCode, response.Header.Get("Location"))
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("read response body: %v", err)
+	}
+	if !strings.Contains(string(body), `id="recovery-code"`) || !strings.Contains(string(body), `action="/reset-password"`) {
+		t.Fatal("expected the new recovery code with a link to the password change")
+	}
+	if authCookie := responseCookie(response.Cookies(), authCookieName); authCookie != nil && authCookie.Value != "" {
+		t.Fatal("did not expect a session before the password change")
+	}
+	if responseCookie(response.Cookies(), resetPasswordCookieName) == nil {
+		t.Fatal("expected the reset password cookie")
+	}
+
+	recoveryCode = mustSetRecoveryCodeForUser(t, database, user.ID)
+	challengeCookie = loginForTwoFactorChallenge(t, app, user.Email, "StrongPass1")
+	jsonResponse := postTwoFactorCodeForTest(t, app, challengeCookie, recoveryCode, true)
+	defer jsonResponse.Body.Close()
+	if jsonResponse.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", jsonResponse.StatusCode)
+	}
+	payload := map[string]any{}
+	if err := json.NewDecoder(jsonResponse.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	newCode, _ := payload["recovery_code"].(string)
+	if payload["error"] != "password change required" || newCode == "" || newCode == recoveryCode {
+		t.Fatalf("expected the new recovery code with the password change error, got %#v", payload)
+	}
+}
+
+func TestTwoFactorLoginIsThrottled(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "totp-throttle@example.com", "StrongPass1", true)
+	secret := enableTwoFactorForTest(t, database, user.ID)
+	challengeCookie := loginForTwoFactorChallenge(t, app, user.Email, "StrongPass1")
+
+	for attempt := 0; attempt < 8; attempt++ {
+		response := postTwoFactorCodeForTest(t, app, challengeCookie, "000000", true)
+		response.Body.Close()
+		if response.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: expected status 401, got %d", attempt+1, response.StatusCode)
+		}
+	}
+
+	response := postTwoFactorCodeForTest(t, app, challengeCookie, currentTOTPCodeForTest(t, secret), true)
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429 after repeated failures, got %d", response.StatusCode)
+	}
+	if errorValue := readAPIError(t, response.Body); errorValue != "too many two factor attempts" {
+		t.Fatalf("expected throttling error, got %q", errorValue)
+	}
+}
+
+func TestTwoFactorLoginJSONAndMissingChallenge(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "totp-json@example.com", "StrongPass1", true)
+	enableTwoFactorForTest(t, database, user.ID)
+
+	form := url.Values{"email": {user.Email}, "password": {"StrongPass1"}}
+	request := httptest.NewRequest(http.MethodPost, "/api/auth/login", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	response, err := app.Test(request, -1)
+	if err != nil {
+		t.Fatalf("login request failed: %v", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", response.StatusCode)
+	}
+	if errorValue := readAPIError(t, response.Body); errorValue != "two factor required" {
+		t.Fatalf("expected two factor required error, got %q", errorValue)
+	}
+
+	missing := postTwoFactorCodeForTest(t, app, "", "123456", true)
+	defer missing.Body.Close()
+	if missing.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected status 401 without challenge, got %d", missing.StatusCode)
+	}
+	if errorValue := readAPIError(t, missing.Body); errorValue != "two factor session expired" {
+		t.Fatalf("expected expired challenge error, got %q", errorValue)
+	}
+
+	smokeGET(t, app, "", "/login/two-factor", http.StatusSeeOther)
+}
+
+func TestTwoFactorSettingsEnrollmentAndDisable(t *testing.T) {
+	t.Parallel()
+
+	app, database := newOnboardingTestApp(t)
+	user := createOnboardingTestUser(t, database, "totp-settings@example.com", "StrongPass1", true)
+	authCookie := loginAndExtractAuthCookie(t, app, user.Email, "StrongPass1")
+
+	setup := postSessionFormForTest(t, app, authCookie, "/api/settings/two-factor/setup", url.Values{})
+	defer setup.Body.Close()
+	if setup.StatusCode != http.StatusOK {
+		t.Fatalf("expected setup status 200, got %d", setup.StatusCode)
+	}
+	enrollment := map[string]any{}
+	if err := json.NewDecoder(setup.Body).Decode(&enrollment); err != nil {
+		t.Fatalf("decode setup response: %v", err)
+	}
+	secret, _ := enrollment["secret"].(string)
+	uri, _ := enrollment["otpauth_uri"].(string)
+	if secret == "" || !strings.HasPrefix(uri, "otpauth://totp/") {
+		t.Fatalf("expected secret and otpauth uri, got %#v", enrollment)
+	}
+
+	settingsBody := smokeGET(t, app, authCookie, "/settings", http.StatusOK)
+	if !strings.Contains(settingsBody, secret) || !strings.Contains(settingsBody, `action="/api/settings/two-factor/enable"`) {
+		t.Fatal("expected settings to show the pending enrollment")
+	}
+
+	wrong := postSessionFormForTest(t, app, authCookie, "/api/settings/two-factor/enable", url.Values{"code": {"000000"}})
+	defer wrong.Body.Close()
+	if wrong.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for wrong code, got %d", wrong.StatusCode)
+	}
+
+	enable := postSessionFormForTest(t, app, authCookie, "/api/settings/two-factor/enable", url.Values{"code": {currentTOTPCodeForTest(t, secret)}})
+	enable.Body.Close()
+	if enable.StatusCode != http.StatusOK {
+		t.Fatalf("expected enable status 200, got %d", enable.StatusCode)
+	}
+
+	var enabled models.User
+	if err := database.First(&enabled, user.ID).Error; err != nil {
+		t.Fatalf("load user: %v", err)
+	}
+	if !enabled.TOTPEnabled || enabled.TOTPSecret != secret {
+		t.Fatal("expected two factor to be enabled with the enrolled secret")
+	}
+
+	badDisable := postSessionFormForTest(t, app, authCookie, "/api/settings/two-factor/disable", url.Values{"password": {"WrongPass1"}})
+	defer badDisable.Body.Close()
+	if badDisable.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected status 401 for wrong password, got %d", badDisable.StatusCode)
+	}
+
+	disable := postSessionFormForTest(t, app, authCookie, "/api/settings/two-factor/disable", url.Values{"password": {"StrongPass1"}})
+	disable.Body.Close()
+	if disable.StatusCode != http.StatusOK {
+		t.Fatalf("expected disable status 200, got %d", disable.StatusCode)
+	}
+
+	var disabled models.User
+	if err := database.First(&disabled, user.ID).Error; err != nil {
+		t.Fatalf("load user: %v", err)
+	}
+	if disabled.TOTPEnabled || disabled.TOTPSecret != "" {
+		t.Fatal("expected two factor to be cleared after disable")
+	}
+}
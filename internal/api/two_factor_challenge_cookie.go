@@ -0,0 +1,98 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+const twoFactorChallengeCookieTTL = 5 * time.Minute
+
+// twoFactorChallengePayload remembers a password check that still needs its
+// second factor. PasswordState ties it to the password hash, so a password
+// change invalidates pending challenges.
+type twoFactorChallengePayload struct {
+	UserID        uint   `json:"uid"`
+	RememberMe    bool   `json:"remember_me,omitempty"`
+	PasswordState string `json:"password_state"`
+	ExpiresAt     int64  `json:"exp"`
+}
+
+func (handler *Handler) setTwoFactorChallengeCookie(c *fiber.Ctx, user *models.User, rememberMe bool) error {
+	expiresAt := time.Now().Add(twoFactorChallengeCookieTTL)
+	payload := twoFactorChallengePayload{
+		UserID:        user.ID,
+		RememberMe:    rememberMe,
+		PasswordState: services.PasswordStateFingerprint(user.PasswordHash),
+		ExpiresAt:     expiresAt.Unix(),
+	}
+	serialized, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	codec, err := newSecureCookieCodec(handler.secretKey)
+	if err != nil {
+		return err
+	}
+	encoded, err := codec.seal(twoFactorCookieName, serialized)
+	if err != nil {
+		return err
+	}
+
+	c.Cookie(&fiber.Cookie{
+		Name:     twoFactorCookieName,
+		Value:    encoded,
+		Path:     "/",
+		HTTPOnly: true,
+		Secure:   handler.cookieSecure,
+		SameSite: "Lax",
+		Expires:  expiresAt,
+	})
+	return nil
+}
+
+func (handler *Handler) readTwoFactorChallengeCookie(c *fiber.Ctx) (twoFactorChallengePayload, bool) {
+	raw := strings.TrimSpace(c.Cookies(twoFactorCookieName))
+	if raw == "" {
+		return twoFactorChallengePayload{}, false
+	}
+
+	codec, err := newSecureCookieCodec(handler.secretKey)
+	if err != nil {
+		handler.clearTwoFactorChallengeCookie(c)
+		return twoFactorChallengePayload{}, false
+	}
+	decoded, err := codec.open(twoFactorCookieName, raw)
+	if err != nil {
+		handler.clearTwoFactorChallengeCookie(c)
+		return twoFactorChallengePayload{}, false
+	}
+
+	payload := twoFactorChallengePayload{}
+	if err := json.Unmarshal(decoded, &payload); err != nil {
+		handler.clearTwoFactorChallengeCookie(c)
+		return twoFactorChallengePayload{}, false
+	}
+	if payload.UserID == 0 || time.Now().Unix() >= payload.ExpiresAt {
+		handler.clearTwoFactorChallengeCookie(c)
+		return twoFactorChallengePayload{}, false
+	}
+	return payload, true
+}
+
+func (handler *Handler) clearTwoFactorChallengeCookie(c *fiber.Ctx) {
+	c.Cookie(&fiber.Cookie{
+		Name:     twoFactorCookieName,
+		Value:    "",
+		Path:     "/",
+		HTTPOnly: true,
+		Secure:   handler.cookieSecure,
+		SameSite: "Lax",
+		Expires:  time.Now().Add(-1 * time.Hour),
+	})
+}
@@ -23,11 +23,33 @@ func (handler *Handler) fetchSymptomsForViewer(user *models.User) ([]models.Symp
 	if !services.ShouldExposeSymptomsForViewer(user) {
 		return []models.SymptomType{}, nil
 	}
-	return handler.fetchSymptoms(user.ID)
+	if isOwnerUser(user) {
+		return handler.fetchSymptoms(user.ID)
+	}
+
+	dataOwner, err := handler.resolveDataOwner(user)
+	if err != nil {
+		return nil, err
+	}
+	symptoms, err := handler.fetchSymptoms(dataOwner.ID)
+	if err != nil {
+		return nil, err
+	}
+	return services.FilterSymptomsForViewer(user, symptoms), nil
+}
+
+func (handler *Handler) resolveDataOwner(user *models.User) (*models.User, error) {
+	handler.ensureDependencies()
+	return handler.partnerService.ResolveDataOwner(user)
 }
 
 func (handler *Handler) fetchDayLogForViewer(user *models.User, day time.Time) (models.DailyLog, []models.SymptomType, error) {
-	logEntry, err := handler.fetchLogByDate(user.ID, day)
+	dataOwner, err := handler.resolveDataOwner(user)
+	if err != nil {
+		return models.DailyLog{}, nil, err
+	}
+
+	logEntry, err := handler.fetchLogByDate(dataOwner.ID, day)
 	if err != nil {
 		return models.DailyLog{}, nil, err
 	}
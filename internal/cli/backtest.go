@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/db"
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+	"gorm.io/gorm"
+)
+
+// RunBacktestCommand replays an owner's cycle history through every
+// prediction algorithm and prints each one's mean absolute error.
+func RunBacktestCommand(dbPath string, email string, location *time.Location) error {
+	return runBacktestCommand(dbPath, email, location, os.Stdout)
+}
+
+func runBacktestCommand(dbPath string, email string, location *time.Location, output io.Writer) error {
+	normalizedEmail := strings.ToLower(strings.TrimSpace(email))
+	if _, err := mail.ParseAddress(normalizedEmail); err != nil {
+		return fmt.Errorf("invalid email address: %w", err)
+	}
+
+	database, err := db.OpenSQLite(dbPath)
+	if err != nil {
+		return fmt.Errorf("database init failed: %w", err)
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		return fmt.Errorf("database init failed: %w", err)
+	}
+	defer func() {
+		_ = sqlDB.Close()
+	}()
+
+	var user models.User
+	if err := database.Where("email = ?", normalizedEmail).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("user %s not found", normalizedEmail)
+		}
+		return fmt.Errorf("load user: %w", err)
+	}
+	if user.Role != models.RoleOwner {
+		return fmt.Errorf("user %s is not an owner account", normalizedEmail)
+	}
+
+	repositories := db.NewRepositories(database)
+	dayService := services.NewDayService(repositories.DailyLogs, repositories.Users)
+	symptomService := services.NewSymptomService(repositories.Symptoms, repositories.DailyLogs)
+	statsService := services.NewStatsService(dayService, symptomService)
+
+	accuracy, err := statsService.BuildPredictionAccuracy(&user, time.Now().In(location), location)
+	if err != nil {
+		return fmt.Errorf("backtest: %w", err)
+	}
+
+	if output == nil {
+		output = os.Stdout
+	}
+	current := services.PredictorForUser(&user).Name()
+	best := services.MostAccuratePredictionAlgorithm(accuracy)
+	for _, result := range accuracy {
+		markers := make([]string, 0, 2)
+		if result.Algorithm == current {
+			markers = append(markers, "selected")
+		}
+		if result.Algorithm == best {
+			markers = append(markers, "most accurate")
+		}
+		suffix := ""
+		if len(markers) > 0 {
+			suffix = " (" + strings.Join(markers, ", ") + ")"
+		}
+		if result.Samples == 0 {
+			fmt.Fprintf(output, "%-17s not enough completed cycles%s\n", result.Algorithm, suffix)
+			continue
+		}
+		fmt.Fprintf(output, "%-17s MAE %.2f days over %d cycles%s\n", result.Algorithm, result.MeanAbsoluteError, result.Samples, suffix)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/db"
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func TestRunBacktestCommandReportsEachAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	databasePath := createCLIResetDatabase(t)
+	createCLIResetUser(t, databasePath, "backtest-owner@example.com", "StrongPass1")
+
+	database, err := db.OpenSQLite(databasePath)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		t.Fatalf("open sql db: %v", err)
+	}
+	var owner models.User
+	if err := database.Where("email = ?", "backtest-owner@example.com").First(&owner).Error; err != nil {
+		t.Fatalf("load owner: %v", err)
+	}
+	start := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	for _, length := range []int{28, 30, 29, 31, 30} {
+		entry := models.DailyLog{UserID: owner.ID, Date: start, IsPeriod: true, Flow: models.FlowMedium}
+		if err := database.Create(&entry).Error; err != nil {
+			t.Fatalf("create period log: %v", err)
+		}
+		start = start.AddDate(0, 0, length)
+	}
+	entry := models.DailyLog{UserID: owner.ID, Date: start, IsPeriod: true, Flow: models.FlowMedium}
+	if err := database.Create(&entry).Error; err != nil {
+		t.Fatalf("create period log: %v", err)
+	}
+	_ = sqlDB.Close()
+
+	var output bytes.Buffer
+	if err := runBacktestCommand(databasePath, "backtest-owner@example.com", time.UTC, &output); err != nil {
+		t.Fatalf("backtest returned error: %v", err)
+	}
+	for _, want := range []string{"median", "weighted_average", "bayesian", "over 3 cycles", "(selected"} {
+		if !strings.Contains(output.String(), want) {
+			t.Fatalf("expected %q in backtest output, got %q", want, output.String())
+		}
+	}
+
+	if err := runBacktestCommand(databasePath, "missing@example.com", time.UTC, &bytes.Buffer{}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
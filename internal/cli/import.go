@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/db"
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+	"gorm.io/gorm"
+)
+
+const importUsage = "usage: ovumcy import <email> <export-file> [--format=json|clue|flo|drip|csv] [--mode=merge|skip_existing|overwrite] [--dry-run]"
+
+type ImportOptions struct {
+	Email    string
+	FilePath string
+	Format   string
+	Mode     services.ImportMode
+	DryRun   bool
+}
+
+// ParseImportArgs parses the arguments that follow "ovumcy import".
+func ParseImportArgs(args []string) (ImportOptions, error) {
+	options := ImportOptions{Mode: services.ImportModeMerge}
+	positional := make([]string, 0, 2)
+	for _, arg := range args {
+		switch {
+		case arg == "--dry-run":
+			options.DryRun = true
+		case strings.HasPrefix(arg, "--format="):
+			format := strings.TrimPrefix(arg, "--format=")
+			if _, err := services.LookupImportFormat(format); err != nil {
+				return ImportOptions{}, fmt.Errorf("invalid format %q: %s", format, importUsage)
+			}
+			options.Format = format
+		case strings.HasPrefix(arg, "--mode="):
+			mode, err := services.ParseImportMode(strings.TrimPrefix(arg, "--mode="))
+			if err != nil {
+				return ImportOptions{}, fmt.Errorf("invalid mode %q: %s", strings.TrimPrefix(arg, "--mode="), importUsage)
+			}
+			options.Mode = mode
+		case strings.HasPrefix(arg, "--"):
+			return ImportOptions{}, fmt.Errorf("unknown flag %q: %s", arg, importUsage)
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) != 2 {
+		return ImportOptions{}, errors.New(importUsage)
+	}
+	options.Email = positional[0]
+	options.FilePath = positional[1]
+	return options, nil
+}
+
+func RunImportCommand(dbPath string, options ImportOptions, location *time.Location) error {
+	return runImportCommand(dbPath, options, location, os.Stdout)
+}
+
+func runImportCommand(dbPath string, options ImportOptions, location *time.Location, output io.Writer) error {
+	normalizedEmail := strings.ToLower(strings.TrimSpace(options.Email))
+	if _, err := mail.ParseAddress(normalizedEmail); err != nil {
+		return fmt.Errorf("invalid email address: %w", err)
+	}
+
+	raw, err := os.ReadFile(options.FilePath)
+	if err != nil {
+		return fmt.Errorf("read import file: %w", err)
+	}
+	format, err := services.LookupImportFormat(options.Format)
+	if err != nil {
+		return err
+	}
+	payload, err := format.Parse(raw)
+	if err != nil {
+		return err
+	}
+
+	database, err := db.OpenSQLite(dbPath)
+	if err != nil {
+		return fmt.Errorf("database init failed: %w", err)
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		return fmt.Errorf("database init failed: %w", err)
+	}
+	defer func() {
+		_ = sqlDB.Close()
+	}()
+
+	var user models.User
+	if err := database.Where("email = ?", normalizedEmail).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("user %s not found", normalizedEmail)
+		}
+		return fmt.Errorf("load user: %w", err)
+	}
+	if user.Role != models.RoleOwner {
+		return fmt.Errorf("user %s is not an owner account", normalizedEmail)
+	}
+
+	repositories := db.NewRepositories(database)
+	service := services.NewImportService(func(fn func(stores services.ImportStores) error) error {
+		return repositories.Transaction(func(tx *db.Repositories) error {
+			return fn(services.NewImportStores(tx.DailyLogs, tx.Users, tx.Symptoms, tx.Medications, tx.Metrics))
+		})
+	})
+
+	mode := options.Mode
+	if mode == "" {
+		mode = services.ImportModeMerge
+	}
+	report, err := service.Import(user.ID, payload, mode, options.DryRun, location)
+	if err != nil {
+		return fmt.Errorf("import: %w", err)
+	}
+
+	if output == nil {
+		output = os.Stdout
+	}
+	if report.DryRun {
+		fmt.Fprintf(output, "Dry run (%s): nothing was written.\n", report.Mode)
+	} else {
+		fmt.Fprintf(output, "✅ Import finished (%s)\n", report.Mode)
+	}
+	fmt.Fprintf(output, "Entries: %d total, %d created, %d updated, %d skipped, %d unchanged, %d conflicts\n",
+		report.TotalEntries, report.Created, report.Updated, report.Skipped, report.Unchanged, report.Conflicts)
+	if len(report.SymptomsCreated) > 0 {
+		fmt.Fprintf(output, "New symptoms: %s\n", strings.Join(report.SymptomsCreated, ", "))
+	}
+	if len(report.MedicationsCreated) > 0 {
+		fmt.Fprintf(output, "New medications: %s\n", strings.Join(report.MedicationsCreated, ", "))
+	}
+	if len(report.MetricsCreated) > 0 {
+		fmt.Fprintf(output, "New metrics: %s\n", strings.Join(report.MetricsCreated, ", "))
+	}
+	if report.DryRun {
+		for _, change := range report.Changes {
+			if change.Action == services.ImportActionUnchanged {
+				continue
+			}
+			if change.Conflict {
+				fmt.Fprintf(output, "  %s %s (conflict)\n", change.Date, change.Action)
+				continue
+			}
+			fmt.Fprintf(output, "  %s %s\n", change.Date, change.Action)
+		}
+	}
+	return nil
+}
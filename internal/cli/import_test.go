@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/db"
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+const cliImportDocument = `{
+  "exported_at": "2026-03-01T10:00:00Z",
+  "custom_symptoms": [{"name": "Dizziness", "icon": "🌀", "color": "#123456"}],
+  "entries": [
+    {"date": "2026-02-10", "period": true, "flow": "heavy", "symptoms": {"cramps": true}, "other_symptoms": ["Dizziness"], "notes": "day one"},
+    {"date": "2026-02-11", "period": true, "flow": "medium", "symptoms": {}, "other_symptoms": [], "notes": ""}
+  ]
+}`
+
+func TestParseImportArgs(t *testing.T) {
+	t.Parallel()
+
+	options, err := ParseImportArgs([]string{"owner@example.com", "backup.json", "--mode=overwrite", "--dry-run"})
+	if err != nil {
+		t.Fatalf("ParseImportArgs returned error: %v", err)
+	}
+	if options.Email != "owner@example.com" || options.FilePath != "backup.json" || options.Mode != services.ImportModeOverwrite || !options.DryRun {
+		t.Fatalf("unexpected options: %#v", options)
+	}
+
+	options, err = ParseImportArgs([]string{"--format=drip", "owner@example.com", "drip.csv"})
+	if err != nil {
+		t.Fatalf("ParseImportArgs with format returned error: %v", err)
+	}
+	if options.Format != "drip" || options.Mode != services.ImportModeMerge {
+		t.Fatalf("unexpected options: %#v", options)
+	}
+
+	for _, args := range [][]string{
+		{"owner@example.com"},
+		{"owner@example.com", "backup.json", "--mode=replace"},
+		{"owner@example.com", "backup.json", "--force"},
+		{"owner@example.com", "backup.json", "--format=xml"},
+	} {
+		if _, err := ParseImportArgs(args); err == nil {
+			t.Fatalf("expected error for %v", args)
+		}
+	}
+}
+
+func TestRunImportCommandDryRunThenImport(t *testing.T) {
+	t.Parallel()
+
+	databasePath := createCLIResetDatabase(t)
+	createCLIResetUser(t, databasePath, "import-owner@example.com", "StrongPass1")
+
+	documentPath := filepath.Join(t.TempDir(), "export.json")
+	if err := os.WriteFile(documentPath, []byte(cliImportDocument), 0o600); err != nil {
+		t.Fatalf("write import document: %v", err)
+	}
+	options := ImportOptions{Email: "import-owner@example.com", FilePath: documentPath, Mode: services.ImportModeMerge, DryRun: true}
+
+	var dryRunOutput bytes.Buffer
+	if err := runImportCommand(databasePath, options, time.UTC, &dryRunOutput); err != nil {
+		t.Fatalf("dry run returned error: %v", err)
+	}
+	if !strings.Contains(dryRunOutput.String(), "Dry run") || !strings.Contains(dryRunOutput.String(), "2 created") {
+		t.Fatalf("unexpected dry run output: %q", dryRunOutput.String())
+	}
+	if count := countCLIImportLogs(t, databasePath); count != 0 {
+		t.Fatalf("expected dry run to write nothing, found %d logs", count)
+	}
+
+	options.DryRun = false
+	var output bytes.Buffer
+	if err := runImportCommand(databasePath, options, time.UTC, &output); err != nil {
+		t.Fatalf("import returned error: %v", err)
+	}
+	if !strings.Contains(output.String(), "New symptoms: Dizziness") {
+		t.Fatalf("expected new symptom in output, got %q", output.String())
+	}
+	if count := countCLIImportLogs(t, databasePath); count != 2 {
+		t.Fatalf("expected 2 imported logs, found %d", count)
+	}
+
+	var rerun bytes.Buffer
+	if err := runImportCommand(databasePath, options, time.UTC, &rerun); err != nil {
+		t.Fatalf("second import returned error: %v", err)
+	}
+	if !strings.Contains(rerun.String(), "0 created, 0 updated, 0 skipped, 2 unchanged") {
+		t.Fatalf("expected re-import to be a no-op, got %q", rerun.String())
+	}
+}
+
+func TestRunImportCommandReadsForeignFormat(t *testing.T) {
+	t.Parallel()
+
+	databasePath := createCLIResetDatabase(t)
+	createCLIResetUser(t, databasePath, "import-csv@example.com", "StrongPass1")
+
+	documentPath := filepath.Join(t.TempDir(), "tracker.csv")
+	document := "date,flow,symptoms\n2026-02-10,heavy,cramps\n2026-02-11,light,\n2026-02-12,,headache\n"
+	if err := os.WriteFile(documentPath, []byte(document), 0o600); err != nil {
+		t.Fatalf("write import document: %v", err)
+	}
+
+	var output bytes.Buffer
+	options := ImportOptions{Email: "import-csv@example.com", FilePath: documentPath, Format: "csv", Mode: services.ImportModeMerge}
+	if err := runImportCommand(databasePath, options, time.UTC, &output); err != nil {
+		t.Fatalf("import returned error: %v", err)
+	}
+	if !strings.Contains(output.String(), "3 total, 3 created") {
+		t.Fatalf("unexpected import output: %q", output.String())
+	}
+	if count := countCLIImportLogs(t, databasePath); count != 3 {
+		t.Fatalf("expected 3 imported logs, found %d", count)
+	}
+
+	options.Format = "flo"
+	if err := runImportCommand(databasePath, options, time.UTC, &bytes.Buffer{}); err == nil {
+		t.Fatal("expected csv file to be rejected as flo export")
+	}
+}
+
+func TestRunImportCommandRollsBackFailedImport(t *testing.T) {
+	t.Parallel()
+
+	databasePath := createCLIResetDatabase(t)
+	createCLIResetUser(t, databasePath, "import-rollback@example.com", "StrongPass1")
+
+	database, err := db.OpenSQLite(databasePath)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	trigger := `CREATE TRIGGER fail_second_import_day BEFORE INSERT ON daily_logs
+		WHEN NEW.date LIKE '2026-02-11%' BEGIN SELECT RAISE(ABORT, 'disk full'); END`
+	if err := database.Exec(trigger).Error; err != nil {
+		t.Fatalf("create trigger: %v", err)
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		t.Fatalf("open sql db: %v", err)
+	}
+	_ = sqlDB.Close()
+
+	documentPath := filepath.Join(t.TempDir(), "export.json")
+	if err := os.WriteFile(documentPath, []byte(cliImportDocument), 0o600); err != nil {
+		t.Fatalf("write import document: %v", err)
+	}
+	options := ImportOptions{Email: "import-rollback@example.com", FilePath: documentPath, Mode: services.ImportModeMerge}
+	if err := runImportCommand(databasePath, options, time.UTC, &bytes.Buffer{}); err == nil {
+		t.Fatal("expected the failing write to abort the import")
+	}
+
+	if count := countCLIImportLogs(t, databasePath); count != 0 {
+		t.Fatalf("expected the first day to be rolled back, found %d logs", count)
+	}
+	database, err = db.OpenSQLite(databasePath)
+	if err != nil {
+		t.Fatalf("reopen sqlite: %v", err)
+	}
+	sqlDB, err = database.DB()
+	if err != nil {
+		t.Fatalf("reopen sql db: %v", err)
+	}
+	defer sqlDB.Close()
+	var symptoms int64
+	if err := database.Model(&models.SymptomType{}).Where("name = ?", "Dizziness").Count(&symptoms).Error; err != nil || symptoms != 0 {
+		t.Fatalf("expected the custom symptom to be rolled back, got %d (%v)", symptoms, err)
+	}
+}
+
+func TestRunImportCommandRejectsUnknownUser(t *testing.T) {
+	t.Parallel()
+
+	databasePath := createCLIResetDatabase(t)
+	documentPath := filepath.Join(t.TempDir(), "export.json")
+	if err := os.WriteFile(documentPath, []byte(cliImportDocument), 0o600); err != nil {
+		t.Fatalf("write import document: %v", err)
+	}
+
+	err := runImportCommand(databasePath, ImportOptions{Email: "missing@example.com", FilePath: documentPath}, time.UTC, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected user not found error, got %v", err)
+	}
+}
+
+func countCLIImportLogs(t *testing.T, databasePath string) int64 {
+	t.Helper()
+
+	database, err := db.OpenSQLite(databasePath)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		t.Fatalf("open sql db: %v", err)
+	}
+	defer sqlDB.Close()
+
+	var count int64
+	if err := database.Model(&models.DailyLog{}).Count(&count).Error; err != nil {
+		t.Fatalf("count logs: %v", err)
+	}
+	return count
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/db"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func RunCreateRegistrationInviteCommand(dbPath string, ttl time.Duration) error {
+	return runCreateRegistrationInviteCommand(dbPath, ttl, time.Now(), os.Stdout)
+}
+
+func runCreateRegistrationInviteCommand(dbPath string, ttl time.Duration, now time.Time, output io.Writer) error {
+	database, err := db.OpenSQLite(dbPath)
+	if err != nil {
+		return fmt.Errorf("database init failed: %w", err)
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		return fmt.Errorf("database init failed: %w", err)
+	}
+	defer func() {
+		_ = sqlDB.Close()
+	}()
+
+	repositories := db.NewRepositories(database)
+	service := services.NewRegistrationService(services.RegistrationModeInvite, repositories.Users, repositories.RegistrationInvites)
+	rawToken, invite, err := service.CreateInvite(now, ttl)
+	if err != nil {
+		return fmt.Errorf("create registration invite: %w", err)
+	}
+
+	if output == nil {
+		output = os.Stdout
+	}
+	fmt.Fprintln(output, "✅ Registration invite created")
+	fmt.Fprintf(output, "Sign-up link: /register?registration_token=%s\n", url.QueryEscape(rawToken))
+	fmt.Fprintf(output, "Expires: %s\n", invite.ExpiresAt.UTC().Format(time.RFC3339))
+	fmt.Fprintln(output, "The link works for one sign up.")
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"bytes"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/db"
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+)
+
+func TestRunCreateRegistrationInviteCommandStoresOnlyTokenHash(t *testing.T) {
+	t.Parallel()
+
+	databasePath := createCLIResetDatabase(t)
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	var output bytes.Buffer
+
+	if err := runCreateRegistrationInviteCommand(databasePath, 48*time.Hour, now, &output); err != nil {
+		t.Fatalf("runCreateRegistrationInviteCommand returned error: %v", err)
+	}
+
+	match := regexp.MustCompile(`/register\?registration_token=(\S+)`).FindStringSubmatch(output.String())
+	if len(match) != 2 {
+		t.Fatalf("expected sign-up link in command output, got %q", output.String())
+	}
+	rawToken, err := url.QueryUnescape(match[1])
+	if err != nil {
+		t.Fatalf("unescape token: %v", err)
+	}
+
+	database, err := db.OpenSQLite(databasePath)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		t.Fatalf("open sql db: %v", err)
+	}
+	defer sqlDB.Close()
+
+	invite := models.RegistrationInvite{}
+	if err := database.First(&invite).Error; err != nil {
+		t.Fatalf("load registration invite: %v", err)
+	}
+	if invite.TokenHash != services.HashRegistrationInviteToken(rawToken) {
+		t.Fatal("expected stored hash to match printed token")
+	}
+	if strings.Contains(invite.TokenHash, rawToken) {
+		t.Fatal("did not expect raw token to be stored")
+	}
+	if !invite.ExpiresAt.Equal(now.Add(48 * time.Hour)) {
+		t.Fatalf("expected expiry %s, got %s", now.Add(48*time.Hour), invite.ExpiresAt)
+	}
+}
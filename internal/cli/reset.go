@@ -73,12 +73,16 @@ func runResetPasswordCommand(dbPath string, email string, prompt passwordPromptF
 	if err := database.Save(&user).Error; err != nil {
 		return fmt.Errorf("update user password: %w", err)
 	}
+	if _, err := db.NewSessionRepository(database).DeleteAllForUserExcept(user.ID, 0); err != nil {
+		return fmt.Errorf("revoke sessions: %w", err)
+	}
 
 	if output == nil {
 		output = os.Stdout
 	}
 	fmt.Fprintln(output, "✅ Password reset successful")
 	fmt.Fprintln(output, "User must change password on next login.")
+	fmt.Fprintln(output, "All existing sessions have been signed out.")
 
 	return nil
 }
@@ -70,6 +70,56 @@ func TestRunResetPasswordCommandReturnsPromptError(t *testing.T) {
 	}
 }
 
+func TestRunResetPasswordCommandRevokesExistingSessions(t *testing.T) {
+	t.Parallel()
+
+	databasePath := createCLIResetDatabase(t)
+	createCLIResetUser(t, databasePath, "cli-reset-sessions@example.com", "StrongPass1")
+	user := loadCLIResetUser(t, databasePath, "cli-reset-sessions@example.com")
+
+	database, err := db.OpenSQLite(databasePath)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		t.Fatalf("open sql db: %v", err)
+	}
+	defer sqlDB.Close()
+
+	now := time.Now()
+	session := models.AuthSession{
+		UserID:     user.ID,
+		TokenHash:  "cli-reset-session-hash",
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(time.Hour),
+	}
+	if err := database.Create(&session).Error; err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+
+	err = runResetPasswordCommand(
+		databasePath,
+		"cli-reset-sessions@example.com",
+		func() ([]byte, error) {
+			return []byte("EvenStronger2"), nil
+		},
+		io.Discard,
+	)
+	if err != nil {
+		t.Fatalf("runResetPasswordCommand returned error: %v", err)
+	}
+
+	var remaining int64
+	if err := database.Model(&models.AuthSession{}).Where("user_id = ?", user.ID).Count(&remaining).Error; err != nil {
+		t.Fatalf("count sessions: %v", err)
+	}
+	if remaining != 0 {
+		t.Fatalf("expected cli reset to revoke all sessions, got %d left", remaining)
+	}
+}
+
 func createCLIResetDatabase(t *testing.T) string {
 	t.Helper()
 
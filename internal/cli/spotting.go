@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/db"
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/services"
+	"gorm.io/gorm"
+)
+
+// RunConvertSpottingCommand moves an owner's days logged with the Spotting
+// symptom to the spotting flow level and prints the converted days.
+func RunConvertSpottingCommand(dbPath string, email string, dryRun bool, location *time.Location) error {
+	return runConvertSpottingCommand(dbPath, email, dryRun, location, os.Stdout)
+}
+
+func runConvertSpottingCommand(dbPath string, email string, dryRun bool, location *time.Location, output io.Writer) error {
+	normalizedEmail := strings.ToLower(strings.TrimSpace(email))
+	if _, err := mail.ParseAddress(normalizedEmail); err != nil {
+		return fmt.Errorf("invalid email address: %w", err)
+	}
+
+	database, err := db.OpenSQLite(dbPath)
+	if err != nil {
+		return fmt.Errorf("database init failed: %w", err)
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		return fmt.Errorf("database init failed: %w", err)
+	}
+	defer func() {
+		_ = sqlDB.Close()
+	}()
+
+	var user models.User
+	if err := database.Where("email = ?", normalizedEmail).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("user %s not found", normalizedEmail)
+		}
+		return fmt.Errorf("load user: %w", err)
+	}
+	if user.Role != models.RoleOwner {
+		return fmt.Errorf("user %s is not an owner account", normalizedEmail)
+	}
+
+	repositories := db.NewRepositories(database)
+	symptoms, err := repositories.Symptoms.ListByUser(user.ID)
+	if err != nil {
+		return fmt.Errorf("load symptoms: %w", err)
+	}
+	if output == nil {
+		output = os.Stdout
+	}
+	spottingID, ok := services.SpottingSymptomID(symptoms)
+	if !ok {
+		fmt.Fprintln(output, "no spotting symptom to convert")
+		return nil
+	}
+
+	dayService := services.NewDayService(repositories.DailyLogs, repositories.Users)
+	converted, err := dayService.ConvertSpottingSymptomDays(user.ID, spottingID, dryRun, location)
+	if err != nil {
+		return fmt.Errorf("convert spotting: %w", err)
+	}
+
+	for _, day := range converted {
+		fmt.Fprintln(output, day.Format("2006-01-02"))
+	}
+	verb := "converted"
+	if dryRun {
+		verb = "would convert"
+	}
+	fmt.Fprintf(output, "%s %d spotting days\n", verb, len(converted))
+	return nil
+}
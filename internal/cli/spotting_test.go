@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/db"
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func TestRunConvertSpottingCommandMovesSymptomDaysToSpottingFlow(t *testing.T) {
+	t.Parallel()
+
+	databasePath := createCLIResetDatabase(t)
+	createCLIResetUser(t, databasePath, "spotting-owner@example.com", "StrongPass1")
+
+	database, err := db.OpenSQLite(databasePath)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		t.Fatalf("open sql db: %v", err)
+	}
+	var owner models.User
+	if err := database.Where("email = ?", "spotting-owner@example.com").First(&owner).Error; err != nil {
+		t.Fatalf("load owner: %v", err)
+	}
+	spotting := models.SymptomType{UserID: owner.ID, Name: "Spotting", Icon: "🩹", Color: "#C55A7A", IsBuiltin: true}
+	if err := database.Create(&spotting).Error; err != nil {
+		t.Fatalf("create symptom: %v", err)
+	}
+	for _, entry := range []models.DailyLog{
+		{UserID: owner.ID, Date: time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC), IsPeriod: true, Flow: models.FlowMedium, SymptomIDs: []uint{spotting.ID}},
+		{UserID: owner.ID, Date: time.Date(2026, time.March, 20, 0, 0, 0, 0, time.UTC), IsPeriod: true, Flow: models.FlowLight, SymptomIDs: []uint{spotting.ID}},
+	} {
+		if err := database.Create(&entry).Error; err != nil {
+			t.Fatalf("create log: %v", err)
+		}
+	}
+	_ = sqlDB.Close()
+
+	var output bytes.Buffer
+	if err := runConvertSpottingCommand(databasePath, "spotting-owner@example.com", true, time.UTC, &output); err != nil {
+		t.Fatalf("dry run returned error: %v", err)
+	}
+	if !strings.Contains(output.String(), "2026-03-20") || !strings.Contains(output.String(), "would convert 1 spotting days") {
+		t.Fatalf("unexpected dry run output %q", output.String())
+	}
+
+	output.Reset()
+	if err := runConvertSpottingCommand(databasePath, "spotting-owner@example.com", false, time.UTC, &output); err != nil {
+		t.Fatalf("convert returned error: %v", err)
+	}
+	if !strings.Contains(output.String(), "converted 1 spotting days") {
+		t.Fatalf("unexpected output %q", output.String())
+	}
+
+	database, err = db.OpenSQLite(databasePath)
+	if err != nil {
+		t.Fatalf("reopen sqlite: %v", err)
+	}
+	sqlDB, err = database.DB()
+	if err != nil {
+		t.Fatalf("reopen sql db: %v", err)
+	}
+	defer func() {
+		_ = sqlDB.Close()
+	}()
+	converted := models.DailyLog{}
+	if err := database.Where("user_id = ? AND flow = ?", owner.ID, models.FlowSpotting).First(&converted).Error; err != nil {
+		t.Fatalf("expected a spotting day: %v", err)
+	}
+	if !converted.IsPeriod || len(converted.SymptomIDs) != 0 {
+		t.Fatalf("expected the symptom to move to the flow, got %#v", converted)
+	}
+}
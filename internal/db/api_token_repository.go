@@ -0,0 +1,55 @@
+package db
+
+import (
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"gorm.io/gorm"
+)
+
+type APITokenRepository struct {
+	database *gorm.DB
+}
+
+func NewAPITokenRepository(database *gorm.DB) *APITokenRepository {
+	return &APITokenRepository{database: database}
+}
+
+func (repo *APITokenRepository) Create(token *models.APIToken) error {
+	return repo.database.Create(token).Error
+}
+
+func (repo *APITokenRepository) FindByTokenHash(tokenHash string) (models.APIToken, error) {
+	token := models.APIToken{}
+	if err := repo.database.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return models.APIToken{}, err
+	}
+	return token, nil
+}
+
+func (repo *APITokenRepository) ListByUser(userID uint) ([]models.APIToken, error) {
+	tokens := make([]models.APIToken, 0)
+	if err := repo.database.
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (repo *APITokenRepository) TouchLastUsed(tokenID uint, usedAt time.Time) error {
+	return repo.database.Model(&models.APIToken{}).
+		Where("id = ?", tokenID).
+		Update("last_used_at", usedAt).Error
+}
+
+func (repo *APITokenRepository) DeleteForUser(tokenID uint, userID uint) (bool, error) {
+	result := repo.database.
+		Where("id = ? AND user_id = ?", tokenID, userID).
+		Delete(&models.APIToken{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
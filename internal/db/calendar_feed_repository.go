@@ -0,0 +1,42 @@
+package db
+
+import (
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"gorm.io/gorm"
+)
+
+type CalendarFeedRepository struct {
+	database *gorm.DB
+}
+
+func NewCalendarFeedRepository(database *gorm.DB) *CalendarFeedRepository {
+	return &CalendarFeedRepository{database: database}
+}
+
+func (repo *CalendarFeedRepository) FindByUser(userID uint) (models.CalendarFeed, error) {
+	feed := models.CalendarFeed{}
+	if err := repo.database.Where("user_id = ?", userID).First(&feed).Error; err != nil {
+		return models.CalendarFeed{}, err
+	}
+	return feed, nil
+}
+
+func (repo *CalendarFeedRepository) FindByTokenHash(tokenHash string) (models.CalendarFeed, error) {
+	feed := models.CalendarFeed{}
+	if err := repo.database.Where("token_hash = ?", tokenHash).First(&feed).Error; err != nil {
+		return models.CalendarFeed{}, err
+	}
+	return feed, nil
+}
+
+func (repo *CalendarFeedRepository) Save(feed *models.CalendarFeed) error {
+	return repo.database.Save(feed).Error
+}
+
+func (repo *CalendarFeedRepository) DeleteForUser(userID uint) (bool, error) {
+	result := repo.database.Where("user_id = ?", userID).Delete(&models.CalendarFeed{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -53,8 +53,8 @@ func (repo *DailyLogRepository) ListByUserDayRange(userID uint, dayStart time.Ti
 func (repo *DailyLogRepository) ListPeriodDays(userID uint) ([]models.DailyLog, error) {
 	logs := make([]models.DailyLog, 0)
 	if err := repo.database.
-		Select("date", "is_period").
-		Where("user_id = ? AND is_period = ?", userID, true).
+		Select("date", "is_period", "flow", "cycle_start_override").
+		Where("user_id = ? AND (is_period = ? OR cycle_start_override <> '')", userID, true).
 		Order("date ASC").
 		Find(&logs).Error; err != nil {
 		return nil, err
@@ -65,7 +65,7 @@ func (repo *DailyLogRepository) ListPeriodDays(userID uint) ([]models.DailyLog,
 func (repo *DailyLogRepository) FindByUserAndDayRange(userID uint, dayStart time.Time, dayEnd time.Time) (models.DailyLog, bool, error) {
 	entry := models.DailyLog{}
 	result := repo.database.
-		Select("id", "user_id", "date", "is_period", "flow", "symptom_ids", "notes", "created_at", "updated_at").
+		Select("id", "user_id", "date", "is_period", "flow", "symptom_ids", "symptom_severities", "notes", "bbt", "bbt_time", "bbt_disturbed", "mucus", "cervix_position", "cervix_firmness", "lh_test", "pregnancy_test", "test_brand", "pill_taken", "intimacy", "intimacy_protection", "intimacy_methods", "cycle_start_override", "cycle_exclusion", "created_at", "updated_at").
 		Where("user_id = ? AND date >= ? AND date < ?", userID, dayStart, dayEnd).
 		Order("date DESC, id DESC").
 		Limit(1).
@@ -92,5 +92,5 @@ func (repo *DailyLogRepository) DeleteByUserAndDayRange(userID uint, dayStart ti
 }
 
 func (repo *DailyLogRepository) UpdateSymptomIDs(entry *models.DailyLog) error {
-	return repo.database.Model(entry).Select("symptom_ids").Updates(entry).Error
+	return repo.database.Model(entry).Select("symptom_ids", "symptom_severities").Updates(entry).Error
 }
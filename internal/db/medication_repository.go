@@ -0,0 +1,95 @@
+package db
+
+import (
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"gorm.io/gorm"
+)
+
+type MedicationRepository struct {
+	database *gorm.DB
+}
+
+func NewMedicationRepository(database *gorm.DB) *MedicationRepository {
+	return &MedicationRepository{database: database}
+}
+
+func (repo *MedicationRepository) ListByUser(userID uint) ([]models.Medication, error) {
+	medications := make([]models.Medication, 0)
+	if err := repo.database.
+		Where("user_id = ?", userID).
+		Order("name ASC, id ASC").
+		Find(&medications).Error; err != nil {
+		return nil, err
+	}
+	return medications, nil
+}
+
+func (repo *MedicationRepository) Create(medication *models.Medication) error {
+	return repo.database.Create(medication).Error
+}
+
+func (repo *MedicationRepository) CountByUserAndIDs(userID uint, ids []uint) (int64, error) {
+	var count int64
+	if err := repo.database.Model(&models.Medication{}).
+		Where("user_id = ? AND id IN ?", userID, ids).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// DeleteForUser removes a catalog entry together with its intake history.
+func (repo *MedicationRepository) DeleteForUser(medicationID uint, userID uint) (bool, error) {
+	deleted := false
+	err := repo.database.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND user_id = ?", medicationID, userID).Delete(&models.Medication{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		deleted = true
+		return tx.Where("medication_id = ? AND user_id = ?", medicationID, userID).Delete(&models.MedicationIntake{}).Error
+	})
+	return deleted, err
+}
+
+func (repo *MedicationRepository) ListIntakesByUserRange(userID uint, fromStart *time.Time, toEnd *time.Time) ([]models.MedicationIntake, error) {
+	query := repo.database.Model(&models.MedicationIntake{}).Where("user_id = ?", userID)
+	if fromStart != nil {
+		query = query.Where("date >= ?", *fromStart)
+	}
+	if toEnd != nil {
+		query = query.Where("date < ?", *toEnd)
+	}
+
+	intakes := make([]models.MedicationIntake, 0)
+	if err := query.Order("date ASC, medication_id ASC").Find(&intakes).Error; err != nil {
+		return nil, err
+	}
+	return intakes, nil
+}
+
+// ReplaceIntakesForDay swaps the intakes recorded in a day range for the
+// given set in one transaction.
+func (repo *MedicationRepository) ReplaceIntakesForDay(userID uint, dayStart time.Time, dayEnd time.Time, intakes []models.MedicationIntake) error {
+	return repo.database.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND date >= ? AND date < ?", userID, dayStart, dayEnd).
+			Delete(&models.MedicationIntake{}).Error; err != nil {
+			return err
+		}
+		if len(intakes) == 0 {
+			return nil
+		}
+		return tx.Create(&intakes).Error
+	})
+}
+
+func (repo *MedicationRepository) DeleteIntakesByUserAndDayRange(userID uint, dayStart time.Time, dayEnd time.Time) error {
+	return repo.database.
+		Where("user_id = ? AND date >= ? AND date < ?", userID, dayStart, dayEnd).
+		Delete(&models.MedicationIntake{}).Error
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"gorm.io/gorm"
+)
+
+type MetricRepository struct {
+	database *gorm.DB
+}
+
+func NewMetricRepository(database *gorm.DB) *MetricRepository {
+	return &MetricRepository{database: database}
+}
+
+func (repo *MetricRepository) ListTypesByUser(userID uint) ([]models.MetricType, error) {
+	metrics := make([]models.MetricType, 0)
+	if err := repo.database.
+		Where("user_id = ?", userID).
+		Order("name ASC, id ASC").
+		Find(&metrics).Error; err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+func (repo *MetricRepository) CreateType(metric *models.MetricType) error {
+	return repo.database.Create(metric).Error
+}
+
+// DeleteTypeForUser removes a metric together with its recorded values.
+func (repo *MetricRepository) DeleteTypeForUser(metricTypeID uint, userID uint) (bool, error) {
+	deleted := false
+	err := repo.database.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND user_id = ?", metricTypeID, userID).Delete(&models.MetricType{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		deleted = true
+		return tx.Where("metric_type_id = ? AND user_id = ?", metricTypeID, userID).Delete(&models.MetricValue{}).Error
+	})
+	return deleted, err
+}
+
+func (repo *MetricRepository) ListValuesByUserRange(userID uint, fromStart *time.Time, toEnd *time.Time) ([]models.MetricValue, error) {
+	query := repo.database.Model(&models.MetricValue{}).Where("user_id = ?", userID)
+	if fromStart != nil {
+		query = query.Where("date >= ?", *fromStart)
+	}
+	if toEnd != nil {
+		query = query.Where("date < ?", *toEnd)
+	}
+
+	values := make([]models.MetricValue, 0)
+	if err := query.Order("date ASC, metric_type_id ASC").Find(&values).Error; err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// ReplaceValuesForDay swaps the values recorded in a day range for the given
+// set in one transaction.
+func (repo *MetricRepository) ReplaceValuesForDay(userID uint, dayStart time.Time, dayEnd time.Time, values []models.MetricValue) error {
+	return repo.database.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND date >= ? AND date < ?", userID, dayStart, dayEnd).
+			Delete(&models.MetricValue{}).Error; err != nil {
+			return err
+		}
+		if len(values) == 0 {
+			return nil
+		}
+		return tx.Create(&values).Error
+	})
+}
+
+func (repo *MetricRepository) DeleteValuesByUserAndDayRange(userID uint, dayStart time.Time, dayEnd time.Time) error {
+	return repo.database.
+		Where("user_id = ? AND date >= ? AND date < ?", userID, dayStart, dayEnd).
+		Delete(&models.MetricValue{}).Error
+}
@@ -20,6 +20,14 @@ func TestOpenSQLiteAppliesEmbeddedMigrationsOnCleanDatabase(t *testing.T) {
 	assertUsersSchemaReconciled(t, database)
 	assertDailyLogsSchemaReconciled(t, database)
 	assertNormalizedEmailIndexExists(t, database)
+	assertPartnerInvitesSchemaExists(t, database)
+	assertRegistrationInvitesSchemaExists(t, database)
+	assertAuthSessionsSchemaExists(t, database)
+	assertAPITokensSchemaExists(t, database)
+	assertCalendarFeedsSchemaExists(t, database)
+	assertMedicationsSchemaExists(t, database)
+	assertMetricsSchemaExists(t, database)
+	assertPredictionSnapshotsSchemaExists(t, database)
 	assertAllEmbeddedMigrationsApplied(t, database)
 }
 
@@ -67,13 +75,14 @@ func TestOpenSQLiteUpgradesLegacyInitSchema(t *testing.T) {
 	}
 
 	var migratedLog struct {
-		Flow       string  `gorm:"column:flow"`
-		SymptomIDs *string `gorm:"column:symptom_ids"`
-		Notes      string  `gorm:"column:notes"`
+		Flow              string  `gorm:"column:flow"`
+		SymptomIDs        *string `gorm:"column:symptom_ids"`
+		SymptomSeverities *string `gorm:"column:symptom_severities"`
+		Notes             string  `gorm:"column:notes"`
 	}
 	if err := database.
 		Table("daily_logs").
-		Select("flow", "symptom_ids", "notes").
+		Select("flow", "symptom_ids", "symptom_severities", "notes").
 		Where("notes = ?", "legacy-log").
 		First(&migratedLog).Error; err != nil {
 		t.Fatalf("load migrated legacy daily log: %v", err)
@@ -85,6 +94,9 @@ func TestOpenSQLiteUpgradesLegacyInitSchema(t *testing.T) {
 	if migratedLog.SymptomIDs == nil || strings.TrimSpace(*migratedLog.SymptomIDs) != "[1,2]" {
 		t.Fatalf("expected migrated symptom_ids to remain [1,2], got %v", migratedLog.SymptomIDs)
 	}
+	if migratedLog.SymptomSeverities == nil || strings.TrimSpace(*migratedLog.SymptomSeverities) != `{"1":2,"2":2}` {
+		t.Fatalf("expected migrated symptom_severities to default to moderate, got %v", migratedLog.SymptomSeverities)
+	}
 }
 
 func TestOpenSQLiteMigrationBootstrapIsIdempotent(t *testing.T) {
@@ -202,7 +214,29 @@ func assertUsersSchemaReconciled(t *testing.T, database *gorm.DB) {
 		"cycle_length",
 		"period_length",
 		"auto_period_fill",
+		"temperature_unit",
 		"last_period_start",
+		"linked_owner_id",
+		"share_hide_period_days",
+		"share_hide_flow",
+		"share_hide_predictions",
+		"share_hide_fertile_window",
+		"share_notes",
+		"shared_symptom_ids",
+		"totp_secret",
+		"totp_enabled",
+		"totp_last_step",
+		"cycle_mode",
+		"pregnancy_start",
+		"due_date",
+		"postpartum_start",
+		"contraception_method",
+		"contraception_pack_length",
+		"contraception_placebo_days",
+		"contraception_start",
+		"intimacy_tracking",
+		"prediction_algorithm",
+		"luteal_phase_length",
 	}
 
 	for _, column := range expectedColumns {
@@ -216,8 +250,10 @@ func assertDailyLogsSchemaReconciled(t *testing.T, database *gorm.DB) {
 	t.Helper()
 
 	columns := loadTableColumns(t, database, "daily_logs")
-	if _, exists := columns["symptom_ids"]; !exists {
-		t.Fatal("expected daily_logs.symptom_ids column to exist after migrations")
+	for _, column := range []string{"symptom_ids", "bbt", "bbt_time", "bbt_disturbed", "mucus", "cervix_position", "cervix_firmness", "lh_test", "pregnancy_test", "test_brand", "pill_taken", "symptom_severities", "intimacy", "intimacy_protection", "intimacy_methods", "cycle_start_override", "cycle_exclusion"} {
+		if _, exists := columns[column]; !exists {
+			t.Fatalf("expected daily_logs.%s column to exist after migrations", column)
+		}
 	}
 
 	notNullFlags := loadTableColumnNotNullFlags(t, database, "daily_logs")
@@ -232,6 +268,106 @@ func assertDailyLogsSchemaReconciled(t *testing.T, database *gorm.DB) {
 	}
 }
 
+func assertPartnerInvitesSchemaExists(t *testing.T, database *gorm.DB) {
+	t.Helper()
+
+	columns := loadTableColumns(t, database, "partner_invites")
+	for _, column := range []string{"owner_id", "token_hash", "expires_at", "used_at", "partner_id"} {
+		if _, exists := columns[column]; !exists {
+			t.Fatalf("expected partner_invites.%s column to exist after migrations", column)
+		}
+	}
+}
+
+func assertRegistrationInvitesSchemaExists(t *testing.T, database *gorm.DB) {
+	t.Helper()
+
+	columns := loadTableColumns(t, database, "registration_invites")
+	for _, column := range []string{"token_hash", "expires_at", "used_at", "used_by_user_id"} {
+		if _, exists := columns[column]; !exists {
+			t.Fatalf("expected registration_invites.%s column to exist after migrations", column)
+		}
+	}
+}
+
+func assertAuthSessionsSchemaExists(t *testing.T, database *gorm.DB) {
+	t.Helper()
+
+	columns := loadTableColumns(t, database, "auth_sessions")
+	for _, column := range []string{"user_id", "token_hash", "user_agent", "ip_address", "last_seen_at", "expires_at"} {
+		if _, exists := columns[column]; !exists {
+			t.Fatalf("expected auth_sessions.%s column to exist after migrations", column)
+		}
+	}
+}
+
+func assertAPITokensSchemaExists(t *testing.T, database *gorm.DB) {
+	t.Helper()
+
+	columns := loadTableColumns(t, database, "api_tokens")
+	for _, column := range []string{"user_id", "name", "token_hash", "scope", "last_used_at", "created_at"} {
+		if _, exists := columns[column]; !exists {
+			t.Fatalf("expected api_tokens.%s column to exist after migrations", column)
+		}
+	}
+}
+
+func assertCalendarFeedsSchemaExists(t *testing.T, database *gorm.DB) {
+	t.Helper()
+
+	columns := loadTableColumns(t, database, "calendar_feeds")
+	for _, column := range []string{"user_id", "token_hash", "language", "cycles", "include_fertility", "include_ovulation", "neutral_titles", "updated_at"} {
+		if _, exists := columns[column]; !exists {
+			t.Fatalf("expected calendar_feeds.%s column to exist after migrations", column)
+		}
+	}
+}
+
+func assertMedicationsSchemaExists(t *testing.T, database *gorm.DB) {
+	t.Helper()
+
+	columns := loadTableColumns(t, database, "medications")
+	for _, column := range []string{"user_id", "name", "dose", "unit", "schedule", "created_at"} {
+		if _, exists := columns[column]; !exists {
+			t.Fatalf("expected medications.%s column to exist after migrations", column)
+		}
+	}
+	columns = loadTableColumns(t, database, "medication_intakes")
+	for _, column := range []string{"user_id", "medication_id", "date", "dose", "unit"} {
+		if _, exists := columns[column]; !exists {
+			t.Fatalf("expected medication_intakes.%s column to exist after migrations", column)
+		}
+	}
+}
+
+func assertMetricsSchemaExists(t *testing.T, database *gorm.DB) {
+	t.Helper()
+
+	columns := loadTableColumns(t, database, "metric_types")
+	for _, column := range []string{"user_id", "name", "unit", "kind", "min_value", "max_value", "created_at"} {
+		if _, exists := columns[column]; !exists {
+			t.Fatalf("expected metric_types.%s column to exist after migrations", column)
+		}
+	}
+	columns = loadTableColumns(t, database, "metric_values")
+	for _, column := range []string{"user_id", "metric_type_id", "date", "value"} {
+		if _, exists := columns[column]; !exists {
+			t.Fatalf("expected metric_values.%s column to exist after migrations", column)
+		}
+	}
+}
+
+func assertPredictionSnapshotsSchemaExists(t *testing.T, database *gorm.DB) {
+	t.Helper()
+
+	columns := loadTableColumns(t, database, "prediction_snapshots")
+	for _, column := range []string{"user_id", "cycle_start", "predicted_period_start", "predicted_ovulation", "algorithm", "created_at"} {
+		if _, exists := columns[column]; !exists {
+			t.Fatalf("expected prediction_snapshots.%s column to exist after migrations", column)
+		}
+	}
+}
+
 func assertNormalizedEmailIndexExists(t *testing.T, database *gorm.DB) {
 	t.Helper()
 
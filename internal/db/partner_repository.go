@@ -0,0 +1,117 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"gorm.io/gorm"
+)
+
+var ErrPartnerInviteUnavailable = errors.New("partner invite unavailable")
+
+type PartnerRepository struct {
+	database *gorm.DB
+}
+
+func NewPartnerRepository(database *gorm.DB) *PartnerRepository {
+	return &PartnerRepository{database: database}
+}
+
+func (repo *PartnerRepository) CreateInvite(invite *models.PartnerInvite) error {
+	return repo.database.Create(invite).Error
+}
+
+func (repo *PartnerRepository) FindActiveInviteByTokenHash(tokenHash string, now time.Time) (models.PartnerInvite, error) {
+	invite := models.PartnerInvite{}
+	if err := repo.database.
+		Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+		First(&invite).Error; err != nil {
+		return models.PartnerInvite{}, err
+	}
+	return invite, nil
+}
+
+func (repo *PartnerRepository) ListActiveInvitesByOwner(ownerID uint, now time.Time) ([]models.PartnerInvite, error) {
+	invites := make([]models.PartnerInvite, 0)
+	if err := repo.database.
+		Where("owner_id = ? AND used_at IS NULL AND expires_at > ?", ownerID, now).
+		Order("created_at DESC, id DESC").
+		Find(&invites).Error; err != nil {
+		return nil, err
+	}
+	return invites, nil
+}
+
+func (repo *PartnerRepository) DeleteInviteForOwner(inviteID uint, ownerID uint) (bool, error) {
+	result := repo.database.
+		Where("id = ? AND owner_id = ? AND used_at IS NULL", inviteID, ownerID).
+		Delete(&models.PartnerInvite{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (repo *PartnerRepository) ListPartnersByOwner(ownerID uint) ([]models.User, error) {
+	partners := make([]models.User, 0)
+	if err := repo.database.
+		Where("linked_owner_id = ? AND role = ?", ownerID, models.RolePartner).
+		Order("created_at ASC, id ASC").
+		Find(&partners).Error; err != nil {
+		return nil, err
+	}
+	return partners, nil
+}
+
+func (repo *PartnerRepository) CreatePartnerFromInvite(partner *models.User, inviteID uint, now time.Time) error {
+	return repo.database.Transaction(func(tx *gorm.DB) error {
+		claimed := tx.Model(&models.PartnerInvite{}).
+			Where("id = ? AND used_at IS NULL AND expires_at > ?", inviteID, now).
+			Update("used_at", now)
+		if claimed.Error != nil {
+			return claimed.Error
+		}
+		if claimed.RowsAffected == 0 {
+			return ErrPartnerInviteUnavailable
+		}
+
+		if err := tx.Create(partner).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.PartnerInvite{}).Where("id = ?", inviteID).Update("partner_id", partner.ID).Error
+	})
+}
+
+func (repo *PartnerRepository) DeletePartnerForOwner(partnerID uint, ownerID uint) (bool, error) {
+	result := repo.database.
+		Where("id = ? AND linked_owner_id = ? AND role = ?", partnerID, ownerID, models.RolePartner).
+		Delete(&models.User{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (repo *PartnerRepository) UpdateSharingPolicyForOwner(partnerID uint, ownerID uint, policy models.PartnerSharingPolicy) (bool, error) {
+	sharedSymptomIDs, err := json.Marshal(policy.SharedSymptomIDs)
+	if err != nil {
+		return false, err
+	}
+
+	result := repo.database.Model(&models.User{}).
+		Where("id = ? AND linked_owner_id = ? AND role = ?", partnerID, ownerID, models.RolePartner).
+		Updates(map[string]any{
+			"share_hide_period_days":    policy.HidePeriodDays,
+			"share_hide_flow":           policy.HideFlow,
+			"share_hide_predictions":    policy.HidePredictions,
+			"share_hide_fertile_window": policy.HideFertileWindow,
+			"share_notes":               policy.ShareNotes,
+			"shared_symptom_ids":        string(sharedSymptomIDs),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
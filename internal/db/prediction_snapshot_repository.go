@@ -0,0 +1,38 @@
+package db
+
+import (
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PredictionSnapshotRepository struct {
+	database *gorm.DB
+}
+
+func NewPredictionSnapshotRepository(database *gorm.DB) *PredictionSnapshotRepository {
+	return &PredictionSnapshotRepository{database: database}
+}
+
+func (repo *PredictionSnapshotRepository) ListByUser(userID uint) ([]models.PredictionSnapshot, error) {
+	snapshots := make([]models.PredictionSnapshot, 0)
+	if err := repo.database.
+		Where("user_id = ?", userID).
+		Order("cycle_start ASC").
+		Find(&snapshots).Error; err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+// CreateIfMissing stores snapshot unless the cycle already has one, so the
+// first forecast for a cycle is never overwritten.
+func (repo *PredictionSnapshotRepository) CreateIfMissing(snapshot *models.PredictionSnapshot) (bool, error) {
+	result := repo.database.
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "user_id"}, {Name: "cycle_start"}}, DoNothing: true}).
+		Create(snapshot)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"gorm.io/gorm"
+)
+
+var ErrRegistrationInviteUnavailable = errors.New("registration invite unavailable")
+
+type RegistrationInviteRepository struct {
+	database *gorm.DB
+}
+
+func NewRegistrationInviteRepository(database *gorm.DB) *RegistrationInviteRepository {
+	return &RegistrationInviteRepository{database: database}
+}
+
+func (repo *RegistrationInviteRepository) CreateInvite(invite *models.RegistrationInvite) error {
+	return repo.database.Create(invite).Error
+}
+
+func (repo *RegistrationInviteRepository) FindActiveInviteByTokenHash(tokenHash string, now time.Time) (models.RegistrationInvite, error) {
+	invite := models.RegistrationInvite{}
+	if err := repo.database.
+		Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+		First(&invite).Error; err != nil {
+		return models.RegistrationInvite{}, err
+	}
+	return invite, nil
+}
+
+func (repo *RegistrationInviteRepository) CreateUserFromInvite(user *models.User, inviteID uint, now time.Time) error {
+	return repo.database.Transaction(func(tx *gorm.DB) error {
+		claimed := tx.Model(&models.RegistrationInvite{}).
+			Where("id = ? AND used_at IS NULL AND expires_at > ?", inviteID, now).
+			Update("used_at", now)
+		if claimed.Error != nil {
+			return claimed.Error
+		}
+		if claimed.RowsAffected == 0 {
+			return ErrRegistrationInviteUnavailable
+		}
+
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.RegistrationInvite{}).Where("id = ?", inviteID).Update("used_by_user_id", user.ID).Error
+	})
+}
@@ -3,15 +3,42 @@ package db
 import "gorm.io/gorm"
 
 type Repositories struct {
-	Users     *UserRepository
-	DailyLogs *DailyLogRepository
-	Symptoms  *SymptomRepository
+	Users               *UserRepository
+	DailyLogs           *DailyLogRepository
+	Symptoms            *SymptomRepository
+	Partners            *PartnerRepository
+	RegistrationInvites *RegistrationInviteRepository
+	Sessions            *SessionRepository
+	APITokens           *APITokenRepository
+	CalendarFeeds       *CalendarFeedRepository
+	Medications         *MedicationRepository
+	Metrics             *MetricRepository
+	PredictionSnapshots *PredictionSnapshotRepository
+
+	database *gorm.DB
 }
 
 func NewRepositories(database *gorm.DB) *Repositories {
 	return &Repositories{
-		Users:     NewUserRepository(database),
-		DailyLogs: NewDailyLogRepository(database),
-		Symptoms:  NewSymptomRepository(database),
+		Users:               NewUserRepository(database),
+		DailyLogs:           NewDailyLogRepository(database),
+		Symptoms:            NewSymptomRepository(database),
+		Partners:            NewPartnerRepository(database),
+		RegistrationInvites: NewRegistrationInviteRepository(database),
+		Sessions:            NewSessionRepository(database),
+		APITokens:           NewAPITokenRepository(database),
+		CalendarFeeds:       NewCalendarFeedRepository(database),
+		Medications:         NewMedicationRepository(database),
+		Metrics:             NewMetricRepository(database),
+		PredictionSnapshots: NewPredictionSnapshotRepository(database),
+		database:            database,
 	}
 }
+
+// Transaction runs fn with repositories bound to one database transaction,
+// which is rolled back when fn returns an error.
+func (repositories *Repositories) Transaction(fn func(tx *Repositories) error) error {
+	return repositories.database.Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepositories(tx))
+	})
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"gorm.io/gorm"
+)
+
+type SessionRepository struct {
+	database *gorm.DB
+}
+
+func NewSessionRepository(database *gorm.DB) *SessionRepository {
+	return &SessionRepository{database: database}
+}
+
+func (repo *SessionRepository) Create(session *models.AuthSession) error {
+	return repo.database.Create(session).Error
+}
+
+func (repo *SessionRepository) FindActiveByTokenHash(tokenHash string, now time.Time) (models.AuthSession, error) {
+	session := models.AuthSession{}
+	if err := repo.database.
+		Where("token_hash = ? AND expires_at > ?", tokenHash, now).
+		First(&session).Error; err != nil {
+		return models.AuthSession{}, err
+	}
+	return session, nil
+}
+
+func (repo *SessionRepository) ListActiveByUser(userID uint, now time.Time) ([]models.AuthSession, error) {
+	sessions := make([]models.AuthSession, 0)
+	if err := repo.database.
+		Where("user_id = ? AND expires_at > ?", userID, now).
+		Order("last_seen_at DESC, id DESC").
+		Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (repo *SessionRepository) TouchLastSeen(sessionID uint, seenAt time.Time) error {
+	return repo.database.Model(&models.AuthSession{}).
+		Where("id = ?", sessionID).
+		Update("last_seen_at", seenAt).Error
+}
+
+func (repo *SessionRepository) DeleteForUser(sessionID uint, userID uint) (bool, error) {
+	result := repo.database.
+		Where("id = ? AND user_id = ?", sessionID, userID).
+		Delete(&models.AuthSession{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (repo *SessionRepository) DeleteAllForUserExcept(userID uint, keepSessionID uint) (int64, error) {
+	query := repo.database.Where("user_id = ?", userID)
+	if keepSessionID != 0 {
+		query = query.Where("id <> ?", keepSessionID)
+	}
+	result := query.Delete(&models.AuthSession{})
+	return result.RowsAffected, result.Error
+}
+
+func (repo *SessionRepository) DeleteExpired(now time.Time) error {
+	return repo.database.Where("expires_at <= ?", now).Delete(&models.AuthSession{}).Error
+}
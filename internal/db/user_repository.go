@@ -80,7 +80,8 @@ func (repo *UserRepository) UpdateByID(userID uint, updates map[string]any) erro
 func (repo *UserRepository) LoadSettingsByID(userID uint) (models.User, error) {
 	var user models.User
 	if err := repo.database.
-		Select("cycle_length", "period_length", "auto_period_fill", "last_period_start").
+		Select("cycle_length", "period_length", "auto_period_fill", "last_period_start", "temperature_unit", "cycle_mode", "pregnancy_start", "due_date", "postpartum_start",
+			"contraception_method", "contraception_pack_length", "contraception_placebo_days", "contraception_start", "intimacy_tracking", "prediction_algorithm", "luteal_phase_length").
 		First(&user, userID).Error; err != nil {
 		return models.User{}, err
 	}
@@ -114,11 +115,33 @@ func (repo *UserRepository) ClearAllDataAndResetSettings(userID uint) error {
 		if err := tx.Where("user_id = ?", userID).Delete(&models.DailyLog{}).Error; err != nil {
 			return err
 		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.MedicationIntake{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.MetricValue{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.PredictionSnapshot{}).Error; err != nil {
+			return err
+		}
 		return tx.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]any{
 			"cycle_length":      models.DefaultCycleLength,
 			"period_length":     models.DefaultPeriodLength,
 			"auto_period_fill":  true,
 			"last_period_start": nil,
+			"cycle_mode":        models.CycleModeCycle,
+			"pregnancy_start":   nil,
+			"due_date":          nil,
+			"postpartum_start":  nil,
+
+			"contraception_method":       "",
+			"contraception_pack_length":  models.DefaultPackLength,
+			"contraception_placebo_days": models.DefaultPlaceboDays,
+			"contraception_start":        nil,
+
+			"intimacy_tracking":    false,
+			"prediction_algorithm": models.PredictionAlgorithmMedian,
+			"luteal_phase_length":  0,
 		}).Error
 	})
 }
@@ -128,9 +151,30 @@ func (repo *UserRepository) DeleteAccountAndRelatedData(userID uint) error {
 		if err := tx.Where("user_id = ?", userID).Delete(&models.DailyLog{}).Error; err != nil {
 			return err
 		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.MedicationIntake{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.MetricValue{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.PredictionSnapshot{}).Error; err != nil {
+			return err
+		}
 		if err := tx.Where("user_id = ?", userID).Delete(&models.SymptomType{}).Error; err != nil {
 			return err
 		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.Medication{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.MetricType{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("owner_id = ?", userID).Delete(&models.PartnerInvite{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("linked_owner_id = ? AND role = ?", userID, models.RolePartner).Delete(&models.User{}).Error; err != nil {
+			return err
+		}
 		return tx.Delete(&models.User{}, userID).Error
 	})
 }
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+const (
+	APITokenScopeRead      = "read"
+	APITokenScopeReadWrite = "read_write"
+)
+
+// APIToken is a personal access token for scripted /api access. Only the
+// hash of the raw token is stored.
+type APIToken struct {
+	ID         uint       `gorm:"primaryKey"`
+	UserID     uint       `gorm:"not null;index"`
+	Name       string     `gorm:"not null"`
+	TokenHash  string     `gorm:"not null;uniqueIndex"`
+	Scope      string     `gorm:"not null;default:'read'"`
+	LastUsedAt *time.Time `gorm:"column:last_used_at"`
+	CreatedAt  time.Time  `gorm:"not null"`
+}
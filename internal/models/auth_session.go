@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// AuthSession is a server-side record of one signed-in device. The auth
+// cookie only carries the raw session token; the database keeps its hash.
+type AuthSession struct {
+	ID         uint      `gorm:"primaryKey"`
+	UserID     uint      `gorm:"not null;index"`
+	TokenHash  string    `gorm:"not null;uniqueIndex"`
+	UserAgent  string    `gorm:"not null;default:''"`
+	IPAddress  string    `gorm:"column:ip_address;not null;default:''"`
+	CreatedAt  time.Time `gorm:"not null"`
+	LastSeenAt time.Time `gorm:"not null"`
+	ExpiresAt  time.Time `gorm:"not null"`
+}
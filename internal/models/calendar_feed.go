@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+const DefaultCalendarFeedCycles = 6
+
+// CalendarFeed is a per-user iCalendar subscription. The feed URL carries a
+// secret token; only its hash is stored.
+type CalendarFeed struct {
+	ID               uint      `gorm:"primaryKey"`
+	UserID           uint      `gorm:"not null;uniqueIndex"`
+	TokenHash        string    `gorm:"not null;uniqueIndex"`
+	Language         string    `gorm:"not null;default:''"`
+	Cycles           int       `gorm:"not null;default:6"`
+	IncludeFertility bool      `gorm:"column:include_fertility;not null;default:false"`
+	IncludeOvulation bool      `gorm:"column:include_ovulation;not null;default:false"`
+	NeutralTitles    bool      `gorm:"column:neutral_titles;not null;default:false"`
+	CreatedAt        time.Time `gorm:"not null"`
+	UpdatedAt        time.Time `gorm:"not null"`
+}
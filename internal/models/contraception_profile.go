@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+const (
+	ContraceptionCombinedPill  = "combined_pill"
+	ContraceptionProgestinPill = "progestin_pill"
+	ContraceptionPatch         = "patch"
+	ContraceptionRing          = "ring"
+
+	DefaultPackLength  = 28
+	DefaultPlaceboDays = 7
+)
+
+// ContraceptionProfile is stored on owner accounts and describes a
+// pack-based hormonal method. An empty Method means no profile is active.
+type ContraceptionProfile struct {
+	Method      string     `gorm:"column:contraception_method;not null;default:''"`
+	PackLength  int        `gorm:"column:contraception_pack_length;not null;default:28"`
+	PlaceboDays int        `gorm:"column:contraception_placebo_days;not null;default:7"`
+	Start       *time.Time `gorm:"column:contraception_start;type:date"`
+}
@@ -2,21 +2,116 @@ package models
 
 import "time"
 
+// FlowSpotting is logged on bleeding days that are lighter than a period;
+// such days never start a cycle or count towards its period length.
 const (
-	FlowNone   = "none"
-	FlowLight  = "light"
-	FlowMedium = "medium"
-	FlowHeavy  = "heavy"
+	FlowNone     = "none"
+	FlowSpotting = "spotting"
+	FlowLight    = "light"
+	FlowMedium   = "medium"
+	FlowHeavy    = "heavy"
+)
+
+// DailyLog.BBT always holds Celsius; the unit only affects input and display.
+// A zero BBT means the day has no temperature reading.
+const (
+	TemperatureUnitCelsius    = "c"
+	TemperatureUnitFahrenheit = "f"
+)
+
+// Cervical mucus observations, from least to most fertile. An empty value
+// means nothing was observed that day.
+const (
+	MucusDry      = "dry"
+	MucusSticky   = "sticky"
+	MucusCreamy   = "creamy"
+	MucusWatery   = "watery"
+	MucusEggWhite = "egg_white"
+)
+
+const (
+	CervixPositionLow    = "low"
+	CervixPositionMedium = "medium"
+	CervixPositionHigh   = "high"
+
+	CervixFirmnessFirm   = "firm"
+	CervixFirmnessMedium = "medium"
+	CervixFirmnessSoft   = "soft"
+)
+
+// Home test results. An empty value means no test was taken that day.
+const (
+	LHTestNegative = "negative"
+	LHTestPositive = "positive"
+	LHTestPeak     = "peak"
+
+	PregnancyTestNegative = "negative"
+	PregnancyTestPositive = "positive"
+	PregnancyTestFaint    = "faint"
+)
+
+// Symptom severities, keyed by symptom ID in DailyLog.SymptomSeverities.
+// Symptoms logged without a severity count as moderate.
+const (
+	SymptomSeverityMild     = 1
+	SymptomSeverityModerate = 2
+	SymptomSeveritySevere   = 3
+
+	DefaultSymptomSeverity = SymptomSeverityModerate
+)
+
+// Intimacy protection and contraception methods. An empty protection means
+// it was not recorded; any method implies a protected encounter.
+const (
+	IntimacyProtected   = "protected"
+	IntimacyUnprotected = "unprotected"
+
+	IntimacyMethodCondom     = "condom"
+	IntimacyMethodHormonal   = "hormonal"
+	IntimacyMethodIUD        = "iud"
+	IntimacyMethodWithdrawal = "withdrawal"
+	IntimacyMethodOther      = "other"
+)
+
+// Cycle overrides correct the automatic cycle-start detection on one day.
+// A cycle exclusion on any day leaves the cycle containing it out of the
+// statistics; the value is the reason.
+const (
+	CycleStartOverrideStart    = "start"
+	CycleStartOverrideNotStart = "not_start"
+
+	CycleExclusionIllness    = "illness"
+	CycleExclusionMedication = "medication"
+	CycleExclusionPostpartum = "postpartum"
+	CycleExclusionOther      = "other"
 )
 
 type DailyLog struct {
-	ID         uint      `gorm:"primaryKey"`
-	UserID     uint      `gorm:"not null;uniqueIndex:uidx_user_date"`
-	Date       time.Time `gorm:"type:date;not null;uniqueIndex:uidx_user_date"`
-	IsPeriod   bool      `gorm:"not null;default:false"`
-	Flow       string    `gorm:"not null;default:none"`
-	SymptomIDs []uint    `gorm:"serializer:json"`
-	Notes      string
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID                uint         `gorm:"primaryKey"`
+	UserID            uint         `gorm:"not null;uniqueIndex:uidx_user_date"`
+	Date              time.Time    `gorm:"type:date;not null;uniqueIndex:uidx_user_date"`
+	IsPeriod          bool         `gorm:"not null;default:false"`
+	Flow              string       `gorm:"not null;default:none"`
+	SymptomIDs        []uint       `gorm:"serializer:json"`
+	SymptomSeverities map[uint]int `gorm:"column:symptom_severities;serializer:json"`
+	Notes             string
+	BBT               float64 `gorm:"column:bbt;not null;default:0"`
+	BBTTime           string  `gorm:"column:bbt_time;not null;default:''"`
+	BBTDisturbed      bool    `gorm:"column:bbt_disturbed;not null;default:false"`
+	Mucus             string  `gorm:"column:mucus;not null;default:''"`
+	CervixPosition    string  `gorm:"column:cervix_position;not null;default:''"`
+	CervixFirmness    string  `gorm:"column:cervix_firmness;not null;default:''"`
+	LHTest            string  `gorm:"column:lh_test;not null;default:''"`
+	PregnancyTest     string  `gorm:"column:pregnancy_test;not null;default:''"`
+	TestBrand         string  `gorm:"column:test_brand;not null;default:''"`
+	PillTaken         bool    `gorm:"column:pill_taken;not null;default:false"`
+	// Intimacy entries are never shared with partners and stay hidden
+	// until the owner turns on intimacy tracking.
+	Intimacy           bool     `gorm:"column:intimacy;not null;default:false"`
+	IntimacyProtection string   `gorm:"column:intimacy_protection;not null;default:''"`
+	IntimacyMethods    []string `gorm:"column:intimacy_methods;serializer:json"`
+	CycleStartOverride string   `gorm:"column:cycle_start_override;not null;default:''"`
+	CycleExclusion     string   `gorm:"column:cycle_exclusion;not null;default:''"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
 }
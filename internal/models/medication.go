@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Medication schedules describe how a catalog entry is meant to be taken.
+// They are informational; intakes are always recorded per day.
+const (
+	MedicationScheduleDaily      = "daily"
+	MedicationScheduleTwiceDaily = "twice_daily"
+	MedicationScheduleWeekly     = "weekly"
+	MedicationScheduleAsNeeded   = "as_needed"
+)
+
+// Medication is an entry in the owner's medication and supplement catalog.
+type Medication struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index"`
+	Name      string    `gorm:"not null"`
+	Dose      float64   `gorm:"not null;default:0"`
+	Unit      string    `gorm:"not null;default:''"`
+	Schedule  string    `gorm:"not null;default:'as_needed'"`
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+// MedicationIntake records that a medication was taken on a day. Dose and
+// unit are copied from the catalog so that later edits keep history intact.
+type MedicationIntake struct {
+	ID           uint      `gorm:"primaryKey"`
+	UserID       uint      `gorm:"not null;index"`
+	MedicationID uint      `gorm:"not null"`
+	Date         time.Time `gorm:"type:date;not null"`
+	Dose         float64   `gorm:"not null;default:0"`
+	Unit         string    `gorm:"not null;default:''"`
+	CreatedAt    time.Time `gorm:"not null"`
+}
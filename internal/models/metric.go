@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Metric kinds decide which values a metric accepts. Scale metrics always
+// have a range and, like integer metrics, only take whole numbers.
+const (
+	MetricKindInteger = "integer"
+	MetricKindDecimal = "decimal"
+	MetricKindScale   = "scale"
+)
+
+// MetricType is an owner-defined daily measurement such as weight or sleep
+// hours. A nil bound leaves that side of the range open.
+type MetricType struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index"`
+	Name      string    `gorm:"not null"`
+	Unit      string    `gorm:"not null;default:''"`
+	Kind      string    `gorm:"not null;default:'decimal'"`
+	MinValue  *float64  `gorm:"column:min_value"`
+	MaxValue  *float64  `gorm:"column:max_value"`
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+// MetricValue is the value of one metric on one day.
+type MetricValue struct {
+	ID           uint      `gorm:"primaryKey"`
+	UserID       uint      `gorm:"not null;index"`
+	MetricTypeID uint      `gorm:"not null"`
+	Date         time.Time `gorm:"type:date;not null"`
+	Value        float64   `gorm:"not null"`
+	CreatedAt    time.Time `gorm:"not null"`
+}
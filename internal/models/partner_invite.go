@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+type PartnerInvite struct {
+	ID        uint       `gorm:"primaryKey"`
+	OwnerID   uint       `gorm:"not null;index"`
+	TokenHash string     `gorm:"not null;uniqueIndex"`
+	ExpiresAt time.Time  `gorm:"not null"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+	PartnerID *uint      `gorm:"column:partner_id"`
+	CreatedAt time.Time  `gorm:"not null"`
+}
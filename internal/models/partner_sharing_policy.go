@@ -0,0 +1,13 @@
+package models
+
+// PartnerSharingPolicy is stored on partner accounts. The zero value matches
+// the original partner view: period days, flow and predictions are visible,
+// notes and symptoms are hidden.
+type PartnerSharingPolicy struct {
+	HidePeriodDays    bool   `gorm:"column:share_hide_period_days;not null;default:false"`
+	HideFlow          bool   `gorm:"column:share_hide_flow;not null;default:false"`
+	HidePredictions   bool   `gorm:"column:share_hide_predictions;not null;default:false"`
+	HideFertileWindow bool   `gorm:"column:share_hide_fertile_window;not null;default:false"`
+	ShareNotes        bool   `gorm:"column:share_notes;not null;default:false"`
+	SharedSymptomIDs  []uint `gorm:"column:shared_symptom_ids;serializer:json"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// PredictionSnapshot keeps the forecast made when a cycle started so it can
+// later be compared with the cycle that actually followed.
+type PredictionSnapshot struct {
+	ID                   uint       `gorm:"primaryKey"`
+	UserID               uint       `gorm:"not null;index"`
+	CycleStart           time.Time  `gorm:"column:cycle_start;type:date;not null"`
+	PredictedPeriodStart time.Time  `gorm:"column:predicted_period_start;type:date;not null"`
+	PredictedOvulation   *time.Time `gorm:"column:predicted_ovulation;type:date"`
+	Algorithm            string     `gorm:"not null;default:median"`
+	CreatedAt            time.Time  `gorm:"not null"`
+}
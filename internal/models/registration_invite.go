@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+type RegistrationInvite struct {
+	ID           uint       `gorm:"primaryKey"`
+	TokenHash    string     `gorm:"not null;uniqueIndex"`
+	ExpiresAt    time.Time  `gorm:"not null"`
+	UsedAt       *time.Time `gorm:"column:used_at"`
+	UsedByUserID *uint      `gorm:"column:used_by_user_id"`
+	CreatedAt    time.Time  `gorm:"not null"`
+}
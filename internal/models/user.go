@@ -7,20 +7,45 @@ const (
 	RolePartner         = "partner"
 	DefaultCycleLength  = 28
 	DefaultPeriodLength = 5
+	// DefaultLutealPhaseLength is the textbook number of days from ovulation
+	// to the next period, used until the owner's own length is known.
+	DefaultLutealPhaseLength = 14
+
+	CycleModeCycle      = "cycle"
+	CycleModePregnancy  = "pregnancy"
+	CycleModePostpartum = "postpartum"
+
+	PredictionAlgorithmMedian          = "median"
+	PredictionAlgorithmWeightedAverage = "weighted_average"
+	PredictionAlgorithmBayesian        = "bayesian"
 )
 
 type User struct {
-	ID                  uint       `gorm:"primaryKey"`
-	DisplayName         string     `gorm:"size:80"`
-	Email               string     `gorm:"uniqueIndex;not null"`
-	PasswordHash        string     `gorm:"not null"`
-	RecoveryCodeHash    string     `gorm:"column:recovery_code_hash"`
-	MustChangePassword  bool       `gorm:"column:must_change_password;not null;default:false"`
-	Role                string     `gorm:"not null;default:owner"`
-	OnboardingCompleted bool       `gorm:"not null;default:false"`
-	CycleLength         int        `gorm:"not null;default:28"`
-	PeriodLength        int        `gorm:"not null;default:5"`
-	AutoPeriodFill      bool       `gorm:"column:auto_period_fill;not null;default:true"`
-	LastPeriodStart     *time.Time `gorm:"type:date"`
-	CreatedAt           time.Time  `gorm:"not null"`
+	ID                  uint                 `gorm:"primaryKey"`
+	DisplayName         string               `gorm:"size:80"`
+	Email               string               `gorm:"uniqueIndex;not null"`
+	PasswordHash        string               `gorm:"not null"`
+	RecoveryCodeHash    string               `gorm:"column:recovery_code_hash"`
+	MustChangePassword  bool                 `gorm:"column:must_change_password;not null;default:false"`
+	TOTPSecret          string               `gorm:"column:totp_secret"`
+	TOTPEnabled         bool                 `gorm:"column:totp_enabled;not null;default:false"`
+	TOTPLastStep        int64                `gorm:"column:totp_last_step;not null;default:0"`
+	Role                string               `gorm:"not null;default:owner"`
+	LinkedOwnerID       *uint                `gorm:"column:linked_owner_id;index"`
+	SharingPolicy       PartnerSharingPolicy `gorm:"embedded"`
+	OnboardingCompleted bool                 `gorm:"not null;default:false"`
+	CycleLength         int                  `gorm:"not null;default:28"`
+	PeriodLength        int                  `gorm:"not null;default:5"`
+	AutoPeriodFill      bool                 `gorm:"column:auto_period_fill;not null;default:true"`
+	TemperatureUnit     string               `gorm:"column:temperature_unit;not null;default:c"`
+	LastPeriodStart     *time.Time           `gorm:"type:date"`
+	CycleMode           string               `gorm:"column:cycle_mode;not null;default:cycle"`
+	PregnancyStart      *time.Time           `gorm:"column:pregnancy_start;type:date"`
+	DueDate             *time.Time           `gorm:"column:due_date;type:date"`
+	PostpartumStart     *time.Time           `gorm:"column:postpartum_start;type:date"`
+	Contraception       ContraceptionProfile `gorm:"embedded"`
+	IntimacyTracking    bool                 `gorm:"column:intimacy_tracking;not null;default:false"`
+	PredictionAlgorithm string               `gorm:"column:prediction_algorithm;not null;default:median"`
+	LutealPhaseLength   int                  `gorm:"column:luteal_phase_length;not null;default:0"`
+	CreatedAt           time.Time            `gorm:"not null"`
 }
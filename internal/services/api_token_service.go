@@ -0,0 +1,149 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/security"
+)
+
+const (
+	APITokenPrefix           = "ovm_"
+	apiTokenLength           = 40
+	apiTokenNameMaxLen       = 64
+	APITokenLastUsedInterval = time.Minute
+)
+
+var (
+	ErrAPITokenOwnerRequired = errors.New("api token owner required")
+	ErrAPITokenNameInvalid   = errors.New("api token name invalid")
+	ErrAPITokenScopeInvalid  = errors.New("api token scope invalid")
+	ErrAPITokenCreate        = errors.New("api token create failed")
+	ErrAPITokenInvalid       = errors.New("api token invalid")
+	ErrAPITokenNotFound      = errors.New("api token not found")
+)
+
+type APITokenRepository interface {
+	Create(token *models.APIToken) error
+	FindByTokenHash(tokenHash string) (models.APIToken, error)
+	ListByUser(userID uint) ([]models.APIToken, error)
+	TouchLastUsed(tokenID uint, usedAt time.Time) error
+	DeleteForUser(tokenID uint, userID uint) (bool, error)
+}
+
+type APITokenService struct {
+	tokens APITokenRepository
+}
+
+func NewAPITokenService(tokens APITokenRepository) *APITokenService {
+	return &APITokenService{tokens: tokens}
+}
+
+func HashAPIToken(rawToken string) string {
+	sum := sha256.Sum256([]byte("ovumcy.api-token.v1:" + strings.TrimSpace(rawToken)))
+	return hex.EncodeToString(sum[:])
+}
+
+func NormalizeAPITokenScope(raw string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", models.APITokenScopeRead:
+		return models.APITokenScopeRead, true
+	case models.APITokenScopeReadWrite:
+		return models.APITokenScopeReadWrite, true
+	default:
+		return "", false
+	}
+}
+
+// APITokenAllowsMethod reports whether a token's scope permits an HTTP
+// method. Read-only tokens are limited to safe methods.
+func APITokenAllowsMethod(token models.APIToken, method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return token.Scope == models.APITokenScopeReadWrite
+	}
+}
+
+func (service *APITokenService) Create(owner *models.User, name string, scope string, now time.Time) (string, models.APIToken, error) {
+	if !IsOwnerUser(owner) {
+		return "", models.APIToken{}, ErrAPITokenOwnerRequired
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > apiTokenNameMaxLen {
+		return "", models.APIToken{}, ErrAPITokenNameInvalid
+	}
+	normalizedScope, ok := NormalizeAPITokenScope(scope)
+	if !ok {
+		return "", models.APIToken{}, ErrAPITokenScopeInvalid
+	}
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	randomPart, err := security.RandomString(apiTokenLength, partnerInviteTokenAlphabet)
+	if err != nil {
+		return "", models.APIToken{}, fmt.Errorf("%w: %v", ErrAPITokenCreate, err)
+	}
+	rawToken := APITokenPrefix + randomPart
+
+	token := models.APIToken{
+		UserID:    owner.ID,
+		Name:      name,
+		TokenHash: HashAPIToken(rawToken),
+		Scope:     normalizedScope,
+		CreatedAt: now,
+	}
+	if err := service.tokens.Create(&token); err != nil {
+		return "", models.APIToken{}, fmt.Errorf("%w: %v", ErrAPITokenCreate, err)
+	}
+	return rawToken, token, nil
+}
+
+// Resolve looks up the token behind a bearer credential and refreshes its
+// last-used time at most once per APITokenLastUsedInterval.
+func (service *APITokenService) Resolve(rawToken string, now time.Time) (models.APIToken, error) {
+	rawToken = strings.TrimSpace(rawToken)
+	if !strings.HasPrefix(rawToken, APITokenPrefix) {
+		return models.APIToken{}, ErrAPITokenInvalid
+	}
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	token, err := service.tokens.FindByTokenHash(HashAPIToken(rawToken))
+	if err != nil {
+		return models.APIToken{}, ErrAPITokenInvalid
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= APITokenLastUsedInterval {
+		if err := service.tokens.TouchLastUsed(token.ID, now); err == nil {
+			usedAt := now
+			token.LastUsedAt = &usedAt
+		}
+	}
+	return token, nil
+}
+
+func (service *APITokenService) List(userID uint) ([]models.APIToken, error) {
+	return service.tokens.ListByUser(userID)
+}
+
+func (service *APITokenService) Revoke(userID uint, tokenID uint) error {
+	deleted, err := service.tokens.DeleteForUser(tokenID, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrAPITokenNotFound
+	}
+	return nil
+}
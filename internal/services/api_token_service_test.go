@@ -0,0 +1,185 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+type stubAPITokenRepo struct {
+	created   models.APIToken
+	stored    models.APIToken
+	findErr   error
+	touchedID uint
+	touchedAt time.Time
+	deleteOK  bool
+}
+
+func (stub *stubAPITokenRepo) Create(token *models.APIToken) error {
+	token.ID = 21
+	stub.created = *token
+	return nil
+}
+
+func (stub *stubAPITokenRepo) FindByTokenHash(string) (models.APIToken, error) {
+	if stub.findErr != nil {
+		return models.APIToken{}, stub.findErr
+	}
+	return stub.stored, nil
+}
+
+func (stub *stubAPITokenRepo) ListByUser(uint) ([]models.APIToken, error) {
+	return []models.APIToken{stub.stored}, nil
+}
+
+func (stub *stubAPITokenRepo) TouchLastUsed(tokenID uint, usedAt time.Time) error {
+	stub.touchedID = tokenID
+	stub.touchedAt = usedAt
+	return nil
+}
+
+func (stub *stubAPITokenRepo) DeleteForUser(uint, uint) (bool, error) {
+	return stub.deleteOK, nil
+}
+
+func TestAPITokenServiceCreateStoresHashedToken(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubAPITokenRepo{}
+	service := NewAPITokenService(repo)
+	owner := &models.User{ID: 3, Role: models.RoleOwner}
+	now := time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	rawToken, token, err := service.Create(owner, "  backup script ", "read_write", now)
+	if err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(rawToken, APITokenPrefix) || token.ID != 21 {
+		t.Fatalf("expected prefixed raw token and persisted row, got %q / %#v", rawToken, token)
+	}
+	if repo.created.TokenHash != HashAPIToken(rawToken) || repo.created.TokenHash == rawToken {
+		t.Fatalf("expected stored token hash, got %q", repo.created.TokenHash)
+	}
+	if repo.created.Name != "backup script" || repo.created.Scope != models.APITokenScopeReadWrite {
+		t.Fatalf("expected normalized name and scope, got %#v", repo.created)
+	}
+	if repo.created.UserID != owner.ID || !repo.created.CreatedAt.Equal(now) {
+		t.Fatalf("expected owner and creation time, got %#v", repo.created)
+	}
+}
+
+func TestAPITokenServiceCreateValidation(t *testing.T) {
+	t.Parallel()
+
+	owner := &models.User{ID: 3, Role: models.RoleOwner}
+	testCases := []struct {
+		name    string
+		user    *models.User
+		label   string
+		scope   string
+		wantErr error
+	}{
+		{name: "partner", user: &models.User{ID: 4, Role: models.RolePartner}, label: "x", wantErr: ErrAPITokenOwnerRequired},
+		{name: "nil user", user: nil, label: "x", wantErr: ErrAPITokenOwnerRequired},
+		{name: "blank name", user: owner, label: "   ", wantErr: ErrAPITokenNameInvalid},
+		{name: "long name", user: owner, label: strings.Repeat("n", 65), wantErr: ErrAPITokenNameInvalid},
+		{name: "unknown scope", user: owner, label: "x", scope: "admin", wantErr: ErrAPITokenScopeInvalid},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := NewAPITokenService(&stubAPITokenRepo{})
+			_, _, err := service.Create(testCase.user, testCase.label, testCase.scope, time.Time{})
+			if !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("expected %v, got %v", testCase.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAPITokenServiceResolve(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+	recent := now.Add(-10 * time.Second)
+	stale := now.Add(-time.Hour)
+
+	testCases := []struct {
+		name        string
+		rawToken    string
+		stored      models.APIToken
+		findErr     error
+		wantErr     error
+		wantTouched bool
+	}{
+		{name: "missing prefix", rawToken: "abc", wantErr: ErrAPITokenInvalid},
+		{name: "unknown token", rawToken: "ovm_unknown", findErr: errors.New("not found"), wantErr: ErrAPITokenInvalid},
+		{name: "first use", rawToken: "ovm_first", stored: models.APIToken{ID: 7, UserID: 3}, wantTouched: true},
+		{name: "recent use", rawToken: "ovm_recent", stored: models.APIToken{ID: 7, UserID: 3, LastUsedAt: &recent}},
+		{name: "stale use", rawToken: "ovm_stale", stored: models.APIToken{ID: 7, UserID: 3, LastUsedAt: &stale}, wantTouched: true},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &stubAPITokenRepo{stored: testCase.stored, findErr: testCase.findErr}
+			token, err := NewAPITokenService(repo).Resolve(testCase.rawToken, now)
+			if testCase.wantErr != nil {
+				if !errors.Is(err, testCase.wantErr) {
+					t.Fatalf("expected %v, got %v", testCase.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve() unexpected error: %v", err)
+			}
+			if token.ID != testCase.stored.ID {
+				t.Fatalf("expected token %d, got %d", testCase.stored.ID, token.ID)
+			}
+			touched := repo.touchedID == token.ID && repo.touchedAt.Equal(now)
+			if touched != testCase.wantTouched {
+				t.Fatalf("expected touched=%v, got %v", testCase.wantTouched, touched)
+			}
+			if testCase.wantTouched && (token.LastUsedAt == nil || !token.LastUsedAt.Equal(now)) {
+				t.Fatalf("expected last used time to be refreshed, got %v", token.LastUsedAt)
+			}
+		})
+	}
+}
+
+func TestAPITokenServiceRevokeReportsMissingToken(t *testing.T) {
+	t.Parallel()
+
+	service := NewAPITokenService(&stubAPITokenRepo{deleteOK: false})
+	if err := service.Revoke(3, 99); !errors.Is(err, ErrAPITokenNotFound) {
+		t.Fatalf("expected ErrAPITokenNotFound, got %v", err)
+	}
+}
+
+func TestAPITokenAllowsMethod(t *testing.T) {
+	t.Parallel()
+
+	readOnly := models.APIToken{Scope: models.APITokenScopeRead}
+	readWrite := models.APIToken{Scope: models.APITokenScopeReadWrite}
+
+	if !APITokenAllowsMethod(readOnly, http.MethodGet) {
+		t.Fatal("expected read-only token to allow GET")
+	}
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodDelete} {
+		if APITokenAllowsMethod(readOnly, method) {
+			t.Fatalf("expected read-only token to reject %s", method)
+		}
+		if !APITokenAllowsMethod(readWrite, method) {
+			t.Fatalf("expected read-write token to allow %s", method)
+		}
+	}
+}
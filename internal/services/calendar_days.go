@@ -7,16 +7,35 @@ import (
 )
 
 type CalendarDayState struct {
-	Date        time.Time
-	DateString  string
-	Day         int
-	InMonth     bool
-	IsToday     bool
-	IsPeriod    bool
+	Date       time.Time
+	DateString string
+	Day        int
+	InMonth    bool
+	IsToday    bool
+	IsPeriod   bool
+	// IsSpotting marks bleeding days logged as spotting, which are drawn
+	// apart from period days.
+	IsSpotting  bool
 	IsPredicted bool
-	IsFertility bool
-	IsOvulation bool
-	HasData     bool
+	// IsPredictionRange marks days where a predicted period could start
+	// instead, given the cycle-length variation.
+	IsPredictionRange bool
+	IsFertility       bool
+	IsOvulation       bool
+	HasData           bool
+	// FertilityStatus is the symptothermal status observed from temperature
+	// and mucus, kept apart from the IsFertility prediction, and
+	// FertilityRule the rule that decided it.
+	FertilityStatus string
+	FertilityRule   string
+	// Intimacy marks days with an intimacy entry; IntimacyUnprotected
+	// those where it was unprotected.
+	Intimacy            bool
+	IntimacyUnprotected bool
+	// CycleStartOverride is the manual cycle-start correction logged on
+	// the day and CycleExcluded marks days logged with a cycle exclusion.
+	CycleStartOverride string
+	CycleExcluded      bool
 }
 
 func CalendarLogRange(monthStart time.Time) (time.Time, time.Time) {
@@ -24,7 +43,28 @@ func CalendarLogRange(monthStart time.Time) (time.Time, time.Time) {
 	return monthStart.AddDate(0, 0, -70), monthEnd.AddDate(0, 0, 70)
 }
 
-func BuildCalendarDayStates(monthStart time.Time, logs []models.DailyLog, stats CycleStats, now time.Time, location *time.Location) []CalendarDayState {
+// PredictionLengths returns the cycle and period lengths used to project
+// future cycles from stats, falling back to the defaults.
+func PredictionLengths(stats CycleStats) (int, int) {
+	cycleLength := stats.PredictedCycleLength
+	if cycleLength <= 0 {
+		cycleLength = stats.MedianCycleLength
+	}
+	if cycleLength <= 0 {
+		cycleLength = int(stats.AverageCycleLength + 0.5)
+	}
+	if cycleLength <= 0 {
+		cycleLength = models.DefaultCycleLength
+	}
+
+	periodLength := int(stats.AveragePeriodLength + 0.5)
+	if periodLength <= 0 {
+		periodLength = models.DefaultPeriodLength
+	}
+	return cycleLength, periodLength
+}
+
+func BuildCalendarDayStates(user *models.User, monthStart time.Time, logs []models.DailyLog, stats CycleStats, now time.Time, location *time.Location) []CalendarDayState {
 	monthEnd := monthStart.AddDate(0, 1, -1)
 	gridStart := monthStart.AddDate(0, 0, -int(monthStart.Weekday()))
 	gridEnd := monthEnd.AddDate(0, 0, 6-int(monthEnd.Weekday()))
@@ -52,46 +92,37 @@ func BuildCalendarDayStates(monthStart time.Time, logs []models.DailyLog, stats
 	if !stats.OvulationDate.IsZero() {
 		ovulationMap[stats.OvulationDate.Format("2006-01-02")] = true
 	}
-
-	predictedCycleLength := stats.MedianCycleLength
-	if predictedCycleLength <= 0 {
-		predictedCycleLength = int(stats.AverageCycleLength + 0.5)
-	}
-	if predictedCycleLength <= 0 {
-		predictedCycleLength = models.DefaultCycleLength
-	}
-
-	predictedPeriodLength := int(stats.AveragePeriodLength + 0.5)
-	if predictedPeriodLength <= 0 {
-		predictedPeriodLength = models.DefaultPeriodLength
+	for _, confirmed := range ConfirmedOvulationDates(logs, location) {
+		ovulationMap[confirmed.Format("2006-01-02")] = true
 	}
 
-	if !stats.NextPeriodStart.IsZero() {
-		cycleStart := DateAtLocation(stats.NextPeriodStart, location)
-		for !cycleStart.After(gridEnd) {
-			for offset := 0; offset < predictedPeriodLength; offset++ {
-				day := cycleStart.AddDate(0, 0, offset)
-				predictedPeriodMap[day.Format("2006-01-02")] = true
-			}
+	predictionRangeMap := make(map[string]bool)
+	for _, prediction := range BuildCyclePredictions(stats, MaxPredictionCycles, location) {
+		if prediction.EarliestStart.After(gridEnd) {
+			break
+		}
+		for day := prediction.PeriodStart; !day.After(prediction.PeriodEnd); day = day.AddDate(0, 0, 1) {
+			predictedPeriodMap[day.Format("2006-01-02")] = true
+		}
+		for day := prediction.EarliestStart; !day.After(prediction.LatestStart); day = day.AddDate(0, 0, 1) {
+			predictionRangeMap[day.Format("2006-01-02")] = true
+		}
 
-			ovulationDate, fertilityStart, fertilityEnd, _, calculable := PredictCycleWindow(
-				cycleStart,
-				predictedCycleLength,
-				predictedPeriodLength,
-			)
-			if calculable {
-				ovulationMap[ovulationDate.Format("2006-01-02")] = true
-				if !fertilityStart.IsZero() && !fertilityEnd.IsZero() {
-					for day := fertilityStart; !day.After(fertilityEnd); day = day.AddDate(0, 0, 1) {
-						fertilityMap[day.Format("2006-01-02")] = true
-					}
+		if !prediction.OvulationImpossible {
+			ovulationMap[prediction.OvulationDate.Format("2006-01-02")] = true
+			if !prediction.FertilityWindowStart.IsZero() && !prediction.FertilityWindowEnd.IsZero() {
+				for day := prediction.FertilityWindowStart; !day.After(prediction.FertilityWindowEnd); day = day.AddDate(0, 0, 1) {
+					fertilityMap[day.Format("2006-01-02")] = true
 				}
 			}
-
-			cycleStart = cycleStart.AddDate(0, 0, predictedCycleLength)
 		}
 	}
 
+	observedStatusMap := make(map[string]FertilityDayStatus)
+	for _, status := range NewSymptothermalRulesEngine().Evaluate(user, logs, gridStart, gridEnd, now, location) {
+		observedStatusMap[status.Date.Format("2006-01-02")] = status
+	}
+
 	todayKey := DateAtLocation(now, location).Format("2006-01-02")
 
 	days := make([]CalendarDayState, 0, 42)
@@ -99,26 +130,46 @@ func BuildCalendarDayStates(monthStart time.Time, logs []models.DailyLog, stats
 		key := day.Format("2006-01-02")
 		inMonth := day.Month() == monthStart.Month()
 		entry, hasEntry := latestLogByDate[key]
-		isPeriod := hasEntry && entry.IsPeriod
+		isPeriod := hasEntry && IsMenstrualDay(entry)
+		isSpotting := hasEntry && entry.IsPeriod && entry.Flow == models.FlowSpotting
 		isPredicted := predictedPeriodMap[key]
+		isPredictionRange := predictionRangeMap[key] && !isPredicted
 		isFertility := fertilityMap[key]
 		isToday := key == todayKey
 		isOvulation := ovulationMap[key]
 		if isOvulation {
 			isFertility = false
 		}
+		observed := observedStatusMap[key]
+		if stats.ContraceptionActive && !day.Before(stats.ContraceptionStart) {
+			// Hormonal contraception suppresses ovulation, so neither the
+			// prediction nor the observed signs describe fertility.
+			isFertility = false
+			isOvulation = false
+			observed = FertilityDayStatus{}
+		}
 
 		days = append(days, CalendarDayState{
-			Date:        day,
-			DateString:  key,
-			Day:         day.Day(),
-			InMonth:     inMonth,
-			IsToday:     isToday,
-			IsPeriod:    isPeriod,
-			IsPredicted: isPredicted,
-			IsFertility: isFertility,
-			IsOvulation: isOvulation,
-			HasData:     hasDataMap[key],
+			Date:              day,
+			DateString:        key,
+			Day:               day.Day(),
+			InMonth:           inMonth,
+			IsToday:           isToday,
+			IsPeriod:          isPeriod,
+			IsSpotting:        isSpotting,
+			IsPredicted:       isPredicted,
+			IsPredictionRange: isPredictionRange,
+			IsFertility:       isFertility,
+			IsOvulation:       isOvulation,
+			HasData:           hasDataMap[key],
+			FertilityStatus:   observed.Status,
+			FertilityRule:     observed.Rule,
+
+			Intimacy:            hasEntry && entry.Intimacy,
+			IntimacyUnprotected: hasEntry && IsUnprotectedIntimacy(entry),
+
+			CycleStartOverride: entry.CycleStartOverride,
+			CycleExcluded:      hasEntry && entry.CycleExclusion != "",
 		})
 	}
 
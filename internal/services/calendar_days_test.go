@@ -38,7 +38,7 @@ func TestBuildCalendarDayStatesUsesLatestLogPerDateDeterministically(t *testing.
 		},
 	}
 
-	days := BuildCalendarDayStates(monthStart, logs, CycleStats{}, now, time.UTC)
+	days := BuildCalendarDayStates(nil, monthStart, logs, CycleStats{}, now, time.UTC)
 
 	day17 := findCalendarDayStateByDateString(t, days, "2026-02-17")
 	if day17.IsPeriod {
@@ -77,7 +77,7 @@ func TestBuildCalendarDayStatesProjectsOvulationIntoFutureCycles(t *testing.T) {
 		FertilityWindowEnd:   time.Date(2026, time.February, 24, 0, 0, 0, 0, time.UTC),
 	}
 
-	days := BuildCalendarDayStates(monthStart, nil, stats, now, time.UTC)
+	days := BuildCalendarDayStates(nil, monthStart, nil, stats, now, time.UTC)
 
 	ovulationDay := findCalendarDayStateByDateString(t, days, "2026-03-23")
 	if !ovulationDay.IsOvulation {
@@ -91,6 +91,77 @@ func TestBuildCalendarDayStatesProjectsOvulationIntoFutureCycles(t *testing.T) {
 	}
 }
 
+func TestBuildCalendarDayStatesMarksPredictionRangeOnFutureMonths(t *testing.T) {
+	monthStart := time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, time.February, 23, 0, 0, 0, 0, time.UTC)
+
+	stats := CycleStats{
+		MedianCycleLength:   28,
+		AveragePeriodLength: 5,
+		NextPeriodStart:     time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC),
+	}
+
+	days := BuildCalendarDayStates(nil, monthStart, nil, stats, now, time.UTC)
+
+	// The fourth projected cycle starts on 2026-06-02 with a +/-4 day range,
+	// the fifth on 2026-06-30 with a +/-5 day range.
+	start := findCalendarDayStateByDateString(t, days, "2026-06-02")
+	if !start.IsPredicted || start.IsPredictionRange {
+		t.Fatalf("expected predicted period start without range marker, got %#v", start)
+	}
+	for _, date := range []string{"2026-05-31", "2026-06-01", "2026-06-25"} {
+		if day := findCalendarDayStateByDateString(t, days, date); !day.IsPredictionRange || day.IsPredicted {
+			t.Fatalf("expected prediction range on %s, got %#v", date, day)
+		}
+	}
+	if day := findCalendarDayStateByDateString(t, days, "2026-06-24"); day.IsPredictionRange {
+		t.Fatalf("did not expect prediction range before earliest start, got %#v", day)
+	}
+}
+
+func TestBuildCalendarDayStatesStopsAtPredictionHorizon(t *testing.T) {
+	monthStart := time.Date(2027, time.June, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, time.February, 23, 0, 0, 0, 0, time.UTC)
+
+	stats := CycleStats{
+		MedianCycleLength:   28,
+		AveragePeriodLength: 5,
+		NextPeriodStart:     time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC),
+	}
+
+	for _, day := range BuildCalendarDayStates(nil, monthStart, nil, stats, now, time.UTC) {
+		if day.IsPredicted || day.IsPredictionRange || day.IsOvulation || day.IsFertility {
+			t.Fatalf("expected no predictions beyond %d cycles, got %#v", MaxPredictionCycles, day)
+		}
+	}
+}
+
+func TestBuildCalendarDayStatesAddsObservedFertilityStatus(t *testing.T) {
+	monthStart := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, time.March, 20, 12, 0, 0, 0, time.UTC)
+	logs := symptothermalCycleLogs(t)
+
+	days := BuildCalendarDayStates(nil, monthStart, logs, CycleStats{}, now, time.UTC)
+
+	day9 := findCalendarDayStateByDateString(t, days, "2026-03-09")
+	if day9.FertilityStatus != FertilityStatusFertile || day9.FertilityRule != FertilityRuleMucusObserved {
+		t.Fatalf("expected 2026-03-09 observed fertile, got %#v", day9)
+	}
+	if day9.IsFertility {
+		t.Fatalf("expected observed status to stay separate from the predicted window, got %#v", day9)
+	}
+
+	day18 := findCalendarDayStateByDateString(t, days, "2026-03-18")
+	if day18.FertilityStatus != FertilityStatusInfertile || day18.FertilityRule != FertilityRuleDoubleCheck {
+		t.Fatalf("expected 2026-03-18 observed infertile, got %#v", day18)
+	}
+
+	day25 := findCalendarDayStateByDateString(t, days, "2026-03-25")
+	if day25.FertilityStatus != FertilityStatusUnknown {
+		t.Fatalf("expected future day unknown, got %#v", day25)
+	}
+}
+
 func findCalendarDayStateByDateString(t *testing.T, days []CalendarDayState, date string) CalendarDayState {
 	t.Helper()
 	for _, day := range days {
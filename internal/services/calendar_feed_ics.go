@@ -0,0 +1,190 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+const (
+	CalendarEventPeriod          = "period"
+	CalendarEventPredictedPeriod = "predicted-period"
+	CalendarEventFertileWindow   = "fertile-window"
+	CalendarEventOvulation       = "ovulation"
+
+	calendarFeedDateLayout  = "20060102"
+	calendarFeedStampLayout = "20060102T150405Z"
+	calendarFeedLineLimit   = 75
+)
+
+// CalendarFeedEvent is one all-day event. End is exclusive, as in
+// iCalendar DTEND.
+type CalendarFeedEvent struct {
+	Kind  string
+	Start time.Time
+	End   time.Time
+}
+
+// CalendarFeedTitles holds the rendered event and calendar names. Callers
+// fill it from translations; with neutral titles every field carries the
+// same inconspicuous text.
+type CalendarFeedTitles struct {
+	Calendar        string
+	Period          string
+	PredictedPeriod string
+	FertileWindow   string
+	Ovulation       string
+}
+
+// BuildCalendarFeedEvents lists logged periods plus feed.Cycles predicted
+// periods starting at stats.NextPeriodStart. Fertile windows and ovulation
+// days are added for the current and predicted cycles when the feed asks
+// for them.
+func BuildCalendarFeedEvents(feed models.CalendarFeed, logs []models.DailyLog, stats CycleStats, location *time.Location) []CalendarFeedEvent {
+	if location == nil {
+		location = time.UTC
+	}
+	events := loggedPeriodEvents(logs, location)
+
+	cycles := feed.Cycles
+	if cycles <= 0 {
+		cycles = models.DefaultCalendarFeedCycles
+	}
+	if !stats.OvulationImpossible {
+		events = append(events, cycleWindowEvents(feed, stats.OvulationDate, stats.FertilityWindowStart, stats.FertilityWindowEnd, location)...)
+	}
+	for _, prediction := range BuildCyclePredictions(stats, cycles, location) {
+		events = append(events, CalendarFeedEvent{
+			Kind:  CalendarEventPredictedPeriod,
+			Start: prediction.PeriodStart,
+			End:   prediction.PeriodEnd.AddDate(0, 0, 1),
+		})
+		if !prediction.OvulationImpossible {
+			events = append(events, cycleWindowEvents(feed, prediction.OvulationDate, prediction.FertilityWindowStart, prediction.FertilityWindowEnd, location)...)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Start.Before(events[j].Start)
+	})
+	return events
+}
+
+func loggedPeriodEvents(logs []models.DailyLog, location *time.Location) []CalendarFeedEvent {
+	periodDays := make(map[string]time.Time)
+	for _, logEntry := range logs {
+		if !IsMenstrualDay(logEntry) {
+			continue
+		}
+		day := DateAtLocation(logEntry.Date, location)
+		periodDays[day.Format("2006-01-02")] = day
+	}
+	days := make([]time.Time, 0, len(periodDays))
+	for _, day := range periodDays {
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	events := make([]CalendarFeedEvent, 0)
+	for _, day := range days {
+		if last := len(events) - 1; last >= 0 && sameCalendarDay(events[last].End, day) {
+			events[last].End = day.AddDate(0, 0, 1)
+			continue
+		}
+		events = append(events, CalendarFeedEvent{Kind: CalendarEventPeriod, Start: day, End: day.AddDate(0, 0, 1)})
+	}
+	return events
+}
+
+func cycleWindowEvents(feed models.CalendarFeed, ovulation time.Time, fertilityStart time.Time, fertilityEnd time.Time, location *time.Location) []CalendarFeedEvent {
+	events := make([]CalendarFeedEvent, 0, 2)
+	if feed.IncludeFertility && !fertilityStart.IsZero() && !fertilityEnd.IsZero() {
+		start := DateAtLocation(fertilityStart, location)
+		events = append(events, CalendarFeedEvent{
+			Kind:  CalendarEventFertileWindow,
+			Start: start,
+			End:   DateAtLocation(fertilityEnd, location).AddDate(0, 0, 1),
+		})
+	}
+	if feed.IncludeOvulation && !ovulation.IsZero() {
+		day := DateAtLocation(ovulation, location)
+		events = append(events, CalendarFeedEvent{Kind: CalendarEventOvulation, Start: day, End: day.AddDate(0, 0, 1)})
+	}
+	return events
+}
+
+// RenderCalendarFeedICS writes events as an RFC 5545 calendar. UIDs are
+// derived from the feed, kind and start date so clients update events in
+// place when the feed is refreshed.
+func RenderCalendarFeedICS(feed models.CalendarFeed, events []CalendarFeedEvent, titles CalendarFeedTitles, now time.Time) string {
+	stamp := now.UTC().Format(calendarFeedStampLayout)
+
+	var builder strings.Builder
+	writeLine := func(line string) {
+		builder.WriteString(foldICSLine(line))
+		builder.WriteString("\r\n")
+	}
+
+	writeLine("BEGIN:VCALENDAR")
+	writeLine("VERSION:2.0")
+	writeLine("PRODID:-//Ovumcy//Calendar Feed//EN")
+	writeLine("CALSCALE:GREGORIAN")
+	writeLine("METHOD:PUBLISH")
+	writeLine("X-WR-CALNAME:" + escapeICSText(titles.Calendar))
+	writeLine("X-PUBLISHED-TTL:PT6H")
+	writeLine("REFRESH-INTERVAL;VALUE=DURATION:PT6H")
+	for _, event := range events {
+		start := event.Start.Format(calendarFeedDateLayout)
+		writeLine("BEGIN:VEVENT")
+		writeLine(fmt.Sprintf("UID:%s-%s-%d@ovumcy", event.Kind, start, feed.ID))
+		writeLine("DTSTAMP:" + stamp)
+		writeLine("DTSTART;VALUE=DATE:" + start)
+		writeLine("DTEND;VALUE=DATE:" + event.End.Format(calendarFeedDateLayout))
+		writeLine("SUMMARY:" + escapeICSText(calendarEventTitle(event.Kind, titles)))
+		writeLine("TRANSP:TRANSPARENT")
+		writeLine("END:VEVENT")
+	}
+	writeLine("END:VCALENDAR")
+	return builder.String()
+}
+
+func calendarEventTitle(kind string, titles CalendarFeedTitles) string {
+	switch kind {
+	case CalendarEventPeriod:
+		return titles.Period
+	case CalendarEventPredictedPeriod:
+		return titles.PredictedPeriod
+	case CalendarEventFertileWindow:
+		return titles.FertileWindow
+	default:
+		return titles.Ovulation
+	}
+}
+
+func escapeICSText(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return replacer.Replace(value)
+}
+
+// foldICSLine splits lines longer than 75 octets without breaking UTF-8
+// sequences; continuation lines start with a space.
+func foldICSLine(line string) string {
+	if len(line) <= calendarFeedLineLimit {
+		return line
+	}
+	var builder strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > calendarFeedLineLimit {
+			builder.WriteString("\r\n ")
+			width = 1
+		}
+		builder.WriteRune(r)
+		width += size
+	}
+	return builder.String()
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func calendarFeedTestDay(t *testing.T, raw string) time.Time {
+	t.Helper()
+
+	parsed, err := time.ParseInLocation("2006-01-02", raw, time.UTC)
+	if err != nil {
+		t.Fatalf("parse day %q: %v", raw, err)
+	}
+	return parsed
+}
+
+func TestBuildCalendarFeedEvents(t *testing.T) {
+	t.Parallel()
+
+	logs := []models.DailyLog{
+		{Date: calendarFeedTestDay(t, "2026-02-01"), IsPeriod: true},
+		{Date: calendarFeedTestDay(t, "2026-02-02"), IsPeriod: true},
+		{Date: calendarFeedTestDay(t, "2026-02-03"), IsPeriod: true},
+		{Date: calendarFeedTestDay(t, "2026-02-10"), IsPeriod: false},
+		{Date: calendarFeedTestDay(t, "2026-03-01"), IsPeriod: true},
+	}
+	stats := CycleStats{
+		MedianCycleLength:    28,
+		AveragePeriodLength:  4,
+		NextPeriodStart:      calendarFeedTestDay(t, "2026-03-29"),
+		OvulationDate:        calendarFeedTestDay(t, "2026-03-15"),
+		FertilityWindowStart: calendarFeedTestDay(t, "2026-03-10"),
+		FertilityWindowEnd:   calendarFeedTestDay(t, "2026-03-16"),
+	}
+
+	testCases := []struct {
+		name      string
+		feed      models.CalendarFeed
+		wantKinds map[string]int
+	}{
+		{
+			name:      "periods only",
+			feed:      models.CalendarFeed{Cycles: 3},
+			wantKinds: map[string]int{CalendarEventPeriod: 2, CalendarEventPredictedPeriod: 3},
+		},
+		{
+			name: "with fertility and ovulation",
+			feed: models.CalendarFeed{Cycles: 2, IncludeFertility: true, IncludeOvulation: true},
+			wantKinds: map[string]int{
+				CalendarEventPeriod:          2,
+				CalendarEventPredictedPeriod: 2,
+				CalendarEventFertileWindow:   3,
+				CalendarEventOvulation:       3,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			events := BuildCalendarFeedEvents(testCase.feed, logs, stats, time.UTC)
+			kinds := make(map[string]int)
+			for index, event := range events {
+				kinds[event.Kind]++
+				if !event.End.After(event.Start) {
+					t.Fatalf("expected exclusive end after start, got %#v", event)
+				}
+				if index > 0 && event.Start.Before(events[index-1].Start) {
+					t.Fatalf("expected events sorted by start, got %#v", events)
+				}
+			}
+			if len(kinds) != len(testCase.wantKinds) {
+				t.Fatalf("expected kinds %#v, got %#v", testCase.wantKinds, kinds)
+			}
+			for kind, want := range testCase.wantKinds {
+				if kinds[kind] != want {
+					t.Fatalf("expected %d %s events, got %#v", want, kind, kinds)
+				}
+			}
+
+			first := events[0]
+			if first.Kind != CalendarEventPeriod || !first.Start.Equal(logs[0].Date) || !first.End.Equal(calendarFeedTestDay(t, "2026-02-04")) {
+				t.Fatalf("expected consecutive period days to merge into one event, got %#v", first)
+			}
+		})
+	}
+}
+
+func TestBuildCalendarFeedEventsPredictsFromNextPeriod(t *testing.T) {
+	t.Parallel()
+
+	stats := CycleStats{MedianCycleLength: 30, AveragePeriodLength: 5, NextPeriodStart: calendarFeedTestDay(t, "2026-04-01")}
+	events := BuildCalendarFeedEvents(models.CalendarFeed{Cycles: 2}, nil, stats, time.UTC)
+	if len(events) != 2 {
+		t.Fatalf("expected two predicted periods, got %#v", events)
+	}
+	if !events[1].Start.Equal(calendarFeedTestDay(t, "2026-05-01")) || !events[1].End.Equal(calendarFeedTestDay(t, "2026-05-06")) {
+		t.Fatalf("expected second prediction one cycle later, got %#v", events[1])
+	}
+}
+
+func TestRenderCalendarFeedICS(t *testing.T) {
+	t.Parallel()
+
+	events := []CalendarFeedEvent{{
+		Kind:  CalendarEventPredictedPeriod,
+		Start: calendarFeedTestDay(t, "2026-03-29"),
+		End:   calendarFeedTestDay(t, "2026-04-02"),
+	}}
+	titles := CalendarFeedTitles{Calendar: "Cycle, private; " + strings.Repeat("x", 80), PredictedPeriod: "Period (predicted)"}
+	now := time.Date(2026, time.March, 1, 8, 30, 0, 0, time.UTC)
+
+	rendered := RenderCalendarFeedICS(models.CalendarFeed{ID: 7}, events, titles, now)
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:predicted-period-20260329-7@ovumcy\r\n",
+		"DTSTAMP:20260301T083000Z\r\n",
+		"DTSTART;VALUE=DATE:20260329\r\n",
+		"DTEND;VALUE=DATE:20260402\r\n",
+		"SUMMARY:Period (predicted)\r\n",
+		`X-WR-CALNAME:Cycle\, private\; `,
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(rendered, want) {
+			t.Fatalf("expected %q in rendered feed:\n%s", want, rendered)
+		}
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(rendered, "\r\n"), "\r\n") {
+		if len(line) > calendarFeedLineLimit {
+			t.Fatalf("expected folded lines of at most %d octets, got %q", calendarFeedLineLimit, line)
+		}
+	}
+}
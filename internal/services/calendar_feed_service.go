@@ -0,0 +1,165 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/security"
+)
+
+const (
+	calendarFeedTokenLength   = 40
+	calendarFeedTokenAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	MaxCalendarFeedCycles     = 12
+)
+
+var (
+	ErrCalendarFeedOwnerRequired = errors.New("calendar feed owner required")
+	ErrCalendarFeedCyclesInvalid = errors.New("calendar feed cycles invalid")
+	ErrCalendarFeedCreate        = errors.New("calendar feed create failed")
+	ErrCalendarFeedInvalid       = errors.New("calendar feed invalid")
+	ErrCalendarFeedNotFound      = errors.New("calendar feed not found")
+)
+
+type CalendarFeedRepository interface {
+	FindByUser(userID uint) (models.CalendarFeed, error)
+	FindByTokenHash(tokenHash string) (models.CalendarFeed, error)
+	Save(feed *models.CalendarFeed) error
+	DeleteForUser(userID uint) (bool, error)
+}
+
+// CalendarFeedOptions are the user-facing switches of a feed.
+type CalendarFeedOptions struct {
+	Language         string
+	Cycles           int
+	IncludeFertility bool
+	IncludeOvulation bool
+	NeutralTitles    bool
+}
+
+type CalendarFeedService struct {
+	feeds CalendarFeedRepository
+}
+
+func NewCalendarFeedService(feeds CalendarFeedRepository) *CalendarFeedService {
+	return &CalendarFeedService{feeds: feeds}
+}
+
+func HashCalendarFeedToken(rawToken string) string {
+	sum := sha256.Sum256([]byte("ovumcy.calendar-feed.v1:" + strings.TrimSpace(rawToken)))
+	return hex.EncodeToString(sum[:])
+}
+
+// NormalizeCalendarFeedCycles accepts 1 to MaxCalendarFeedCycles predicted
+// cycles. Zero selects the default.
+func NormalizeCalendarFeedCycles(cycles int) (int, bool) {
+	switch {
+	case cycles == 0:
+		return models.DefaultCalendarFeedCycles, true
+	case cycles < 1 || cycles > MaxCalendarFeedCycles:
+		return 0, false
+	default:
+		return cycles, true
+	}
+}
+
+// Find returns the user's feed, if any.
+func (service *CalendarFeedService) Find(userID uint) (models.CalendarFeed, bool) {
+	feed, err := service.feeds.FindByUser(userID)
+	if err != nil {
+		return models.CalendarFeed{}, false
+	}
+	return feed, true
+}
+
+// Enable creates the owner's feed or rotates the token of an existing one,
+// which stops old subscription URLs from working. The raw token is only
+// returned here.
+func (service *CalendarFeedService) Enable(owner *models.User, options CalendarFeedOptions, now time.Time) (string, models.CalendarFeed, error) {
+	if !IsOwnerUser(owner) {
+		return "", models.CalendarFeed{}, ErrCalendarFeedOwnerRequired
+	}
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	feed, found := service.Find(owner.ID)
+	if !found {
+		feed = models.CalendarFeed{UserID: owner.ID, CreatedAt: now}
+	}
+	if err := applyCalendarFeedOptions(&feed, options); err != nil {
+		return "", models.CalendarFeed{}, err
+	}
+
+	rawToken, err := security.RandomString(calendarFeedTokenLength, calendarFeedTokenAlphabet)
+	if err != nil {
+		return "", models.CalendarFeed{}, fmt.Errorf("%w: %v", ErrCalendarFeedCreate, err)
+	}
+	feed.TokenHash = HashCalendarFeedToken(rawToken)
+	feed.UpdatedAt = now
+	if err := service.feeds.Save(&feed); err != nil {
+		return "", models.CalendarFeed{}, fmt.Errorf("%w: %v", ErrCalendarFeedCreate, err)
+	}
+	return rawToken, feed, nil
+}
+
+// UpdateOptions changes what the feed contains without touching its URL.
+func (service *CalendarFeedService) UpdateOptions(userID uint, options CalendarFeedOptions, now time.Time) (models.CalendarFeed, error) {
+	feed, found := service.Find(userID)
+	if !found {
+		return models.CalendarFeed{}, ErrCalendarFeedNotFound
+	}
+	if err := applyCalendarFeedOptions(&feed, options); err != nil {
+		return models.CalendarFeed{}, err
+	}
+	if now.IsZero() {
+		now = time.Now()
+	}
+	feed.UpdatedAt = now
+	if err := service.feeds.Save(&feed); err != nil {
+		return models.CalendarFeed{}, err
+	}
+	return feed, nil
+}
+
+func (service *CalendarFeedService) Disable(userID uint) error {
+	deleted, err := service.feeds.DeleteForUser(userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrCalendarFeedNotFound
+	}
+	return nil
+}
+
+func (service *CalendarFeedService) Resolve(rawToken string) (models.CalendarFeed, error) {
+	if strings.TrimSpace(rawToken) == "" {
+		return models.CalendarFeed{}, ErrCalendarFeedInvalid
+	}
+	feed, err := service.feeds.FindByTokenHash(HashCalendarFeedToken(rawToken))
+	if err != nil {
+		return models.CalendarFeed{}, ErrCalendarFeedInvalid
+	}
+	return feed, nil
+}
+
+func applyCalendarFeedOptions(feed *models.CalendarFeed, options CalendarFeedOptions) error {
+	cycles, ok := NormalizeCalendarFeedCycles(options.Cycles)
+	if !ok {
+		return ErrCalendarFeedCyclesInvalid
+	}
+	feed.Cycles = cycles
+	feed.IncludeFertility = options.IncludeFertility
+	feed.IncludeOvulation = options.IncludeOvulation
+	feed.NeutralTitles = options.NeutralTitles
+	if language := strings.TrimSpace(options.Language); language != "" {
+		feed.Language = language
+	}
+	return nil
+}
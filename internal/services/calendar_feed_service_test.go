@@ -0,0 +1,136 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+type stubCalendarFeedRepo struct {
+	feeds map[uint]models.CalendarFeed
+	saves int
+}
+
+func newStubCalendarFeedRepo() *stubCalendarFeedRepo {
+	return &stubCalendarFeedRepo{feeds: make(map[uint]models.CalendarFeed)}
+}
+
+func (stub *stubCalendarFeedRepo) FindByUser(userID uint) (models.CalendarFeed, error) {
+	feed, ok := stub.feeds[userID]
+	if !ok {
+		return models.CalendarFeed{}, errors.New("record not found")
+	}
+	return feed, nil
+}
+
+func (stub *stubCalendarFeedRepo) FindByTokenHash(tokenHash string) (models.CalendarFeed, error) {
+	for _, feed := range stub.feeds {
+		if feed.TokenHash == tokenHash {
+			return feed, nil
+		}
+	}
+	return models.CalendarFeed{}, errors.New("record not found")
+}
+
+func (stub *stubCalendarFeedRepo) Save(feed *models.CalendarFeed) error {
+	if feed.ID == 0 {
+		feed.ID = uint(40 + len(stub.feeds))
+	}
+	stub.feeds[feed.UserID] = *feed
+	stub.saves++
+	return nil
+}
+
+func (stub *stubCalendarFeedRepo) DeleteForUser(userID uint) (bool, error) {
+	_, ok := stub.feeds[userID]
+	delete(stub.feeds, userID)
+	return ok, nil
+}
+
+func TestCalendarFeedServiceEnableRotatesToken(t *testing.T) {
+	t.Parallel()
+
+	repo := newStubCalendarFeedRepo()
+	service := NewCalendarFeedService(repo)
+	owner := &models.User{ID: 5, Role: models.RoleOwner}
+	now := time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC)
+
+	firstToken, feed, err := service.Enable(owner, CalendarFeedOptions{Language: "ru", IncludeOvulation: true}, now)
+	if err != nil {
+		t.Fatalf("Enable() unexpected error: %v", err)
+	}
+	if feed.TokenHash != HashCalendarFeedToken(firstToken) || feed.TokenHash == firstToken {
+		t.Fatalf("expected hashed token to be stored, got %#v", feed)
+	}
+	if feed.Cycles != models.DefaultCalendarFeedCycles || !feed.IncludeOvulation || feed.Language != "ru" {
+		t.Fatalf("expected options to be applied with default cycles, got %#v", feed)
+	}
+	if resolved, err := service.Resolve(firstToken); err != nil || resolved.UserID != owner.ID {
+		t.Fatalf("expected first token to resolve, got %#v (%v)", resolved, err)
+	}
+
+	secondToken, rotated, err := service.Enable(owner, CalendarFeedOptions{Cycles: 3}, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("second Enable() unexpected error: %v", err)
+	}
+	if rotated.ID != feed.ID || secondToken == firstToken || rotated.Language != "ru" {
+		t.Fatalf("expected the same feed with a new token, got %#v", rotated)
+	}
+	if _, err := service.Resolve(firstToken); !errors.Is(err, ErrCalendarFeedInvalid) {
+		t.Fatalf("expected old token to stop working, got %v", err)
+	}
+	if _, err := service.Resolve(secondToken); err != nil {
+		t.Fatalf("expected new token to resolve, got %v", err)
+	}
+}
+
+func TestCalendarFeedServiceValidation(t *testing.T) {
+	t.Parallel()
+
+	repo := newStubCalendarFeedRepo()
+	service := NewCalendarFeedService(repo)
+
+	if _, _, err := service.Enable(&models.User{ID: 6, Role: models.RolePartner}, CalendarFeedOptions{}, time.Time{}); !errors.Is(err, ErrCalendarFeedOwnerRequired) {
+		t.Fatalf("expected ErrCalendarFeedOwnerRequired, got %v", err)
+	}
+	if _, _, err := service.Enable(&models.User{ID: 5, Role: models.RoleOwner}, CalendarFeedOptions{Cycles: 13}, time.Time{}); !errors.Is(err, ErrCalendarFeedCyclesInvalid) {
+		t.Fatalf("expected ErrCalendarFeedCyclesInvalid, got %v", err)
+	}
+	if _, err := service.UpdateOptions(5, CalendarFeedOptions{}, time.Time{}); !errors.Is(err, ErrCalendarFeedNotFound) {
+		t.Fatalf("expected ErrCalendarFeedNotFound on update, got %v", err)
+	}
+	if err := service.Disable(5); !errors.Is(err, ErrCalendarFeedNotFound) {
+		t.Fatalf("expected ErrCalendarFeedNotFound on disable, got %v", err)
+	}
+	if _, err := service.Resolve("  "); !errors.Is(err, ErrCalendarFeedInvalid) {
+		t.Fatalf("expected ErrCalendarFeedInvalid for empty token, got %v", err)
+	}
+	if repo.saves != 0 {
+		t.Fatalf("expected rejected calls not to save, got %d saves", repo.saves)
+	}
+}
+
+func TestCalendarFeedServiceUpdateOptionsKeepsToken(t *testing.T) {
+	t.Parallel()
+
+	repo := newStubCalendarFeedRepo()
+	service := NewCalendarFeedService(repo)
+	owner := &models.User{ID: 5, Role: models.RoleOwner}
+
+	rawToken, feed, err := service.Enable(owner, CalendarFeedOptions{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Enable() unexpected error: %v", err)
+	}
+	updated, err := service.UpdateOptions(owner.ID, CalendarFeedOptions{Cycles: 12, IncludeFertility: true, NeutralTitles: true}, time.Time{})
+	if err != nil {
+		t.Fatalf("UpdateOptions() unexpected error: %v", err)
+	}
+	if updated.TokenHash != feed.TokenHash || updated.Cycles != 12 || !updated.IncludeFertility || !updated.NeutralTitles {
+		t.Fatalf("unexpected updated feed: %#v", updated)
+	}
+	if _, err := service.Resolve(rawToken); err != nil {
+		t.Fatalf("expected token to survive option changes, got %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+// mucusPeakInfertileAfterDays is the peak-day rule: the fertile phase lasts
+// until the end of the third day after the last day of peak-type mucus.
+const mucusPeakInfertileAfterDays = 3
+
+var (
+	ErrInvalidDayMucus  = errors.New("invalid day mucus")
+	ErrInvalidDayCervix = errors.New("invalid day cervix observation")
+)
+
+var mucusRanks = map[string]int{
+	models.MucusDry:      1,
+	models.MucusSticky:   2,
+	models.MucusCreamy:   3,
+	models.MucusWatery:   4,
+	models.MucusEggWhite: 5,
+}
+
+// MucusRank orders observations by fertility; zero means no observation.
+func MucusRank(mucus string) int {
+	return mucusRanks[mucus]
+}
+
+// IsPeakTypeMucus reports whether mucus counts towards the peak day.
+func IsPeakTypeMucus(mucus string) bool {
+	return MucusRank(mucus) >= MucusRank(models.MucusWatery)
+}
+
+func NormalizeCervicalValue(raw string) string {
+	normalized := strings.ToLower(strings.TrimSpace(raw))
+	return strings.NewReplacer("-", "_", " ", "_").Replace(normalized)
+}
+
+func IsValidMucus(mucus string) bool {
+	return mucus == "" || MucusRank(mucus) > 0
+}
+
+func IsValidCervixPosition(position string) bool {
+	switch position {
+	case "", models.CervixPositionLow, models.CervixPositionMedium, models.CervixPositionHigh:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidCervixFirmness(firmness string) bool {
+	switch firmness {
+	case "", models.CervixFirmnessFirm, models.CervixFirmnessMedium, models.CervixFirmnessSoft:
+		return true
+	default:
+		return false
+	}
+}
+
+// MucusObservation is one day's mucus as used by the peak-day rule.
+type MucusObservation struct {
+	Date  time.Time
+	Mucus string
+}
+
+// MucusObservations collects mucus observations from logs between from and
+// to inclusive, in date order.
+func MucusObservations(logs []models.DailyLog, from time.Time, to time.Time, location *time.Location) []MucusObservation {
+	observations := make([]MucusObservation, 0)
+	for _, logEntry := range logs {
+		if MucusRank(logEntry.Mucus) == 0 {
+			continue
+		}
+		day := DateAtLocation(logEntry.Date, location)
+		if (!from.IsZero() && day.Before(from)) || (!to.IsZero() && day.After(to)) {
+			continue
+		}
+		observations = append(observations, MucusObservation{Date: day, Mucus: logEntry.Mucus})
+	}
+	sort.Slice(observations, func(i, j int) bool {
+		return observations[i].Date.Before(observations[j].Date)
+	})
+	return observations
+}
+
+// DetectMucusPeak returns the last day of peak-type mucus in one cycle's
+// observations. The peak is only known in hindsight, so it needs a later
+// observation of less fertile mucus.
+func DetectMucusPeak(observations []MucusObservation) (time.Time, bool) {
+	for index := len(observations) - 2; index >= 0; index-- {
+		if !IsPeakTypeMucus(observations[index].Mucus) {
+			continue
+		}
+		if IsPeakTypeMucus(observations[index+1].Mucus) {
+			return time.Time{}, false
+		}
+		return observations[index].Date, true
+	}
+	return time.Time{}, false
+}
+
+// ApplyMucusPeak refines the fertile window of the current cycle with the
+// mucus observations. The window opens on the first day with any mucus if
+// that comes before the predicted start, and once a peak day is known it
+// closes three days after it. An ovulation confirmed by temperature or
+// anchored by an LH test is kept; otherwise the peak day becomes the
+// ovulation estimate.
+func ApplyMucusPeak(stats CycleStats, logs []models.DailyLog, now time.Time, location *time.Location) CycleStats {
+	if stats.LastPeriodStart.IsZero() {
+		return stats
+	}
+	if location == nil {
+		location = time.UTC
+	}
+
+	cycleStart := DateAtLocation(stats.LastPeriodStart, location)
+	today := DateAtLocation(now.In(location), location)
+	observations := MucusObservations(logs, cycleStart, today, location)
+
+	changed := false
+	for _, observation := range observations {
+		if MucusRank(observation.Mucus) <= MucusRank(models.MucusDry) {
+			continue
+		}
+		if stats.FertilityWindowStart.IsZero() || observation.Date.Before(stats.FertilityWindowStart) {
+			stats.FertilityWindowStart = observation.Date
+			changed = true
+		}
+		break
+	}
+
+	if peak, ok := DetectMucusPeak(observations); ok {
+		stats.MucusPeakDate = peak
+		windowEnd := peak.AddDate(0, 0, mucusPeakInfertileAfterDays)
+		if !stats.OvulationConfirmed && stats.LHSurgeDate.IsZero() {
+			stats.OvulationDate = peak
+			stats.OvulationExact = true
+			stats.OvulationImpossible = false
+			stats.FertilityWindowEnd = windowEnd
+		} else if windowEnd.After(stats.FertilityWindowEnd) {
+			stats.FertilityWindowEnd = windowEnd
+		}
+		if stats.FertilityWindowStart.IsZero() || stats.FertilityWindowStart.After(peak) {
+			stats.FertilityWindowStart = peak.AddDate(0, 0, -5)
+		}
+		changed = true
+	}
+
+	if !changed {
+		return stats
+	}
+	if stats.FertilityWindowStart.Before(cycleStart) {
+		stats.FertilityWindowStart = cycleStart
+	}
+	stats.CurrentPhase = DetectCurrentPhase(stats, logs, today, location)
+	return stats
+}
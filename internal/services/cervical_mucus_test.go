@@ -0,0 +1,186 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func mucusLogs(t *testing.T, start string, observations []string) []models.DailyLog {
+	t.Helper()
+
+	first := mustParseDay(t, start)
+	logs := make([]models.DailyLog, 0, len(observations))
+	for index, mucus := range observations {
+		logs = append(logs, models.DailyLog{Date: first.AddDate(0, 0, index), Flow: models.FlowNone, Mucus: mucus})
+	}
+	return logs
+}
+
+func TestDetectMucusPeak(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		observations []string
+		wantPeak     string
+	}{
+		{
+			name:         "last egg-white day before drying up",
+			observations: []string{"dry", "sticky", "creamy", "watery", "egg_white", "egg_white", "sticky", "dry"},
+			wantPeak:     "2026-03-06",
+		},
+		{
+			name:         "watery counts as peak type",
+			observations: []string{"dry", "creamy", "watery", "creamy"},
+			wantPeak:     "2026-03-03",
+		},
+		{
+			name:         "unobserved days are skipped",
+			observations: []string{"sticky", "egg_white", "", "", "dry"},
+			wantPeak:     "2026-03-02",
+		},
+		{
+			name:         "later fertile patch moves the peak",
+			observations: []string{"watery", "sticky", "creamy", "egg_white", "dry"},
+			wantPeak:     "2026-03-04",
+		},
+		{
+			name:         "peak not confirmed while still egg-white",
+			observations: []string{"dry", "creamy", "egg_white", "egg_white"},
+		},
+		{
+			name:         "no peak-type mucus",
+			observations: []string{"dry", "sticky", "creamy", "sticky"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			observations := MucusObservations(mucusLogs(t, "2026-03-01", testCase.observations), time.Time{}, time.Time{}, time.UTC)
+			peak, ok := DetectMucusPeak(observations)
+			if ok != (testCase.wantPeak != "") {
+				t.Fatalf("expected peak %q, got %v (found=%t)", testCase.wantPeak, peak, ok)
+			}
+			if ok && peak.Format("2006-01-02") != testCase.wantPeak {
+				t.Fatalf("expected peak %s, got %s", testCase.wantPeak, peak.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestApplyMucusPeak(t *testing.T) {
+	t.Parallel()
+
+	baseline := CycleStats{
+		LastPeriodStart:      mustParseDay(t, "2026-03-01"),
+		OvulationDate:        mustParseDay(t, "2026-03-15"),
+		FertilityWindowStart: mustParseDay(t, "2026-03-10"),
+		FertilityWindowEnd:   mustParseDay(t, "2026-03-16"),
+	}
+	observations := []string{"dry", "sticky", "creamy", "watery", "egg_white", "sticky", "dry"}
+
+	testCases := []struct {
+		name          string
+		stats         func() CycleStats
+		now           string
+		wantStart     string
+		wantEnd       string
+		wantOvulation string
+		wantPeak      string
+	}{
+		{
+			name:          "peak replaces the estimated ovulation",
+			stats:         func() CycleStats { return baseline },
+			now:           "2026-03-20",
+			wantStart:     "2026-03-08",
+			wantEnd:       "2026-03-14",
+			wantOvulation: "2026-03-11",
+			wantPeak:      "2026-03-11",
+		},
+		{
+			name: "temperature confirmation keeps its ovulation day",
+			stats: func() CycleStats {
+				stats := baseline
+				stats.OvulationDate = mustParseDay(t, "2026-03-12")
+				stats.OvulationConfirmed = true
+				stats.FertilityWindowStart = mustParseDay(t, "2026-03-08")
+				stats.FertilityWindowEnd = mustParseDay(t, "2026-03-13")
+				return stats
+			},
+			now:           "2026-03-20",
+			wantStart:     "2026-03-08",
+			wantEnd:       "2026-03-14",
+			wantOvulation: "2026-03-12",
+			wantPeak:      "2026-03-11",
+		},
+		{
+			name: "positive LH test keeps its ovulation day",
+			stats: func() CycleStats {
+				stats := baseline
+				stats.LHSurgeDate = mustParseDay(t, "2026-03-12")
+				stats.OvulationDate = mustParseDay(t, "2026-03-13")
+				stats.FertilityWindowStart = mustParseDay(t, "2026-03-08")
+				stats.FertilityWindowEnd = mustParseDay(t, "2026-03-14")
+				return stats
+			},
+			now:           "2026-03-20",
+			wantStart:     "2026-03-08",
+			wantEnd:       "2026-03-14",
+			wantOvulation: "2026-03-13",
+			wantPeak:      "2026-03-11",
+		},
+		{
+			name:          "early mucus opens the window before the peak is known",
+			stats:         func() CycleStats { return baseline },
+			now:           "2026-03-11",
+			wantStart:     "2026-03-08",
+			wantEnd:       "2026-03-16",
+			wantOvulation: "2026-03-15",
+		},
+		{
+			name: "no observations leave stats alone",
+			stats: func() CycleStats {
+				stats := baseline
+				stats.LastPeriodStart = mustParseDay(t, "2026-03-19")
+				return stats
+			},
+			now:           "2026-03-25",
+			wantStart:     "2026-03-10",
+			wantEnd:       "2026-03-16",
+			wantOvulation: "2026-03-15",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			logs := mucusLogs(t, "2026-03-07", observations)
+			stats := ApplyMucusPeak(testCase.stats(), logs, mustParseDay(t, testCase.now), time.UTC)
+			if got := stats.FertilityWindowStart.Format("2006-01-02"); got != testCase.wantStart {
+				t.Fatalf("expected window start %s, got %s", testCase.wantStart, got)
+			}
+			if got := stats.FertilityWindowEnd.Format("2006-01-02"); got != testCase.wantEnd {
+				t.Fatalf("expected window end %s, got %s", testCase.wantEnd, got)
+			}
+			if got := stats.OvulationDate.Format("2006-01-02"); got != testCase.wantOvulation {
+				t.Fatalf("expected ovulation %s, got %s", testCase.wantOvulation, got)
+			}
+			if testCase.wantPeak == "" {
+				if !stats.MucusPeakDate.IsZero() {
+					t.Fatalf("did not expect a peak day, got %s", stats.MucusPeakDate)
+				}
+				return
+			}
+			if got := stats.MucusPeakDate.Format("2006-01-02"); got != testCase.wantPeak {
+				t.Fatalf("expected peak %s, got %s", testCase.wantPeak, got)
+			}
+		})
+	}
+}
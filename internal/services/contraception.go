@@ -0,0 +1,369 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+const (
+	// MinContraceptionPackLength and MaxContraceptionPackLength cover
+	// 21-day packs up to extended 84+7 regimens.
+	MinContraceptionPackLength  = 21
+	MaxContraceptionPackLength  = 91
+	MaxContraceptionPlaceboDays = 7
+)
+
+var (
+	ErrSettingsContraceptionMethodInvalid = errors.New("settings contraception method invalid")
+	ErrSettingsContraceptionPackInvalid   = errors.New("settings contraception pack invalid")
+	ErrSettingsContraceptionStartRequired = errors.New("settings contraception start required")
+	ErrSettingsContraceptionStartInvalid  = errors.New("settings contraception start invalid")
+)
+
+type ContraceptionValidationInput struct {
+	Method      string
+	PackLength  int
+	PlaceboDays int
+	StartRaw    string
+}
+
+// PillPackDay is one cell of the pack grid on the dashboard.
+type PillPackDay struct {
+	Date    time.Time
+	Number  int
+	Placebo bool
+	Taken   bool
+	Today   bool
+	Future  bool
+	Missed  bool
+}
+
+// PillPack describes the pack that contains today.
+type PillPack struct {
+	Number       int
+	Start        time.Time
+	End          time.Time
+	PlaceboStart time.Time
+	DailyPill    bool
+	TakenCount   int
+	MissedCount  int
+	Days         []PillPackDay
+}
+
+func NormalizeContraceptionMethod(raw string) string {
+	return strings.ToLower(strings.TrimSpace(raw))
+}
+
+func IsValidContraceptionMethod(method string) bool {
+	switch method {
+	case "", models.ContraceptionCombinedPill, models.ContraceptionProgestinPill, models.ContraceptionPatch, models.ContraceptionRing:
+		return true
+	default:
+		return false
+	}
+}
+
+// ContraceptionTracksDailyPill reports whether the method is taken daily, so
+// untaken active days count as missed pills.
+func ContraceptionTracksDailyPill(method string) bool {
+	return method == models.ContraceptionCombinedPill || method == models.ContraceptionProgestinPill
+}
+
+// ContraceptionActive reports whether the profile covers day.
+func ContraceptionActive(profile models.ContraceptionProfile, day time.Time, location *time.Location) bool {
+	if profile.Method == "" || profile.Start == nil {
+		return false
+	}
+	if location == nil {
+		location = time.UTC
+	}
+	return !DateAtLocation(day, location).Before(DateAtLocation(*profile.Start, location))
+}
+
+// ContraceptionPackDay returns the 1-based pack number and day within the
+// pack for day, counting packs back to back from the profile start.
+func ContraceptionPackDay(profile models.ContraceptionProfile, day time.Time, location *time.Location) (int, int, bool) {
+	if !ContraceptionActive(profile, day, location) {
+		return 0, 0, false
+	}
+	if location == nil {
+		location = time.UTC
+	}
+	packLength, _ := contraceptionPackLayout(profile)
+	elapsed := calendarDaysBetween(DateAtLocation(*profile.Start, location), DateAtLocation(day, location))
+	return elapsed/packLength + 1, elapsed%packLength + 1, true
+}
+
+// IsPlaceboDay reports whether day falls into the placebo or hormone-free
+// days at the end of a pack.
+func IsPlaceboDay(profile models.ContraceptionProfile, day time.Time, location *time.Location) bool {
+	_, packDay, ok := ContraceptionPackDay(profile, day, location)
+	if !ok {
+		return false
+	}
+	packLength, placeboDays := contraceptionPackLayout(profile)
+	return packDay > packLength-placeboDays
+}
+
+// MaskWithdrawalBleeding returns a copy of logs in which bleeding on placebo
+// days no longer counts as a period, so it does not start a natural cycle.
+// A bleed that begins in the placebo days stays masked for as long as it
+// runs on, including into the first active days of the next pack.
+func MaskWithdrawalBleeding(user *models.User, logs []models.DailyLog, location *time.Location) []models.DailyLog {
+	if user == nil || user.Contraception.Method == "" || user.Contraception.Start == nil {
+		return logs
+	}
+	if location == nil {
+		location = time.UTC
+	}
+	profile := user.Contraception
+	masked := make([]models.DailyLog, len(logs))
+	copy(masked, logs)
+
+	order := make([]int, len(masked))
+	for index := range order {
+		order[index] = index
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return masked[order[i]].Date.Before(masked[order[j]].Date)
+	})
+
+	var lastMasked time.Time
+	for _, index := range order {
+		if !masked[index].IsPeriod {
+			continue
+		}
+		day := DateAtLocation(masked[index].Date, location)
+		continuesBleed := !lastMasked.IsZero() && !day.After(lastMasked.AddDate(0, 0, 1))
+		if continuesBleed || IsPlaceboDay(profile, day, location) {
+			masked[index].IsPeriod = false
+			lastMasked = day
+		}
+	}
+	return masked
+}
+
+// WithdrawalBleeds returns the first bleeding day of every placebo week that
+// has one, oldest first.
+func WithdrawalBleeds(profile models.ContraceptionProfile, logs []models.DailyLog, location *time.Location) []time.Time {
+	if location == nil {
+		location = time.UTC
+	}
+	firstByPack := make(map[int]time.Time)
+	for _, logEntry := range logs {
+		if !logEntry.IsPeriod || !IsPlaceboDay(profile, logEntry.Date, location) {
+			continue
+		}
+		packNumber, _, _ := ContraceptionPackDay(profile, logEntry.Date, location)
+		day := DateAtLocation(logEntry.Date, location)
+		if existing, ok := firstByPack[packNumber]; !ok || day.Before(existing) {
+			firstByPack[packNumber] = day
+		}
+	}
+
+	bleeds := make([]time.Time, 0, len(firstByPack))
+	for _, day := range firstByPack {
+		bleeds = append(bleeds, day)
+	}
+	sort.Slice(bleeds, func(i, j int) bool {
+		return bleeds[i].Before(bleeds[j])
+	})
+	return bleeds
+}
+
+// ApplyContraception replaces the natural-cycle predictions while the
+// profile is active: ovulation and the fertile window are suppressed and the
+// next period becomes the withdrawal bleed expected in the placebo days.
+// logs must be the unmasked logs so withdrawal bleeds can be found.
+func ApplyContraception(user *models.User, stats CycleStats, logs []models.DailyLog, now time.Time, location *time.Location) CycleStats {
+	if user == nil {
+		return stats
+	}
+	if location == nil {
+		location = time.UTC
+	}
+	profile := user.Contraception
+
+	if bleeds := WithdrawalBleeds(profile, logs, location); len(bleeds) > 0 {
+		stats.LastWithdrawalBleed = bleeds[len(bleeds)-1]
+	}
+
+	today := DateAtLocation(now.In(location), location)
+	packNumber, packDay, ok := ContraceptionPackDay(profile, today, location)
+	if !ok {
+		return stats
+	}
+
+	stats.ContraceptionActive = true
+	stats.ContraceptionStart = DateAtLocation(*profile.Start, location)
+	stats.CurrentCycleDay = packDay
+	stats.NextPeriodStart = time.Time{}
+	stats.OvulationDate = time.Time{}
+	stats.OvulationExact = false
+	stats.OvulationConfirmed = false
+	stats.OvulationImpossible = false
+	stats.FertilityWindowStart = time.Time{}
+	stats.FertilityWindowEnd = time.Time{}
+	stats.MucusPeakDate = time.Time{}
+	stats.LHSurgeDate = time.Time{}
+
+	packLength, placeboDays := contraceptionPackLayout(profile)
+	if placeboDays > 0 {
+		packStart := stats.ContraceptionStart.AddDate(0, 0, (packNumber-1)*packLength)
+		if packDay > packLength-placeboDays {
+			// This pack's placebo days have begun, so the next bleed is
+			// expected in the following pack.
+			packStart = packStart.AddDate(0, 0, packLength)
+		}
+		stats.NextWithdrawalBleed = packStart.AddDate(0, 0, packLength-placeboDays)
+	}
+
+	stats.CurrentPhase = "unknown"
+	for _, logEntry := range logs {
+		if logEntry.IsPeriod && sameCalendarDay(DateAtLocation(logEntry.Date, location), today) {
+			stats.CurrentPhase = "menstrual"
+			break
+		}
+	}
+	return stats
+}
+
+// BuildPillPack lays out the pack containing today for the dashboard grid.
+// It returns false when no contraception profile is active.
+func BuildPillPack(profile models.ContraceptionProfile, logs []models.DailyLog, now time.Time, location *time.Location) (PillPack, bool) {
+	if location == nil {
+		location = time.UTC
+	}
+	today := DateAtLocation(now.In(location), location)
+	packNumber, _, ok := ContraceptionPackDay(profile, today, location)
+	if !ok {
+		return PillPack{}, false
+	}
+
+	packLength, placeboDays := contraceptionPackLayout(profile)
+	start := DateAtLocation(*profile.Start, location).AddDate(0, 0, (packNumber-1)*packLength)
+	pack := PillPack{
+		Number:    packNumber,
+		Start:     start,
+		End:       start.AddDate(0, 0, packLength-1),
+		DailyPill: ContraceptionTracksDailyPill(profile.Method),
+		Days:      make([]PillPackDay, 0, packLength),
+	}
+	if placeboDays > 0 {
+		pack.PlaceboStart = start.AddDate(0, 0, packLength-placeboDays)
+	}
+
+	takenByDate := make(map[string]bool)
+	for _, logEntry := range logs {
+		if logEntry.PillTaken {
+			takenByDate[DateAtLocation(logEntry.Date, location).Format("2006-01-02")] = true
+		}
+	}
+
+	for index := 0; index < packLength; index++ {
+		day := start.AddDate(0, 0, index)
+		cell := PillPackDay{
+			Date:    day,
+			Number:  index + 1,
+			Placebo: index >= packLength-placeboDays,
+			Taken:   takenByDate[day.Format("2006-01-02")],
+			Today:   sameCalendarDay(day, today),
+			Future:  day.After(today),
+		}
+		cell.Missed = pack.DailyPill && !cell.Placebo && !cell.Taken && !cell.Today && !cell.Future
+		if cell.Taken {
+			pack.TakenCount++
+		}
+		if cell.Missed {
+			pack.MissedCount++
+		}
+		pack.Days = append(pack.Days, cell)
+	}
+	return pack, true
+}
+
+// ValidateContraception parses the contraception form. An empty method
+// turns the profile off and resets the pack layout.
+func (service *SettingsService) ValidateContraception(input ContraceptionValidationInput, now time.Time, location *time.Location) (models.ContraceptionProfile, error) {
+	if location == nil {
+		location = time.UTC
+	}
+
+	method := NormalizeContraceptionMethod(input.Method)
+	if !IsValidContraceptionMethod(method) {
+		return models.ContraceptionProfile{}, ErrSettingsContraceptionMethodInvalid
+	}
+	profile := models.ContraceptionProfile{
+		PackLength:  models.DefaultPackLength,
+		PlaceboDays: models.DefaultPlaceboDays,
+	}
+	if method == "" {
+		return profile, nil
+	}
+
+	if input.PackLength < MinContraceptionPackLength || input.PackLength > MaxContraceptionPackLength {
+		return models.ContraceptionProfile{}, ErrSettingsContraceptionPackInvalid
+	}
+	if input.PlaceboDays < 0 || input.PlaceboDays > MaxContraceptionPlaceboDays || input.PlaceboDays >= input.PackLength {
+		return models.ContraceptionProfile{}, ErrSettingsContraceptionPackInvalid
+	}
+
+	start, err := parsePregnancyModeDate(input.StartRaw, location, ErrSettingsContraceptionStartInvalid)
+	if err != nil {
+		return models.ContraceptionProfile{}, err
+	}
+	if start == nil {
+		return models.ContraceptionProfile{}, ErrSettingsContraceptionStartRequired
+	}
+	if start.After(DateAtLocation(now.In(location), location)) {
+		return models.ContraceptionProfile{}, ErrSettingsContraceptionStartInvalid
+	}
+
+	profile.Method = method
+	profile.PackLength = input.PackLength
+	profile.PlaceboDays = input.PlaceboDays
+	profile.Start = start
+	return profile, nil
+}
+
+func (service *SettingsService) SaveContraception(userID uint, profile models.ContraceptionProfile) error {
+	return service.users.UpdateByID(userID, map[string]any{
+		"contraception_method":       profile.Method,
+		"contraception_pack_length":  profile.PackLength,
+		"contraception_placebo_days": profile.PlaceboDays,
+		"contraception_start":        optionalDateValue(profile.Start),
+	})
+}
+
+func (service *SettingsService) ApplyContraceptionSettings(user *models.User, profile models.ContraceptionProfile) {
+	if user == nil {
+		return
+	}
+	user.Contraception = profile
+}
+
+// contraceptionPackLayout returns the pack length and placebo days, falling
+// back to a 21+7 pack for rows written before the columns existed.
+func contraceptionPackLayout(profile models.ContraceptionProfile) (int, int) {
+	packLength := profile.PackLength
+	placeboDays := profile.PlaceboDays
+	if packLength < MinContraceptionPackLength || packLength > MaxContraceptionPackLength {
+		packLength = models.DefaultPackLength
+	}
+	if placeboDays < 0 || placeboDays > MaxContraceptionPlaceboDays || placeboDays >= packLength {
+		placeboDays = models.DefaultPlaceboDays
+	}
+	return packLength, placeboDays
+}
+
+// calendarDaysBetween counts calendar days from one date to another,
+// ignoring daylight saving shifts between them.
+func calendarDaysBetween(from time.Time, to time.Time) int {
+	fromDay := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDay := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDay.Sub(fromDay).Hours() / 24)
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func contraceptionTestUser(t *testing.T, method string, start string) *models.User {
+	t.Helper()
+
+	user := &models.User{Role: models.RoleOwner, CycleLength: 28, PeriodLength: 5}
+	user.Contraception = models.ContraceptionProfile{
+		Method:      method,
+		PackLength:  models.DefaultPackLength,
+		PlaceboDays: models.DefaultPlaceboDays,
+	}
+	if start != "" {
+		day := mustParseDay(t, start)
+		user.Contraception.Start = &day
+	}
+	return user
+}
+
+func TestContraceptionPackDay(t *testing.T) {
+	t.Parallel()
+
+	profile := contraceptionTestUser(t, models.ContraceptionCombinedPill, "2026-01-01").Contraception
+
+	testCases := []struct {
+		day         string
+		wantPack    int
+		wantDay     int
+		wantPlacebo bool
+		wantActive  bool
+	}{
+		{day: "2025-12-31"},
+		{day: "2026-01-01", wantPack: 1, wantDay: 1, wantActive: true},
+		{day: "2026-01-21", wantPack: 1, wantDay: 21, wantActive: true},
+		{day: "2026-01-22", wantPack: 1, wantDay: 22, wantPlacebo: true, wantActive: true},
+		{day: "2026-01-28", wantPack: 1, wantDay: 28, wantPlacebo: true, wantActive: true},
+		{day: "2026-01-29", wantPack: 2, wantDay: 1, wantActive: true},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.day, func(t *testing.T) {
+			t.Parallel()
+
+			day := mustParseDay(t, testCase.day)
+			pack, packDay, ok := ContraceptionPackDay(profile, day, time.UTC)
+			if ok != testCase.wantActive || pack != testCase.wantPack || packDay != testCase.wantDay {
+				t.Fatalf("expected pack %d day %d (active=%t), got pack %d day %d (active=%t)", testCase.wantPack, testCase.wantDay, testCase.wantActive, pack, packDay, ok)
+			}
+			if got := IsPlaceboDay(profile, day, time.UTC); got != testCase.wantPlacebo {
+				t.Fatalf("expected placebo=%t, got %t", testCase.wantPlacebo, got)
+			}
+		})
+	}
+}
+
+func TestWithdrawalBleedingDoesNotStartCycles(t *testing.T) {
+	t.Parallel()
+
+	user := contraceptionTestUser(t, models.ContraceptionCombinedPill, "2026-01-01")
+	logs := []models.DailyLog{
+		makeLog(t, "2025-11-05", true),
+		makeLog(t, "2025-12-03", true),
+		makeLog(t, "2026-01-23", true),
+		makeLog(t, "2026-01-24", true),
+		makeLog(t, "2026-02-20", true),
+		makeLog(t, "2026-02-21", true),
+	}
+
+	starts := DetectCycleStarts(MaskWithdrawalBleeding(user, logs, time.UTC))
+	if len(starts) != 2 || starts[1].Format("2006-01-02") != "2025-12-03" {
+		t.Fatalf("expected only the natural cycle starts, got %v", starts)
+	}
+
+	bleeds := WithdrawalBleeds(user.Contraception, logs, time.UTC)
+	if len(bleeds) != 2 || bleeds[0].Format("2006-01-02") != "2026-01-23" || bleeds[1].Format("2006-01-02") != "2026-02-20" {
+		t.Fatalf("expected withdrawal bleeds on 2026-01-23 and 2026-02-20, got %v", bleeds)
+	}
+
+	now := mustParseDay(t, "2026-03-01")
+	cycleLogs := MaskWithdrawalBleeding(user, logs, time.UTC)
+	stats := BuildCycleStats(cycleLogs, now)
+	stats = ApplyUserCycleBaseline(user, cycleLogs, stats, now, time.UTC)
+	stats = ApplyContraception(user, stats, logs, now, time.UTC)
+
+	if !stats.ContraceptionActive || stats.CurrentCycleDay != 4 {
+		t.Fatalf("expected pack day 4 with contraception active, got %#v", stats)
+	}
+	if !stats.OvulationDate.IsZero() || !stats.FertilityWindowStart.IsZero() || !stats.NextPeriodStart.IsZero() {
+		t.Fatalf("expected ovulation and fertility predictions suppressed, got %#v", stats)
+	}
+	if stats.NextWithdrawalBleed.Format("2006-01-02") != "2026-03-19" || stats.LastWithdrawalBleed.Format("2006-01-02") != "2026-02-20" {
+		t.Fatalf("expected withdrawal bleeds 2026-02-20 and 2026-03-19, got %#v", stats)
+	}
+
+	context := BuildDashboardCycleContext(user, stats, now, time.UTC)
+	if context.CycleDataStale || !context.DisplayOvulationDate.IsZero() || !context.DisplayNextPeriodStart.Equal(stats.NextWithdrawalBleed) {
+		t.Fatalf("expected withdrawal bleed as the next date without ovulation, got %#v", context)
+	}
+
+	placeboDay := mustParseDay(t, "2026-03-20")
+	placeboStats := ApplyContraception(user, BuildCycleStats(cycleLogs, placeboDay), logs, placeboDay, time.UTC)
+	if placeboStats.NextWithdrawalBleed.Format("2006-01-02") != "2026-04-16" {
+		t.Fatalf("expected the next pack's withdrawal bleed during the placebo days, got %v", placeboStats.NextWithdrawalBleed)
+	}
+
+	fertileStats := BuildCycleStats(nil, now)
+	fertileStats.ContraceptionActive = true
+	fertileStats.ContraceptionStart = mustParseDay(t, "2026-01-01")
+	fertileStats.FertilityWindowStart = mustParseDay(t, "2026-03-10")
+	fertileStats.FertilityWindowEnd = mustParseDay(t, "2026-03-15")
+	fertileStats.OvulationDate = mustParseDay(t, "2026-03-14")
+	for _, day := range BuildCalendarDayStates(nil, mustParseDay(t, "2026-03-01"), nil, fertileStats, now, time.UTC) {
+		if day.IsFertility || day.IsOvulation {
+			t.Fatalf("expected no fertile days while contraception is active, got %#v", day)
+		}
+	}
+}
+
+func TestWithdrawalBleedCrossingPackBoundaryDoesNotStartCycle(t *testing.T) {
+	t.Parallel()
+
+	user := contraceptionTestUser(t, models.ContraceptionCombinedPill, "2026-01-01")
+	logs := []models.DailyLog{
+		makeLog(t, "2025-12-03", true),
+		makeLog(t, "2026-01-30", true),
+		makeLog(t, "2026-01-27", true),
+		makeLog(t, "2026-01-28", true),
+		makeLog(t, "2026-01-29", true),
+		makeLog(t, "2026-01-31", true),
+		makeLog(t, "2026-02-10", true),
+	}
+
+	masked := MaskWithdrawalBleeding(user, logs, time.UTC)
+	for _, logEntry := range masked {
+		day := logEntry.Date.Format("2006-01-02")
+		wantPeriod := day == "2025-12-03" || day == "2026-02-10"
+		if logEntry.IsPeriod != wantPeriod {
+			t.Fatalf("expected period=%t on %s, got %t", wantPeriod, day, logEntry.IsPeriod)
+		}
+	}
+
+	starts := DetectCycleStarts(masked)
+	if len(starts) != 2 || starts[1].Format("2006-01-02") != "2026-02-10" {
+		t.Fatalf("expected the bleed into the new pack not to start a cycle, got %v", starts)
+	}
+}
+
+func TestBuildPillPack(t *testing.T) {
+	t.Parallel()
+
+	user := contraceptionTestUser(t, models.ContraceptionCombinedPill, "2026-01-01")
+	logs := []models.DailyLog{
+		{Date: mustParseDay(t, "2026-02-26"), PillTaken: true},
+		{Date: mustParseDay(t, "2026-02-27"), PillTaken: true},
+		{Date: mustParseDay(t, "2026-03-01"), PillTaken: true},
+	}
+
+	pack, ok := BuildPillPack(user.Contraception, logs, mustParseDay(t, "2026-03-01"), time.UTC)
+	if !ok {
+		t.Fatal("expected a pill pack while contraception is active")
+	}
+	if pack.Number != 3 || pack.Start.Format("2006-01-02") != "2026-02-26" || len(pack.Days) != 28 {
+		t.Fatalf("expected third pack from 2026-02-26 with 28 days, got %#v", pack)
+	}
+	if pack.TakenCount != 3 || pack.MissedCount != 1 || !pack.Days[2].Missed || !pack.Days[3].Today {
+		t.Fatalf("expected 3 taken and 2026-02-28 missed, got %#v", pack)
+	}
+	if !pack.Days[21].Placebo || pack.Days[20].Placebo || pack.PlaceboStart.Format("2006-01-02") != "2026-03-19" {
+		t.Fatalf("expected placebo days from day 22, got %#v", pack)
+	}
+
+	if _, ok := BuildPillPack(contraceptionTestUser(t, "", "").Contraception, logs, mustParseDay(t, "2026-03-01"), time.UTC); ok {
+		t.Fatal("expected no pill pack without a contraception profile")
+	}
+}
+
+func TestValidateContraception(t *testing.T) {
+	t.Parallel()
+
+	service := NewSettingsService(nil)
+	now := mustParseDay(t, "2026-03-01")
+
+	testCases := []struct {
+		name    string
+		input   ContraceptionValidationInput
+		wantErr error
+	}{
+		{name: "combined pill", input: ContraceptionValidationInput{Method: "combined_pill", PackLength: 28, PlaceboDays: 7, StartRaw: "2026-01-01"}},
+		{name: "extended regimen", input: ContraceptionValidationInput{Method: "combined_pill", PackLength: 91, PlaceboDays: 7, StartRaw: "2026-01-01"}},
+		{name: "progestin pill without placebo", input: ContraceptionValidationInput{Method: "progestin_pill", PackLength: 28, PlaceboDays: 0, StartRaw: "2026-01-01"}},
+		{name: "no method clears the profile", input: ContraceptionValidationInput{Method: ""}},
+		{name: "unknown method", input: ContraceptionValidationInput{Method: "implant"}, wantErr: ErrSettingsContraceptionMethodInvalid},
+		{name: "pack too short", input: ContraceptionValidationInput{Method: "patch", PackLength: 14, PlaceboDays: 7, StartRaw: "2026-01-01"}, wantErr: ErrSettingsContraceptionPackInvalid},
+		{name: "too many placebo days", input: ContraceptionValidationInput{Method: "ring", PackLength: 28, PlaceboDays: 8, StartRaw: "2026-01-01"}, wantErr: ErrSettingsContraceptionPackInvalid},
+		{name: "start required", input: ContraceptionValidationInput{Method: "combined_pill", PackLength: 28, PlaceboDays: 7}, wantErr: ErrSettingsContraceptionStartRequired},
+		{name: "future start", input: ContraceptionValidationInput{Method: "combined_pill", PackLength: 28, PlaceboDays: 7, StartRaw: "2026-03-02"}, wantErr: ErrSettingsContraceptionStartInvalid},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			profile, err := service.ValidateContraception(testCase.input, now, time.UTC)
+			if testCase.wantErr != nil {
+				if !errors.Is(err, testCase.wantErr) {
+					t.Fatalf("expected %v, got %v", testCase.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if profile.Method != testCase.input.Method {
+				t.Fatalf("expected method %q, got %#v", testCase.input.Method, profile)
+			}
+			if profile.Method == "" && (profile.Start != nil || profile.PackLength != models.DefaultPackLength) {
+				t.Fatalf("expected a cleared profile, got %#v", profile)
+			}
+			if profile.Method != "" && (profile.Start == nil || profile.PackLength != testCase.input.PackLength || profile.PlaceboDays != testCase.input.PlaceboDays) {
+				t.Fatalf("expected the submitted pack layout, got %#v", profile)
+			}
+		})
+	}
+}
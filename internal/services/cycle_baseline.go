@@ -33,11 +33,12 @@ func ApplyUserCycleBaseline(user *models.User, logs []models.DailyLog, stats Cyc
 		periodLength = models.DefaultPeriodLength
 	}
 
-	reliableCycleData := len(CycleLengths(logs)) >= 2
+	reliableCycleData := len(CycleLengthsExcluding(logs, ExcludedCycleSpans(user, now, location))) >= 2
 	if !reliableCycleData {
 		if cycleLength > 0 {
 			stats.AverageCycleLength = float64(cycleLength)
 			stats.MedianCycleLength = cycleLength
+			stats.PredictedCycleLength = cycleLength
 		}
 		if periodLength > 0 {
 			stats.AveragePeriodLength = float64(periodLength)
@@ -62,6 +63,7 @@ func ApplyUserCycleBaseline(user *models.User, logs []models.DailyLog, stats Cyc
 			stats.LastPeriodStart,
 			cycleLength,
 			predictedPeriodLength,
+			stats.LutealPhaseLength,
 		)
 		if !ovulationCalculable {
 			stats.OvulationDate = time.Time{}
@@ -105,7 +107,7 @@ func DetectCurrentPhase(stats CycleStats, logs []models.DailyLog, today time.Tim
 	}
 	periodByDate := make(map[string]bool, len(logs))
 	for _, logEntry := range logs {
-		if logEntry.IsPeriod {
+		if IsMenstrualDay(logEntry) {
 			periodByDate[DateAtLocation(logEntry.Date, location).Format("2006-01-02")] = true
 		}
 	}
@@ -3,6 +3,8 @@ package services
 import (
 	"testing"
 	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
 )
 
 func TestCalcOvulationDay(t *testing.T) {
@@ -12,20 +14,26 @@ func TestCalcOvulationDay(t *testing.T) {
 		name         string
 		cycleLength  int
 		periodLength int
+		lutealLength int
 		wantDay      int
 		wantExact    bool
 	}{
-		{name: "regular cycle", cycleLength: 28, periodLength: 5, wantDay: 14, wantExact: true},
-		{name: "short cycle approximate", cycleLength: 15, periodLength: 7, wantDay: 8, wantExact: false},
-		{name: "incompatible short cycle", cycleLength: 15, periodLength: 8, wantDay: 0, wantExact: false},
-		{name: "incompatible long period", cycleLength: 21, periodLength: 14, wantDay: 0, wantExact: false},
-		{name: "long cycle long period", cycleLength: 35, periodLength: 14, wantDay: 21, wantExact: true},
+		{name: "regular cycle", cycleLength: 28, periodLength: 5, lutealLength: 14, wantDay: 14, wantExact: true},
+		{name: "short cycle approximate", cycleLength: 15, periodLength: 7, lutealLength: 14, wantDay: 8, wantExact: false},
+		{name: "incompatible short cycle", cycleLength: 15, periodLength: 8, lutealLength: 14, wantDay: 0, wantExact: false},
+		{name: "incompatible long period", cycleLength: 21, periodLength: 14, lutealLength: 14, wantDay: 0, wantExact: false},
+		{name: "long cycle long period", cycleLength: 35, periodLength: 14, lutealLength: 14, wantDay: 21, wantExact: true},
+		{name: "short luteal phase", cycleLength: 28, periodLength: 5, lutealLength: 11, wantDay: 17, wantExact: true},
+		{name: "long luteal phase", cycleLength: 28, periodLength: 5, lutealLength: 16, wantDay: 12, wantExact: true},
+		{name: "long luteal phase short cycle", cycleLength: 21, periodLength: 5, lutealLength: 16, wantDay: 6, wantExact: false},
+		{name: "unset luteal phase", cycleLength: 28, periodLength: 5, lutealLength: 0, wantDay: 14, wantExact: true},
+		{name: "out of range luteal phase", cycleLength: 28, periodLength: 5, lutealLength: 25, wantDay: 14, wantExact: true},
 	}
 
 	for _, testCase := range cases {
 		testCase := testCase
 		t.Run(testCase.name, func(t *testing.T) {
-			gotDay, gotExact := CalcOvulationDay(testCase.cycleLength, testCase.periodLength)
+			gotDay, gotExact := CalcOvulationDay(testCase.cycleLength, testCase.periodLength, testCase.lutealLength)
 			if gotDay != testCase.wantDay {
 				t.Fatalf("expected ovulation day %d, got %d", testCase.wantDay, gotDay)
 			}
@@ -40,7 +48,7 @@ func TestPredictCycleWindow_IncompatibleValues(t *testing.T) {
 	t.Parallel()
 
 	periodStart := mustParseDay(t, "2026-02-10")
-	ovulationDate, fertilityStart, fertilityEnd, exact, calculable := PredictCycleWindow(periodStart, 15, 10)
+	ovulationDate, fertilityStart, fertilityEnd, exact, calculable := PredictCycleWindow(periodStart, 15, 10, models.DefaultLutealPhaseLength)
 
 	if calculable {
 		t.Fatalf("expected incompatible values to be non-calculable")
@@ -60,7 +68,7 @@ func TestPredictCycleWindow_ApproximateForShortRemaining(t *testing.T) {
 	t.Parallel()
 
 	periodStart := mustParseDay(t, "2026-02-10")
-	ovulationDate, fertilityStart, fertilityEnd, exact, calculable := PredictCycleWindow(periodStart, 15, 7)
+	ovulationDate, fertilityStart, fertilityEnd, exact, calculable := PredictCycleWindow(periodStart, 15, 7, models.DefaultLutealPhaseLength)
 
 	if !calculable {
 		t.Fatalf("expected calculable prediction for remaining=8")
@@ -85,7 +93,7 @@ func TestPredictCycleWindow_NormalCycle(t *testing.T) {
 	t.Parallel()
 
 	periodStart := mustParseDay(t, "2026-02-10")
-	ovulationDate, fertilityStart, fertilityEnd, exact, calculable := PredictCycleWindow(periodStart, 28, 5)
+	ovulationDate, fertilityStart, fertilityEnd, exact, calculable := PredictCycleWindow(periodStart, 28, 5, models.DefaultLutealPhaseLength)
 
 	if !calculable {
 		t.Fatalf("expected calculable prediction for regular cycle")
@@ -106,6 +114,28 @@ func TestPredictCycleWindow_NormalCycle(t *testing.T) {
 	assertCyclePredictionInvariants(t, periodStart, 28, 5, ovulationDate, fertilityStart, fertilityEnd)
 }
 
+func TestPredictCycleWindow_PersonalisedLutealPhase(t *testing.T) {
+	t.Parallel()
+
+	periodStart := mustParseDay(t, "2026-02-10")
+	ovulationDate, fertilityStart, fertilityEnd, exact, calculable := PredictCycleWindow(periodStart, 28, 5, 11)
+
+	if !calculable || !exact {
+		t.Fatalf("expected an exact prediction for an 11-day luteal phase")
+	}
+	if got := ovulationDate.Format("2006-01-02"); got != "2026-02-26" {
+		t.Fatalf("expected ovulation 11 days before the next period on 2026-02-26, got %s", got)
+	}
+	if got := fertilityStart.Format("2006-01-02"); got != "2026-02-21" {
+		t.Fatalf("expected fertility start 2026-02-21, got %s", got)
+	}
+	if got := fertilityEnd.Format("2006-01-02"); got != "2026-02-27" {
+		t.Fatalf("expected fertility end 2026-02-27, got %s", got)
+	}
+
+	assertCyclePredictionInvariants(t, periodStart, 28, 5, ovulationDate, fertilityStart, fertilityEnd)
+}
+
 func TestPredictCycleWindow_InvariantsAcrossRanges(t *testing.T) {
 	t.Parallel()
 
@@ -113,13 +143,16 @@ func TestPredictCycleWindow_InvariantsAcrossRanges(t *testing.T) {
 		name           string
 		cycleLength    int
 		periodLength   int
+		lutealLength   int
 		wantCalculable bool
 	}{
-		{name: "incompatible", cycleLength: 15, periodLength: 10, wantCalculable: false},
-		{name: "approximate", cycleLength: 15, periodLength: 7, wantCalculable: true},
-		{name: "regular", cycleLength: 28, periodLength: 5, wantCalculable: true},
-		{name: "long period", cycleLength: 35, periodLength: 14, wantCalculable: true},
-		{name: "max cycle", cycleLength: 90, periodLength: 14, wantCalculable: true},
+		{name: "incompatible", cycleLength: 15, periodLength: 10, lutealLength: 14, wantCalculable: false},
+		{name: "approximate", cycleLength: 15, periodLength: 7, lutealLength: 14, wantCalculable: true},
+		{name: "regular", cycleLength: 28, periodLength: 5, lutealLength: 14, wantCalculable: true},
+		{name: "long period", cycleLength: 35, periodLength: 14, lutealLength: 14, wantCalculable: true},
+		{name: "max cycle", cycleLength: 90, periodLength: 14, lutealLength: 14, wantCalculable: true},
+		{name: "shortest luteal phase", cycleLength: 21, periodLength: 7, lutealLength: MinLutealPhaseLength, wantCalculable: true},
+		{name: "longest luteal phase", cycleLength: 24, periodLength: 7, lutealLength: MaxLutealPhaseLength, wantCalculable: true},
 	}
 
 	periodStart := mustParseDay(t, "2026-02-10")
@@ -130,6 +163,7 @@ func TestPredictCycleWindow_InvariantsAcrossRanges(t *testing.T) {
 				periodStart,
 				testCase.cycleLength,
 				testCase.periodLength,
+				testCase.lutealLength,
 			)
 			if calculable != testCase.wantCalculable {
 				t.Fatalf("expected calculable=%v, got %v", testCase.wantCalculable, calculable)
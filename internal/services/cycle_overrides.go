@@ -0,0 +1,141 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+var ErrInvalidDayCycleOverride = errors.New("invalid day cycle override")
+
+// cycleExclusionReasonOrder is the order reasons are offered in.
+var cycleExclusionReasonOrder = []string{
+	models.CycleExclusionIllness,
+	models.CycleExclusionMedication,
+	models.CycleExclusionPostpartum,
+	models.CycleExclusionOther,
+}
+
+// CycleExclusionReasons lists the reasons a cycle can be excluded for.
+func CycleExclusionReasons() []string {
+	return append([]string(nil), cycleExclusionReasonOrder...)
+}
+
+func IsValidCycleStartOverride(value string) bool {
+	switch value {
+	case "", models.CycleStartOverrideStart, models.CycleStartOverrideNotStart:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidCycleExclusion(value string) bool {
+	if value == "" {
+		return true
+	}
+	for _, reason := range cycleExclusionReasonOrder {
+		if value == reason {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeDayCycleOverride(input DayEntryInput) (DayEntryInput, error) {
+	input.CycleStartOverride = strings.ToLower(strings.TrimSpace(input.CycleStartOverride))
+	input.CycleExclusion = strings.ToLower(strings.TrimSpace(input.CycleExclusion))
+	if !IsValidCycleStartOverride(input.CycleStartOverride) || !IsValidCycleExclusion(input.CycleExclusion) {
+		return input, ErrInvalidDayCycleOverride
+	}
+	return input, nil
+}
+
+func applyDayCycleOverride(entry *models.DailyLog, payload DayEntryInput) {
+	if !payload.CycleOverrideSet {
+		return
+	}
+	entry.CycleStartOverride = payload.CycleStartOverride
+	entry.CycleExclusion = payload.CycleExclusion
+}
+
+// ExcludedCycle is a cycle the owner left out of the statistics. End is the
+// day before the next cycle start, or the last logged day while the cycle is
+// still running.
+type ExcludedCycle struct {
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Reason    string    `json:"reason"`
+	Completed bool      `json:"completed"`
+}
+
+// ExcludedCycles finds the cycles that contain a day with a cycle exclusion,
+// oldest first. The first reason logged in a cycle is reported. Exclusions
+// logged before the first detected cycle start are ignored.
+func ExcludedCycles(logs []models.DailyLog) []ExcludedCycle {
+	sorted := make([]models.DailyLog, 0, len(logs))
+	sorted = append(sorted, logs...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	starts := DetectCycleStarts(sorted)
+	if len(starts) == 0 {
+		return nil
+	}
+	lastLogged := dateOnly(sorted[len(sorted)-1].Date)
+
+	excluded := make([]ExcludedCycle, 0)
+	for _, log := range sorted {
+		if log.CycleExclusion == "" {
+			continue
+		}
+		day := dateOnly(log.Date)
+		index := sort.Search(len(starts), func(i int) bool {
+			return starts[i].After(day)
+		}) - 1
+		if index < 0 {
+			continue
+		}
+		start := starts[index]
+		if len(excluded) > 0 && excluded[len(excluded)-1].Start.Equal(start) {
+			continue
+		}
+
+		cycle := ExcludedCycle{Start: start, End: lastLogged, Reason: log.CycleExclusion}
+		if index+1 < len(starts) {
+			cycle.End = starts[index+1].AddDate(0, 0, -1)
+			cycle.Completed = true
+		}
+		excluded = append(excluded, cycle)
+	}
+	return excluded
+}
+
+// CycleExclusionSpans returns the spans of the cycles excluded in logs, in
+// the form the cycle statistics skip.
+func CycleExclusionSpans(logs []models.DailyLog) []DateSpan {
+	cycles := ExcludedCycles(logs)
+	if len(cycles) == 0 {
+		return nil
+	}
+	spans := make([]DateSpan, 0, len(cycles))
+	for _, cycle := range cycles {
+		spans = append(spans, DateSpan{Start: cycle.Start, End: cycle.End})
+	}
+	return spans
+}
+
+// withLoggedExclusions adds the cycles excluded in logs to excluded.
+func withLoggedExclusions(logs []models.DailyLog, excluded []DateSpan) []DateSpan {
+	logged := CycleExclusionSpans(logs)
+	if len(logged) == 0 {
+		return excluded
+	}
+	merged := make([]DateSpan, 0, len(excluded)+len(logged))
+	merged = append(merged, excluded...)
+	return append(merged, logged...)
+}
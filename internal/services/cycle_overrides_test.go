@@ -0,0 +1,108 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func cycleOverrideTestLogs(t *testing.T) []models.DailyLog {
+	t.Helper()
+
+	period := func(day string) models.DailyLog {
+		return models.DailyLog{Date: mustParseDay(t, day), IsPeriod: true, Flow: models.FlowMedium}
+	}
+	return []models.DailyLog{
+		period("2026-01-01"),
+		period("2026-01-29"),
+		period("2026-02-26"),
+		period("2026-03-26"),
+		period("2026-04-23"),
+	}
+}
+
+func TestDetectCycleStartsHonoursOverrides(t *testing.T) {
+	logs := cycleOverrideTestLogs(t)
+	logs[2].CycleStartOverride = models.CycleStartOverrideNotStart
+	logs = append(logs, models.DailyLog{Date: mustParseDay(t, "2026-03-10"), CycleStartOverride: models.CycleStartOverrideStart})
+
+	starts := DetectCycleStarts(logs)
+	got := make([]string, 0, len(starts))
+	for _, start := range starts {
+		got = append(got, start.Format("2006-01-02"))
+	}
+	want := []string{"2026-01-01", "2026-01-29", "2026-03-10", "2026-03-26", "2026-04-23"}
+	if len(got) != len(want) {
+		t.Fatalf("expected starts %v, got %v", want, got)
+	}
+	for index := range want {
+		if got[index] != want[index] {
+			t.Fatalf("expected starts %v, got %v", want, got)
+		}
+	}
+}
+
+func TestCycleExclusionLeavesCycleOutOfLengthsAndTrend(t *testing.T) {
+	logs := cycleOverrideTestLogs(t)
+	logs = append(logs, models.DailyLog{Date: mustParseDay(t, "2026-02-10"), CycleExclusion: models.CycleExclusionIllness})
+	logs[1].Date = mustParseDay(t, "2026-02-04")
+
+	if lengths := CycleLengths(logs); len(lengths) != 3 || lengths[0] != 34 || lengths[1] != 28 {
+		t.Fatalf("expected the excluded 22-day cycle to be skipped, got %#v", lengths)
+	}
+	trend := CompletedCycleTrendLengths(logs, mustParseDay(t, "2026-05-01"), time.UTC)
+	if len(trend) != 3 || trend[1] != 28 {
+		t.Fatalf("expected the trend to skip the excluded cycle, got %#v", trend)
+	}
+
+	stats := BuildCycleStats(logs, mustParseDay(t, "2026-05-01"))
+	if stats.AverageCycleLength != float64(34+28+28)/3 {
+		t.Fatalf("expected the average to ignore the excluded cycle, got %v", stats.AverageCycleLength)
+	}
+}
+
+func TestExcludedCyclesReportsReasonAndRange(t *testing.T) {
+	logs := cycleOverrideTestLogs(t)
+	logs = append(logs,
+		models.DailyLog{Date: mustParseDay(t, "2025-12-20"), CycleExclusion: models.CycleExclusionOther},
+		models.DailyLog{Date: mustParseDay(t, "2026-02-01"), CycleExclusion: models.CycleExclusionMedication},
+		models.DailyLog{Date: mustParseDay(t, "2026-02-15"), CycleExclusion: models.CycleExclusionIllness},
+		models.DailyLog{Date: mustParseDay(t, "2026-04-30"), CycleExclusion: models.CycleExclusionPostpartum},
+	)
+
+	cycles := ExcludedCycles(logs)
+	if len(cycles) != 2 {
+		t.Fatalf("expected two excluded cycles, got %#v", cycles)
+	}
+	first := cycles[0]
+	if first.Start.Format("2006-01-02") != "2026-01-29" || first.End.Format("2006-01-02") != "2026-02-25" || first.Reason != models.CycleExclusionMedication || !first.Completed {
+		t.Fatalf("unexpected first excluded cycle %#v", first)
+	}
+	current := cycles[1]
+	if current.Start.Format("2006-01-02") != "2026-04-23" || current.End.Format("2006-01-02") != "2026-04-30" || current.Completed {
+		t.Fatalf("unexpected current excluded cycle %#v", current)
+	}
+}
+
+func TestNormalizeDayEntryInputValidatesCycleOverride(t *testing.T) {
+	input, err := NormalizeDayEntryInput(DayEntryInput{
+		Flow:               models.FlowNone,
+		CycleOverrideSet:   true,
+		CycleStartOverride: " Start ",
+		CycleExclusion:     "ILLNESS",
+	})
+	if err != nil || input.CycleStartOverride != models.CycleStartOverrideStart || input.CycleExclusion != models.CycleExclusionIllness {
+		t.Fatalf("expected normalized overrides, got %#v (%v)", input, err)
+	}
+
+	for _, invalid := range []DayEntryInput{
+		{Flow: models.FlowNone, CycleOverrideSet: true, CycleStartOverride: "maybe"},
+		{Flow: models.FlowNone, CycleOverrideSet: true, CycleExclusion: "holiday"},
+	} {
+		if _, err := NormalizeDayEntryInput(invalid); !errors.Is(err, ErrInvalidDayCycleOverride) {
+			t.Fatalf("expected ErrInvalidDayCycleOverride for %#v, got %v", invalid, err)
+		}
+	}
+}
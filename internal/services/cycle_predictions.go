@@ -0,0 +1,117 @@
+package services
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	DefaultPredictionCycles = 6
+	MinPredictionCycles     = 3
+	MaxPredictionCycles     = 12
+
+	// defaultCycleLengthDeviation is used until at least two cycle lengths
+	// are known, so early predictions still carry a visible range.
+	defaultCycleLengthDeviation = 2.0
+)
+
+// CyclePrediction is one projected future cycle. The predicted start may
+// fall anywhere between EarliestStart and LatestStart; the range widens with
+// every cycle projected further ahead.
+type CyclePrediction struct {
+	Cycle                int       `json:"cycle"`
+	PeriodStart          time.Time `json:"period_start"`
+	PeriodEnd            time.Time `json:"period_end"`
+	EarliestStart        time.Time `json:"earliest_start"`
+	LatestStart          time.Time `json:"latest_start"`
+	UncertaintyDays      int       `json:"uncertainty_days"`
+	OvulationDate        time.Time `json:"ovulation_date"`
+	OvulationExact       bool      `json:"ovulation_exact"`
+	OvulationImpossible  bool      `json:"ovulation_impossible"`
+	FertilityWindowStart time.Time `json:"fertility_window_start"`
+	FertilityWindowEnd   time.Time `json:"fertility_window_end"`
+}
+
+// NormalizePredictionCycles maps a requested horizon to the supported
+// 3..12 range. Zero selects the default; other out-of-range values are
+// reported as invalid.
+func NormalizePredictionCycles(cycles int) (int, bool) {
+	if cycles == 0 {
+		return DefaultPredictionCycles, true
+	}
+	if cycles < MinPredictionCycles || cycles > MaxPredictionCycles {
+		return 0, false
+	}
+	return cycles, true
+}
+
+// BuildCyclePredictions projects count cycles forward from
+// stats.NextPeriodStart. The uncertainty of cycle k is the cycle-length
+// standard deviation scaled by sqrt(k), since every projected cycle adds its
+// own independent variation to the start date.
+func BuildCyclePredictions(stats CycleStats, count int, location *time.Location) []CyclePrediction {
+	if stats.NextPeriodStart.IsZero() || count <= 0 {
+		return []CyclePrediction{}
+	}
+	if location == nil {
+		location = time.UTC
+	}
+
+	cycleLength, periodLength := PredictionLengths(stats)
+	deviation := stats.CycleLengthDeviation
+	if deviation <= 0 {
+		deviation = defaultCycleLengthDeviation
+	}
+
+	predictions := make([]CyclePrediction, 0, count)
+	cycleStart := DateAtLocation(stats.NextPeriodStart, location)
+	for index := 1; index <= count; index++ {
+		uncertainty := predictionUncertaintyDays(deviation, index, cycleLength)
+		prediction := CyclePrediction{
+			Cycle:           index,
+			PeriodStart:     cycleStart,
+			PeriodEnd:       cycleStart.AddDate(0, 0, periodLength-1),
+			EarliestStart:   cycleStart.AddDate(0, 0, -uncertainty),
+			LatestStart:     cycleStart.AddDate(0, 0, uncertainty),
+			UncertaintyDays: uncertainty,
+		}
+
+		ovulationDate, fertilityStart, fertilityEnd, exact, calculable := PredictCycleWindow(cycleStart, cycleLength, periodLength, stats.LutealPhaseLength)
+		if calculable {
+			prediction.OvulationDate = ovulationDate
+			prediction.OvulationExact = exact
+			prediction.FertilityWindowStart = fertilityStart
+			prediction.FertilityWindowEnd = fertilityEnd
+		} else {
+			prediction.OvulationImpossible = true
+		}
+
+		predictions = append(predictions, prediction)
+		cycleStart = cycleStart.AddDate(0, 0, cycleLength)
+	}
+	return predictions
+}
+
+func predictionUncertaintyDays(deviation float64, cycle int, cycleLength int) int {
+	days := int(math.Ceil(deviation * math.Sqrt(float64(cycle))))
+	if days < 1 {
+		days = 1
+	}
+	if limit := cycleLength / 2; limit > 0 && days > limit {
+		days = limit
+	}
+	return days
+}
+
+func standardDeviationInts(values []int) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+	mean := averageInts(values)
+	var sum float64
+	for _, value := range values {
+		delta := float64(value) - mean
+		sum += delta * delta
+	}
+	return math.Sqrt(sum / float64(len(values)-1))
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func TestBuildCyclePredictions(t *testing.T) {
+	t.Parallel()
+
+	nextPeriod := time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name            string
+		stats           CycleStats
+		count           int
+		wantStarts      []string
+		wantUncertainty []int
+	}{
+		{
+			name:            "default deviation without history",
+			stats:           CycleStats{NextPeriodStart: nextPeriod},
+			count:           3,
+			wantStarts:      []string{"2026-04-01", "2026-04-29", "2026-05-27"},
+			wantUncertainty: []int{2, 3, 4},
+		},
+		{
+			name:            "measured deviation widens with distance",
+			stats:           CycleStats{NextPeriodStart: nextPeriod, MedianCycleLength: 30, CycleLengthDeviation: 1.5},
+			count:           4,
+			wantStarts:      []string{"2026-04-01", "2026-05-01", "2026-05-31", "2026-06-30"},
+			wantUncertainty: []int{2, 3, 3, 3},
+		},
+		{
+			name:            "uncertainty is capped at half a cycle",
+			stats:           CycleStats{NextPeriodStart: nextPeriod, MedianCycleLength: 24, CycleLengthDeviation: 9},
+			count:           2,
+			wantStarts:      []string{"2026-04-01", "2026-04-25"},
+			wantUncertainty: []int{9, 12},
+		},
+		{
+			name:  "no next period",
+			stats: CycleStats{MedianCycleLength: 28},
+			count: 6,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			predictions := BuildCyclePredictions(testCase.stats, testCase.count, time.UTC)
+			if len(predictions) != len(testCase.wantStarts) {
+				t.Fatalf("expected %d predictions, got %#v", len(testCase.wantStarts), predictions)
+			}
+			for index, prediction := range predictions {
+				if prediction.Cycle != index+1 {
+					t.Fatalf("expected cycle %d, got %d", index+1, prediction.Cycle)
+				}
+				if got := prediction.PeriodStart.Format("2006-01-02"); got != testCase.wantStarts[index] {
+					t.Fatalf("expected cycle %d to start %s, got %s", index+1, testCase.wantStarts[index], got)
+				}
+				want := testCase.wantUncertainty[index]
+				if prediction.UncertaintyDays != want {
+					t.Fatalf("expected cycle %d uncertainty %d, got %d", index+1, want, prediction.UncertaintyDays)
+				}
+				if !prediction.EarliestStart.Equal(prediction.PeriodStart.AddDate(0, 0, -want)) || !prediction.LatestStart.Equal(prediction.PeriodStart.AddDate(0, 0, want)) {
+					t.Fatalf("expected symmetric range around start, got %#v", prediction)
+				}
+				if prediction.OvulationImpossible || !prediction.OvulationDate.After(prediction.PeriodEnd) {
+					t.Fatalf("expected ovulation after predicted period, got %#v", prediction)
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizePredictionCycles(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		input  int
+		want   int
+		wantOK bool
+	}{
+		{input: 0, want: DefaultPredictionCycles, wantOK: true},
+		{input: 3, want: 3, wantOK: true},
+		{input: 12, want: 12, wantOK: true},
+		{input: 2, wantOK: false},
+		{input: 13, wantOK: false},
+	}
+
+	for _, testCase := range testCases {
+		got, ok := NormalizePredictionCycles(testCase.input)
+		if got != testCase.want || ok != testCase.wantOK {
+			t.Fatalf("NormalizePredictionCycles(%d) = (%d, %t), want (%d, %t)", testCase.input, got, ok, testCase.want, testCase.wantOK)
+		}
+	}
+}
+
+func TestBuildCycleStatsReportsCycleLengthDeviation(t *testing.T) {
+	t.Parallel()
+
+	logs := []models.DailyLog{}
+	for _, day := range []string{"2026-01-01", "2026-01-02", "2026-01-27", "2026-01-28", "2026-02-26", "2026-02-27"} {
+		logs = append(logs, makeLog(t, day, true))
+	}
+	stats := BuildCycleStats(logs, mustParseDay(t, "2026-03-05"))
+
+	// Cycle lengths 26 and 30 give a sample deviation of sqrt(8).
+	if stats.CycleLengthDeviation < 2.82 || stats.CycleLengthDeviation > 2.83 {
+		t.Fatalf("expected deviation close to 2.83, got %f", stats.CycleLengthDeviation)
+	}
+}
@@ -8,18 +8,31 @@ import (
 )
 
 type CycleStats struct {
-	CurrentCycleDay      int       `json:"current_cycle_day"`
-	CurrentPhase         string    `json:"current_phase"`
-	AverageCycleLength   float64   `json:"average_cycle_length"`
-	MedianCycleLength    int       `json:"median_cycle_length"`
-	AveragePeriodLength  float64   `json:"average_period_length"`
-	LastPeriodStart      time.Time `json:"last_period_start"`
-	NextPeriodStart      time.Time `json:"next_period_start"`
-	OvulationDate        time.Time `json:"ovulation_date"`
-	OvulationExact       bool      `json:"ovulation_exact"`
-	OvulationImpossible  bool      `json:"ovulation_impossible"`
-	FertilityWindowStart time.Time `json:"fertility_window_start"`
-	FertilityWindowEnd   time.Time `json:"fertility_window_end"`
+	CurrentCycleDay         int       `json:"current_cycle_day"`
+	CurrentPhase            string    `json:"current_phase"`
+	AverageCycleLength      float64   `json:"average_cycle_length"`
+	MedianCycleLength       int       `json:"median_cycle_length"`
+	CycleLengthDeviation    float64   `json:"cycle_length_deviation"`
+	PredictedCycleLength    int       `json:"predicted_cycle_length"`
+	PredictionAlgorithm     string    `json:"prediction_algorithm"`
+	LutealPhaseLength       int       `json:"luteal_phase_length"`
+	LutealPhasePersonalised bool      `json:"luteal_phase_personalised"`
+	AveragePeriodLength     float64   `json:"average_period_length"`
+	LastPeriodStart         time.Time `json:"last_period_start"`
+	NextPeriodStart         time.Time `json:"next_period_start"`
+	OvulationDate           time.Time `json:"ovulation_date"`
+	OvulationExact          bool      `json:"ovulation_exact"`
+	OvulationConfirmed      bool      `json:"ovulation_confirmed"`
+	OvulationImpossible     bool      `json:"ovulation_impossible"`
+	FertilityWindowStart    time.Time `json:"fertility_window_start"`
+	FertilityWindowEnd      time.Time `json:"fertility_window_end"`
+	MucusPeakDate           time.Time `json:"mucus_peak_date"`
+	LHSurgeDate             time.Time `json:"lh_surge_date"`
+	PredictionsPaused       bool      `json:"predictions_paused"`
+	ContraceptionActive     bool      `json:"contraception_active"`
+	ContraceptionStart      time.Time `json:"-"`
+	NextWithdrawalBleed     time.Time `json:"next_withdrawal_bleed"`
+	LastWithdrawalBleed     time.Time `json:"last_withdrawal_bleed"`
 }
 
 type detectedCycle struct {
@@ -29,7 +42,24 @@ type detectedCycle struct {
 }
 
 func BuildCycleStats(logs []models.DailyLog, now time.Time) CycleStats {
-	stats := CycleStats{CurrentPhase: "unknown"}
+	return BuildCycleStatsExcluding(logs, now, nil)
+}
+
+// BuildCycleStatsExcluding works like BuildCycleStats but leaves cycles that
+// overlap any of the excluded spans out of the length and period averages.
+func BuildCycleStatsExcluding(logs []models.DailyLog, now time.Time, excluded []DateSpan) CycleStats {
+	return BuildCycleStatsWithPredictor(logs, now, excluded, MedianPredictor{}, models.DefaultLutealPhaseLength)
+}
+
+// BuildCycleStatsWithPredictor works like BuildCycleStatsExcluding but lets
+// predictor choose the cycle length the next period is projected with and
+// places ovulation lutealLength days before it.
+func BuildCycleStatsWithPredictor(logs []models.DailyLog, now time.Time, excluded []DateSpan, predictor Predictor, lutealLength int) CycleStats {
+	stats := CycleStats{
+		CurrentPhase:        "unknown",
+		PredictionAlgorithm: predictor.Name(),
+		LutealPhaseLength:   NormalizeLutealPhaseLength(lutealLength),
+	}
 	if len(logs) == 0 {
 		return stats
 	}
@@ -45,13 +75,18 @@ func BuildCycleStats(logs []models.DailyLog, now time.Time) CycleStats {
 		return stats
 	}
 
-	cycles := buildCycles(starts, sorted)
-	lengths := cycleLengths(starts)
+	excluded = withLoggedExclusions(sorted, excluded)
+	cycles := excludeCycles(buildCycles(starts, sorted), excluded)
+	lengths := cycleLengths(starts, excluded)
 	recentLengths := tailInts(lengths, 6)
 
 	if len(recentLengths) > 0 {
 		stats.AverageCycleLength = averageInts(recentLengths)
 		stats.MedianCycleLength = medianInt(recentLengths)
+		stats.CycleLengthDeviation = standardDeviationInts(recentLengths)
+	}
+	if predicted, ok := predictor.PredictCycleLength(lengths); ok {
+		stats.PredictedCycleLength = predicted
 	}
 
 	periodLengths := make([]int, 0, len(cycles))
@@ -66,7 +101,7 @@ func BuildCycleStats(logs []models.DailyLog, now time.Time) CycleStats {
 
 	stats.LastPeriodStart = starts[len(starts)-1]
 
-	predictionCycleLength := stats.MedianCycleLength
+	predictionCycleLength := stats.PredictedCycleLength
 	if predictionCycleLength == 0 {
 		predictionCycleLength = models.DefaultCycleLength
 	}
@@ -81,6 +116,7 @@ func BuildCycleStats(logs []models.DailyLog, now time.Time) CycleStats {
 		stats.LastPeriodStart,
 		predictionCycleLength,
 		predictedPeriodLength,
+		stats.LutealPhaseLength,
 	)
 	if ovulationCalculable {
 		stats.OvulationDate = ovulationDate
@@ -103,7 +139,7 @@ func BuildCycleStats(logs []models.DailyLog, now time.Time) CycleStats {
 
 	periodByDate := make(map[string]bool, len(sorted))
 	for _, log := range sorted {
-		if log.IsPeriod {
+		if IsMenstrualDay(log) {
 			periodByDate[dateOnly(log.Date).Format("2006-01-02")] = true
 		}
 	}
@@ -133,16 +169,20 @@ func BuildCycleStats(logs []models.DailyLog, now time.Time) CycleStats {
 // - ovulation is strictly after period end and before next period start
 // - fertility window never overlaps period days
 // - if the clamped fertility range becomes empty, it is suppressed
-func CalcOvulationDay(cycleLen, periodLen int) (int, bool) {
+//
+// CalcOvulationDay places ovulation lutealLen days before the next period;
+// lengths outside the valid range fall back to the default 14 days.
+func CalcOvulationDay(cycleLen, periodLen, lutealLen int) (int, bool) {
+	lutealLen = NormalizeLutealPhaseLength(lutealLen)
 	remaining := cycleLen - periodLen
 	if remaining < 8 {
 		return 0, false
 	}
-	if remaining < 15 {
+	if remaining <= lutealLen {
 		return periodLen + 1, false
 	}
 
-	ovDay := cycleLen - 14
+	ovDay := cycleLen - lutealLen
 	if ovDay <= periodLen {
 		ovDay = periodLen + 1
 	}
@@ -152,14 +192,14 @@ func CalcOvulationDay(cycleLen, periodLen int) (int, bool) {
 	return ovDay, true
 }
 
-func PredictCycleWindow(periodStart time.Time, cycleLength int, periodLength int) (time.Time, time.Time, time.Time, bool, bool) {
+func PredictCycleWindow(periodStart time.Time, cycleLength int, periodLength int, lutealLength int) (time.Time, time.Time, time.Time, bool, bool) {
 	if periodStart.IsZero() || cycleLength <= 0 {
 		return time.Time{}, time.Time{}, time.Time{}, false, false
 	}
 	if periodLength <= 0 {
 		periodLength = models.DefaultPeriodLength
 	}
-	ovulationDay, ovulationExact := CalcOvulationDay(cycleLength, periodLength)
+	ovulationDay, ovulationExact := CalcOvulationDay(cycleLength, periodLength, lutealLength)
 	if ovulationDay <= 0 {
 		return time.Time{}, time.Time{}, time.Time{}, false, false
 	}
@@ -192,6 +232,16 @@ func PredictCycleWindow(periodStart time.Time, cycleLength int, periodLength int
 	return ovulationDate, fertilityStart, fertilityEnd, ovulationExact, true
 }
 
+// IsMenstrualDay reports whether entry is a period day that counts for cycle
+// detection and period length, which spotting does not.
+func IsMenstrualDay(entry models.DailyLog) bool {
+	return entry.IsPeriod && entry.Flow != models.FlowSpotting
+}
+
+// DetectCycleStarts finds the first days of cycles: a period day after at
+// least five days without bleeding. Manual overrides win, so a day marked as
+// a cycle start always begins a cycle and a period day marked as not a new
+// cycle never does. Spotting is ignored.
 func DetectCycleStarts(logs []models.DailyLog) []time.Time {
 	if len(logs) == 0 {
 		return nil
@@ -208,7 +258,18 @@ func DetectCycleStarts(logs []models.DailyLog) []time.Time {
 
 	for _, log := range sorted {
 		day := dateOnly(log.Date)
-		if !log.IsPeriod {
+		if log.CycleStartOverride == models.CycleStartOverrideStart {
+			if len(starts) == 0 || !starts[len(starts)-1].Equal(day) {
+				starts = append(starts, day)
+			}
+			previousPeriodDay = day
+			continue
+		}
+		if !IsMenstrualDay(log) {
+			continue
+		}
+		if log.CycleStartOverride == models.CycleStartOverrideNotStart {
+			previousPeriodDay = day
 			continue
 		}
 
@@ -229,8 +290,14 @@ func DetectCycleStarts(logs []models.DailyLog) []time.Time {
 }
 
 func CycleLengths(logs []models.DailyLog) []int {
+	return CycleLengthsExcluding(logs, nil)
+}
+
+// CycleLengthsExcluding returns the lengths of completed cycles that do not
+// overlap any of the excluded spans or carry a logged cycle exclusion.
+func CycleLengthsExcluding(logs []models.DailyLog, excluded []DateSpan) []int {
 	starts := DetectCycleStarts(logs)
-	return cycleLengths(starts)
+	return cycleLengths(starts, withLoggedExclusions(logs, excluded))
 }
 
 func buildCycles(starts []time.Time, logs []models.DailyLog) []detectedCycle {
@@ -241,7 +308,7 @@ func buildCycles(starts []time.Time, logs []models.DailyLog) []detectedCycle {
 	isPeriodByDate := make(map[string]bool, len(logs))
 	for _, log := range logs {
 		day := dateOnly(log.Date).Format("2006-01-02")
-		isPeriodByDate[day] = log.IsPeriod
+		isPeriodByDate[day] = IsMenstrualDay(log)
 	}
 
 	cycles := make([]detectedCycle, 0, len(starts))
@@ -269,18 +336,35 @@ func buildCycles(starts []time.Time, logs []models.DailyLog) []detectedCycle {
 	return cycles
 }
 
-func cycleLengths(starts []time.Time) []int {
+func cycleLengths(starts []time.Time, excluded []DateSpan) []int {
 	if len(starts) < 2 {
 		return nil
 	}
 
 	lengths := make([]int, 0, len(starts)-1)
 	for i := 1; i < len(starts); i++ {
+		if spansOverlap(excluded, starts[i-1], starts[i].AddDate(0, 0, -1)) {
+			continue
+		}
 		lengths = append(lengths, int(starts[i].Sub(starts[i-1]).Hours()/24))
 	}
 	return lengths
 }
 
+func excludeCycles(cycles []detectedCycle, excluded []DateSpan) []detectedCycle {
+	if len(excluded) == 0 {
+		return cycles
+	}
+	kept := make([]detectedCycle, 0, len(cycles))
+	for _, cycle := range cycles {
+		if spansOverlap(excluded, cycle.Start, cycle.Start) {
+			continue
+		}
+		kept = append(kept, cycle)
+	}
+	return kept
+}
+
 func tailInts(values []int, n int) []int {
 	if len(values) <= n {
 		return values
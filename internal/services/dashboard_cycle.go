@@ -18,7 +18,13 @@ type DashboardCycleContext struct {
 	OvulationInPast            bool
 }
 
+// DashboardCycleReferenceLength is the cycle length the dashboard compares
+// the current cycle day against. The chosen predictor's length wins; the
+// onboarding value only applies when there is no prediction.
 func DashboardCycleReferenceLength(user *models.User, stats CycleStats) int {
+	if stats.PredictedCycleLength > 0 {
+		return stats.PredictedCycleLength
+	}
 	if user != nil && IsValidOnboardingCycleLength(user.CycleLength) {
 		return user.CycleLength
 	}
@@ -74,6 +80,7 @@ func DashboardUpcomingPredictions(stats CycleStats, user *models.User, today tim
 		cycleStart,
 		cycleLength,
 		predictedPeriodLength,
+		stats.LutealPhaseLength,
 	)
 	if ovulationCalculable && ovulationDate.Before(today) {
 		cycleStart = ShiftCycleStartToFutureOvulation(cycleStart, ovulationDate, cycleLength, today)
@@ -82,6 +89,7 @@ func DashboardUpcomingPredictions(stats CycleStats, user *models.User, today tim
 			cycleStart,
 			cycleLength,
 			predictedPeriodLength,
+			stats.LutealPhaseLength,
 		)
 	}
 	if !ovulationCalculable {
@@ -92,6 +100,15 @@ func DashboardUpcomingPredictions(stats CycleStats, user *models.User, today tim
 
 func BuildDashboardCycleContext(user *models.User, stats CycleStats, today time.Time, location *time.Location) DashboardCycleContext {
 	cycleDayReference := DashboardCycleReferenceLength(user, stats)
+	if stats.PredictionsPaused {
+		return DashboardCycleContext{CycleDayReference: cycleDayReference}
+	}
+	if stats.ContraceptionActive {
+		return DashboardCycleContext{
+			CycleDayReference:      cycleDayReference,
+			DisplayNextPeriodStart: stats.NextWithdrawalBleed,
+		}
+	}
 	cycleDayWarning := DashboardCycleDayLooksLong(stats.CurrentCycleDay, cycleDayReference)
 	cycleStaleAnchor := DashboardCycleStaleAnchor(user, stats, location)
 	cycleDataStale := DashboardCycleDataLooksStale(cycleStaleAnchor, today, cycleDayReference)
@@ -127,10 +144,15 @@ func DashboardPredictedPeriodLength(user *models.User, stats CycleStats) int {
 }
 
 func CompletedCycleTrendLengths(logs []models.DailyLog, now time.Time, location *time.Location) []int {
+	return CompletedCycleTrendLengthsExcluding(logs, now, location, nil)
+}
+
+func CompletedCycleTrendLengthsExcluding(logs []models.DailyLog, now time.Time, location *time.Location, excluded []DateSpan) []int {
 	starts := DetectCycleStarts(logs)
 	if len(starts) < 2 {
 		return nil
 	}
+	excluded = withLoggedExclusions(logs, excluded)
 
 	today := DateAtLocation(now, location)
 	lengths := make([]int, 0, len(starts)-1)
@@ -140,6 +162,9 @@ func CompletedCycleTrendLengths(logs []models.DailyLog, now time.Time, location
 		if !currentStart.Before(today) {
 			break
 		}
+		if spansOverlap(excluded, previousStart, currentStart.AddDate(0, 0, -1)) {
+			continue
+		}
 		lengths = append(lengths, int(currentStart.Sub(previousStart).Hours()/24))
 	}
 	return lengths
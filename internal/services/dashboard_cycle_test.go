@@ -15,6 +15,14 @@ func TestDashboardCycleReferenceLengthPrefersUserValue(t *testing.T) {
 	}
 }
 
+func TestDashboardCycleReferenceLengthPrefersPredictedLength(t *testing.T) {
+	user := &models.User{CycleLength: 29}
+	stats := CycleStats{PredictedCycleLength: 33, MedianCycleLength: 28}
+	if got := DashboardCycleReferenceLength(user, stats); got != 33 {
+		t.Fatalf("expected the predicted length 33, got %d", got)
+	}
+}
+
 func TestDashboardCycleStaleAnchorPrefersUserBaseline(t *testing.T) {
 	userBaseline := time.Date(2026, time.January, 1, 15, 30, 0, 0, time.UTC)
 	statsBaseline := time.Date(2026, time.February, 20, 7, 0, 0, 0, time.UTC)
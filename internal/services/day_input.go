@@ -2,6 +2,7 @@ package services
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/terraincognita07/ovumcy/internal/models"
 )
@@ -18,13 +19,107 @@ func NormalizeDayEntryInput(input DayEntryInput) (DayEntryInput, error) {
 		input.Flow = models.FlowNone
 		input.SymptomIDs = []uint{}
 	}
+	severities, err := NormalizeSymptomSeverities(input.SymptomIDs, input.SymptomSeverities)
+	if err != nil {
+		return input, err
+	}
+	input.SymptomSeverities = severities
 	input.Notes = TrimDayNotes(input.Notes)
+	if input.TemperatureSet {
+		normalized, err := normalizeDayTemperature(input)
+		if err != nil {
+			return input, err
+		}
+		input = normalized
+	}
+	if input.CervicalSet {
+		normalized, err := normalizeDayCervical(input)
+		if err != nil {
+			return input, err
+		}
+		input = normalized
+	}
+	if input.TestsSet {
+		normalized, err := normalizeDayTests(input)
+		if err != nil {
+			return input, err
+		}
+		input = normalized
+	}
+	if input.IntimacySet {
+		normalized, err := normalizeDayIntimacy(input)
+		if err != nil {
+			return input, err
+		}
+		input = normalized
+	}
+	if input.CycleOverrideSet {
+		normalized, err := normalizeDayCycleOverride(input)
+		if err != nil {
+			return input, err
+		}
+		input = normalized
+	}
+	return input, nil
+}
+
+// normalizeDayTests validates the test results. The brand note is dropped
+// when no test was taken.
+func normalizeDayTests(input DayEntryInput) (DayEntryInput, error) {
+	input.LHTest = NormalizeTestResult(input.LHTest)
+	input.PregnancyTest = NormalizeTestResult(input.PregnancyTest)
+	if !IsValidLHTest(input.LHTest) {
+		return input, ErrInvalidDayLHTest
+	}
+	if !IsValidPregnancyTest(input.PregnancyTest) {
+		return input, ErrInvalidDayPregnancyTest
+	}
+	input.TestBrand = TrimTestBrand(input.TestBrand)
+	if input.LHTest == "" && input.PregnancyTest == "" {
+		input.TestBrand = ""
+	}
+	return input, nil
+}
+
+func normalizeDayCervical(input DayEntryInput) (DayEntryInput, error) {
+	input.Mucus = NormalizeCervicalValue(input.Mucus)
+	input.CervixPosition = NormalizeCervicalValue(input.CervixPosition)
+	input.CervixFirmness = NormalizeCervicalValue(input.CervixFirmness)
+	if !IsValidMucus(input.Mucus) {
+		return input, ErrInvalidDayMucus
+	}
+	if !IsValidCervixPosition(input.CervixPosition) || !IsValidCervixFirmness(input.CervixFirmness) {
+		return input, ErrInvalidDayCervix
+	}
+	return input, nil
+}
+
+// normalizeDayTemperature converts the reading to Celsius. A zero
+// temperature clears the reading together with its time and flag.
+func normalizeDayTemperature(input DayEntryInput) (DayEntryInput, error) {
+	input.TemperatureTime = strings.TrimSpace(input.TemperatureTime)
+	if input.Temperature == 0 {
+		input.TemperatureTime = ""
+		input.TemperatureDisturbed = false
+		input.TemperatureUnit = models.TemperatureUnitCelsius
+		return input, nil
+	}
+
+	celsius := TemperatureToCelsius(input.Temperature, input.TemperatureUnit)
+	if !IsValidBBTCelsius(celsius) {
+		return input, ErrInvalidDayTemperature
+	}
+	if !IsValidBBTTime(input.TemperatureTime) {
+		return input, ErrInvalidDayTemperatureTime
+	}
+	input.Temperature = celsius
+	input.TemperatureUnit = models.TemperatureUnitCelsius
 	return input, nil
 }
 
 func IsValidDayFlow(flow string) bool {
 	switch flow {
-	case models.FlowNone, models.FlowLight, models.FlowMedium, models.FlowHeavy:
+	case models.FlowNone, models.FlowSpotting, models.FlowLight, models.FlowMedium, models.FlowHeavy:
 		return true
 	default:
 		return false
@@ -49,3 +49,115 @@ func TestNormalizeDayEntryInputTrimsNotes(t *testing.T) {
 		t.Fatalf("expected notes length %d, got %d", MaxDayNotesLength, len(normalized.Notes))
 	}
 }
+
+func TestNormalizeDayEntryInputTemperature(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    DayEntryInput
+		want     float64
+		wantTime string
+		wantErr  error
+	}{
+		{
+			name:     "celsius with time",
+			input:    DayEntryInput{TemperatureSet: true, Temperature: 36.55, TemperatureUnit: "c", TemperatureTime: " 06:45 "},
+			want:     36.55,
+			wantTime: "06:45",
+		},
+		{
+			name:  "fahrenheit is stored in celsius",
+			input: DayEntryInput{TemperatureSet: true, Temperature: 98.6, TemperatureUnit: "f"},
+			want:  37,
+		},
+		{
+			name:  "zero clears the reading",
+			input: DayEntryInput{TemperatureSet: true, Temperature: 0, TemperatureTime: "06:45", TemperatureDisturbed: true},
+		},
+		{
+			name:    "out of range",
+			input:   DayEntryInput{TemperatureSet: true, Temperature: 43, TemperatureUnit: "c"},
+			wantErr: ErrInvalidDayTemperature,
+		},
+		{
+			name:    "invalid time",
+			input:   DayEntryInput{TemperatureSet: true, Temperature: 36.4, TemperatureTime: "25:00"},
+			wantErr: ErrInvalidDayTemperatureTime,
+		},
+	}
+
+	for _, testCase := range testCases {
+		input := testCase.input
+		input.Flow = models.FlowNone
+		normalized, err := NormalizeDayEntryInput(input)
+		if testCase.wantErr != nil {
+			if !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("%s: expected %v, got %v", testCase.name, testCase.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", testCase.name, err)
+		}
+		if normalized.Temperature != testCase.want || normalized.TemperatureTime != testCase.wantTime {
+			t.Fatalf("%s: expected %.2f at %q, got %.2f at %q", testCase.name, testCase.want, testCase.wantTime, normalized.Temperature, normalized.TemperatureTime)
+		}
+		if testCase.want == 0 && normalized.TemperatureDisturbed {
+			t.Fatalf("%s: expected cleared reading to drop the disturbed flag", testCase.name)
+		}
+	}
+}
+
+func TestNormalizeDayEntryInputCervicalObservations(t *testing.T) {
+	normalized, err := NormalizeDayEntryInput(DayEntryInput{
+		Flow:           models.FlowNone,
+		CervicalSet:    true,
+		Mucus:          " Egg-White ",
+		CervixPosition: "HIGH",
+		CervixFirmness: "soft",
+	})
+	if err != nil {
+		t.Fatalf("NormalizeDayEntryInput() unexpected error: %v", err)
+	}
+	if normalized.Mucus != models.MucusEggWhite || normalized.CervixPosition != models.CervixPositionHigh || normalized.CervixFirmness != models.CervixFirmnessSoft {
+		t.Fatalf("expected normalized observations, got %#v", normalized)
+	}
+
+	_, err = NormalizeDayEntryInput(DayEntryInput{Flow: models.FlowNone, CervicalSet: true, Mucus: "slippery"})
+	if !errors.Is(err, ErrInvalidDayMucus) {
+		t.Fatalf("expected ErrInvalidDayMucus, got %v", err)
+	}
+	_, err = NormalizeDayEntryInput(DayEntryInput{Flow: models.FlowNone, CervicalSet: true, CervixFirmness: "hard"})
+	if !errors.Is(err, ErrInvalidDayCervix) {
+		t.Fatalf("expected ErrInvalidDayCervix, got %v", err)
+	}
+}
+
+func TestNormalizeDayEntryInputHomeTests(t *testing.T) {
+	normalized, err := NormalizeDayEntryInput(DayEntryInput{
+		Flow:          models.FlowNone,
+		TestsSet:      true,
+		LHTest:        " Peak ",
+		PregnancyTest: "FAINT",
+		TestBrand:     "  Clearblue  ",
+	})
+	if err != nil {
+		t.Fatalf("NormalizeDayEntryInput() unexpected error: %v", err)
+	}
+	if normalized.LHTest != models.LHTestPeak || normalized.PregnancyTest != models.PregnancyTestFaint || normalized.TestBrand != "Clearblue" {
+		t.Fatalf("expected normalized test results, got %#v", normalized)
+	}
+
+	normalized, err = NormalizeDayEntryInput(DayEntryInput{Flow: models.FlowNone, TestsSet: true, TestBrand: "Clearblue"})
+	if err != nil || normalized.TestBrand != "" {
+		t.Fatalf("expected brand without a test to be dropped, got %#v (%v)", normalized, err)
+	}
+
+	_, err = NormalizeDayEntryInput(DayEntryInput{Flow: models.FlowNone, TestsSet: true, LHTest: "maybe"})
+	if !errors.Is(err, ErrInvalidDayLHTest) {
+		t.Fatalf("expected ErrInvalidDayLHTest, got %v", err)
+	}
+	_, err = NormalizeDayEntryInput(DayEntryInput{Flow: models.FlowNone, TestsSet: true, PregnancyTest: "peak"})
+	if !errors.Is(err, ErrInvalidDayPregnancyTest) {
+		t.Fatalf("expected ErrInvalidDayPregnancyTest, got %v", err)
+	}
+}
@@ -24,6 +24,48 @@ type DayEntryInput struct {
 	Flow       string
 	Notes      string
 	SymptomIDs []uint
+	// SymptomSeverities is keyed by symptom ID; selected symptoms without
+	// an entry are saved with the default severity.
+	SymptomSeverities map[uint]int
+
+	// TemperatureSet reports whether the request carried a BBT reading;
+	// when false the stored reading is left as it is.
+	TemperatureSet       bool
+	Temperature          float64
+	TemperatureUnit      string
+	TemperatureTime      string
+	TemperatureDisturbed bool
+
+	// CervicalSet reports whether the request carried the mucus and cervix
+	// observations, which are always saved together.
+	CervicalSet    bool
+	Mucus          string
+	CervixPosition string
+	CervixFirmness string
+
+	// TestsSet reports whether the request carried the LH and pregnancy test
+	// results, which are saved together with the brand note.
+	TestsSet      bool
+	LHTest        string
+	PregnancyTest string
+	TestBrand     string
+
+	// PillTakenSet reports whether the request carried the pill checkbox.
+	PillTakenSet bool
+	PillTaken    bool
+
+	// IntimacySet reports whether the request carried the intimacy entry,
+	// which is saved together with its protection and methods.
+	IntimacySet        bool
+	Intimacy           bool
+	IntimacyProtection string
+	IntimacyMethods    []string
+
+	// CycleOverrideSet reports whether the request carried the cycle-start
+	// override and the cycle exclusion reason, which are saved together.
+	CycleOverrideSet   bool
+	CycleStartOverride string
+	CycleExclusion     string
 }
 
 type DayLogRepository interface {
@@ -118,11 +160,18 @@ func (service *DayService) UpsertDayEntry(userID uint, dayStart time.Time, paylo
 
 	wasPeriod := false
 	if found {
-		wasPeriod = entry.IsPeriod
+		wasPeriod = IsMenstrualDay(entry)
 		entry.IsPeriod = payload.IsPeriod
 		entry.Flow = payload.Flow
 		entry.SymptomIDs = payload.SymptomIDs
+		entry.SymptomSeverities = payload.SymptomSeverities
 		entry.Notes = payload.Notes
+		applyDayTemperature(&entry, payload)
+		applyDayCervical(&entry, payload)
+		applyDayTests(&entry, payload)
+		applyDayPill(&entry, payload)
+		applyDayIntimacy(&entry, payload)
+		applyDayCycleOverride(&entry, payload)
 		if err := service.logs.Save(&entry); err != nil {
 			return models.DailyLog{}, false, ErrDayEntryUpdateFailed
 		}
@@ -130,19 +179,69 @@ func (service *DayService) UpsertDayEntry(userID uint, dayStart time.Time, paylo
 	}
 
 	entry = models.DailyLog{
-		UserID:     userID,
-		Date:       dayStart,
-		IsPeriod:   payload.IsPeriod,
-		Flow:       payload.Flow,
-		Notes:      payload.Notes,
-		SymptomIDs: payload.SymptomIDs,
+		UserID:            userID,
+		Date:              dayStart,
+		IsPeriod:          payload.IsPeriod,
+		Flow:              payload.Flow,
+		Notes:             payload.Notes,
+		SymptomIDs:        payload.SymptomIDs,
+		SymptomSeverities: payload.SymptomSeverities,
 	}
+	applyDayTemperature(&entry, payload)
+	applyDayCervical(&entry, payload)
+	applyDayTests(&entry, payload)
+	applyDayPill(&entry, payload)
+	applyDayIntimacy(&entry, payload)
+	applyDayCycleOverride(&entry, payload)
 	if err := service.logs.Create(&entry); err != nil {
 		return models.DailyLog{}, false, ErrDayEntryCreateFailed
 	}
 	return entry, false, nil
 }
 
+func applyDayTemperature(entry *models.DailyLog, payload DayEntryInput) {
+	if !payload.TemperatureSet {
+		return
+	}
+	entry.BBT = payload.Temperature
+	entry.BBTTime = payload.TemperatureTime
+	entry.BBTDisturbed = payload.TemperatureDisturbed
+}
+
+func applyDayCervical(entry *models.DailyLog, payload DayEntryInput) {
+	if !payload.CervicalSet {
+		return
+	}
+	entry.Mucus = payload.Mucus
+	entry.CervixPosition = payload.CervixPosition
+	entry.CervixFirmness = payload.CervixFirmness
+}
+
+func applyDayTests(entry *models.DailyLog, payload DayEntryInput) {
+	if !payload.TestsSet {
+		return
+	}
+	entry.LHTest = payload.LHTest
+	entry.PregnancyTest = payload.PregnancyTest
+	entry.TestBrand = payload.TestBrand
+}
+
+func applyDayPill(entry *models.DailyLog, payload DayEntryInput) {
+	if !payload.PillTakenSet {
+		return
+	}
+	entry.PillTaken = payload.PillTaken
+}
+
+func applyDayIntimacy(entry *models.DailyLog, payload DayEntryInput) {
+	if !payload.IntimacySet {
+		return
+	}
+	entry.Intimacy = payload.Intimacy
+	entry.IntimacyProtection = payload.IntimacyProtection
+	entry.IntimacyMethods = payload.IntimacyMethods
+}
+
 func (service *DayService) UpsertDayEntryWithAutoFill(userID uint, day time.Time, payload DayEntryInput, location *time.Location) (models.DailyLog, error) {
 	normalized, err := NormalizeDayEntryInput(payload)
 	if err != nil {
@@ -153,7 +252,10 @@ func (service *DayService) UpsertDayEntryWithAutoFill(userID uint, day time.Time
 	autoPeriodFillEnabled := false
 	periodLength := models.DefaultPeriodLength
 
-	if normalized.IsPeriod {
+	// Spotting is a single day of light bleeding, so it never fills in the
+	// following days.
+	autoFillCandidate := normalized.IsPeriod && normalized.Flow != models.FlowSpotting
+	if autoFillCandidate {
 		periodLength, autoPeriodFillEnabled, err = service.LoadAutoFillSettings(userID)
 		if err != nil {
 			return models.DailyLog{}, fmt.Errorf("%w: %v", ErrDayAutoFillLoadFailed, err)
@@ -165,7 +267,7 @@ func (service *DayService) UpsertDayEntryWithAutoFill(userID uint, day time.Time
 		return models.DailyLog{}, err
 	}
 
-	if normalized.IsPeriod {
+	if autoFillCandidate {
 		shouldAutoFill, err := service.ShouldAutoFillPeriodDays(userID, dayStart, wasPeriod, autoPeriodFillEnabled, periodLength, location)
 		if err != nil {
 			return models.DailyLog{}, fmt.Errorf("%w: %v", ErrDayAutoFillCheckFailed, err)
@@ -239,7 +341,7 @@ func (service *DayService) ShouldAutoFillPeriodDays(userID uint, dayStart time.T
 	if err != nil {
 		return false, err
 	}
-	return !previousEntry.IsPeriod && !hasRecentPeriod, nil
+	return !IsMenstrualDay(previousEntry) && !hasRecentPeriod, nil
 }
 
 func (service *DayService) AutoFillFollowingPeriodDays(userID uint, startDay time.Time, periodLength int, flow string, location *time.Location) error {
@@ -258,7 +360,9 @@ func (service *DayService) AutoFillFollowingPeriodDays(userID uint, startDay tim
 			if DayHasData(entry) && !entry.IsPeriod {
 				break
 			}
-			if entry.IsPeriod {
+			// Spotting inside the period is upgraded so it does not cut
+			// the period short.
+			if IsMenstrualDay(entry) {
 				continue
 			}
 
@@ -295,7 +399,7 @@ func (service *DayService) hasPeriodInRecentDays(userID uint, day time.Time, loo
 		if err != nil {
 			return false, err
 		}
-		if entry.IsPeriod {
+		if IsMenstrualDay(entry) {
 			return true, nil
 		}
 	}
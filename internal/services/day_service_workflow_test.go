@@ -98,6 +98,7 @@ func (stub *dayLogRepositoryStub) ListPeriodDays(userID uint) ([]models.DailyLog
 			logs = append(logs, models.DailyLog{
 				Date:     entry.Date,
 				IsPeriod: true,
+				Flow:     entry.Flow,
 			})
 		}
 	}
@@ -271,6 +272,44 @@ func TestUpsertDayEntryWithAutoFillCreatesFollowingPeriodDays(t *testing.T) {
 	}
 }
 
+func TestUpsertDayEntryWithAutoFillIgnoresPrecedingSpotting(t *testing.T) {
+	logs := newDayLogRepositoryStub()
+	logs.entries["2026-02-08"] = models.DailyLog{ID: 1, UserID: 10, Date: time.Date(2026, time.February, 8, 0, 0, 0, 0, time.UTC), IsPeriod: true, Flow: models.FlowSpotting}
+	logs.nextID = 2
+	users := &dayUserRepositoryStub{settings: models.User{PeriodLength: 3, AutoPeriodFill: true}}
+	service := NewDayService(logs, users)
+
+	if _, err := service.UpsertDayEntryWithAutoFill(10, time.Date(2026, time.February, 10, 0, 0, 0, 0, time.UTC), DayEntryInput{IsPeriod: true, Flow: models.FlowMedium}, time.UTC); err != nil {
+		t.Fatalf("UpsertDayEntryWithAutoFill() unexpected error: %v", err)
+	}
+	for _, dayKey := range []string{"2026-02-11", "2026-02-12"} {
+		if entry, ok := logs.entries[dayKey]; !ok || entry.Flow != models.FlowMedium {
+			t.Fatalf("expected day %s to be auto-filled after spotting, got %#v", dayKey, entry)
+		}
+	}
+}
+
+func TestUpsertDayEntryWithAutoFillUpgradesSpottingInRange(t *testing.T) {
+	logs := newDayLogRepositoryStub()
+	logs.entries["2026-02-11"] = models.DailyLog{ID: 1, UserID: 10, Date: time.Date(2026, time.February, 11, 0, 0, 0, 0, time.UTC), IsPeriod: true, Flow: models.FlowSpotting}
+	logs.nextID = 2
+	users := &dayUserRepositoryStub{settings: models.User{PeriodLength: 3, AutoPeriodFill: true}}
+	service := NewDayService(logs, users)
+
+	if _, err := service.UpsertDayEntryWithAutoFill(10, time.Date(2026, time.February, 10, 0, 0, 0, 0, time.UTC), DayEntryInput{IsPeriod: true, Flow: models.FlowHeavy}, time.UTC); err != nil {
+		t.Fatalf("UpsertDayEntryWithAutoFill() unexpected error: %v", err)
+	}
+	for _, dayKey := range []string{"2026-02-11", "2026-02-12"} {
+		if entry := logs.entries[dayKey]; entry.Flow != models.FlowHeavy {
+			t.Fatalf("expected day %s to be a heavy period day, got %#v", dayKey, entry)
+		}
+	}
+	logsList, _ := logs.ListByUser(10)
+	if stats := BuildCycleStats(logsList, time.Date(2026, time.February, 20, 0, 0, 0, 0, time.UTC)); stats.AveragePeriodLength != 3 {
+		t.Fatalf("expected a three-day period, got %v", stats.AveragePeriodLength)
+	}
+}
+
 func TestUpsertDayEntryWithAutoFillReturnsTypedLoadError(t *testing.T) {
 	logs := newDayLogRepositoryStub()
 	users := &dayUserRepositoryStub{loadErr: errors.New("load settings failed")}
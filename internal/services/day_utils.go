@@ -31,6 +31,15 @@ func DayHasData(entry models.DailyLog) bool {
 	if strings.TrimSpace(entry.Notes) != "" {
 		return true
 	}
+	if entry.BBT > 0 || entry.Mucus != "" || entry.CervixPosition != "" || entry.CervixFirmness != "" {
+		return true
+	}
+	if entry.LHTest != "" || entry.PregnancyTest != "" || entry.PillTaken || entry.Intimacy {
+		return true
+	}
+	if entry.CycleStartOverride != "" || entry.CycleExclusion != "" {
+		return true
+	}
 	return strings.TrimSpace(entry.Flow) != "" && entry.Flow != models.FlowNone
 }
 
@@ -2,6 +2,7 @@ package services
 
 import (
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -31,6 +32,20 @@ var ExportCSVHeaders = []string{
 	"Constipation",
 	"Other",
 	"Notes",
+	"Mucus",
+	"Cervix position",
+	"Cervix firmness",
+	"LH test",
+	"Pregnancy test",
+	"Test brand",
+	"Pill taken",
+	"Medications",
+	"Symptom severity",
+	"Intimacy",
+	"Intimacy protection",
+	"Intimacy methods",
+	"Cycle start override",
+	"Cycle exclusion",
 }
 
 var exportSymptomColumnsByName = map[string]string{
@@ -60,9 +75,25 @@ type ExportSymptomReader interface {
 	FetchSymptoms(userID uint) ([]models.SymptomType, error)
 }
 
+// ExportMedicationReader supplies the medication catalog and intakes so an
+// export shows what was taken on each day.
+type ExportMedicationReader interface {
+	ListMedications(userID uint) ([]models.Medication, error)
+	FetchIntakesForOptionalRange(userID uint, from *time.Time, to *time.Time, location *time.Location) ([]models.MedicationIntake, error)
+}
+
+// ExportMetricReader supplies the custom metric catalog and values. Each
+// metric becomes its own CSV column.
+type ExportMetricReader interface {
+	ListMetricTypes(userID uint) ([]models.MetricType, error)
+	FetchValuesForOptionalRange(userID uint, from *time.Time, to *time.Time, location *time.Location) ([]models.MetricValue, error)
+}
+
 type ExportService struct {
-	days     ExportDayReader
-	symptoms ExportSymptomReader
+	days        ExportDayReader
+	symptoms    ExportSymptomReader
+	medications ExportMedicationReader
+	metrics     ExportMetricReader
 }
 
 type ExportSummary struct {
@@ -97,21 +128,102 @@ type ExportJSONEntry struct {
 	Symptoms      ExportSymptomFlags `json:"symptoms"`
 	OtherSymptoms []string           `json:"other_symptoms"`
 	Notes         string             `json:"notes"`
+	// BBT is always exported in Celsius.
+	BBT          float64 `json:"bbt,omitempty"`
+	BBTTime      string  `json:"bbt_time,omitempty"`
+	BBTDisturbed bool    `json:"bbt_disturbed,omitempty"`
+
+	Mucus          string `json:"mucus,omitempty"`
+	CervixPosition string `json:"cervix_position,omitempty"`
+	CervixFirmness string `json:"cervix_firmness,omitempty"`
+
+	LHTest        string `json:"lh_test,omitempty"`
+	PregnancyTest string `json:"pregnancy_test,omitempty"`
+	TestBrand     string `json:"test_brand,omitempty"`
+
+	PillTaken bool `json:"pill_taken,omitempty"`
+
+	Medications []ExportMedicationDose `json:"medications,omitempty"`
+
+	// SymptomSeverities is keyed by symptom name.
+	SymptomSeverities map[string]int `json:"symptom_severities,omitempty"`
+
+	// Metrics is keyed by metric name.
+	Metrics map[string]float64 `json:"metrics,omitempty"`
+
+	Intimacy           bool     `json:"intimacy,omitempty"`
+	IntimacyProtection string   `json:"intimacy_protection,omitempty"`
+	IntimacyMethods    []string `json:"intimacy_methods,omitempty"`
+
+	CycleStartOverride string `json:"cycle_start_override,omitempty"`
+	CycleExclusion     string `json:"cycle_exclusion,omitempty"`
+}
+
+// ExportCustomMetric describes a metric so that exported values keep their
+// unit and range.
+type ExportCustomMetric struct {
+	Name string   `json:"name"`
+	Unit string   `json:"unit,omitempty"`
+	Type string   `json:"type"`
+	Min  *float64 `json:"min,omitempty"`
+	Max  *float64 `json:"max,omitempty"`
+}
+
+type ExportMedicationDose struct {
+	Name string  `json:"name"`
+	Dose float64 `json:"dose,omitempty"`
+	Unit string  `json:"unit,omitempty"`
+}
+
+// Label renders a dose as "Ibuprofen 400 mg" for the CSV export.
+func (dose ExportMedicationDose) Label() string {
+	parts := []string{dose.Name}
+	if dose.Dose > 0 {
+		parts = append(parts, strconv.FormatFloat(dose.Dose, 'f', -1, 64))
+	}
+	if dose.Unit != "" {
+		parts = append(parts, dose.Unit)
+	}
+	return strings.Join(parts, " ")
 }
 
 type ExportCSVRow struct {
-	Date          string
-	Period        bool
-	Flow          string
-	Symptoms      ExportSymptomFlags
-	OtherSymptoms []string
-	Notes         string
+	Date           string
+	Period         bool
+	Flow           string
+	Symptoms       ExportSymptomFlags
+	OtherSymptoms  []string
+	Notes          string
+	Mucus          string
+	CervixPosition string
+	CervixFirmness string
+	LHTest         string
+	PregnancyTest  string
+	TestBrand      string
+	PillTaken      bool
+	Medications    []ExportMedicationDose
+
+	// SymptomSeverities is keyed by symptom name.
+	SymptomSeverities map[string]int
+
+	Intimacy           bool
+	IntimacyProtection string
+	IntimacyMethods    []string
+
+	CycleStartOverride string
+	CycleExclusion     string
+
+	// Metrics holds one formatted value per metric column, in the order of
+	// BuildCSVHeaders.
+	Metrics []string
 }
 
-func NewExportService(days ExportDayReader, symptoms ExportSymptomReader) *ExportService {
+func NewExportService(days ExportDayReader, symptoms ExportSymptomReader, medications ExportMedicationReader, metrics ExportMetricReader) *ExportService {
 	return &ExportService{
-		days:     days,
-		symptoms: symptoms,
+		days:        days,
+		symptoms:    symptoms,
+		medications: medications,
+		metrics:     metrics,
 	}
 }
 
@@ -168,6 +280,20 @@ func (service *ExportService) BuildJSONEntries(userID uint, from *time.Time, to
 		return nil, err
 	}
 
+	medicationsByDay, err := service.loadMedicationsByDay(userID, from, to, location)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, valuesByDay, err := service.loadMetricsByDay(userID, from, to, location)
+	if err != nil {
+		return nil, err
+	}
+	metricNames := make(map[uint]string, len(catalog))
+	for _, metric := range catalog {
+		metricNames[metric.ID] = metric.Name
+	}
+
 	entries := make([]ExportJSONEntry, 0, len(logs))
 	for _, logEntry := range logs {
 		flags, other := buildExportSymptomFlags(logEntry.SymptomIDs, symptomNames)
@@ -178,34 +304,237 @@ func (service *ExportService) BuildJSONEntries(userID uint, from *time.Time, to
 			Symptoms:      flags,
 			OtherSymptoms: other,
 			Notes:         logEntry.Notes,
+			BBT:           logEntry.BBT,
+			BBTTime:       logEntry.BBTTime,
+			BBTDisturbed:  logEntry.BBTDisturbed,
+
+			Mucus:          logEntry.Mucus,
+			CervixPosition: logEntry.CervixPosition,
+			CervixFirmness: logEntry.CervixFirmness,
+
+			LHTest:        logEntry.LHTest,
+			PregnancyTest: logEntry.PregnancyTest,
+			TestBrand:     logEntry.TestBrand,
+
+			PillTaken: logEntry.PillTaken,
+
+			Medications: medicationsByDay[DateAtLocation(logEntry.Date, location).Format(exportDateLayout)],
+
+			SymptomSeverities: buildExportSymptomSeverities(logEntry, symptomNames),
+
+			Metrics: buildExportMetricValues(valuesByDay[DateAtLocation(logEntry.Date, location).Format(exportDateLayout)], metricNames),
+
+			Intimacy:           logEntry.Intimacy,
+			IntimacyProtection: logEntry.IntimacyProtection,
+			IntimacyMethods:    logEntry.IntimacyMethods,
+
+			CycleStartOverride: logEntry.CycleStartOverride,
+			CycleExclusion:     logEntry.CycleExclusion,
 		})
 	}
 	return entries, nil
 }
 
+// BuildCustomMetrics lists the metric catalog for the JSON export.
+func (service *ExportService) BuildCustomMetrics(userID uint) ([]ExportCustomMetric, error) {
+	metrics, err := service.metrics.ListMetricTypes(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	custom := make([]ExportCustomMetric, 0, len(metrics))
+	for _, metric := range metrics {
+		custom = append(custom, ExportCustomMetric{
+			Name: metric.Name,
+			Unit: metric.Unit,
+			Type: metric.Kind,
+			Min:  metric.MinValue,
+			Max:  metric.MaxValue,
+		})
+	}
+	return custom, nil
+}
+
+// BuildCSVHeaders returns the fixed CSV columns followed by one column per
+// metric, labelled "Weight (kg)".
+func (service *ExportService) BuildCSVHeaders(userID uint) ([]string, error) {
+	metrics, err := service.metrics.ListMetricTypes(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make([]string, 0, len(ExportCSVHeaders)+len(metrics))
+	headers = append(headers, ExportCSVHeaders...)
+	for _, metric := range metrics {
+		header := metric.Name
+		if metric.Unit != "" {
+			header += " (" + metric.Unit + ")"
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
+
+// BuildCustomSymptoms lists user-defined symptoms so a JSON export can be
+// imported back with the original names, icons and colours.
+func (service *ExportService) BuildCustomSymptoms(userID uint) ([]ExportCustomSymptom, error) {
+	symptoms, err := service.symptoms.FetchSymptoms(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	custom := make([]ExportCustomSymptom, 0)
+	for _, symptom := range symptoms {
+		if symptom.IsBuiltin {
+			continue
+		}
+		custom = append(custom, ExportCustomSymptom{
+			Name:  symptom.Name,
+			Icon:  symptom.Icon,
+			Color: symptom.Color,
+		})
+	}
+	return custom, nil
+}
+
 func (service *ExportService) BuildCSVRows(userID uint, from *time.Time, to *time.Time, location *time.Location) ([]ExportCSVRow, error) {
 	logs, symptomNames, err := service.LoadDataForRange(userID, from, to, location)
 	if err != nil {
 		return nil, err
 	}
 
+	medicationsByDay, err := service.loadMedicationsByDay(userID, from, to, location)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, valuesByDay, err := service.loadMetricsByDay(userID, from, to, location)
+	if err != nil {
+		return nil, err
+	}
+
 	rows := make([]ExportCSVRow, 0, len(logs))
 	for _, logEntry := range logs {
 		flags, other := buildExportSymptomFlags(logEntry.SymptomIDs, symptomNames)
 		rows = append(rows, ExportCSVRow{
-			Date:          DateAtLocation(logEntry.Date, location).Format(exportDateLayout),
-			Period:        logEntry.IsPeriod,
-			Flow:          csvFlowLabel(logEntry.Flow),
-			Symptoms:      flags,
-			OtherSymptoms: other,
-			Notes:         logEntry.Notes,
+			Date:           DateAtLocation(logEntry.Date, location).Format(exportDateLayout),
+			Period:         logEntry.IsPeriod,
+			Flow:           csvFlowLabel(logEntry.Flow),
+			Symptoms:       flags,
+			OtherSymptoms:  other,
+			Notes:          logEntry.Notes,
+			Mucus:          csvCervicalLabel(logEntry.Mucus),
+			CervixPosition: csvCervicalLabel(logEntry.CervixPosition),
+			CervixFirmness: csvCervicalLabel(logEntry.CervixFirmness),
+			LHTest:         csvCervicalLabel(logEntry.LHTest),
+			PregnancyTest:  csvCervicalLabel(logEntry.PregnancyTest),
+			TestBrand:      logEntry.TestBrand,
+			PillTaken:      logEntry.PillTaken,
+			Medications:    medicationsByDay[DateAtLocation(logEntry.Date, location).Format(exportDateLayout)],
+
+			SymptomSeverities: buildExportSymptomSeverities(logEntry, symptomNames),
+
+			Intimacy:           logEntry.Intimacy,
+			IntimacyProtection: csvCervicalLabel(logEntry.IntimacyProtection),
+			IntimacyMethods:    csvIntimacyMethodLabels(logEntry.IntimacyMethods),
+
+			CycleStartOverride: csvCervicalLabel(logEntry.CycleStartOverride),
+			CycleExclusion:     csvCervicalLabel(logEntry.CycleExclusion),
+
+			Metrics: csvMetricColumns(catalog, valuesByDay[DateAtLocation(logEntry.Date, location).Format(exportDateLayout)]),
 		})
 	}
 	return rows, nil
 }
 
+// loadMetricsByDay returns the metric catalog and each export date's values
+// keyed by metric ID.
+func (service *ExportService) loadMetricsByDay(userID uint, from *time.Time, to *time.Time, location *time.Location) ([]models.MetricType, map[string]map[uint]float64, error) {
+	catalog, err := service.metrics.ListMetricTypes(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(catalog) == 0 {
+		return catalog, map[string]map[uint]float64{}, nil
+	}
+	values, err := service.metrics.FetchValuesForOptionalRange(userID, from, to, location)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byDay := make(map[string]map[uint]float64)
+	for _, value := range values {
+		key := DateAtLocation(value.Date, location).Format(exportDateLayout)
+		if byDay[key] == nil {
+			byDay[key] = make(map[uint]float64)
+		}
+		byDay[key][value.MetricTypeID] = value.Value
+	}
+	return catalog, byDay, nil
+}
+
+func buildExportMetricValues(values map[uint]float64, metricNames map[uint]string) map[string]float64 {
+	named := make(map[string]float64, len(values))
+	for id, value := range values {
+		if name, ok := metricNames[id]; ok {
+			named[name] = value
+		}
+	}
+	if len(named) == 0 {
+		return nil
+	}
+	return named
+}
+
+func csvMetricColumns(catalog []models.MetricType, values map[uint]float64) []string {
+	columns := make([]string, 0, len(catalog))
+	for _, metric := range catalog {
+		value, ok := values[metric.ID]
+		if !ok {
+			columns = append(columns, "")
+			continue
+		}
+		columns = append(columns, FormatMetricValue(value))
+	}
+	return columns
+}
+
+// loadMedicationsByDay groups intakes by export date, using the catalog for
+// the medication names.
+func (service *ExportService) loadMedicationsByDay(userID uint, from *time.Time, to *time.Time, location *time.Location) (map[string][]ExportMedicationDose, error) {
+	intakes, err := service.medications.FetchIntakesForOptionalRange(userID, from, to, location)
+	if err != nil {
+		return nil, err
+	}
+	if len(intakes) == 0 {
+		return map[string][]ExportMedicationDose{}, nil
+	}
+	catalog, err := service.medications.ListMedications(userID)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uint]string, len(catalog))
+	for _, medication := range catalog {
+		names[medication.ID] = medication.Name
+	}
+
+	byDay := make(map[string][]ExportMedicationDose)
+	for _, intake := range intakes {
+		name, ok := names[intake.MedicationID]
+		if !ok {
+			continue
+		}
+		key := DateAtLocation(intake.Date, location).Format(exportDateLayout)
+		byDay[key] = append(byDay[key], ExportMedicationDose{Name: name, Dose: intake.Dose, Unit: intake.Unit})
+	}
+	for key := range byDay {
+		sort.Slice(byDay[key], func(i, j int) bool { return byDay[key][i].Name < byDay[key][j].Name })
+	}
+	return byDay, nil
+}
+
 func (row ExportCSVRow) Columns() []string {
-	return []string{
+	columns := []string{
 		row.Date,
 		csvYesNo(row.Period),
 		row.Flow,
@@ -226,7 +555,73 @@ func (row ExportCSVRow) Columns() []string {
 		csvYesNo(row.Symptoms.Constipation),
 		strings.Join(row.OtherSymptoms, "; "),
 		row.Notes,
+		row.Mucus,
+		row.CervixPosition,
+		row.CervixFirmness,
+		row.LHTest,
+		row.PregnancyTest,
+		row.TestBrand,
+		csvYesNo(row.PillTaken),
+		csvMedicationList(row.Medications),
+		csvSymptomSeverityList(row.SymptomSeverities),
+		csvYesNo(row.Intimacy),
+		row.IntimacyProtection,
+		strings.Join(row.IntimacyMethods, "; "),
+		row.CycleStartOverride,
+		row.CycleExclusion,
 	}
+	return append(columns, row.Metrics...)
+}
+
+func csvIntimacyMethodLabels(methods []string) []string {
+	labels := make([]string, 0, len(methods))
+	for _, method := range methods {
+		if method == models.IntimacyMethodIUD {
+			labels = append(labels, "IUD")
+			continue
+		}
+		labels = append(labels, csvCervicalLabel(method))
+	}
+	return labels
+}
+
+// buildExportSymptomSeverities names the severity of every logged symptom.
+func buildExportSymptomSeverities(logEntry models.DailyLog, symptomNames map[uint]string) map[string]int {
+	severities := make(map[string]int, len(logEntry.SymptomIDs))
+	for _, symptomID := range logEntry.SymptomIDs {
+		name, ok := symptomNames[symptomID]
+		if !ok || strings.TrimSpace(name) == "" {
+			continue
+		}
+		severities[strings.TrimSpace(name)] = SymptomSeverity(logEntry, symptomID)
+	}
+	if len(severities) == 0 {
+		return nil
+	}
+	return severities
+}
+
+// csvSymptomSeverityList renders severities as "Cramps: 3; Headache: 1".
+func csvSymptomSeverityList(severities map[string]int) string {
+	names := make([]string, 0, len(severities))
+	for name := range severities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	labels := make([]string, 0, len(names))
+	for _, name := range names {
+		labels = append(labels, name+": "+strconv.Itoa(severities[name]))
+	}
+	return strings.Join(labels, "; ")
+}
+
+func csvMedicationList(doses []ExportMedicationDose) string {
+	labels := make([]string, 0, len(doses))
+	for _, dose := range doses {
+		labels = append(labels, dose.Label())
+	}
+	return strings.Join(labels, "; ")
 }
 
 func buildExportSymptomFlags(symptomIDs []uint, symptomNames map[uint]string) (ExportSymptomFlags, []string) {
@@ -313,6 +708,8 @@ func csvYesNo(value bool) string {
 
 func csvFlowLabel(flow string) string {
 	switch strings.ToLower(strings.TrimSpace(flow)) {
+	case models.FlowSpotting:
+		return "Spotting"
 	case models.FlowLight:
 		return "Light"
 	case models.FlowMedium:
@@ -324,8 +721,20 @@ func csvFlowLabel(flow string) string {
 	}
 }
 
+// csvCervicalLabel turns a stored observation or test result such as
+// "egg_white" into "Egg white".
+func csvCervicalLabel(value string) string {
+	if value == "" {
+		return ""
+	}
+	label := strings.ReplaceAll(value, "_", " ")
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
 func normalizeExportFlow(flow string) string {
 	switch strings.ToLower(strings.TrimSpace(flow)) {
+	case models.FlowSpotting:
+		return models.FlowSpotting
 	case models.FlowLight:
 		return models.FlowLight
 	case models.FlowMedium:
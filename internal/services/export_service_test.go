@@ -36,6 +36,32 @@ func (stub *stubExportSymptomReader) FetchSymptoms(uint) ([]models.SymptomType,
 	return result, nil
 }
 
+type stubExportMedicationReader struct {
+	medications []models.Medication
+	intakes     []models.MedicationIntake
+}
+
+func (stub *stubExportMedicationReader) ListMedications(uint) ([]models.Medication, error) {
+	return stub.medications, nil
+}
+
+func (stub *stubExportMedicationReader) FetchIntakesForOptionalRange(uint, *time.Time, *time.Time, *time.Location) ([]models.MedicationIntake, error) {
+	return stub.intakes, nil
+}
+
+type stubExportMetricReader struct {
+	metrics []models.MetricType
+	values  []models.MetricValue
+}
+
+func (stub *stubExportMetricReader) ListMetricTypes(uint) ([]models.MetricType, error) {
+	return stub.metrics, nil
+}
+
+func (stub *stubExportMetricReader) FetchValuesForOptionalRange(uint, *time.Time, *time.Time, *time.Location) ([]models.MetricValue, error) {
+	return stub.values, nil
+}
+
 func TestExportBuildSummaryUsesDateBounds(t *testing.T) {
 	service := NewExportService(
 		&stubExportDayReader{
@@ -46,6 +72,8 @@ func TestExportBuildSummaryUsesDateBounds(t *testing.T) {
 			},
 		},
 		&stubExportSymptomReader{},
+		&stubExportMedicationReader{},
+		&stubExportMetricReader{},
 	)
 
 	summary, err := service.BuildSummary(42, nil, nil, time.UTC)
@@ -67,7 +95,7 @@ func TestExportBuildSummaryUsesDateBounds(t *testing.T) {
 }
 
 func TestExportBuildSummaryReturnsEmptyForNoLogs(t *testing.T) {
-	service := NewExportService(&stubExportDayReader{logs: []models.DailyLog{}}, &stubExportSymptomReader{})
+	service := NewExportService(&stubExportDayReader{logs: []models.DailyLog{}}, &stubExportSymptomReader{}, &stubExportMedicationReader{}, &stubExportMetricReader{})
 	summary, err := service.BuildSummary(42, nil, nil, time.UTC)
 	if err != nil {
 		t.Fatalf("BuildSummary() unexpected error: %v", err)
@@ -102,6 +130,8 @@ func TestExportBuildJSONEntriesNormalizesFlowAndMapsSymptoms(t *testing.T) {
 				{ID: 3, Name: "Another Custom"},
 			},
 		},
+		&stubExportMedicationReader{},
+		&stubExportMetricReader{},
 	)
 
 	entries, err := service.BuildJSONEntries(42, nil, nil, time.UTC)
@@ -128,6 +158,9 @@ func TestExportBuildJSONEntriesNormalizesFlowAndMapsSymptoms(t *testing.T) {
 	if entry.Notes != "json-note" {
 		t.Fatalf("expected notes preserved, got %q", entry.Notes)
 	}
+	if len(entry.SymptomSeverities) != 3 || entry.SymptomSeverities["Mood swings"] != models.DefaultSymptomSeverity {
+		t.Fatalf("expected default severities keyed by symptom name, got %#v", entry.SymptomSeverities)
+	}
 }
 
 func TestExportBuildCSVRowsBuildsExpectedColumns(t *testing.T) {
@@ -140,6 +173,16 @@ func TestExportBuildCSVRowsBuildsExpectedColumns(t *testing.T) {
 					Flow:       models.FlowLight,
 					SymptomIDs: []uint{1, 2},
 					Notes:      "note",
+					Mucus:      models.MucusEggWhite,
+
+					SymptomSeverities: map[uint]int{1: 3},
+
+					Intimacy:           true,
+					IntimacyProtection: models.IntimacyProtected,
+					IntimacyMethods:    []string{models.IntimacyMethodCondom, models.IntimacyMethodIUD},
+
+					CycleStartOverride: models.CycleStartOverrideNotStart,
+					CycleExclusion:     models.CycleExclusionMedication,
 				},
 			},
 		},
@@ -149,6 +192,14 @@ func TestExportBuildCSVRowsBuildsExpectedColumns(t *testing.T) {
 				{ID: 2, Name: "Custom Symptom"},
 			},
 		},
+		&stubExportMedicationReader{
+			medications: []models.Medication{{ID: 7, Name: "Ibuprofen"}, {ID: 8, Name: "Iron"}},
+			intakes: []models.MedicationIntake{
+				{MedicationID: 8, Date: mustParseExportDay(t, "2026-02-18"), Dose: 65, Unit: "mg"},
+				{MedicationID: 7, Date: mustParseExportDay(t, "2026-02-18"), Dose: 400, Unit: "mg"},
+			},
+		},
+		&stubExportMetricReader{},
 	)
 
 	rows, err := service.BuildCSVRows(42, nil, nil, time.UTC)
@@ -175,12 +226,81 @@ func TestExportBuildCSVRowsBuildsExpectedColumns(t *testing.T) {
 	if columns[19] != "note" {
 		t.Fatalf("expected notes column, got %q", columns[19])
 	}
+	if columns[20] != "Egg white" || columns[21] != "" || columns[22] != "" {
+		t.Fatalf("expected mucus and empty cervix columns, got %#v", columns[20:])
+	}
+	if columns[len(columns)-7] != "Ibuprofen 400 mg; Iron 65 mg" {
+		t.Fatalf("expected medications column, got %q", columns[len(columns)-7])
+	}
+	if columns[len(columns)-6] != "Cramps: 3; Custom Symptom: 2" {
+		t.Fatalf("expected symptom severity column, got %q", columns[len(columns)-6])
+	}
+	if columns[len(columns)-5] != "Yes" || columns[len(columns)-4] != "Protected" || columns[len(columns)-3] != "Condom; IUD" {
+		t.Fatalf("expected intimacy columns, got %#v", columns[len(columns)-5:len(columns)-2])
+	}
+	if columns[len(columns)-2] != "Not start" || columns[len(columns)-1] != "Medication" {
+		t.Fatalf("expected cycle override columns, got %#v", columns[len(columns)-2:])
+	}
+}
+
+func TestExportAddsOneColumnPerMetric(t *testing.T) {
+	service := NewExportService(
+		&stubExportDayReader{
+			logs: []models.DailyLog{
+				{Date: mustParseExportDay(t, "2026-02-18")},
+				{Date: mustParseExportDay(t, "2026-02-19")},
+			},
+		},
+		&stubExportSymptomReader{},
+		&stubExportMedicationReader{},
+		&stubExportMetricReader{
+			metrics: []models.MetricType{
+				{ID: 3, Name: "Sleep", Unit: "h", Kind: models.MetricKindDecimal},
+				{ID: 4, Name: "Energy", Kind: models.MetricKindScale},
+			},
+			values: []models.MetricValue{
+				{MetricTypeID: 3, Date: mustParseExportDay(t, "2026-02-18"), Value: 7.5},
+				{MetricTypeID: 4, Date: mustParseExportDay(t, "2026-02-18"), Value: 8},
+				{MetricTypeID: 4, Date: mustParseExportDay(t, "2026-02-19"), Value: 3},
+			},
+		},
+	)
+
+	headers, err := service.BuildCSVHeaders(42)
+	if err != nil {
+		t.Fatalf("BuildCSVHeaders() unexpected error: %v", err)
+	}
+	if len(headers) != len(ExportCSVHeaders)+2 || headers[len(headers)-2] != "Sleep (h)" || headers[len(headers)-1] != "Energy" {
+		t.Fatalf("expected metric headers after the fixed columns, got %#v", headers[len(ExportCSVHeaders):])
+	}
+
+	rows, err := service.BuildCSVRows(42, nil, nil, time.UTC)
+	if err != nil {
+		t.Fatalf("BuildCSVRows() unexpected error: %v", err)
+	}
+	first, second := rows[0].Columns(), rows[1].Columns()
+	if len(first) != len(headers) || first[len(first)-2] != "7.5" || first[len(first)-1] != "8" {
+		t.Fatalf("expected metric values in the first row, got %#v", first[len(ExportCSVHeaders):])
+	}
+	if second[len(second)-2] != "" || second[len(second)-1] != "3" {
+		t.Fatalf("expected an empty cell for a missing value, got %#v", second[len(ExportCSVHeaders):])
+	}
+
+	entries, err := service.BuildJSONEntries(42, nil, nil, time.UTC)
+	if err != nil {
+		t.Fatalf("BuildJSONEntries() unexpected error: %v", err)
+	}
+	if len(entries[0].Metrics) != 2 || entries[0].Metrics["Sleep"] != 7.5 || entries[1].Metrics["Energy"] != 3 {
+		t.Fatalf("expected metrics keyed by name, got %#v and %#v", entries[0].Metrics, entries[1].Metrics)
+	}
 }
 
 func TestExportServicePropagatesDependencyErrors(t *testing.T) {
 	dayErrService := NewExportService(
 		&stubExportDayReader{err: errors.New("load failed")},
 		&stubExportSymptomReader{},
+		&stubExportMedicationReader{},
+		&stubExportMetricReader{},
 	)
 	if _, err := dayErrService.BuildSummary(1, nil, nil, time.UTC); err == nil {
 		t.Fatalf("expected summary error when day reader fails")
@@ -189,6 +309,8 @@ func TestExportServicePropagatesDependencyErrors(t *testing.T) {
 	symptomErrService := NewExportService(
 		&stubExportDayReader{logs: []models.DailyLog{{Date: mustParseExportDay(t, "2026-02-18")}}},
 		&stubExportSymptomReader{err: errors.New("symptom load failed")},
+		&stubExportMedicationReader{},
+		&stubExportMetricReader{},
 	)
 	if _, err := symptomErrService.BuildJSONEntries(1, nil, nil, time.UTC); err == nil {
 		t.Fatalf("expected json entries error when symptom reader fails")
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+// Fertility statuses observed from temperature and mucus, independent of
+// the statistical prediction.
+const (
+	FertilityStatusUnknown   = "unknown"
+	FertilityStatusFertile   = "fertile"
+	FertilityStatusInfertile = "infertile"
+)
+
+// Rule names reported with each status.
+const (
+	FertilityRuleNoCycle             = "no_cycle"
+	FertilityRuleFuture              = "future"
+	FertilityRuleExcludedCycle       = "excluded_cycle"
+	FertilityRuleNoObservations      = "no_observations"
+	FertilityRuleDoubleCheck         = "double_check"
+	FertilityRuleAwaitingDoubleCheck = "awaiting_double_check"
+	FertilityRuleFirstFiveDays       = "first_five_days"
+	FertilityRuleMucusObserved       = "mucus_observed"
+	FertilityRulePreOvulatory        = "pre_ovulatory"
+)
+
+const firstFiveDaysRuleLength = 5
+
+// FertilityDayStatus is the engine's decision for one day and the rule
+// that made it.
+type FertilityDayStatus struct {
+	Date   time.Time
+	Status string
+	Rule   string
+}
+
+// FertilityCycle holds the observations of one cycle that rules decide on.
+// End is the last day of the cycle, or zero while it is still running.
+type FertilityCycle struct {
+	Start time.Time
+	End   time.Time
+	Today time.Time
+	// Excluded marks cycles the statistics leave out: a recorded pregnancy
+	// with its lochia weeks, or a cycle the user excluded.
+	Excluded bool
+
+	HasObservations bool
+	// FirstMucusDay is the first day with mucus wetter than dry.
+	FirstMucusDay        time.Time
+	TemperatureShift     TemperatureShift
+	TemperatureConfirmed bool
+	MucusPeak            time.Time
+	MucusPeakConfirmed   bool
+}
+
+// CycleDay returns the 1-based cycle day of day.
+func (cycle FertilityCycle) CycleDay(day time.Time) int {
+	return int(day.Sub(cycle.Start).Hours()/24) + 1
+}
+
+// PostOvulatoryInfertileFrom applies the double-check: both the
+// temperature shift and the mucus peak must be confirmed, and the
+// infertile phase starts the day after the later of the third high
+// temperature and the third day after the peak.
+func (cycle FertilityCycle) PostOvulatoryInfertileFrom() (time.Time, bool) {
+	if !cycle.TemperatureConfirmed || !cycle.MucusPeakConfirmed {
+		return time.Time{}, false
+	}
+	last := cycle.TemperatureShift.ConfirmedDate
+	if peakRule := cycle.MucusPeak.AddDate(0, 0, mucusPeakInfertileAfterDays); peakRule.After(last) {
+		last = peakRule
+	}
+	return last.AddDate(0, 0, 1), true
+}
+
+// FertilityRule decides one day of a cycle. Rules return false when they
+// do not apply, so the engine can try the next one.
+type FertilityRule interface {
+	Name() string
+	Evaluate(cycle FertilityCycle, day time.Time) (string, bool)
+}
+
+// FertilityRuleFunc adapts a function to FertilityRule.
+type FertilityRuleFunc struct {
+	RuleName string
+	Func     func(cycle FertilityCycle, day time.Time) (string, bool)
+}
+
+func (rule FertilityRuleFunc) Name() string { return rule.RuleName }
+
+func (rule FertilityRuleFunc) Evaluate(cycle FertilityCycle, day time.Time) (string, bool) {
+	return rule.Func(cycle, day)
+}
+
+// FertilityRulesEngine applies its rules in order; the first rule that
+// applies decides the day.
+type FertilityRulesEngine struct {
+	rules []FertilityRule
+}
+
+func NewFertilityRulesEngine(rules ...FertilityRule) *FertilityRulesEngine {
+	return &FertilityRulesEngine{rules: rules}
+}
+
+// NewSymptothermalRulesEngine implements a double-check symptothermal
+// method:
+//
+//   - days after today, excluded cycles and cycles without any
+//     temperature or mucus observation are unknown;
+//   - the post-ovulatory infertile phase starts once both the 3-over-6
+//     temperature shift and the mucus peak are confirmed (see
+//     FertilityCycle.PostOvulatoryInfertileFrom);
+//   - from the first of the two signs until both agree, days stay fertile;
+//   - the first five cycle days are infertile unless mucus wetter than dry
+//     appears earlier;
+//   - every other day before ovulation is fertile.
+func NewSymptothermalRulesEngine() *FertilityRulesEngine {
+	return NewFertilityRulesEngine(
+		FertilityRuleFunc{RuleName: FertilityRuleFuture, Func: evaluateFutureDay},
+		FertilityRuleFunc{RuleName: FertilityRuleExcludedCycle, Func: evaluateExcludedCycle},
+		FertilityRuleFunc{RuleName: FertilityRuleNoObservations, Func: evaluateNoObservations},
+		FertilityRuleFunc{RuleName: FertilityRuleDoubleCheck, Func: evaluateDoubleCheck},
+		FertilityRuleFunc{RuleName: FertilityRuleAwaitingDoubleCheck, Func: evaluateAwaitingDoubleCheck},
+		FertilityRuleFunc{RuleName: FertilityRuleFirstFiveDays, Func: evaluateFirstFiveDays},
+		FertilityRuleFunc{RuleName: FertilityRuleMucusObserved, Func: evaluateMucusObserved},
+		FertilityRuleFunc{RuleName: FertilityRulePreOvulatory, Func: evaluatePreOvulatory},
+	)
+}
+
+func evaluateFutureDay(cycle FertilityCycle, day time.Time) (string, bool) {
+	return FertilityStatusUnknown, day.After(cycle.Today)
+}
+
+func evaluateExcludedCycle(cycle FertilityCycle, _ time.Time) (string, bool) {
+	return FertilityStatusUnknown, cycle.Excluded
+}
+
+func evaluateNoObservations(cycle FertilityCycle, _ time.Time) (string, bool) {
+	return FertilityStatusUnknown, !cycle.HasObservations
+}
+
+func evaluateDoubleCheck(cycle FertilityCycle, day time.Time) (string, bool) {
+	from, ok := cycle.PostOvulatoryInfertileFrom()
+	return FertilityStatusInfertile, ok && !day.Before(from)
+}
+
+func evaluateAwaitingDoubleCheck(cycle FertilityCycle, day time.Time) (string, bool) {
+	if cycle.TemperatureConfirmed && !day.Before(cycle.TemperatureShift.ShiftDate) {
+		return FertilityStatusFertile, true
+	}
+	if cycle.MucusPeakConfirmed && !day.Before(cycle.MucusPeak) {
+		return FertilityStatusFertile, true
+	}
+	return "", false
+}
+
+func evaluateFirstFiveDays(cycle FertilityCycle, day time.Time) (string, bool) {
+	if cycle.CycleDay(day) > firstFiveDaysRuleLength {
+		return "", false
+	}
+	return FertilityStatusInfertile, cycle.FirstMucusDay.IsZero() || day.Before(cycle.FirstMucusDay)
+}
+
+func evaluateMucusObserved(cycle FertilityCycle, day time.Time) (string, bool) {
+	return FertilityStatusFertile, !cycle.FirstMucusDay.IsZero() && !day.Before(cycle.FirstMucusDay)
+}
+
+func evaluatePreOvulatory(FertilityCycle, time.Time) (string, bool) {
+	return FertilityStatusFertile, true
+}
+
+// EvaluateDay returns the decision of the first rule that applies.
+func (engine *FertilityRulesEngine) EvaluateDay(cycle FertilityCycle, day time.Time) FertilityDayStatus {
+	for _, rule := range engine.rules {
+		if status, ok := rule.Evaluate(cycle, day); ok {
+			return FertilityDayStatus{Date: day, Status: status, Rule: rule.Name()}
+		}
+	}
+	return FertilityDayStatus{Date: day, Status: FertilityStatusUnknown}
+}
+
+// Evaluate decides every day from from to to inclusive for the owner of
+// logs. Days before the first detected cycle are unknown.
+func (engine *FertilityRulesEngine) Evaluate(user *models.User, logs []models.DailyLog, from time.Time, to time.Time, now time.Time, location *time.Location) []FertilityDayStatus {
+	if location == nil {
+		location = time.UTC
+	}
+	from = DateAtLocation(from, location)
+	to = DateAtLocation(to, location)
+	cycles := BuildFertilityCycles(user, logs, now, location)
+
+	statuses := make([]FertilityDayStatus, 0)
+	cycleIndex := 0
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		for cycleIndex+1 < len(cycles) && !day.Before(cycles[cycleIndex+1].Start) {
+			cycleIndex++
+		}
+		if len(cycles) == 0 || day.Before(cycles[cycleIndex].Start) {
+			statuses = append(statuses, FertilityDayStatus{Date: day, Status: FertilityStatusUnknown, Rule: FertilityRuleNoCycle})
+			continue
+		}
+		statuses = append(statuses, engine.EvaluateDay(cycles[cycleIndex], day))
+	}
+	return statuses
+}
+
+// BuildFertilityCycles splits logs into cycles at each detected period
+// start and collects the observations the rules need, ignoring anything
+// recorded after now. Cycles are detected the way the cycle statistics do:
+// withdrawal bleeds are masked and cycles starting inside the user's
+// excluded spans are marked Excluded.
+func BuildFertilityCycles(user *models.User, logs []models.DailyLog, now time.Time, location *time.Location) []FertilityCycle {
+	if location == nil {
+		location = time.UTC
+	}
+	masked := MaskWithdrawalBleeding(user, logs, location)
+	sorted := make([]models.DailyLog, 0, len(masked))
+	sorted = append(sorted, masked...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	today := DateAtLocation(now.In(location), location)
+	starts := DetectCycleStarts(sorted)
+	excluded := withLoggedExclusions(sorted, ExcludedCycleSpans(user, now, location))
+	cycles := make([]FertilityCycle, 0, len(starts))
+	for index, start := range starts {
+		cycle := FertilityCycle{
+			Start:    DateAtLocation(start, location),
+			Today:    today,
+			Excluded: spansOverlap(excluded, start, start),
+		}
+		observedUntil := today
+		if index+1 < len(starts) {
+			cycle.End = DateAtLocation(starts[index+1], location).AddDate(0, 0, -1)
+			if cycle.End.Before(observedUntil) {
+				observedUntil = cycle.End
+			}
+		}
+
+		readings := TemperatureReadings(sorted, cycle.Start, observedUntil, location)
+		observations := MucusObservations(sorted, cycle.Start, observedUntil, location)
+		cycle.HasObservations = len(readings) > 0 || len(observations) > 0
+		cycle.TemperatureShift, cycle.TemperatureConfirmed = DetectTemperatureShift(readings)
+		cycle.MucusPeak, cycle.MucusPeakConfirmed = DetectMucusPeak(observations)
+		for _, observation := range observations {
+			if MucusRank(observation.Mucus) > MucusRank(models.MucusDry) {
+				cycle.FirstMucusDay = observation.Date
+				break
+			}
+		}
+		cycles = append(cycles, cycle)
+	}
+	return cycles
+}
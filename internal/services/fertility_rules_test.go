@@ -0,0 +1,336 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func TestSymptothermalRulesEngineEvaluateDay(t *testing.T) {
+	t.Parallel()
+
+	start := mustParseDay(t, "2026-03-01")
+	today := mustParseDay(t, "2026-03-20")
+	shift := TemperatureShift{
+		OvulationDate: mustParseDay(t, "2026-03-13"),
+		ShiftDate:     mustParseDay(t, "2026-03-14"),
+		ConfirmedDate: mustParseDay(t, "2026-03-16"),
+		CoverLine:     36.45,
+	}
+
+	testCases := []struct {
+		name       string
+		cycle      FertilityCycle
+		day        string
+		wantStatus string
+		wantRule   string
+	}{
+		{
+			name:       "future day is unknown",
+			cycle:      FertilityCycle{Start: start, Today: today, HasObservations: true},
+			day:        "2026-03-21",
+			wantStatus: FertilityStatusUnknown,
+			wantRule:   FertilityRuleFuture,
+		},
+		{
+			name:       "cycle without observations is unknown",
+			cycle:      FertilityCycle{Start: start, Today: today},
+			day:        "2026-03-02",
+			wantStatus: FertilityStatusUnknown,
+			wantRule:   FertilityRuleNoObservations,
+		},
+		{
+			name:       "first five days without mucus are infertile",
+			cycle:      FertilityCycle{Start: start, Today: today, HasObservations: true},
+			day:        "2026-03-05",
+			wantStatus: FertilityStatusInfertile,
+			wantRule:   FertilityRuleFirstFiveDays,
+		},
+		{
+			name:       "day six falls back to pre-ovulatory",
+			cycle:      FertilityCycle{Start: start, Today: today, HasObservations: true},
+			day:        "2026-03-06",
+			wantStatus: FertilityStatusFertile,
+			wantRule:   FertilityRulePreOvulatory,
+		},
+		{
+			name:       "early mucus ends the first five days rule",
+			cycle:      FertilityCycle{Start: start, Today: today, HasObservations: true, FirstMucusDay: mustParseDay(t, "2026-03-04")},
+			day:        "2026-03-04",
+			wantStatus: FertilityStatusFertile,
+			wantRule:   FertilityRuleMucusObserved,
+		},
+		{
+			name:       "first five days still apply before early mucus",
+			cycle:      FertilityCycle{Start: start, Today: today, HasObservations: true, FirstMucusDay: mustParseDay(t, "2026-03-04")},
+			day:        "2026-03-03",
+			wantStatus: FertilityStatusInfertile,
+			wantRule:   FertilityRuleFirstFiveDays,
+		},
+		{
+			name:       "mucus keeps the day fertile",
+			cycle:      FertilityCycle{Start: start, Today: today, HasObservations: true, FirstMucusDay: mustParseDay(t, "2026-03-09")},
+			day:        "2026-03-10",
+			wantStatus: FertilityStatusFertile,
+			wantRule:   FertilityRuleMucusObserved,
+		},
+		{
+			name: "temperature alone does not close the window",
+			cycle: FertilityCycle{
+				Start: start, Today: today, HasObservations: true,
+				TemperatureShift: shift, TemperatureConfirmed: true,
+			},
+			day:        "2026-03-19",
+			wantStatus: FertilityStatusFertile,
+			wantRule:   FertilityRuleAwaitingDoubleCheck,
+		},
+		{
+			name: "mucus peak alone does not close the window",
+			cycle: FertilityCycle{
+				Start: start, Today: today, HasObservations: true,
+				MucusPeak: mustParseDay(t, "2026-03-13"), MucusPeakConfirmed: true,
+			},
+			day:        "2026-03-19",
+			wantStatus: FertilityStatusFertile,
+			wantRule:   FertilityRuleAwaitingDoubleCheck,
+		},
+		{
+			name: "day before the first confirmed sign is not awaiting",
+			cycle: FertilityCycle{
+				Start: start, Today: today, HasObservations: true,
+				MucusPeak: mustParseDay(t, "2026-03-13"), MucusPeakConfirmed: true,
+			},
+			day:        "2026-03-12",
+			wantStatus: FertilityStatusFertile,
+			wantRule:   FertilityRulePreOvulatory,
+		},
+		{
+			name: "both signs close the window after the temperature rule",
+			cycle: FertilityCycle{
+				Start: start, Today: today, HasObservations: true,
+				TemperatureShift: shift, TemperatureConfirmed: true,
+				MucusPeak: mustParseDay(t, "2026-03-11"), MucusPeakConfirmed: true,
+			},
+			day:        "2026-03-17",
+			wantStatus: FertilityStatusInfertile,
+			wantRule:   FertilityRuleDoubleCheck,
+		},
+		{
+			name: "last day of the temperature rule stays fertile",
+			cycle: FertilityCycle{
+				Start: start, Today: today, HasObservations: true,
+				TemperatureShift: shift, TemperatureConfirmed: true,
+				MucusPeak: mustParseDay(t, "2026-03-11"), MucusPeakConfirmed: true,
+			},
+			day:        "2026-03-16",
+			wantStatus: FertilityStatusFertile,
+			wantRule:   FertilityRuleAwaitingDoubleCheck,
+		},
+		{
+			name: "late mucus peak delays the infertile phase",
+			cycle: FertilityCycle{
+				Start: start, Today: today, HasObservations: true,
+				TemperatureShift: shift, TemperatureConfirmed: true,
+				MucusPeak: mustParseDay(t, "2026-03-15"), MucusPeakConfirmed: true,
+			},
+			day:        "2026-03-18",
+			wantStatus: FertilityStatusFertile,
+			wantRule:   FertilityRuleAwaitingDoubleCheck,
+		},
+		{
+			name: "infertile from the day after peak plus three",
+			cycle: FertilityCycle{
+				Start: start, Today: today, HasObservations: true,
+				TemperatureShift: shift, TemperatureConfirmed: true,
+				MucusPeak: mustParseDay(t, "2026-03-15"), MucusPeakConfirmed: true,
+			},
+			day:        "2026-03-19",
+			wantStatus: FertilityStatusInfertile,
+			wantRule:   FertilityRuleDoubleCheck,
+		},
+		{
+			name: "future stays unknown after the double check",
+			cycle: FertilityCycle{
+				Start: start, Today: today, HasObservations: true,
+				TemperatureShift: shift, TemperatureConfirmed: true,
+				MucusPeak: mustParseDay(t, "2026-03-13"), MucusPeakConfirmed: true,
+			},
+			day:        "2026-03-25",
+			wantStatus: FertilityStatusUnknown,
+			wantRule:   FertilityRuleFuture,
+		},
+	}
+
+	engine := NewSymptothermalRulesEngine()
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := engine.EvaluateDay(testCase.cycle, mustParseDay(t, testCase.day))
+			if got.Status != testCase.wantStatus || got.Rule != testCase.wantRule {
+				t.Fatalf("expected %s by %s, got %s by %s", testCase.wantStatus, testCase.wantRule, got.Status, got.Rule)
+			}
+		})
+	}
+}
+
+func symptothermalCycleLogs(t *testing.T) []models.DailyLog {
+	t.Helper()
+
+	logs := make([]models.DailyLog, 0)
+	for _, day := range []string{"2026-03-01", "2026-03-02", "2026-03-03", "2026-03-04", "2026-03-05"} {
+		logs = append(logs, makeLog(t, day, true))
+	}
+	logs = append(logs, mucusLogs(t, "2026-03-06", []string{
+		models.MucusDry, models.MucusDry, models.MucusDry, models.MucusSticky, models.MucusCreamy,
+		models.MucusWatery, models.MucusEggWhite, models.MucusEggWhite, models.MucusSticky, models.MucusDry,
+	})...)
+	logs = append(logs, temperatureLogs(t, "2026-03-06", []float64{
+		36.40, 36.35, 36.45, 36.40, 36.38, 36.42, 36.40, 36.43,
+		36.60, 36.65, 36.70, 36.70, 36.68, 36.72, 36.70,
+	})...)
+	return logs
+}
+
+func TestSymptothermalRulesEngineEvaluate(t *testing.T) {
+	t.Parallel()
+
+	logs := symptothermalCycleLogs(t)
+
+	testCases := []struct {
+		name  string
+		now   string
+		wants map[string][2]string
+	}{
+		{
+			name: "complete cycle",
+			now:  "2026-03-20",
+			wants: map[string][2]string{
+				"2026-02-28": {FertilityStatusUnknown, FertilityRuleNoCycle},
+				"2026-03-01": {FertilityStatusInfertile, FertilityRuleFirstFiveDays},
+				"2026-03-05": {FertilityStatusInfertile, FertilityRuleFirstFiveDays},
+				"2026-03-06": {FertilityStatusFertile, FertilityRulePreOvulatory},
+				"2026-03-09": {FertilityStatusFertile, FertilityRuleMucusObserved},
+				"2026-03-13": {FertilityStatusFertile, FertilityRuleAwaitingDoubleCheck},
+				"2026-03-16": {FertilityStatusFertile, FertilityRuleAwaitingDoubleCheck},
+				"2026-03-17": {FertilityStatusInfertile, FertilityRuleDoubleCheck},
+				"2026-03-20": {FertilityStatusInfertile, FertilityRuleDoubleCheck},
+				"2026-03-21": {FertilityStatusUnknown, FertilityRuleFuture},
+			},
+		},
+		{
+			name: "readings after today are ignored",
+			now:  "2026-03-15",
+			wants: map[string][2]string{
+				"2026-03-12": {FertilityStatusFertile, FertilityRuleMucusObserved},
+				"2026-03-13": {FertilityStatusFertile, FertilityRuleAwaitingDoubleCheck},
+				"2026-03-15": {FertilityStatusFertile, FertilityRuleAwaitingDoubleCheck},
+				"2026-03-16": {FertilityStatusUnknown, FertilityRuleFuture},
+			},
+		},
+		{
+			name: "before any observation",
+			now:  "2026-03-04",
+			wants: map[string][2]string{
+				"2026-03-02": {FertilityStatusUnknown, FertilityRuleNoObservations},
+				"2026-03-05": {FertilityStatusUnknown, FertilityRuleFuture},
+			},
+		},
+	}
+
+	engine := NewSymptothermalRulesEngine()
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			statuses := engine.Evaluate(nil, logs, mustParseDay(t, "2026-02-25"), mustParseDay(t, "2026-03-25"), mustParseDay(t, testCase.now), time.UTC)
+			if len(statuses) != 29 {
+				t.Fatalf("expected 29 days, got %d", len(statuses))
+			}
+			byDay := make(map[string]FertilityDayStatus, len(statuses))
+			for _, status := range statuses {
+				byDay[status.Date.Format("2006-01-02")] = status
+			}
+			for day, want := range testCase.wants {
+				got := byDay[day]
+				if got.Status != want[0] || got.Rule != want[1] {
+					t.Fatalf("expected %s to be %s by %s, got %s by %s", day, want[0], want[1], got.Status, got.Rule)
+				}
+			}
+		})
+	}
+}
+
+func TestSymptothermalRulesEngineSplitsCycles(t *testing.T) {
+	t.Parallel()
+
+	logs := symptothermalCycleLogs(t)
+	for _, day := range []string{"2026-03-29", "2026-03-30", "2026-03-31"} {
+		logs = append(logs, makeLog(t, day, true))
+	}
+
+	statuses := NewSymptothermalRulesEngine().Evaluate(nil, logs, mustParseDay(t, "2026-03-28"), mustParseDay(t, "2026-03-31"), mustParseDay(t, "2026-04-01"), time.UTC)
+	if statuses[0].Status != FertilityStatusInfertile || statuses[0].Rule != FertilityRuleDoubleCheck {
+		t.Fatalf("expected last day of previous cycle infertile by double check, got %#v", statuses[0])
+	}
+	for _, status := range statuses[1:] {
+		if status.Status != FertilityStatusUnknown || status.Rule != FertilityRuleNoObservations {
+			t.Fatalf("expected new cycle without observations to be unknown, got %#v", status)
+		}
+	}
+}
+
+func TestSymptothermalRulesEngineFollowsCycleStatistics(t *testing.T) {
+	t.Parallel()
+
+	logs := symptothermalCycleLogs(t)
+	for _, day := range []string{"2026-03-29", "2026-03-30", "2026-03-31"} {
+		logs = append(logs, makeLog(t, day, true))
+	}
+	from := mustParseDay(t, "2026-03-29")
+	to := mustParseDay(t, "2026-03-31")
+	now := mustParseDay(t, "2026-04-01")
+
+	onPill := contraceptionTestUser(t, models.ContraceptionCombinedPill, "2026-03-08")
+	for _, status := range NewSymptothermalRulesEngine().Evaluate(onPill, logs, from, to, now, time.UTC) {
+		if status.Rule != FertilityRuleDoubleCheck {
+			t.Fatalf("expected a withdrawal bleed not to start a new cycle, got %#v", status)
+		}
+	}
+
+	pregnancyStart := mustParseDay(t, "2026-03-29")
+	pregnant := &models.User{Role: models.RoleOwner, CycleMode: models.CycleModePregnancy, PregnancyStart: &pregnancyStart}
+	for _, status := range NewSymptothermalRulesEngine().Evaluate(pregnant, logs, from, to, now, time.UTC) {
+		if status.Status != FertilityStatusUnknown || status.Rule != FertilityRuleExcludedCycle {
+			t.Fatalf("expected the pregnancy cycle to stay unknown, got %#v", status)
+		}
+	}
+}
+
+func TestFertilityRulesEngineUsesFirstMatchingRule(t *testing.T) {
+	t.Parallel()
+
+	always := func(status string) func(FertilityCycle, time.Time) (string, bool) {
+		return func(FertilityCycle, time.Time) (string, bool) { return status, true }
+	}
+	never := func(FertilityCycle, time.Time) (string, bool) { return "", false }
+
+	engine := NewFertilityRulesEngine(
+		FertilityRuleFunc{RuleName: "skipped", Func: never},
+		FertilityRuleFunc{RuleName: "first", Func: always(FertilityStatusInfertile)},
+		FertilityRuleFunc{RuleName: "second", Func: always(FertilityStatusFertile)},
+	)
+	day := mustParseDay(t, "2026-03-10")
+	got := engine.EvaluateDay(FertilityCycle{Start: mustParseDay(t, "2026-03-01")}, day)
+	if got.Status != FertilityStatusInfertile || got.Rule != "first" || !got.Date.Equal(day) {
+		t.Fatalf("expected first matching rule to decide, got %#v", got)
+	}
+
+	got = NewFertilityRulesEngine().EvaluateDay(FertilityCycle{}, day)
+	if got.Status != FertilityStatusUnknown || got.Rule != "" {
+		t.Fatalf("expected unknown without rules, got %#v", got)
+	}
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+const (
+	MaxTestBrandLength = 80
+
+	// lhSurgeOvulationDelayDays is how long after the first positive LH
+	// test ovulation is expected; the surge precedes it by 24-36 hours.
+	lhSurgeOvulationDelayDays = 1
+)
+
+var (
+	ErrInvalidDayLHTest        = errors.New("invalid day lh test")
+	ErrInvalidDayPregnancyTest = errors.New("invalid day pregnancy test")
+)
+
+func NormalizeTestResult(raw string) string {
+	return strings.ToLower(strings.TrimSpace(raw))
+}
+
+func IsValidLHTest(result string) bool {
+	switch result {
+	case "", models.LHTestNegative, models.LHTestPositive, models.LHTestPeak:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidPregnancyTest(result string) bool {
+	switch result {
+	case "", models.PregnancyTestNegative, models.PregnancyTestPositive, models.PregnancyTestFaint:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsPositiveLHTest reports whether result shows the LH surge.
+func IsPositiveLHTest(result string) bool {
+	return result == models.LHTestPositive || result == models.LHTestPeak
+}
+
+// IsPositivePregnancyTest counts a faint line as positive: any visible test
+// line means hCG was detected.
+func IsPositivePregnancyTest(result string) bool {
+	return result == models.PregnancyTestPositive || result == models.PregnancyTestFaint
+}
+
+func TrimTestBrand(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) <= MaxTestBrandLength {
+		return value
+	}
+	return value[:MaxTestBrandLength]
+}
+
+// FirstPositiveLHTest returns the first day between from and to inclusive
+// with a positive or peak LH test.
+func FirstPositiveLHTest(logs []models.DailyLog, from time.Time, to time.Time, location *time.Location) (time.Time, bool) {
+	first := time.Time{}
+	for _, logEntry := range logs {
+		if !IsPositiveLHTest(logEntry.LHTest) {
+			continue
+		}
+		day := DateAtLocation(logEntry.Date, location)
+		if (!from.IsZero() && day.Before(from)) || (!to.IsZero() && day.After(to)) {
+			continue
+		}
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+	}
+	return first, !first.IsZero()
+}
+
+// LatestPositivePregnancyTest returns the last day between from and to
+// inclusive with a positive or faint pregnancy test.
+func LatestPositivePregnancyTest(logs []models.DailyLog, from time.Time, to time.Time, location *time.Location) (time.Time, bool) {
+	latest := time.Time{}
+	for _, logEntry := range logs {
+		if !IsPositivePregnancyTest(logEntry.PregnancyTest) {
+			continue
+		}
+		day := DateAtLocation(logEntry.Date, location)
+		if (!from.IsZero() && day.Before(from)) || (!to.IsZero() && day.After(to)) {
+			continue
+		}
+		if day.After(latest) {
+			latest = day
+		}
+	}
+	return latest, !latest.IsZero()
+}
+
+// PregnancyTestPromptStart returns the first day a positive pregnancy test
+// may prompt a switch to pregnancy mode: the last detected cycle start, or
+// the day after a recorded pregnancy and its lochia weeks when that is
+// later. Tests from an earlier pregnancy never prompt again.
+func PregnancyTestPromptStart(user *models.User, logs []models.DailyLog, now time.Time, location *time.Location) time.Time {
+	if location == nil {
+		location = time.UTC
+	}
+
+	from := time.Time{}
+	if starts := DetectCycleStarts(MaskWithdrawalBleeding(user, logs, location)); len(starts) > 0 {
+		from = DateAtLocation(starts[len(starts)-1], location)
+	}
+	for _, span := range ExcludedCycleSpans(user, now, location) {
+		if afterSpan := span.End.AddDate(0, 0, 1); afterSpan.After(from) {
+			from = afterSpan
+		}
+	}
+	return from
+}
+
+// ApplyLHTest replaces the estimated ovulation of the current cycle with
+// the day after its first positive LH test. A later temperature shift still
+// takes precedence, since it confirms ovulation after the fact.
+func ApplyLHTest(stats CycleStats, logs []models.DailyLog, now time.Time, location *time.Location) CycleStats {
+	if stats.LastPeriodStart.IsZero() {
+		return stats
+	}
+	if location == nil {
+		location = time.UTC
+	}
+
+	cycleStart := DateAtLocation(stats.LastPeriodStart, location)
+	today := DateAtLocation(now.In(location), location)
+	surge, ok := FirstPositiveLHTest(logs, cycleStart, today, location)
+	if !ok {
+		return stats
+	}
+
+	ovulation := surge.AddDate(0, 0, lhSurgeOvulationDelayDays)
+	stats.LHSurgeDate = surge
+	stats.OvulationDate = ovulation
+	stats.OvulationExact = true
+	stats.OvulationImpossible = false
+	stats.FertilityWindowStart = ovulation.AddDate(0, 0, -5)
+	if stats.FertilityWindowStart.Before(cycleStart) {
+		stats.FertilityWindowStart = cycleStart
+	}
+	stats.FertilityWindowEnd = ovulation.AddDate(0, 0, 1)
+	stats.CurrentPhase = DetectCurrentPhase(stats, logs, today, location)
+	return stats
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func lhTestLogs(t *testing.T, start string, results []string) []models.DailyLog {
+	t.Helper()
+
+	first := mustParseDay(t, start)
+	logs := make([]models.DailyLog, 0, len(results))
+	for index, result := range results {
+		logs = append(logs, models.DailyLog{Date: first.AddDate(0, 0, index), Flow: models.FlowNone, LHTest: result})
+	}
+	return logs
+}
+
+func TestApplyLHTest(t *testing.T) {
+	t.Parallel()
+
+	baseline := CycleStats{
+		LastPeriodStart:      mustParseDay(t, "2026-03-01"),
+		OvulationDate:        mustParseDay(t, "2026-03-15"),
+		FertilityWindowStart: mustParseDay(t, "2026-03-10"),
+		FertilityWindowEnd:   mustParseDay(t, "2026-03-16"),
+	}
+
+	testCases := []struct {
+		name          string
+		stats         CycleStats
+		results       []string
+		now           string
+		wantSurge     string
+		wantOvulation string
+		wantStart     string
+		wantEnd       string
+	}{
+		{
+			name:          "first positive test anchors ovulation the next day",
+			stats:         baseline,
+			results:       []string{"negative", "negative", "positive", "peak", "negative"},
+			now:           "2026-03-20",
+			wantSurge:     "2026-03-12",
+			wantOvulation: "2026-03-13",
+			wantStart:     "2026-03-08",
+			wantEnd:       "2026-03-14",
+		},
+		{
+			name:          "peak counts as positive",
+			stats:         baseline,
+			results:       []string{"negative", "peak"},
+			now:           "2026-03-20",
+			wantSurge:     "2026-03-11",
+			wantOvulation: "2026-03-12",
+			wantStart:     "2026-03-07",
+			wantEnd:       "2026-03-13",
+		},
+		{
+			name:          "negative tests leave the estimate alone",
+			stats:         baseline,
+			results:       []string{"negative", "negative", "negative"},
+			now:           "2026-03-20",
+			wantOvulation: "2026-03-15",
+			wantStart:     "2026-03-10",
+			wantEnd:       "2026-03-16",
+		},
+		{
+			name:          "tests logged after today are ignored",
+			stats:         baseline,
+			results:       []string{"negative", "negative", "positive"},
+			now:           "2026-03-11",
+			wantOvulation: "2026-03-15",
+			wantStart:     "2026-03-10",
+			wantEnd:       "2026-03-16",
+		},
+		{
+			name: "tests from the previous cycle are ignored",
+			stats: func() CycleStats {
+				stats := baseline
+				stats.LastPeriodStart = mustParseDay(t, "2026-03-14")
+				return stats
+			}(),
+			results:       []string{"positive"},
+			now:           "2026-03-20",
+			wantOvulation: "2026-03-15",
+			wantStart:     "2026-03-10",
+			wantEnd:       "2026-03-16",
+		},
+		{
+			name: "window is clamped to the cycle start",
+			stats: func() CycleStats {
+				stats := baseline
+				stats.LastPeriodStart = mustParseDay(t, "2026-03-09")
+				return stats
+			}(),
+			results:       []string{"negative", "positive"},
+			now:           "2026-03-20",
+			wantSurge:     "2026-03-11",
+			wantOvulation: "2026-03-12",
+			wantStart:     "2026-03-09",
+			wantEnd:       "2026-03-13",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			logs := lhTestLogs(t, "2026-03-10", testCase.results)
+			stats := ApplyLHTest(testCase.stats, logs, mustParseDay(t, testCase.now), time.UTC)
+
+			gotSurge := ""
+			if !stats.LHSurgeDate.IsZero() {
+				gotSurge = stats.LHSurgeDate.Format("2006-01-02")
+			}
+			if gotSurge != testCase.wantSurge {
+				t.Fatalf("expected surge %q, got %q", testCase.wantSurge, gotSurge)
+			}
+			if got := stats.OvulationDate.Format("2006-01-02"); got != testCase.wantOvulation {
+				t.Fatalf("expected ovulation %s, got %s", testCase.wantOvulation, got)
+			}
+			if testCase.wantSurge != "" && !stats.OvulationExact {
+				t.Fatalf("expected LH-anchored ovulation to be exact, got %#v", stats)
+			}
+			if got := stats.FertilityWindowStart.Format("2006-01-02"); got != testCase.wantStart {
+				t.Fatalf("expected window start %s, got %s", testCase.wantStart, got)
+			}
+			if got := stats.FertilityWindowEnd.Format("2006-01-02"); got != testCase.wantEnd {
+				t.Fatalf("expected window end %s, got %s", testCase.wantEnd, got)
+			}
+		})
+	}
+}
+
+func TestApplyTemperatureShiftOverridesLHTest(t *testing.T) {
+	t.Parallel()
+
+	stats := CycleStats{LastPeriodStart: mustParseDay(t, "2026-03-01")}
+	logs := lhTestLogs(t, "2026-03-10", []string{"positive"})
+	logs = append(logs, temperatureLogs(t, "2026-03-06", []float64{
+		36.40, 36.35, 36.45, 36.40, 36.38, 36.42, 36.40, 36.43, 36.60, 36.65, 36.70,
+	})...)
+	now := mustParseDay(t, "2026-03-17")
+
+	stats = ApplyLHTest(stats, logs, now, time.UTC)
+	stats = ApplyTemperatureShift(stats, logs, now, time.UTC)
+	if !stats.OvulationConfirmed || stats.OvulationDate.Format("2006-01-02") != "2026-03-13" {
+		t.Fatalf("expected temperature-confirmed ovulation 2026-03-13, got %#v", stats)
+	}
+	if stats.LHSurgeDate.Format("2006-01-02") != "2026-03-10" {
+		t.Fatalf("expected LH surge to stay reported, got %s", stats.LHSurgeDate)
+	}
+}
+
+func TestLatestPositivePregnancyTest(t *testing.T) {
+	t.Parallel()
+
+	logs := []models.DailyLog{
+		{Date: mustParseDay(t, "2026-03-02"), PregnancyTest: models.PregnancyTestPositive},
+		{Date: mustParseDay(t, "2026-03-20"), PregnancyTest: models.PregnancyTestNegative},
+		{Date: mustParseDay(t, "2026-03-24"), PregnancyTest: models.PregnancyTestFaint},
+		{Date: mustParseDay(t, "2026-03-30"), PregnancyTest: models.PregnancyTestPositive},
+	}
+
+	got, ok := LatestPositivePregnancyTest(logs, mustParseDay(t, "2026-03-10"), mustParseDay(t, "2026-03-28"), time.UTC)
+	if !ok || got.Format("2006-01-02") != "2026-03-24" {
+		t.Fatalf("expected faint test on 2026-03-24, got %v (found=%t)", got, ok)
+	}
+	if _, ok := LatestPositivePregnancyTest(logs, mustParseDay(t, "2026-03-05"), mustParseDay(t, "2026-03-20"), time.UTC); ok {
+		t.Fatal("expected no positive test between 2026-03-05 and 2026-03-20")
+	}
+}
+
+func TestPregnancyTestPromptStartSkipsRecordedPregnancy(t *testing.T) {
+	t.Parallel()
+
+	logs := []models.DailyLog{
+		{Date: mustParseDay(t, "2025-01-01"), IsPeriod: true, Flow: models.FlowMedium},
+		{Date: mustParseDay(t, "2025-02-01"), PregnancyTest: models.PregnancyTestPositive},
+	}
+	now := mustParseDay(t, "2026-01-20")
+
+	cycleUser := &models.User{CycleMode: models.CycleModeCycle}
+	if got := PregnancyTestPromptStart(cycleUser, logs, now, time.UTC); got.Format("2006-01-02") != "2025-01-01" {
+		t.Fatalf("expected the last cycle start, got %v", got)
+	}
+
+	pregnancyStart := mustParseDay(t, "2025-01-01")
+	birth := mustParseDay(t, "2025-10-01")
+	afterBirth := &models.User{CycleMode: models.CycleModeCycle, PregnancyStart: &pregnancyStart, PostpartumStart: &birth}
+	from := PregnancyTestPromptStart(afterBirth, logs, now, time.UTC)
+	if from.Format("2006-01-02") != "2025-11-12" {
+		t.Fatalf("expected the day after the lochia weeks, got %v", from)
+	}
+	if _, ok := LatestPositivePregnancyTest(logs, from, now, time.UTC); ok {
+		t.Fatal("expected the test from the recorded pregnancy not to prompt again")
+	}
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+// clueImportFormat reads Clue's JSON data export: a list of measurements,
+// each with a date, a type and a value holding one or more options.
+type clueImportFormat struct{}
+
+type clueMeasurement struct {
+	Date  string          `json:"date"`
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+type clueOption struct {
+	Option string `json:"option"`
+}
+
+func (clueImportFormat) Name() string { return "clue" }
+
+func (clueImportFormat) Parse(raw []byte) (ImportJSONPayload, error) {
+	measurements, err := decodeClueMeasurements(raw)
+	if err != nil {
+		return ImportJSONPayload{}, err
+	}
+
+	builder := newImportEntryBuilder()
+	for index, measurement := range measurements {
+		date, ok := normalizeImportDate(measurement.Date)
+		if !ok {
+			return ImportJSONPayload{}, fmt.Errorf("%w: measurement %d: invalid date %q", ErrImportEntryInvalid, index+1, measurement.Date)
+		}
+
+		kind := strings.ToLower(strings.TrimSpace(measurement.Type))
+		if kind == "note" || kind == "notes" {
+			var note string
+			if err := json.Unmarshal(measurement.Value, &note); err == nil {
+				builder.addNote(date, note)
+			}
+			continue
+		}
+
+		for _, option := range decodeClueOptions(measurement.Value) {
+			if kind != "period" {
+				builder.addSymptom(date, option)
+				continue
+			}
+			flow, ok := foreignFlowLevel(option)
+			if !ok {
+				return ImportJSONPayload{}, fmt.Errorf("%w: %s: unknown period option %q", ErrImportEntryInvalid, date, option)
+			}
+			if flow != models.FlowNone {
+				builder.setFlow(date, flow)
+			}
+		}
+	}
+	return builder.payload(), nil
+}
+
+func decodeClueMeasurements(raw []byte) ([]clueMeasurement, error) {
+	trimmed := bytes.TrimSpace(raw)
+	measurements := make([]clueMeasurement, 0)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &measurements); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrImportPayloadInvalid, err)
+		}
+		return measurements, nil
+	}
+
+	wrapper := struct {
+		Data         []clueMeasurement `json:"data"`
+		Measurements []clueMeasurement `json:"measurements"`
+	}{}
+	if err := json.Unmarshal(trimmed, &wrapper); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrImportPayloadInvalid, err)
+	}
+	measurements = append(wrapper.Data, wrapper.Measurements...)
+	if len(measurements) == 0 {
+		return nil, fmt.Errorf("%w: no measurements", ErrImportPayloadInvalid)
+	}
+	return measurements, nil
+}
+
+// decodeClueOptions accepts {"option": "x"}, [{"option": "x"}, ...] and
+// plain strings. Numeric values (temperature, weight) carry no option and
+// are ignored.
+func decodeClueOptions(raw json.RawMessage) []string {
+	single := clueOption{}
+	if err := json.Unmarshal(raw, &single); err == nil && strings.TrimSpace(single.Option) != "" {
+		return []string{single.Option}
+	}
+	list := make([]clueOption, 0)
+	if err := json.Unmarshal(raw, &list); err == nil {
+		options := make([]string, 0, len(list))
+		for _, item := range list {
+			if strings.TrimSpace(item.Option) != "" {
+				options = append(options, item.Option)
+			}
+		}
+		return options
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil && strings.TrimSpace(text) != "" {
+		return []string{text}
+	}
+	return nil
+}
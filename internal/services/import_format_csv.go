@@ -0,0 +1,150 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+// csvImportFormat reads spreadsheet exports with a header row. It
+// recognises date, period, flow, symptoms and notes columns by name, plus
+// one yes/no column per built-in symptom as written by Ovumcy's CSV
+// export.
+type csvImportFormat struct{}
+
+var (
+	csvDateColumns     = []string{"date", "day", "datum", "дата"}
+	csvPeriodColumns   = []string{"period", "menstruation", "is period", "bleeding", "месячные"}
+	csvFlowColumns     = []string{"flow", "intensity", "menstrual flow", "bleeding flow", "выделения"}
+	csvSymptomColumns  = []string{"symptoms", "symptom", "tags", "other", "other symptoms", "симптомы"}
+	csvNotesColumns    = []string{"notes", "note", "comment", "comments", "заметки"}
+	csvListSeparators  = ";,|"
+	csvTruthyValues    = map[string]bool{"yes": true, "y": true, "true": true, "1": true, "x": true, "да": true}
+	csvBuiltinSymptoms = builtinSymptomColumnHeaders()
+)
+
+func (csvImportFormat) Name() string { return "csv" }
+
+func (csvImportFormat) Parse(raw []byte) (ImportJSONPayload, error) {
+	header, rows, err := readImportCSV(raw)
+	if err != nil {
+		return ImportJSONPayload{}, err
+	}
+
+	dateColumn := findCSVColumn(header, csvDateColumns)
+	if dateColumn < 0 {
+		return ImportJSONPayload{}, fmt.Errorf("%w: no date column", ErrImportPayloadInvalid)
+	}
+	periodColumn := findCSVColumn(header, csvPeriodColumns)
+	flowColumn := findCSVColumn(header, csvFlowColumns)
+	symptomColumns := findCSVColumns(header, csvSymptomColumns)
+	notesColumn := findCSVColumn(header, csvNotesColumns)
+
+	builder := newImportEntryBuilder()
+	for index, row := range rows {
+		rawDate := csvCell(row, dateColumn)
+		if strings.TrimSpace(rawDate) == "" {
+			continue
+		}
+		date, ok := normalizeImportDate(rawDate)
+		if !ok {
+			return ImportJSONPayload{}, fmt.Errorf("%w: row %d: invalid date %q", ErrImportEntryInvalid, index+2, rawDate)
+		}
+		builder.day(date)
+
+		flow, ok := foreignFlowLevel(csvCell(row, flowColumn))
+		if !ok {
+			return ImportJSONPayload{}, fmt.Errorf("%w: row %d: unknown flow %q", ErrImportEntryInvalid, index+2, csvCell(row, flowColumn))
+		}
+		isPeriod := csvTruthyValues[strings.ToLower(strings.TrimSpace(csvCell(row, periodColumn)))]
+		if flow != "none" || isPeriod {
+			builder.setFlow(date, flow)
+		}
+
+		for _, column := range symptomColumns {
+			for _, name := range strings.FieldsFunc(csvCell(row, column), func(r rune) bool {
+				return strings.ContainsRune(csvListSeparators, r)
+			}) {
+				builder.addSymptom(date, name)
+			}
+		}
+		for column, name := range header {
+			symptom, ok := csvBuiltinSymptoms[normalizeCSVHeader(name)]
+			if ok && csvTruthyValues[strings.ToLower(strings.TrimSpace(csvCell(row, column)))] {
+				builder.addSymptom(date, symptom)
+			}
+		}
+		builder.addNote(date, csvCell(row, notesColumn))
+	}
+
+	payload := builder.payload()
+	if len(payload.Entries) == 0 {
+		return ImportJSONPayload{}, fmt.Errorf("%w: no rows", ErrImportPayloadInvalid)
+	}
+	return payload, nil
+}
+
+func builtinSymptomColumnHeaders() map[string]string {
+	headers := make(map[string]string, len(exportSymptomColumnsByName))
+	for name := range exportSymptomColumnsByName {
+		headers[name] = canonicalImportSymptomName(name)
+	}
+	return headers
+}
+
+// readImportCSV splits a CSV file into its header and data rows. Files
+// saved by spreadsheet apps in some locales use ";" instead of ",".
+func readImportCSV(raw []byte) ([]string, [][]string, error) {
+	raw = bytes.TrimPrefix(raw, []byte("\xef\xbb\xbf"))
+	firstLine, _, _ := bytes.Cut(raw, []byte("\n"))
+
+	reader := csv.NewReader(bytes.NewReader(raw))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrImportPayloadInvalid, err)
+	}
+	if len(records) < 2 {
+		return nil, nil, fmt.Errorf("%w: no rows", ErrImportPayloadInvalid)
+	}
+	return records[0], records[1:], nil
+}
+
+func normalizeCSVHeader(value string) string {
+	return strings.ToLower(strings.Join(splitImportWords(value), " "))
+}
+
+func findCSVColumn(header []string, names []string) int {
+	columns := findCSVColumns(header, names)
+	if len(columns) == 0 {
+		return -1
+	}
+	return columns[0]
+}
+
+func findCSVColumns(header []string, names []string) []int {
+	columns := make([]int, 0, 1)
+	for index, value := range header {
+		normalized := normalizeCSVHeader(value)
+		for _, name := range names {
+			if normalized == name {
+				columns = append(columns, index)
+				break
+			}
+		}
+	}
+	return columns
+}
+
+func csvCell(row []string, column int) string {
+	if column < 0 || column >= len(row) {
+		return ""
+	}
+	return row[column]
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+// dripImportFormat reads the CSV export of the drip app. Bleeding is a
+// 0-3 scale (0 is spotting) and symptoms are boolean "pain.*" and
+// "mood.*" columns. Temperatures are recorded in Celsius.
+type dripImportFormat struct{}
+
+var dripBleedingFlows = map[string]string{
+	"0": models.FlowSpotting,
+	"1": models.FlowLight,
+	"2": models.FlowMedium,
+	"3": models.FlowHeavy,
+}
+
+func (dripImportFormat) Name() string { return "drip" }
+
+func (dripImportFormat) Parse(raw []byte) (ImportJSONPayload, error) {
+	header, rows, err := readImportCSV(raw)
+	if err != nil {
+		return ImportJSONPayload{}, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for index, name := range header {
+		columns[strings.TrimSpace(name)] = index
+	}
+	dateColumn, ok := columns["date"]
+	if !ok {
+		return ImportJSONPayload{}, fmt.Errorf("%w: no date column", ErrImportPayloadInvalid)
+	}
+	column := func(name string) int {
+		if index, ok := columns[name]; ok {
+			return index
+		}
+		return -1
+	}
+
+	builder := newImportEntryBuilder()
+	for index, row := range rows {
+		rawDate := csvCell(row, dateColumn)
+		if strings.TrimSpace(rawDate) == "" {
+			continue
+		}
+		date, ok := normalizeImportDate(rawDate)
+		if !ok {
+			return ImportJSONPayload{}, fmt.Errorf("%w: row %d: invalid date %q", ErrImportEntryInvalid, index+2, rawDate)
+		}
+
+		bleeding := strings.TrimSpace(csvCell(row, column("bleeding.value")))
+		excluded := strings.EqualFold(strings.TrimSpace(csvCell(row, column("bleeding.exclude"))), "true")
+		switch {
+		case bleeding == "" || excluded:
+		default:
+			flow, ok := dripBleedingFlows[bleeding]
+			if !ok {
+				return ImportJSONPayload{}, fmt.Errorf("%w: row %d: unknown bleeding value %q", ErrImportEntryInvalid, index+2, bleeding)
+			}
+			builder.setFlow(date, flow)
+		}
+
+		if rawTemperature := strings.TrimSpace(csvCell(row, column("temperature.value"))); rawTemperature != "" {
+			value, err := strconv.ParseFloat(rawTemperature, 64)
+			if err != nil {
+				return ImportJSONPayload{}, fmt.Errorf("%w: row %d: invalid temperature %q", ErrImportEntryInvalid, index+2, rawTemperature)
+			}
+			entry := builder.day(date)
+			entry.BBT = roundTemperature(value)
+			entry.BBTTime = strings.TrimSpace(csvCell(row, column("temperature.time")))
+			entry.BBTDisturbed = strings.EqualFold(strings.TrimSpace(csvCell(row, column("temperature.exclude"))), "true")
+		}
+
+		for columnIndex, name := range header {
+			group, symptom, found := strings.Cut(strings.TrimSpace(name), ".")
+			if !found || (group != "pain" && group != "mood") || symptom == "note" {
+				continue
+			}
+			if strings.EqualFold(strings.TrimSpace(csvCell(row, columnIndex)), "true") {
+				builder.addSymptom(date, symptom)
+			}
+		}
+		builder.addNote(date, csvCell(row, column("note.value")))
+		builder.addNote(date, csvCell(row, column("pain.note")))
+	}
+
+	payload := builder.payload()
+	if len(payload.Entries) == 0 {
+		return ImportJSONPayload{}, fmt.Errorf("%w: no rows", ErrImportPayloadInvalid)
+	}
+	return payload, nil
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+const floMaxPeriodDays = 15
+
+// floImportFormat reads the JSON file from Flo's data download: period
+// ranges under operationalData.cycles and logged events under
+// operationalData.point_events_manual_v2.
+type floImportFormat struct{}
+
+type floExport struct {
+	OperationalData struct {
+		Cycles []struct {
+			PeriodStartDate string `json:"period_start_date"`
+			PeriodEndDate   string `json:"period_end_date"`
+		} `json:"cycles"`
+		PointEvents []struct {
+			Date        string `json:"date"`
+			Category    string `json:"category"`
+			Subcategory string `json:"subcategory"`
+		} `json:"point_events_manual_v2"`
+	} `json:"operationalData"`
+}
+
+func (floImportFormat) Name() string { return "flo" }
+
+func (floImportFormat) Parse(raw []byte) (ImportJSONPayload, error) {
+	document := floExport{}
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return ImportJSONPayload{}, fmt.Errorf("%w: %v", ErrImportPayloadInvalid, err)
+	}
+	data := document.OperationalData
+	if len(data.Cycles) == 0 && len(data.PointEvents) == 0 {
+		return ImportJSONPayload{}, fmt.Errorf("%w: no cycles or events", ErrImportPayloadInvalid)
+	}
+
+	builder := newImportEntryBuilder()
+	for index, cycle := range data.Cycles {
+		start, ok := normalizeImportDate(cycle.PeriodStartDate)
+		if !ok {
+			return ImportJSONPayload{}, fmt.Errorf("%w: cycle %d: invalid period start %q", ErrImportEntryInvalid, index+1, cycle.PeriodStartDate)
+		}
+		end := start
+		if strings.TrimSpace(cycle.PeriodEndDate) != "" {
+			if end, ok = normalizeImportDate(cycle.PeriodEndDate); !ok {
+				return ImportJSONPayload{}, fmt.Errorf("%w: cycle %d: invalid period end %q", ErrImportEntryInvalid, index+1, cycle.PeriodEndDate)
+			}
+		}
+
+		startDay, _ := time.Parse(exportDateLayout, start)
+		endDay, _ := time.Parse(exportDateLayout, end)
+		days := int(endDay.Sub(startDay).Hours()/24) + 1
+		if days < 1 || days > floMaxPeriodDays {
+			return ImportJSONPayload{}, fmt.Errorf("%w: cycle %d: period of %d days", ErrImportEntryInvalid, index+1, days)
+		}
+		for offset := 0; offset < days; offset++ {
+			builder.day(startDay.AddDate(0, 0, offset).Format(exportDateLayout)).Period = true
+		}
+	}
+
+	for index, event := range data.PointEvents {
+		date, ok := normalizeImportDate(event.Date)
+		if !ok {
+			return ImportJSONPayload{}, fmt.Errorf("%w: event %d: invalid date %q", ErrImportEntryInvalid, index+1, event.Date)
+		}
+
+		category := strings.ToLower(strings.Join(splitImportWords(event.Category), " "))
+		switch category {
+		case "menstrual flow", "period", "flow":
+			flow, ok := foreignFlowLevel(event.Subcategory)
+			switch {
+			case !ok:
+				return ImportJSONPayload{}, fmt.Errorf("%w: %s: unknown flow %q", ErrImportEntryInvalid, date, event.Subcategory)
+			case flow != models.FlowNone:
+				builder.setFlow(date, flow)
+			}
+		case "note", "notes":
+			builder.addNote(date, event.Subcategory)
+		default:
+			builder.addSymptom(date, event.Subcategory)
+		}
+	}
+	return builder.payload(), nil
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+var ErrImportFormatUnsupported = errors.New("import format unsupported")
+
+// ImportFormat turns a file exported by some tracker into the neutral
+// document understood by ImportService. Parsers only translate; symptom
+// matching, conflict detection and writes happen in ImportService.
+type ImportFormat interface {
+	Name() string
+	Parse(raw []byte) (ImportJSONPayload, error)
+}
+
+var importFormats = map[string]ImportFormat{
+	"ovumcy": ovumcyImportFormat{},
+	"clue":   clueImportFormat{},
+	"flo":    floImportFormat{},
+	"drip":   dripImportFormat{},
+	"csv":    csvImportFormat{},
+}
+
+// LookupImportFormat resolves a format name. "json" is accepted as an alias
+// for Ovumcy's own JSON export.
+func LookupImportFormat(name string) (ImportFormat, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if key == "" || key == "json" {
+		key = "ovumcy"
+	}
+	format, ok := importFormats[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrImportFormatUnsupported, name)
+	}
+	return format, nil
+}
+
+func ImportFormatNames() []string {
+	names := make([]string, 0, len(importFormats))
+	for name := range importFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type ovumcyImportFormat struct{}
+
+func (ovumcyImportFormat) Name() string { return "ovumcy" }
+
+func (ovumcyImportFormat) Parse(raw []byte) (ImportJSONPayload, error) {
+	return DecodeImportJSON(raw)
+}
+
+// importEntryBuilder collects per-day facts from formats that spread one
+// day across several records.
+type importEntryBuilder struct {
+	entries map[string]*ExportJSONEntry
+}
+
+func newImportEntryBuilder() *importEntryBuilder {
+	return &importEntryBuilder{entries: make(map[string]*ExportJSONEntry)}
+}
+
+func (builder *importEntryBuilder) day(date string) *ExportJSONEntry {
+	entry, ok := builder.entries[date]
+	if !ok {
+		entry = &ExportJSONEntry{Date: date, Flow: models.FlowNone, OtherSymptoms: []string{}}
+		builder.entries[date] = entry
+	}
+	return entry
+}
+
+// setFlow marks date as a period day, keeping the heaviest flow seen.
+// Spotting never downgrades a day already known to be a period day.
+func (builder *importEntryBuilder) setFlow(date string, flow string) {
+	entry := builder.day(date)
+	if flow == models.FlowSpotting && entry.Period {
+		return
+	}
+	entry.Period = true
+	if importFlowRank(flow) > importFlowRank(entry.Flow) {
+		entry.Flow = flow
+	}
+}
+
+func (builder *importEntryBuilder) addSymptom(date string, rawName string) {
+	name := canonicalImportSymptomName(rawName)
+	if name == "" {
+		return
+	}
+	entry := builder.day(date)
+	for _, existing := range entry.OtherSymptoms {
+		if strings.EqualFold(existing, name) {
+			return
+		}
+	}
+	entry.OtherSymptoms = append(entry.OtherSymptoms, name)
+}
+
+func (builder *importEntryBuilder) addNote(date string, note string) {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return
+	}
+	entry := builder.day(date)
+	if entry.Notes == "" {
+		entry.Notes = note
+		return
+	}
+	entry.Notes += "\n" + note
+}
+
+func (builder *importEntryBuilder) payload() ImportJSONPayload {
+	dates := make([]string, 0, len(builder.entries))
+	for date := range builder.entries {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	entries := make([]ExportJSONEntry, 0, len(dates))
+	for _, date := range dates {
+		entry := *builder.entries[date]
+		if entry.Period && entry.Flow == models.FlowNone {
+			entry.Flow = models.FlowMedium
+		}
+		entries = append(entries, entry)
+	}
+	return ImportJSONPayload{Entries: entries}
+}
+
+func importFlowRank(flow string) int {
+	switch flow {
+	case models.FlowSpotting:
+		return 1
+	case models.FlowLight:
+		return 2
+	case models.FlowMedium:
+		return 3
+	case models.FlowHeavy:
+		return 4
+	default:
+		return 0
+	}
+}
+
+var importDateLayouts = []string{exportDateLayout, "2006/01/02", "02.01.2006"}
+
+// normalizeImportDate accepts ISO dates (optionally followed by a time),
+// slash-separated ISO dates and dotted day-first dates.
+func normalizeImportDate(raw string) (string, bool) {
+	value := strings.TrimSpace(raw)
+	if len(value) > 10 && (value[10] == 'T' || value[10] == ' ') {
+		value = value[:10]
+	}
+	for _, layout := range importDateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed.Format(exportDateLayout), true
+		}
+	}
+	return "", false
+}
+
+// foreignFlowLevel maps the flow words used by other trackers.
+func foreignFlowLevel(raw string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", "none", "no", "0", "false":
+		return models.FlowNone, true
+	case "spotting", "spot":
+		return models.FlowSpotting, true
+	case "light", "low", "l", "1", "light flow":
+		return models.FlowLight, true
+	case "medium", "moderate", "normal", "m", "2", "medium flow", "yes", "true":
+		return models.FlowMedium, true
+	case "heavy", "high", "h", "3", "very heavy", "super heavy", "heavy flow":
+		return models.FlowHeavy, true
+	default:
+		return "", false
+	}
+}
+
+var importSymptomAliases = map[string]string{
+	"cramps":            "Cramps",
+	"cramp":             "Cramps",
+	"menstrual cramps":  "Cramps",
+	"period cramps":     "Cramps",
+	"headache":          "Headache",
+	"headaches":         "Headache",
+	"acne":              "Acne",
+	"mood":              "Mood swings",
+	"mood swings":       "Mood swings",
+	"moodswings":        "Mood swings",
+	"bloating":          "Bloating",
+	"bloated":           "Bloating",
+	"fatigue":           "Fatigue",
+	"tired":             "Fatigue",
+	"exhausted":         "Fatigue",
+	"tender breasts":    "Breast tenderness",
+	"breast tenderness": "Breast tenderness",
+	"tender breast":     "Breast tenderness",
+	"back pain":         "Back pain",
+	"backache":          "Back pain",
+	"lower back pain":   "Back pain",
+	"nausea":            "Nausea",
+	"spotting":          "Spotting",
+	"irritability":      "Irritability",
+	"irritable":         "Irritability",
+	"insomnia":          "Insomnia",
+	"food cravings":     "Food cravings",
+	"cravings":          "Food cravings",
+	"diarrhea":          "Diarrhea",
+	"diarrhoea":         "Diarrhea",
+	"constipation":      "Constipation",
+	"swelling":          "Swelling",
+}
+
+// canonicalImportSymptomName maps foreign spellings ("tenderBreasts",
+// "back_pain") onto built-in symptom names and tidies anything unknown so
+// it can become a custom symptom.
+func canonicalImportSymptomName(raw string) string {
+	words := splitImportWords(raw)
+	if len(words) == 0 {
+		return ""
+	}
+	joined := strings.ToLower(strings.Join(words, " "))
+	if alias, ok := importSymptomAliases[joined]; ok {
+		return alias
+	}
+	runes := []rune(joined)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+func splitImportWords(raw string) []string {
+	var words []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = current[:0]
+		}
+	}
+	runes := []rune(strings.TrimSpace(raw))
+	for index, r := range runes {
+		switch {
+		case r == '_' || r == '-' || r == '.' || unicode.IsSpace(r):
+			flush()
+		case unicode.IsUpper(r) && index > 0 && unicode.IsLower(runes[index-1]):
+			flush()
+			current = append(current, r)
+		default:
+			current = append(current, r)
+		}
+	}
+	flush()
+	return words
+}
@@ -0,0 +1,263 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func TestLookupImportFormat(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{raw: "", want: "ovumcy"},
+		{raw: "json", want: "ovumcy"},
+		{raw: " Clue ", want: "clue"},
+		{raw: "flo", want: "flo"},
+		{raw: "drip", want: "drip"},
+		{raw: "CSV", want: "csv"},
+		{raw: "xml", wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.raw, func(t *testing.T) {
+			t.Parallel()
+
+			format, err := LookupImportFormat(testCase.raw)
+			if testCase.wantErr {
+				if !errors.Is(err, ErrImportFormatUnsupported) {
+					t.Fatalf("expected ErrImportFormatUnsupported, got %v", err)
+				}
+				return
+			}
+			if err != nil || format.Name() != testCase.want {
+				t.Fatalf("expected %q, got %v (%v)", testCase.want, format, err)
+			}
+		})
+	}
+}
+
+func TestImportFormatsParse(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		format string
+		raw    string
+		want   []ExportJSONEntry
+	}{
+		{
+			name:   "clue measurements",
+			format: "clue",
+			raw: `{"data":[
+				{"date":"2026-02-10","type":"period","value":{"option":"heavy"}},
+				{"date":"2026-02-10","type":"pain","value":[{"option":"cramps"},{"option":"headache"}]},
+				{"date":"2026-02-11","type":"period","value":{"option":"spotting"}},
+				{"date":"2026-02-11","type":"energy","value":{"option":"exhausted"}},
+				{"date":"2026-02-12T08:30:00Z","type":"note","value":"long walk"},
+				{"date":"2026-02-12","type":"bbt","value":36.6}
+			]}`,
+			want: []ExportJSONEntry{
+				{Date: "2026-02-10", Period: true, Flow: models.FlowHeavy, OtherSymptoms: []string{"Cramps", "Headache"}},
+				{Date: "2026-02-11", Period: true, Flow: models.FlowSpotting, OtherSymptoms: []string{"Fatigue"}},
+				{Date: "2026-02-12", Flow: models.FlowNone, OtherSymptoms: []string{}, Notes: "long walk"},
+			},
+		},
+		{
+			name:   "flo cycles and events",
+			format: "flo",
+			raw: `{"operationalData":{
+				"cycles":[{"period_start_date":"2026-02-10","period_end_date":"2026-02-11"}],
+				"point_events_manual_v2":[
+					{"date":"2026-02-10","category":"MenstrualFlow","subcategory":"Light"},
+					{"date":"2026-02-11","category":"Symptom","subcategory":"TenderBreasts"},
+					{"date":"2026-02-11","category":"MenstrualFlow","subcategory":"Spotting"},
+					{"date":"2026-02-13","category":"Mood","subcategory":"Sensitive"},
+					{"date":"2026-02-20","category":"MenstrualFlow","subcategory":"Spotting"}
+				]
+			}}`,
+			want: []ExportJSONEntry{
+				{Date: "2026-02-10", Period: true, Flow: models.FlowLight, OtherSymptoms: []string{}},
+				{Date: "2026-02-11", Period: true, Flow: models.FlowMedium, OtherSymptoms: []string{"Breast tenderness"}},
+				{Date: "2026-02-13", Flow: models.FlowNone, OtherSymptoms: []string{"Sensitive"}},
+				{Date: "2026-02-20", Period: true, Flow: models.FlowSpotting, OtherSymptoms: []string{}},
+			},
+		},
+		{
+			name:   "drip csv",
+			format: "drip",
+			raw: "date,temperature.value,temperature.time,temperature.exclude,bleeding.value,bleeding.exclude,pain.cramps,pain.backache,pain.note,mood.anxious,note.value\n" +
+				"2026-02-10,,,,3,false,true,true,,false,first day\n" +
+				"2026-02-11,36.45,06:30,true,0,false,false,false,sharp,true,\n" +
+				"2026-02-12,,,,2,true,false,false,,false,\n",
+			want: []ExportJSONEntry{
+				{Date: "2026-02-10", Period: true, Flow: models.FlowHeavy, OtherSymptoms: []string{"Cramps", "Back pain"}, Notes: "first day"},
+				{Date: "2026-02-11", Period: true, Flow: models.FlowSpotting, OtherSymptoms: []string{"Anxious"}, Notes: "sharp", BBT: 36.45, BBTTime: "06:30", BBTDisturbed: true},
+			},
+		},
+		{
+			name:   "generic csv with semicolons",
+			format: "csv",
+			raw: "\xef\xbb\xbfDatum;Menstruation;Intensity;Tags;Comment\n" +
+				"10.02.2026;yes;;cramps, Dizziness;\n" +
+				"2026/02/11;;light;;short note\n",
+			want: []ExportJSONEntry{
+				{Date: "2026-02-10", Period: true, Flow: models.FlowMedium, OtherSymptoms: []string{"Cramps", "Dizziness"}},
+				{Date: "2026-02-11", Period: true, Flow: models.FlowLight, OtherSymptoms: []string{}, Notes: "short note"},
+			},
+		},
+		{
+			name:   "ovumcy csv export",
+			format: "csv",
+			raw: "Date,Period,Flow,Cramps,Headache,Mood,Other,Notes\n" +
+				"2026-02-10,Yes,Heavy,Yes,No,Yes,Dizziness,ok\n",
+			want: []ExportJSONEntry{
+				{Date: "2026-02-10", Period: true, Flow: models.FlowHeavy, OtherSymptoms: []string{"Dizziness", "Cramps", "Mood swings"}, Notes: "ok"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			format, err := LookupImportFormat(testCase.format)
+			if err != nil {
+				t.Fatalf("LookupImportFormat() unexpected error: %v", err)
+			}
+			payload, err := format.Parse([]byte(testCase.raw))
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(payload.Entries, testCase.want) {
+				t.Fatalf("unexpected entries:\n got  %#v\n want %#v", payload.Entries, testCase.want)
+			}
+		})
+	}
+}
+
+func TestImportFormatsRejectInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		format  string
+		raw     string
+		wantErr error
+	}{
+		{name: "clue not json", format: "clue", raw: "date,type", wantErr: ErrImportPayloadInvalid},
+		{name: "clue bad date", format: "clue", raw: `[{"date":"tomorrow","type":"pain","value":{"option":"cramps"}}]`, wantErr: ErrImportEntryInvalid},
+		{name: "clue unknown period option", format: "clue", raw: `[{"date":"2026-02-10","type":"period","value":{"option":"torrential"}}]`, wantErr: ErrImportEntryInvalid},
+		{name: "flo empty", format: "flo", raw: `{"operationalData":{}}`, wantErr: ErrImportPayloadInvalid},
+		{name: "flo endless period", format: "flo", raw: `{"operationalData":{"cycles":[{"period_start_date":"2026-01-01","period_end_date":"2026-02-01"}]}}`, wantErr: ErrImportEntryInvalid},
+		{name: "drip without date column", format: "drip", raw: "day,bleeding.value\n2026-02-10,1\n", wantErr: ErrImportPayloadInvalid},
+		{name: "drip unknown bleeding", format: "drip", raw: "date,bleeding.value\n2026-02-10,7\n", wantErr: ErrImportEntryInvalid},
+		{name: "csv header only", format: "csv", raw: "date,flow\n", wantErr: ErrImportPayloadInvalid},
+		{name: "csv bad date", format: "csv", raw: "date,flow\n31/31/2026,light\n", wantErr: ErrImportEntryInvalid},
+		{name: "csv unknown flow", format: "csv", raw: "date,flow\n2026-02-10,gushing\n", wantErr: ErrImportEntryInvalid},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			format, err := LookupImportFormat(testCase.format)
+			if err != nil {
+				t.Fatalf("LookupImportFormat() unexpected error: %v", err)
+			}
+			if _, err := format.Parse([]byte(testCase.raw)); !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("expected %v, got %v", testCase.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCanonicalImportSymptomName(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"tenderBreasts":   "Breast tenderness",
+		"back_pain":       "Back pain",
+		"MOOD":            "Mood swings",
+		"  cravings ":     "Food cravings",
+		"hot-flashes":     "Hot flashes",
+		"":                "",
+		"BrainFog":        "Brain fog",
+		"lower back pain": "Back pain",
+	}
+
+	for raw, want := range testCases {
+		if got := canonicalImportSymptomName(raw); got != want {
+			t.Fatalf("canonicalImportSymptomName(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}
+
+func TestOvumcyCSVExportRoundTripKeepsSpotting(t *testing.T) {
+	t.Parallel()
+
+	exports := NewExportService(
+		&stubExportDayReader{
+			logs: []models.DailyLog{
+				{Date: mustParseExportDay(t, "2026-02-01"), IsPeriod: true, Flow: models.FlowHeavy},
+				{Date: mustParseExportDay(t, "2026-02-02"), IsPeriod: true, Flow: models.FlowMedium},
+				{Date: mustParseExportDay(t, "2026-02-14"), IsPeriod: true, Flow: models.FlowSpotting},
+			},
+		},
+		&stubExportSymptomReader{},
+		&stubExportMedicationReader{},
+		&stubExportMetricReader{},
+	)
+	headers, err := exports.BuildCSVHeaders(7)
+	if err != nil {
+		t.Fatalf("BuildCSVHeaders() unexpected error: %v", err)
+	}
+	rows, err := exports.BuildCSVRows(7, nil, nil, time.UTC)
+	if err != nil {
+		t.Fatalf("BuildCSVRows() unexpected error: %v", err)
+	}
+	var file bytes.Buffer
+	writer := csv.NewWriter(&file)
+	_ = writer.Write(headers)
+	for _, row := range rows {
+		_ = writer.Write(row.Columns())
+	}
+	writer.Flush()
+
+	format, err := LookupImportFormat("csv")
+	if err != nil {
+		t.Fatalf("LookupImportFormat() unexpected error: %v", err)
+	}
+	payload, err := format.Parse(file.Bytes())
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	logs := &stubImportLogRepo{}
+	service := newTestImportService(logs, &stubImportSymptomStore{symptoms: importTestSymptoms()}, &stubImportCycleSync{})
+	if _, err := service.Import(7, payload, ImportModeMerge, false, time.UTC); err != nil {
+		t.Fatalf("Import() unexpected error: %v", err)
+	}
+
+	if len(logs.created) != 3 {
+		t.Fatalf("expected three imported days, got %#v", logs.created)
+	}
+	spotting := logs.created[2]
+	if !spotting.IsPeriod || spotting.Flow != models.FlowSpotting || len(spotting.SymptomIDs) != 0 {
+		t.Fatalf("expected the spotting day to keep its flow, got %#v", spotting)
+	}
+	if starts := DetectCycleStarts(logs.created); len(starts) != 1 {
+		t.Fatalf("expected the spotting day not to start a cycle, got %v", starts)
+	}
+}
@@ -0,0 +1,1039 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+type ImportMode string
+
+const (
+	ImportModeMerge        ImportMode = "merge"
+	ImportModeSkipExisting ImportMode = "skip_existing"
+	ImportModeOverwrite    ImportMode = "overwrite"
+)
+
+const (
+	ImportActionCreate    = "create"
+	ImportActionUpdate    = "update"
+	ImportActionSkip      = "skip"
+	ImportActionUnchanged = "unchanged"
+
+	importDefaultSymptomColor = "#A1887F"
+	importColumnPrefix        = "column:"
+)
+
+var (
+	ErrImportPayloadInvalid = errors.New("import payload invalid")
+	ErrImportModeInvalid    = errors.New("import mode invalid")
+	ErrImportEntryInvalid   = errors.New("import entry invalid")
+	ErrImportLoadFailed     = errors.New("import load failed")
+	ErrImportWriteFailed    = errors.New("import write failed")
+)
+
+// ExportCustomSymptom describes a user-defined symptom so that an import
+// can recreate it with the original icon and colour.
+type ExportCustomSymptom struct {
+	Name  string `json:"name"`
+	Icon  string `json:"icon"`
+	Color string `json:"color"`
+}
+
+// ImportJSONPayload is the document produced by the JSON export.
+type ImportJSONPayload struct {
+	ExportedAt     string                `json:"exported_at"`
+	CustomSymptoms []ExportCustomSymptom `json:"custom_symptoms"`
+	CustomMetrics  []ExportCustomMetric  `json:"custom_metrics"`
+	Entries        []ExportJSONEntry     `json:"entries"`
+}
+
+// ImportDayChange describes what an import does to one day. Conflict is
+// set when the day already exists with data that differs from the file,
+// whichever mode decides the outcome.
+type ImportDayChange struct {
+	Date     string `json:"date"`
+	Action   string `json:"action"`
+	Conflict bool   `json:"conflict"`
+}
+
+type ImportReport struct {
+	Mode               ImportMode        `json:"mode"`
+	DryRun             bool              `json:"dry_run"`
+	TotalEntries       int               `json:"total_entries"`
+	Created            int               `json:"created"`
+	Updated            int               `json:"updated"`
+	Skipped            int               `json:"skipped"`
+	Unchanged          int               `json:"unchanged"`
+	Conflicts          int               `json:"conflicts"`
+	SymptomsCreated    []string          `json:"symptoms_created"`
+	MedicationsCreated []string          `json:"medications_created"`
+	MetricsCreated     []string          `json:"metrics_created"`
+	Changes            []ImportDayChange `json:"changes"`
+}
+
+type ImportLogRepository interface {
+	ListByUser(userID uint) ([]models.DailyLog, error)
+	Create(entry *models.DailyLog) error
+	Save(entry *models.DailyLog) error
+}
+
+type ImportSymptomStore interface {
+	FetchSymptoms(userID uint) ([]models.SymptomType, error)
+	CreateUserSymptom(symptom *models.SymptomType) error
+}
+
+// ImportMedicationStore reads and writes the medication catalog and the
+// intakes recorded beside each day.
+type ImportMedicationStore interface {
+	ListByUser(userID uint) ([]models.Medication, error)
+	Create(medication *models.Medication) error
+	ListIntakesByUserRange(userID uint, fromStart *time.Time, toEnd *time.Time) ([]models.MedicationIntake, error)
+	ReplaceIntakesForDay(userID uint, dayStart time.Time, dayEnd time.Time, intakes []models.MedicationIntake) error
+}
+
+// ImportMetricStore reads and writes the custom metric catalog and the
+// values recorded for each day.
+type ImportMetricStore interface {
+	ListTypesByUser(userID uint) ([]models.MetricType, error)
+	CreateType(metric *models.MetricType) error
+	ListValuesByUserRange(userID uint, fromStart *time.Time, toEnd *time.Time) ([]models.MetricValue, error)
+	ReplaceValuesForDay(userID uint, dayStart time.Time, dayEnd time.Time, values []models.MetricValue) error
+}
+
+type ImportCycleSync interface {
+	RefreshUserLastPeriodStart(userID uint, location *time.Location) error
+}
+
+// ImportStores are the stores one import reads and writes through.
+type ImportStores struct {
+	Logs        ImportLogRepository
+	Symptoms    ImportSymptomStore
+	Medications ImportMedicationStore
+	Metrics     ImportMetricStore
+	Cycles      ImportCycleSync
+}
+
+// ImportDayLogRepository is the daily log repository behind both the day
+// and symptom services an import uses.
+type ImportDayLogRepository interface {
+	DayLogRepository
+	SymptomLogRepository
+}
+
+// NewImportStores wires the import stores to one set of repositories.
+func NewImportStores(logs ImportDayLogRepository, users DayUserRepository, symptoms SymptomRepository, medications ImportMedicationStore, metrics ImportMetricStore) ImportStores {
+	return ImportStores{
+		Logs:        logs,
+		Symptoms:    NewSymptomService(symptoms, logs),
+		Medications: medications,
+		Metrics:     metrics,
+		Cycles:      NewDayService(logs, users),
+	}
+}
+
+// ImportTransactor runs fn with stores bound to a single database
+// transaction and rolls back everything fn wrote when it returns an error.
+type ImportTransactor func(fn func(stores ImportStores) error) error
+
+type ImportService struct {
+	transact ImportTransactor
+}
+
+func NewImportService(transact ImportTransactor) *ImportService {
+	return &ImportService{transact: transact}
+}
+
+func ParseImportMode(raw string) (ImportMode, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", string(ImportModeMerge):
+		return ImportModeMerge, nil
+	case string(ImportModeSkipExisting), "skip":
+		return ImportModeSkipExisting, nil
+	case string(ImportModeOverwrite):
+		return ImportModeOverwrite, nil
+	default:
+		return "", ErrImportModeInvalid
+	}
+}
+
+func DecodeImportJSON(raw []byte) (ImportJSONPayload, error) {
+	payload := ImportJSONPayload{}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return ImportJSONPayload{}, fmt.Errorf("%w: %v", ErrImportPayloadInvalid, err)
+	}
+	if payload.Entries == nil && payload.CustomSymptoms == nil && payload.CustomMetrics == nil {
+		return ImportJSONPayload{}, fmt.Errorf("%w: no entries", ErrImportPayloadInvalid)
+	}
+	return payload, nil
+}
+
+type importDay struct {
+	key   string
+	date  time.Time
+	input DayEntryInput
+	names []string
+	// severities is keyed by symptom name, as in the export.
+	severities  map[string]int
+	medications []ExportMedicationDose
+	// metrics is keyed by metric name, as in the export.
+	metrics map[string]float64
+}
+
+// importDayState is what an import compares and writes for one day: the
+// log and the intakes and metric values stored beside it, keyed by
+// medication and metric ID.
+type importDayState struct {
+	log     models.DailyLog
+	intakes map[uint]models.MedicationIntake
+	metrics map[uint]float64
+}
+
+// Import applies an export document to userID. Every entry is validated
+// before anything is written, and all writes share one transaction, so a
+// malformed file or a failed write changes nothing. With dryRun the report
+// describes what would change and nothing is stored.
+func (service *ImportService) Import(userID uint, payload ImportJSONPayload, mode ImportMode, dryRun bool, location *time.Location) (ImportReport, error) {
+	if location == nil {
+		location = time.UTC
+	}
+	report := ImportReport{}
+	err := service.transact(func(stores ImportStores) error {
+		var err error
+		report, err = importPayload(stores, userID, payload, mode, dryRun, location)
+		return err
+	})
+	if err != nil {
+		return ImportReport{}, err
+	}
+	return report, nil
+}
+
+func importPayload(stores ImportStores, userID uint, payload ImportJSONPayload, mode ImportMode, dryRun bool, location *time.Location) (ImportReport, error) {
+	report := ImportReport{
+		Mode:               mode,
+		DryRun:             dryRun,
+		TotalEntries:       len(payload.Entries),
+		SymptomsCreated:    []string{},
+		MedicationsCreated: []string{},
+		MetricsCreated:     []string{},
+		Changes:            []ImportDayChange{},
+	}
+
+	days, err := parseImportEntries(payload.Entries, location)
+	if err != nil {
+		return ImportReport{}, err
+	}
+	for _, definition := range payload.CustomSymptoms {
+		if len(strings.TrimSpace(definition.Name)) > maxSymptomNameLength {
+			return ImportReport{}, fmt.Errorf("%w: custom symptom name too long", ErrImportEntryInvalid)
+		}
+	}
+
+	symptoms, err := stores.Symptoms.FetchSymptoms(userID)
+	if err != nil {
+		return ImportReport{}, fmt.Errorf("%w: %v", ErrImportLoadFailed, err)
+	}
+	resolver := newImportSymptomResolver(userID, symptoms, payload.CustomSymptoms)
+
+	existingLogs, err := stores.Logs.ListByUser(userID)
+	if err != nil {
+		return ImportReport{}, fmt.Errorf("%w: %v", ErrImportLoadFailed, err)
+	}
+	existingByDate := make(map[string]models.DailyLog, len(existingLogs))
+	for _, entry := range existingLogs {
+		existingByDate[DateAtLocation(entry.Date, location).Format(exportDateLayout)] = entry
+	}
+
+	medications, err := stores.Medications.ListByUser(userID)
+	if err != nil {
+		return ImportReport{}, fmt.Errorf("%w: %v", ErrImportLoadFailed, err)
+	}
+	medicationResolver := newImportMedicationResolver(userID, medications)
+	existingIntakes, err := stores.Medications.ListIntakesByUserRange(userID, nil, nil)
+	if err != nil {
+		return ImportReport{}, fmt.Errorf("%w: %v", ErrImportLoadFailed, err)
+	}
+	intakesByDate := make(map[string]map[uint]models.MedicationIntake)
+	for _, intake := range existingIntakes {
+		key := DateAtLocation(intake.Date, location).Format(exportDateLayout)
+		if intakesByDate[key] == nil {
+			intakesByDate[key] = make(map[uint]models.MedicationIntake)
+		}
+		intakesByDate[key][intake.MedicationID] = intake
+	}
+
+	metricTypes, err := stores.Metrics.ListTypesByUser(userID)
+	if err != nil {
+		return ImportReport{}, fmt.Errorf("%w: %v", ErrImportLoadFailed, err)
+	}
+	metricResolver := newImportMetricResolver(userID, metricTypes, payload.CustomMetrics)
+	existingValues, err := stores.Metrics.ListValuesByUserRange(userID, nil, nil)
+	if err != nil {
+		return ImportReport{}, fmt.Errorf("%w: %v", ErrImportLoadFailed, err)
+	}
+	valuesByDate := make(map[string]map[uint]float64)
+	for _, value := range existingValues {
+		key := DateAtLocation(value.Date, location).Format(exportDateLayout)
+		if valuesByDate[key] == nil {
+			valuesByDate[key] = make(map[uint]float64)
+		}
+		valuesByDate[key][value.MetricTypeID] = value.Value
+	}
+	for _, definition := range payload.CustomMetrics {
+		if _, err := metricResolver.resolve(definition.Name, stores.Metrics, dryRun); err != nil {
+			return ImportReport{}, err
+		}
+	}
+
+	for _, definition := range payload.CustomSymptoms {
+		if _, err := resolver.resolve(definition.Name, stores.Symptoms, dryRun); err != nil {
+			return ImportReport{}, err
+		}
+	}
+
+	periodChanged := false
+	for _, day := range days {
+		symptomIDs, err := resolver.resolveAll(day.names, stores.Symptoms, dryRun)
+		if err != nil {
+			return ImportReport{}, err
+		}
+		day.input.SymptomIDs = symptomIDs
+		day.input.SymptomSeverities, err = NormalizeSymptomSeverities(symptomIDs, resolver.severitiesByID(day.severities))
+		if err != nil {
+			return ImportReport{}, fmt.Errorf("%w: %s: invalid symptom severity", ErrImportEntryInvalid, day.key)
+		}
+
+		dayStart, dayEnd := DayRange(day.date, location)
+		intakes := make(map[uint]models.MedicationIntake, len(day.medications))
+		for _, dose := range day.medications {
+			medicationID, err := medicationResolver.resolve(dose, stores.Medications, dryRun)
+			if err != nil {
+				return ImportReport{}, err
+			}
+			intakes[medicationID] = models.MedicationIntake{UserID: userID, MedicationID: medicationID, Date: dayStart, Dose: dose.Dose, Unit: dose.Unit}
+		}
+		metricValues := make(map[uint]float64, len(day.metrics))
+		for name, value := range day.metrics {
+			metric, err := metricResolver.resolve(name, stores.Metrics, dryRun)
+			if err != nil {
+				return ImportReport{}, err
+			}
+			normalized, err := NormalizeMetricValue(metric, value)
+			if err != nil {
+				return ImportReport{}, fmt.Errorf("%w: %s: invalid value for metric %q", ErrImportEntryInvalid, day.key, name)
+			}
+			metricValues[metric.ID] = normalized
+		}
+
+		existingLog, found := existingByDate[day.key]
+		existing := importDayState{log: existingLog, intakes: intakesByDate[day.key], metrics: valuesByDate[day.key]}
+		action := ImportActionCreate
+		conflict := false
+		nextLog := models.DailyLog{
+			UserID:            userID,
+			Date:              day.date,
+			IsPeriod:          day.input.IsPeriod,
+			Flow:              day.input.Flow,
+			SymptomIDs:        day.input.SymptomIDs,
+			Notes:             day.input.Notes,
+			SymptomSeverities: day.input.SymptomSeverities,
+		}
+		applyDayTemperature(&nextLog, day.input)
+		applyDayCervical(&nextLog, day.input)
+		applyDayTests(&nextLog, day.input)
+		applyDayPill(&nextLog, day.input)
+		applyDayIntimacy(&nextLog, day.input)
+		applyDayCycleOverride(&nextLog, day.input)
+		next := importDayState{
+			log:     nextLog,
+			intakes: mergeImportIntakes(existing.intakes, intakes),
+			metrics: mergeImportMetricValues(existing.metrics, metricValues),
+		}
+		imported := importDayState{intakes: intakes, metrics: metricValues}
+		if found {
+			conflict = !importDayStatesEqual(existing, applyImportDayState(existing, day.input, imported, ImportModeOverwrite))
+			next = applyImportDayState(existing, day.input, imported, mode)
+			switch {
+			case mode == ImportModeSkipExisting:
+				action = ImportActionSkip
+			case importDayStatesEqual(existing, next):
+				action = ImportActionUnchanged
+			default:
+				action = ImportActionUpdate
+			}
+		}
+
+		switch action {
+		case ImportActionCreate:
+			report.Created++
+		case ImportActionUpdate:
+			report.Updated++
+		case ImportActionSkip:
+			report.Skipped++
+		case ImportActionUnchanged:
+			report.Unchanged++
+		}
+		if conflict {
+			report.Conflicts++
+		}
+		report.Changes = append(report.Changes, ImportDayChange{Date: day.key, Action: action, Conflict: conflict})
+
+		if dryRun || (action != ImportActionCreate && action != ImportActionUpdate) {
+			continue
+		}
+		if action == ImportActionCreate {
+			err = stores.Logs.Create(&next.log)
+		} else {
+			err = stores.Logs.Save(&next.log)
+		}
+		if err != nil {
+			return ImportReport{}, fmt.Errorf("%w: %s: %v", ErrImportWriteFailed, day.key, err)
+		}
+		if !importIntakesEqual(existing.intakes, next.intakes) {
+			if err := stores.Medications.ReplaceIntakesForDay(userID, dayStart, dayEnd, sortedImportIntakes(next.intakes)); err != nil {
+				return ImportReport{}, fmt.Errorf("%w: %s: %v", ErrImportWriteFailed, day.key, err)
+			}
+		}
+		if !importMetricValuesEqual(existing.metrics, next.metrics) {
+			if err := stores.Metrics.ReplaceValuesForDay(userID, dayStart, dayEnd, sortedImportMetricValues(userID, dayStart, next.metrics)); err != nil {
+				return ImportReport{}, fmt.Errorf("%w: %s: %v", ErrImportWriteFailed, day.key, err)
+			}
+		}
+		if next.log.IsPeriod || (found && existing.log.IsPeriod) {
+			periodChanged = true
+		}
+	}
+
+	report.SymptomsCreated = resolver.created
+	report.MedicationsCreated = medicationResolver.created
+	report.MetricsCreated = metricResolver.created
+	if periodChanged && stores.Cycles != nil {
+		if err := stores.Cycles.RefreshUserLastPeriodStart(userID, location); err != nil {
+			return ImportReport{}, fmt.Errorf("%w: %v", ErrSyncLastPeriodFailed, err)
+		}
+	}
+	return report, nil
+}
+
+func parseImportEntries(entries []ExportJSONEntry, location *time.Location) ([]importDay, error) {
+	days := make([]importDay, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+	for index, entry := range entries {
+		key := strings.TrimSpace(entry.Date)
+		parsed, err := time.ParseInLocation(exportDateLayout, key, location)
+		if err != nil {
+			return nil, fmt.Errorf("%w: entry %d: invalid date %q", ErrImportEntryInvalid, index+1, entry.Date)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("%w: entry %d: duplicate date %s", ErrImportEntryInvalid, index+1, key)
+		}
+		seen[key] = true
+
+		flow := strings.ToLower(strings.TrimSpace(entry.Flow))
+		if flow == "" {
+			flow = models.FlowNone
+		}
+		if !IsValidDayFlow(flow) {
+			return nil, fmt.Errorf("%w: %s: invalid flow %q", ErrImportEntryInvalid, key, entry.Flow)
+		}
+		if !entry.Period {
+			flow = models.FlowNone
+		}
+
+		bbtTime := strings.TrimSpace(entry.BBTTime)
+		if entry.BBT != 0 && !IsValidBBTCelsius(entry.BBT) {
+			return nil, fmt.Errorf("%w: %s: invalid bbt %v", ErrImportEntryInvalid, key, entry.BBT)
+		}
+		if !IsValidBBTTime(bbtTime) {
+			return nil, fmt.Errorf("%w: %s: invalid bbt time %q", ErrImportEntryInvalid, key, entry.BBTTime)
+		}
+
+		mucus := NormalizeCervicalValue(entry.Mucus)
+		cervixPosition := NormalizeCervicalValue(entry.CervixPosition)
+		cervixFirmness := NormalizeCervicalValue(entry.CervixFirmness)
+		if !IsValidMucus(mucus) || !IsValidCervixPosition(cervixPosition) || !IsValidCervixFirmness(cervixFirmness) {
+			return nil, fmt.Errorf("%w: %s: invalid cervical observation", ErrImportEntryInvalid, key)
+		}
+
+		lhTest := NormalizeTestResult(entry.LHTest)
+		pregnancyTest := NormalizeTestResult(entry.PregnancyTest)
+		if !IsValidLHTest(lhTest) || !IsValidPregnancyTest(pregnancyTest) {
+			return nil, fmt.Errorf("%w: %s: invalid test result", ErrImportEntryInvalid, key)
+		}
+		testBrand := ""
+		if lhTest != "" || pregnancyTest != "" {
+			testBrand = TrimTestBrand(entry.TestBrand)
+		}
+
+		intimacy, err := normalizeDayIntimacy(DayEntryInput{
+			Intimacy:           entry.Intimacy,
+			IntimacyProtection: entry.IntimacyProtection,
+			IntimacyMethods:    entry.IntimacyMethods,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: invalid intimacy entry", ErrImportEntryInvalid, key)
+		}
+		cycleOverride, err := normalizeDayCycleOverride(DayEntryInput{
+			CycleStartOverride: entry.CycleStartOverride,
+			CycleExclusion:     entry.CycleExclusion,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: invalid cycle override", ErrImportEntryInvalid, key)
+		}
+
+		medications, err := normalizeImportMedications(entry.Medications)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: invalid medication", ErrImportEntryInvalid, key)
+		}
+		for name, value := range entry.Metrics {
+			if strings.TrimSpace(name) == "" || !isFiniteMetricNumber(value) {
+				return nil, fmt.Errorf("%w: %s: invalid metric value", ErrImportEntryInvalid, key)
+			}
+		}
+
+		names := importSymptomNames(entry)
+		for _, name := range names {
+			if len(name) > maxSymptomNameLength {
+				return nil, fmt.Errorf("%w: %s: symptom name too long", ErrImportEntryInvalid, key)
+			}
+		}
+		for _, severity := range entry.SymptomSeverities {
+			if !IsValidSymptomSeverity(severity) {
+				return nil, fmt.Errorf("%w: %s: invalid symptom severity", ErrImportEntryInvalid, key)
+			}
+		}
+
+		days = append(days, importDay{
+			key:  key,
+			date: DateAtLocation(parsed, location),
+			input: DayEntryInput{
+				IsPeriod: entry.Period,
+				Flow:     flow,
+				Notes:    TrimDayNotes(entry.Notes),
+				// Temperatures are only carried when the file has one, so
+				// older exports never clear a stored reading.
+				TemperatureSet:       entry.BBT != 0,
+				Temperature:          entry.BBT,
+				TemperatureUnit:      models.TemperatureUnitCelsius,
+				TemperatureTime:      bbtTime,
+				TemperatureDisturbed: entry.BBTDisturbed,
+				CervicalSet:          mucus != "" || cervixPosition != "" || cervixFirmness != "",
+				Mucus:                mucus,
+				CervixPosition:       cervixPosition,
+				CervixFirmness:       cervixFirmness,
+				TestsSet:             lhTest != "" || pregnancyTest != "",
+				LHTest:               lhTest,
+				PregnancyTest:        pregnancyTest,
+				TestBrand:            testBrand,
+				PillTakenSet:         entry.PillTaken,
+				PillTaken:            entry.PillTaken,
+				IntimacySet:          entry.Intimacy,
+				Intimacy:             intimacy.Intimacy,
+				IntimacyProtection:   intimacy.IntimacyProtection,
+				IntimacyMethods:      intimacy.IntimacyMethods,
+				CycleOverrideSet:     cycleOverride.CycleStartOverride != "" || cycleOverride.CycleExclusion != "",
+				CycleStartOverride:   cycleOverride.CycleStartOverride,
+				CycleExclusion:       cycleOverride.CycleExclusion,
+			},
+			names:       names,
+			severities:  entry.SymptomSeverities,
+			medications: medications,
+			metrics:     entry.Metrics,
+		})
+	}
+	return days, nil
+}
+
+// importSymptomNames returns the symptom column keys set in the export flags
+// followed by the free-form other_symptoms names.
+func importSymptomNames(entry ExportJSONEntry) []string {
+	flags := entry.Symptoms
+	columns := []struct {
+		set    bool
+		column string
+	}{
+		{flags.Cramps, "cramps"},
+		{flags.Headache, "headache"},
+		{flags.Acne, "acne"},
+		{flags.Mood, "mood"},
+		{flags.Bloating, "bloating"},
+		{flags.Fatigue, "fatigue"},
+		{flags.BreastTenderness, "breast_tenderness"},
+		{flags.BackPain, "back_pain"},
+		{flags.Nausea, "nausea"},
+		{flags.Spotting, "spotting"},
+		{flags.Irritability, "irritability"},
+		{flags.Insomnia, "insomnia"},
+		{flags.FoodCravings, "food_cravings"},
+		{flags.Diarrhea, "diarrhea"},
+		{flags.Constipation, "constipation"},
+	}
+
+	names := make([]string, 0, len(entry.OtherSymptoms)+2)
+	for _, column := range columns {
+		if column.set {
+			names = append(names, importColumnPrefix+column.column)
+		}
+	}
+	for _, name := range entry.OtherSymptoms {
+		if trimmed := strings.TrimSpace(name); trimmed != "" {
+			names = append(names, trimmed)
+		}
+	}
+	return names
+}
+
+func applyImportMode(existing models.DailyLog, input DayEntryInput, mode ImportMode) models.DailyLog {
+	next := existing
+	switch mode {
+	case ImportModeOverwrite:
+		next.IsPeriod = input.IsPeriod
+		next.Flow = input.Flow
+		next.SymptomIDs = input.SymptomIDs
+		next.SymptomSeverities = input.SymptomSeverities
+		next.Notes = input.Notes
+		applyDayTemperature(&next, input)
+		applyDayCervical(&next, input)
+		applyDayTests(&next, input)
+		applyDayPill(&next, input)
+		applyDayIntimacy(&next, input)
+		applyDayCycleOverride(&next, input)
+	case ImportModeMerge:
+		next.IsPeriod = existing.IsPeriod || input.IsPeriod
+		if importFlowRank(input.Flow) > importFlowRank(existing.Flow) {
+			next.Flow = input.Flow
+		}
+		if !next.IsPeriod {
+			next.Flow = models.FlowNone
+		}
+		next.SymptomIDs = mergeSymptomIDs(existing.SymptomIDs, input.SymptomIDs)
+		next.SymptomSeverities = mergeSymptomSeverities(existing, input, next.SymptomIDs)
+		if strings.TrimSpace(existing.Notes) == "" {
+			next.Notes = input.Notes
+		}
+		if existing.BBT == 0 {
+			applyDayTemperature(&next, input)
+		}
+		if existing.Mucus == "" && existing.CervixPosition == "" && existing.CervixFirmness == "" {
+			applyDayCervical(&next, input)
+		}
+		if existing.LHTest == "" && existing.PregnancyTest == "" {
+			applyDayTests(&next, input)
+		}
+		if !existing.PillTaken {
+			applyDayPill(&next, input)
+		}
+		if !existing.Intimacy {
+			applyDayIntimacy(&next, input)
+		}
+		if existing.CycleStartOverride == "" && existing.CycleExclusion == "" {
+			applyDayCycleOverride(&next, input)
+		}
+	}
+	return next
+}
+
+// applyImportDayState applies mode to the log, intakes and metric values of
+// an existing day. Like temperatures, intakes and metric values are only
+// replaced when the file has some.
+func applyImportDayState(existing importDayState, input DayEntryInput, imported importDayState, mode ImportMode) importDayState {
+	next := importDayState{log: applyImportMode(existing.log, input, mode), intakes: existing.intakes, metrics: existing.metrics}
+	switch mode {
+	case ImportModeOverwrite:
+		if len(imported.intakes) > 0 {
+			next.intakes = imported.intakes
+		}
+		if len(imported.metrics) > 0 {
+			next.metrics = imported.metrics
+		}
+	case ImportModeMerge:
+		next.intakes = mergeImportIntakes(existing.intakes, imported.intakes)
+		next.metrics = mergeImportMetricValues(existing.metrics, imported.metrics)
+	}
+	return next
+}
+
+// mergeImportIntakes adds the file's intakes of medications the day does not
+// have yet, keeping the stored doses.
+func mergeImportIntakes(existing map[uint]models.MedicationIntake, imported map[uint]models.MedicationIntake) map[uint]models.MedicationIntake {
+	merged := make(map[uint]models.MedicationIntake, len(existing)+len(imported))
+	for id, intake := range imported {
+		merged[id] = intake
+	}
+	for id, intake := range existing {
+		merged[id] = intake
+	}
+	return merged
+}
+
+// mergeImportMetricValues adds the file's values of metrics the day does not
+// have yet, keeping the stored values.
+func mergeImportMetricValues(existing map[uint]float64, imported map[uint]float64) map[uint]float64 {
+	merged := make(map[uint]float64, len(existing)+len(imported))
+	for id, value := range imported {
+		merged[id] = value
+	}
+	for id, value := range existing {
+		merged[id] = value
+	}
+	return merged
+}
+
+func sortedImportMetricValues(userID uint, dayStart time.Time, values map[uint]float64) []models.MetricValue {
+	records := make([]models.MetricValue, 0, len(values))
+	for id, value := range values {
+		records = append(records, models.MetricValue{UserID: userID, MetricTypeID: id, Date: dayStart, Value: value})
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].MetricTypeID < records[j].MetricTypeID })
+	return records
+}
+
+func sortedImportIntakes(intakes map[uint]models.MedicationIntake) []models.MedicationIntake {
+	sorted := make([]models.MedicationIntake, 0, len(intakes))
+	for _, intake := range intakes {
+		sorted = append(sorted, intake)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].MedicationID < sorted[j].MedicationID })
+	return sorted
+}
+
+func mergeSymptomIDs(left []uint, right []uint) []uint {
+	set := make(map[uint]struct{}, len(left)+len(right))
+	for _, id := range left {
+		set[id] = struct{}{}
+	}
+	for _, id := range right {
+		set[id] = struct{}{}
+	}
+	merged := make([]uint, 0, len(set))
+	for id := range set {
+		merged = append(merged, id)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i] < merged[j] })
+	return merged
+}
+
+// mergeSymptomSeverities keeps the stored severity of symptoms the day
+// already had and takes the file's severity for newly added ones.
+func mergeSymptomSeverities(existing models.DailyLog, input DayEntryInput, symptomIDs []uint) map[uint]int {
+	stored := symptomIDLookup(existing.SymptomIDs)
+	severities := make(map[uint]int, len(symptomIDs))
+	for _, id := range symptomIDs {
+		severity, ok := input.SymptomSeverities[id]
+		if stored[id] || !ok {
+			severity = SymptomSeverity(existing, id)
+		}
+		severities[id] = severity
+	}
+	return severities
+}
+
+func importDayStatesEqual(left importDayState, right importDayState) bool {
+	return importLogsEqual(left.log, right.log) &&
+		importIntakesEqual(left.intakes, right.intakes) &&
+		importMetricValuesEqual(left.metrics, right.metrics)
+}
+
+func importMetricValuesEqual(left map[uint]float64, right map[uint]float64) bool {
+	if len(left) != len(right) {
+		return false
+	}
+	for id, value := range left {
+		if other, ok := right[id]; !ok || value != other {
+			return false
+		}
+	}
+	return true
+}
+
+func importIntakesEqual(left map[uint]models.MedicationIntake, right map[uint]models.MedicationIntake) bool {
+	if len(left) != len(right) {
+		return false
+	}
+	for id, intake := range left {
+		other, ok := right[id]
+		if !ok || intake.Dose != other.Dose || intake.Unit != other.Unit {
+			return false
+		}
+	}
+	return true
+}
+
+func importLogsEqual(left models.DailyLog, right models.DailyLog) bool {
+	if left.IsPeriod != right.IsPeriod || left.Flow != right.Flow || left.Notes != right.Notes {
+		return false
+	}
+	if left.BBT != right.BBT || left.BBTTime != right.BBTTime || left.BBTDisturbed != right.BBTDisturbed {
+		return false
+	}
+	if left.Mucus != right.Mucus || left.CervixPosition != right.CervixPosition || left.CervixFirmness != right.CervixFirmness {
+		return false
+	}
+	if left.LHTest != right.LHTest || left.PregnancyTest != right.PregnancyTest || left.TestBrand != right.TestBrand {
+		return false
+	}
+	if left.PillTaken != right.PillTaken {
+		return false
+	}
+	if left.Intimacy != right.Intimacy || left.IntimacyProtection != right.IntimacyProtection || strings.Join(left.IntimacyMethods, ",") != strings.Join(right.IntimacyMethods, ",") {
+		return false
+	}
+	if left.CycleStartOverride != right.CycleStartOverride || left.CycleExclusion != right.CycleExclusion {
+		return false
+	}
+	leftIDs := mergeSymptomIDs(left.SymptomIDs, nil)
+	rightIDs := mergeSymptomIDs(right.SymptomIDs, nil)
+	if len(leftIDs) != len(rightIDs) {
+		return false
+	}
+	for index := range leftIDs {
+		if leftIDs[index] != rightIDs[index] {
+			return false
+		}
+		if SymptomSeverity(left, leftIDs[index]) != SymptomSeverity(right, rightIDs[index]) {
+			return false
+		}
+	}
+	return true
+}
+
+type importSymptomResolver struct {
+	userID      uint
+	byColumn    map[string]uint
+	byName      map[string]uint
+	definitions map[string]ExportCustomSymptom
+	created     []string
+	// placeholderID hands out IDs for symptoms a dry run would create. They
+	// are never persisted and only keep change detection accurate.
+	placeholderID uint
+}
+
+func newImportSymptomResolver(userID uint, symptoms []models.SymptomType, definitions []ExportCustomSymptom) *importSymptomResolver {
+	resolver := &importSymptomResolver{
+		userID:        userID,
+		byColumn:      make(map[string]uint),
+		byName:        make(map[string]uint, len(symptoms)),
+		definitions:   make(map[string]ExportCustomSymptom, len(definitions)),
+		created:       []string{},
+		placeholderID: math.MaxUint32,
+	}
+	for _, symptom := range symptoms {
+		key := strings.ToLower(strings.TrimSpace(symptom.Name))
+		if _, exists := resolver.byName[key]; !exists {
+			resolver.byName[key] = symptom.ID
+		}
+		column := exportSymptomColumn(symptom.Name)
+		if column == "other" {
+			continue
+		}
+		if _, exists := resolver.byColumn[column]; !exists || symptom.IsBuiltin {
+			resolver.byColumn[column] = symptom.ID
+		}
+	}
+	for _, definition := range definitions {
+		key := strings.ToLower(strings.TrimSpace(definition.Name))
+		if key != "" {
+			resolver.definitions[key] = definition
+		}
+	}
+	return resolver
+}
+
+func (resolver *importSymptomResolver) resolveAll(names []string, store ImportSymptomStore, dryRun bool) ([]uint, error) {
+	ids := make([]uint, 0, len(names))
+	for _, name := range names {
+		id, err := resolver.resolve(name, store, dryRun)
+		if err != nil {
+			return nil, err
+		}
+		if id != 0 {
+			ids = append(ids, id)
+		}
+	}
+	return mergeSymptomIDs(ids, nil), nil
+}
+
+// severitiesByID maps severities keyed by symptom name to known symptom IDs.
+// Names that do not match a symptom are ignored.
+func (resolver *importSymptomResolver) severitiesByID(severities map[string]int) map[uint]int {
+	byID := make(map[uint]int, len(severities))
+	for name, severity := range severities {
+		if id, ok := resolver.byName[strings.ToLower(strings.TrimSpace(name))]; ok {
+			byID[id] = severity
+		}
+	}
+	return byID
+}
+
+func (resolver *importSymptomResolver) resolve(name string, store ImportSymptomStore, dryRun bool) (uint, error) {
+	if column, ok := strings.CutPrefix(name, importColumnPrefix); ok {
+		return resolver.byColumn[column], nil
+	}
+
+	trimmed := strings.TrimSpace(name)
+	key := strings.ToLower(trimmed)
+	if key == "" {
+		return 0, nil
+	}
+	if id, ok := resolver.byName[key]; ok {
+		return id, nil
+	}
+	symptom := models.SymptomType{
+		UserID: resolver.userID,
+		Name:   trimmed,
+		Icon:   defaultSymptomIcon,
+		Color:  importDefaultSymptomColor,
+	}
+	if definition, ok := resolver.definitions[key]; ok {
+		if icon := strings.TrimSpace(definition.Icon); icon != "" {
+			symptom.Icon = icon
+		}
+		if color := strings.TrimSpace(definition.Color); hexSymptomColorPattern.MatchString(color) {
+			symptom.Color = color
+		}
+	}
+
+	if dryRun {
+		symptom.ID = resolver.placeholderID
+		resolver.placeholderID--
+	} else if err := store.CreateUserSymptom(&symptom); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrCreateSymptomFailed, err)
+	}
+	resolver.byName[key] = symptom.ID
+	resolver.created = append(resolver.created, trimmed)
+	return symptom.ID, nil
+}
+
+// normalizeImportMedications checks the intakes of one entry and keeps the
+// first intake of each medication.
+func normalizeImportMedications(doses []ExportMedicationDose) ([]ExportMedicationDose, error) {
+	normalized := make([]ExportMedicationDose, 0, len(doses))
+	seen := make(map[string]bool, len(doses))
+	for _, dose := range doses {
+		medication, err := newMedication(0, MedicationInput{Name: dose.Name, Dose: dose.Dose, Unit: dose.Unit})
+		if err != nil {
+			return nil, err
+		}
+		key := strings.ToLower(medication.Name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, ExportMedicationDose{Name: medication.Name, Dose: medication.Dose, Unit: medication.Unit})
+	}
+	return normalized, nil
+}
+
+type importMedicationResolver struct {
+	userID  uint
+	byName  map[string]uint
+	created []string
+	// placeholderID hands out IDs for medications a dry run would create.
+	placeholderID uint
+}
+
+func newImportMedicationResolver(userID uint, medications []models.Medication) *importMedicationResolver {
+	resolver := &importMedicationResolver{
+		userID:        userID,
+		byName:        make(map[string]uint, len(medications)),
+		created:       []string{},
+		placeholderID: math.MaxUint32,
+	}
+	for _, medication := range medications {
+		key := strings.ToLower(strings.TrimSpace(medication.Name))
+		if _, exists := resolver.byName[key]; !exists {
+			resolver.byName[key] = medication.ID
+		}
+	}
+	return resolver
+}
+
+// resolve returns the catalog ID of the medication taken in dose. Unknown
+// names are added to the catalog with the dose of their first intake.
+func (resolver *importMedicationResolver) resolve(dose ExportMedicationDose, store ImportMedicationStore, dryRun bool) (uint, error) {
+	key := strings.ToLower(dose.Name)
+	if id, ok := resolver.byName[key]; ok {
+		return id, nil
+	}
+	medication, err := newMedication(resolver.userID, MedicationInput{Name: dose.Name, Dose: dose.Dose, Unit: dose.Unit})
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrImportEntryInvalid, err)
+	}
+
+	if dryRun {
+		medication.ID = resolver.placeholderID
+		resolver.placeholderID--
+	} else if err := store.Create(&medication); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrCreateMedicationFailed, err)
+	}
+	resolver.byName[key] = medication.ID
+	resolver.created = append(resolver.created, medication.Name)
+	return medication.ID, nil
+}
+
+type importMetricResolver struct {
+	userID      uint
+	byName      map[string]models.MetricType
+	definitions map[string]ExportCustomMetric
+	created     []string
+	// placeholderID hands out IDs for metrics a dry run would create.
+	placeholderID uint
+}
+
+func newImportMetricResolver(userID uint, metrics []models.MetricType, definitions []ExportCustomMetric) *importMetricResolver {
+	resolver := &importMetricResolver{
+		userID:        userID,
+		byName:        make(map[string]models.MetricType, len(metrics)),
+		definitions:   make(map[string]ExportCustomMetric, len(definitions)),
+		created:       []string{},
+		placeholderID: math.MaxUint32,
+	}
+	for _, metric := range metrics {
+		key := strings.ToLower(strings.TrimSpace(metric.Name))
+		if _, exists := resolver.byName[key]; !exists {
+			resolver.byName[key] = metric
+		}
+	}
+	for _, definition := range definitions {
+		key := strings.ToLower(strings.TrimSpace(definition.Name))
+		if key != "" {
+			resolver.definitions[key] = definition
+		}
+	}
+	return resolver
+}
+
+// resolve returns the metric called name. Unknown metrics are added to the
+// catalog from their custom_metrics definition, or as plain decimals when
+// the file has none.
+func (resolver *importMetricResolver) resolve(name string, store ImportMetricStore, dryRun bool) (models.MetricType, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if metric, ok := resolver.byName[key]; ok {
+		return metric, nil
+	}
+	input := MetricTypeInput{Name: name, Kind: models.MetricKindDecimal}
+	if definition, ok := resolver.definitions[key]; ok {
+		input = MetricTypeInput{Name: definition.Name, Unit: definition.Unit, Kind: definition.Type, MinValue: definition.Min, MaxValue: definition.Max}
+	}
+	metric, err := newMetricType(resolver.userID, input)
+	if err != nil {
+		return models.MetricType{}, fmt.Errorf("%w: metric %q: %v", ErrImportEntryInvalid, name, err)
+	}
+
+	if dryRun {
+		metric.ID = resolver.placeholderID
+		resolver.placeholderID--
+	} else if err := store.CreateType(&metric); err != nil {
+		return models.MetricType{}, fmt.Errorf("%w: %v", ErrCreateMetricFailed, err)
+	}
+	resolver.byName[key] = metric
+	resolver.created = append(resolver.created, metric.Name)
+	return metric, nil
+}
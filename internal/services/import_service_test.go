@@ -0,0 +1,674 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+type stubImportLogRepo struct {
+	existing []models.DailyLog
+	created  []models.DailyLog
+	saved    []models.DailyLog
+}
+
+func (stub *stubImportLogRepo) ListByUser(uint) ([]models.DailyLog, error) {
+	return stub.existing, nil
+}
+
+func (stub *stubImportLogRepo) Create(entry *models.DailyLog) error {
+	entry.ID = uint(100 + len(stub.created))
+	stub.created = append(stub.created, *entry)
+	return nil
+}
+
+func (stub *stubImportLogRepo) Save(entry *models.DailyLog) error {
+	stub.saved = append(stub.saved, *entry)
+	return nil
+}
+
+type stubImportSymptomStore struct {
+	symptoms []models.SymptomType
+	created  []models.SymptomType
+}
+
+func (stub *stubImportSymptomStore) FetchSymptoms(uint) ([]models.SymptomType, error) {
+	return stub.symptoms, nil
+}
+
+func (stub *stubImportSymptomStore) CreateUserSymptom(symptom *models.SymptomType) error {
+	symptom.ID = uint(50 + len(stub.created))
+	stub.created = append(stub.created, *symptom)
+	return nil
+}
+
+type stubImportMedicationStore struct {
+	medications []models.Medication
+	intakes     []models.MedicationIntake
+	replaced    map[string][]models.MedicationIntake
+}
+
+func (stub *stubImportMedicationStore) ListByUser(uint) ([]models.Medication, error) {
+	return stub.medications, nil
+}
+
+func (stub *stubImportMedicationStore) Create(medication *models.Medication) error {
+	medication.ID = uint(70 + len(stub.medications))
+	stub.medications = append(stub.medications, *medication)
+	return nil
+}
+
+func (stub *stubImportMedicationStore) ListIntakesByUserRange(uint, *time.Time, *time.Time) ([]models.MedicationIntake, error) {
+	return stub.intakes, nil
+}
+
+func (stub *stubImportMedicationStore) ReplaceIntakesForDay(_ uint, dayStart time.Time, _ time.Time, intakes []models.MedicationIntake) error {
+	if stub.replaced == nil {
+		stub.replaced = make(map[string][]models.MedicationIntake)
+	}
+	stub.replaced[dayStart.Format("2006-01-02")] = intakes
+	return nil
+}
+
+type stubImportMetricStore struct {
+	metrics  []models.MetricType
+	values   []models.MetricValue
+	replaced map[string][]models.MetricValue
+}
+
+func (stub *stubImportMetricStore) ListTypesByUser(uint) ([]models.MetricType, error) {
+	return stub.metrics, nil
+}
+
+func (stub *stubImportMetricStore) CreateType(metric *models.MetricType) error {
+	metric.ID = uint(90 + len(stub.metrics))
+	stub.metrics = append(stub.metrics, *metric)
+	return nil
+}
+
+func (stub *stubImportMetricStore) ListValuesByUserRange(uint, *time.Time, *time.Time) ([]models.MetricValue, error) {
+	return stub.values, nil
+}
+
+func (stub *stubImportMetricStore) ReplaceValuesForDay(_ uint, dayStart time.Time, _ time.Time, values []models.MetricValue) error {
+	if stub.replaced == nil {
+		stub.replaced = make(map[string][]models.MetricValue)
+	}
+	stub.replaced[dayStart.Format("2006-01-02")] = values
+	return nil
+}
+
+type stubImportCycleSync struct {
+	refreshed int
+}
+
+func (stub *stubImportCycleSync) RefreshUserLastPeriodStart(uint, *time.Location) error {
+	stub.refreshed++
+	return nil
+}
+
+func newTestImportService(logs ImportLogRepository, symptoms ImportSymptomStore, cycles ImportCycleSync) *ImportService {
+	return newTestImportServiceWithStores(ImportStores{
+		Logs:        logs,
+		Symptoms:    symptoms,
+		Medications: &stubImportMedicationStore{},
+		Metrics:     &stubImportMetricStore{},
+		Cycles:      cycles,
+	})
+}
+
+func newTestImportServiceWithStores(stores ImportStores) *ImportService {
+	return NewImportService(func(fn func(stores ImportStores) error) error {
+		return fn(stores)
+	})
+}
+
+func importTestSymptoms() []models.SymptomType {
+	return []models.SymptomType{
+		{ID: 1, UserID: 7, Name: "Cramps", IsBuiltin: true},
+		{ID: 2, UserID: 7, Name: "Mood swings", IsBuiltin: true},
+		{ID: 3, UserID: 7, Name: "Swelling", IsBuiltin: true},
+	}
+}
+
+func TestParseImportMode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		raw     string
+		want    ImportMode
+		wantErr bool
+	}{
+		{raw: "", want: ImportModeMerge},
+		{raw: "Merge", want: ImportModeMerge},
+		{raw: "skip", want: ImportModeSkipExisting},
+		{raw: "skip_existing", want: ImportModeSkipExisting},
+		{raw: " overwrite ", want: ImportModeOverwrite},
+		{raw: "replace", wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.raw, func(t *testing.T) {
+			t.Parallel()
+
+			mode, err := ParseImportMode(testCase.raw)
+			if testCase.wantErr {
+				if !errors.Is(err, ErrImportModeInvalid) {
+					t.Fatalf("expected ErrImportModeInvalid, got %v", err)
+				}
+				return
+			}
+			if err != nil || mode != testCase.want {
+				t.Fatalf("expected %q, got %q (%v)", testCase.want, mode, err)
+			}
+		})
+	}
+}
+
+func TestImportServiceCreatesEntriesAndCustomSymptoms(t *testing.T) {
+	t.Parallel()
+
+	logs := &stubImportLogRepo{}
+	symptoms := &stubImportSymptomStore{symptoms: importTestSymptoms()}
+	cycles := &stubImportCycleSync{}
+	service := newTestImportService(logs, symptoms, cycles)
+
+	payload := ImportJSONPayload{
+		CustomSymptoms: []ExportCustomSymptom{{Name: "Dizziness", Icon: "🌀", Color: "#123456"}},
+		Entries: []ExportJSONEntry{{
+			Date:          "2026-02-10",
+			Period:        true,
+			Flow:          "medium",
+			Symptoms:      ExportSymptomFlags{Cramps: true, Mood: true},
+			OtherSymptoms: []string{"swelling", "Dizziness"},
+			Notes:         "restored",
+		}},
+	}
+
+	report, err := service.Import(7, payload, ImportModeMerge, false, time.UTC)
+	if err != nil {
+		t.Fatalf("Import() unexpected error: %v", err)
+	}
+	if report.Created != 1 || report.TotalEntries != 1 {
+		t.Fatalf("unexpected report: %#v", report)
+	}
+	if len(symptoms.created) != 1 || symptoms.created[0].Icon != "🌀" || symptoms.created[0].Color != "#123456" {
+		t.Fatalf("expected custom symptom with original icon and color, got %#v", symptoms.created)
+	}
+	if !reflect.DeepEqual(report.SymptomsCreated, []string{"Dizziness"}) {
+		t.Fatalf("expected Dizziness in created symptoms, got %#v", report.SymptomsCreated)
+	}
+	if len(logs.created) != 1 {
+		t.Fatalf("expected one created log, got %d", len(logs.created))
+	}
+	created := logs.created[0]
+	if !reflect.DeepEqual(created.SymptomIDs, []uint{1, 2, 3, 50}) {
+		t.Fatalf("expected builtin and custom symptom ids, got %#v", created.SymptomIDs)
+	}
+	if !created.IsPeriod || created.Flow != models.FlowMedium || created.Notes != "restored" {
+		t.Fatalf("unexpected created log: %#v", created)
+	}
+	if cycles.refreshed != 1 {
+		t.Fatalf("expected last period start refresh, got %d", cycles.refreshed)
+	}
+}
+
+func TestImportServiceModesForExistingDays(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, time.February, 10, 0, 0, 0, 0, time.UTC)
+	existing := models.DailyLog{
+		ID:         9,
+		UserID:     7,
+		Date:       day,
+		IsPeriod:   true,
+		Flow:       models.FlowHeavy,
+		SymptomIDs: []uint{1},
+		Notes:      "original",
+	}
+	entry := ExportJSONEntry{
+		Date:     "2026-02-10",
+		Period:   true,
+		Flow:     "light",
+		Symptoms: ExportSymptomFlags{Mood: true},
+		Notes:    "imported",
+	}
+
+	testCases := []struct {
+		name       string
+		mode       ImportMode
+		wantAction string
+		wantSaved  *models.DailyLog
+	}{
+		{name: "skip existing", mode: ImportModeSkipExisting, wantAction: ImportActionSkip},
+		{
+			name:       "merge",
+			mode:       ImportModeMerge,
+			wantAction: ImportActionUpdate,
+			wantSaved:  &models.DailyLog{IsPeriod: true, Flow: models.FlowHeavy, SymptomIDs: []uint{1, 2}, Notes: "original"},
+		},
+		{
+			name:       "overwrite",
+			mode:       ImportModeOverwrite,
+			wantAction: ImportActionUpdate,
+			wantSaved:  &models.DailyLog{IsPeriod: true, Flow: models.FlowLight, SymptomIDs: []uint{2}, Notes: "imported"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			logs := &stubImportLogRepo{existing: []models.DailyLog{existing}}
+			service := newTestImportService(logs, &stubImportSymptomStore{symptoms: importTestSymptoms()}, &stubImportCycleSync{})
+
+			report, err := service.Import(7, ImportJSONPayload{Entries: []ExportJSONEntry{entry}}, testCase.mode, false, time.UTC)
+			if err != nil {
+				t.Fatalf("Import() unexpected error: %v", err)
+			}
+			if len(report.Changes) != 1 || report.Changes[0].Action != testCase.wantAction {
+				t.Fatalf("expected action %q, got %#v", testCase.wantAction, report.Changes)
+			}
+			if !report.Changes[0].Conflict || report.Conflicts != 1 {
+				t.Fatalf("expected differing day to be reported as a conflict in every mode, got %#v", report)
+			}
+			if len(logs.created) != 0 {
+				t.Fatalf("did not expect new logs, got %#v", logs.created)
+			}
+			if testCase.wantSaved == nil {
+				if len(logs.saved) != 0 {
+					t.Fatalf("did not expect saves, got %#v", logs.saved)
+				}
+				return
+			}
+			if len(logs.saved) != 1 {
+				t.Fatalf("expected one saved log, got %d", len(logs.saved))
+			}
+			saved := logs.saved[0]
+			if saved.ID != existing.ID ||
+				saved.IsPeriod != testCase.wantSaved.IsPeriod ||
+				saved.Flow != testCase.wantSaved.Flow ||
+				saved.Notes != testCase.wantSaved.Notes ||
+				!reflect.DeepEqual(saved.SymptomIDs, testCase.wantSaved.SymptomIDs) {
+				t.Fatalf("unexpected saved log: %#v", saved)
+			}
+		})
+	}
+}
+
+func TestImportServiceMergeKeepsHeavierFlow(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, time.February, 10, 0, 0, 0, 0, time.UTC)
+	logs := &stubImportLogRepo{existing: []models.DailyLog{
+		{ID: 9, UserID: 7, Date: day, IsPeriod: true, Flow: models.FlowSpotting},
+		{ID: 10, UserID: 7, Date: day.AddDate(0, 0, 1), IsPeriod: true, Flow: models.FlowHeavy},
+	}}
+	service := newTestImportService(logs, &stubImportSymptomStore{symptoms: importTestSymptoms()}, &stubImportCycleSync{})
+
+	payload := ImportJSONPayload{Entries: []ExportJSONEntry{
+		{Date: "2026-02-10", Period: true, Flow: models.FlowMedium},
+		{Date: "2026-02-11", Period: true, Flow: models.FlowSpotting},
+	}}
+	report, err := service.Import(7, payload, ImportModeMerge, false, time.UTC)
+	if err != nil {
+		t.Fatalf("Import() unexpected error: %v", err)
+	}
+	if report.Updated != 1 || report.Unchanged != 1 {
+		t.Fatalf("unexpected report: %#v", report)
+	}
+	if len(logs.saved) != 1 || logs.saved[0].ID != 9 || logs.saved[0].Flow != models.FlowMedium {
+		t.Fatalf("expected spotting to give way to the imported flow, got %#v", logs.saved)
+	}
+}
+
+func TestImportServiceTemperatureModes(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, time.February, 10, 0, 0, 0, 0, time.UTC)
+	entry := ExportJSONEntry{Date: "2026-02-10", Flow: "none", BBT: 36.7, BBTTime: "06:30"}
+
+	testCases := []struct {
+		name    string
+		mode    ImportMode
+		stored  float64
+		wantBBT float64
+	}{
+		{name: "merge fills an empty reading", mode: ImportModeMerge, wantBBT: 36.7},
+		{name: "merge keeps a stored reading", mode: ImportModeMerge, stored: 36.4, wantBBT: 36.4},
+		{name: "overwrite replaces a stored reading", mode: ImportModeOverwrite, stored: 36.4, wantBBT: 36.7},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			existing := models.DailyLog{ID: 9, UserID: 7, Date: day, Flow: models.FlowNone, Notes: "kept", BBT: testCase.stored}
+			logs := &stubImportLogRepo{existing: []models.DailyLog{existing}}
+			service := newTestImportService(logs, &stubImportSymptomStore{symptoms: importTestSymptoms()}, &stubImportCycleSync{})
+
+			if _, err := service.Import(7, ImportJSONPayload{Entries: []ExportJSONEntry{entry}}, testCase.mode, false, time.UTC); err != nil {
+				t.Fatalf("Import() unexpected error: %v", err)
+			}
+			if testCase.wantBBT == testCase.stored {
+				if len(logs.saved) != 0 {
+					t.Fatalf("expected stored reading to be left alone, got %#v", logs.saved)
+				}
+				return
+			}
+			if len(logs.saved) != 1 || logs.saved[0].BBT != testCase.wantBBT || logs.saved[0].BBTTime != "06:30" {
+				t.Fatalf("expected saved bbt %.2f, got %#v", testCase.wantBBT, logs.saved)
+			}
+		})
+	}
+}
+
+func TestImportServiceHomeTestModes(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, time.February, 10, 0, 0, 0, 0, time.UTC)
+	entry := ExportJSONEntry{Date: "2026-02-10", Flow: "none", LHTest: "Positive", TestBrand: "Easy@Home"}
+
+	testCases := []struct {
+		name       string
+		mode       ImportMode
+		stored     string
+		wantLHTest string
+	}{
+		{name: "merge fills an empty result", mode: ImportModeMerge, wantLHTest: models.LHTestPositive},
+		{name: "merge keeps a stored result", mode: ImportModeMerge, stored: models.LHTestNegative, wantLHTest: models.LHTestNegative},
+		{name: "overwrite replaces a stored result", mode: ImportModeOverwrite, stored: models.LHTestNegative, wantLHTest: models.LHTestPositive},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			existing := models.DailyLog{ID: 9, UserID: 7, Date: day, Flow: models.FlowNone, Notes: "kept", LHTest: testCase.stored}
+			logs := &stubImportLogRepo{existing: []models.DailyLog{existing}}
+			service := newTestImportService(logs, &stubImportSymptomStore{symptoms: importTestSymptoms()}, &stubImportCycleSync{})
+
+			if _, err := service.Import(7, ImportJSONPayload{Entries: []ExportJSONEntry{entry}}, testCase.mode, false, time.UTC); err != nil {
+				t.Fatalf("Import() unexpected error: %v", err)
+			}
+			if testCase.wantLHTest == testCase.stored {
+				if len(logs.saved) != 0 {
+					t.Fatalf("expected stored result to be left alone, got %#v", logs.saved)
+				}
+				return
+			}
+			if len(logs.saved) != 1 || logs.saved[0].LHTest != testCase.wantLHTest || logs.saved[0].TestBrand != "Easy@Home" {
+				t.Fatalf("expected saved lh test %q, got %#v", testCase.wantLHTest, logs.saved)
+			}
+		})
+	}
+
+	service := newTestImportService(&stubImportLogRepo{}, &stubImportSymptomStore{symptoms: importTestSymptoms()}, &stubImportCycleSync{})
+	invalid := ExportJSONEntry{Date: "2026-02-10", Flow: "none", PregnancyTest: "maybe"}
+	if _, err := service.Import(7, ImportJSONPayload{Entries: []ExportJSONEntry{invalid}}, ImportModeMerge, false, time.UTC); !errors.Is(err, ErrImportEntryInvalid) {
+		t.Fatalf("expected ErrImportEntryInvalid for invalid pregnancy test, got %v", err)
+	}
+}
+
+func TestImportServiceSymptomSeverities(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, time.February, 10, 0, 0, 0, 0, time.UTC)
+	existing := models.DailyLog{ID: 9, UserID: 7, Date: day, IsPeriod: true, Flow: models.FlowLight, SymptomIDs: []uint{1}, SymptomSeverities: map[uint]int{1: 1}}
+	entry := ExportJSONEntry{
+		Date:              "2026-02-10",
+		Period:            true,
+		Flow:              "light",
+		Symptoms:          ExportSymptomFlags{Cramps: true, Mood: true},
+		SymptomSeverities: map[string]int{"Cramps": 3, "Mood swings": 3},
+	}
+
+	testCases := []struct {
+		name string
+		mode ImportMode
+		want map[uint]int
+	}{
+		{name: "merge keeps stored severities", mode: ImportModeMerge, want: map[uint]int{1: 1, 2: 3}},
+		{name: "overwrite takes the file", mode: ImportModeOverwrite, want: map[uint]int{1: 3, 2: 3}},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			logs := &stubImportLogRepo{existing: []models.DailyLog{existing}}
+			service := newTestImportService(logs, &stubImportSymptomStore{symptoms: importTestSymptoms()}, &stubImportCycleSync{})
+
+			report, err := service.Import(7, ImportJSONPayload{Entries: []ExportJSONEntry{entry}}, testCase.mode, false, time.UTC)
+			if err != nil {
+				t.Fatalf("Import() unexpected error: %v", err)
+			}
+			if report.Conflicts != 1 || len(logs.saved) != 1 {
+				t.Fatalf("expected one conflicting update, got %#v", report)
+			}
+			if !reflect.DeepEqual(logs.saved[0].SymptomSeverities, testCase.want) {
+				t.Fatalf("expected severities %v, got %v", testCase.want, logs.saved[0].SymptomSeverities)
+			}
+		})
+	}
+}
+
+func TestImportServiceRoundTripsMedicationIntakes(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, time.February, 10, 0, 0, 0, 0, time.UTC)
+	exports := NewExportService(
+		&stubExportDayReader{logs: []models.DailyLog{{Date: day, IsPeriod: true, Flow: models.FlowMedium}}},
+		&stubExportSymptomReader{},
+		&stubExportMedicationReader{
+			medications: []models.Medication{{ID: 7, Name: "Ibuprofen", Dose: 200, Unit: "mg"}},
+			intakes:     []models.MedicationIntake{{MedicationID: 7, Date: day, Dose: 400, Unit: "mg"}},
+		},
+		&stubExportMetricReader{},
+	)
+	entries, err := exports.BuildJSONEntries(7, nil, nil, time.UTC)
+	if err != nil {
+		t.Fatalf("BuildJSONEntries() unexpected error: %v", err)
+	}
+	raw, err := json.Marshal(ImportJSONPayload{Entries: entries})
+	if err != nil {
+		t.Fatalf("marshal export: %v", err)
+	}
+	payload, err := DecodeImportJSON(raw)
+	if err != nil {
+		t.Fatalf("DecodeImportJSON() unexpected error: %v", err)
+	}
+
+	medications := &stubImportMedicationStore{}
+	service := newTestImportServiceWithStores(ImportStores{Logs: &stubImportLogRepo{}, Symptoms: &stubImportSymptomStore{}, Medications: medications, Metrics: &stubImportMetricStore{}})
+	report, err := service.Import(7, payload, ImportModeMerge, false, time.UTC)
+	if err != nil {
+		t.Fatalf("Import() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(report.MedicationsCreated, []string{"Ibuprofen"}) {
+		t.Fatalf("expected Ibuprofen to be added to the catalog, got %#v", report.MedicationsCreated)
+	}
+	intakes := medications.replaced["2026-02-10"]
+	if len(intakes) != 1 || intakes[0].MedicationID != medications.medications[0].ID || intakes[0].Dose != 400 || intakes[0].Unit != "mg" {
+		t.Fatalf("expected the intake to be replayed with its dose, got %#v", intakes)
+	}
+
+	existing := &stubImportLogRepo{existing: []models.DailyLog{{ID: 3, UserID: 7, Date: day, IsPeriod: true, Flow: models.FlowMedium}}}
+	medications = &stubImportMedicationStore{
+		medications: []models.Medication{{ID: 7, Name: "ibuprofen", Dose: 200, Unit: "mg"}},
+		intakes:     []models.MedicationIntake{{MedicationID: 7, Date: day, Dose: 400, Unit: "mg"}},
+	}
+	service = newTestImportServiceWithStores(ImportStores{Logs: existing, Symptoms: &stubImportSymptomStore{}, Medications: medications, Metrics: &stubImportMetricStore{}})
+	report, err = service.Import(7, payload, ImportModeMerge, true, time.UTC)
+	if err != nil {
+		t.Fatalf("Import() unexpected error: %v", err)
+	}
+	if report.Unchanged != 1 || len(report.MedicationsCreated) != 0 {
+		t.Fatalf("expected a re-import to change nothing, got %#v", report)
+	}
+
+	medications.intakes = nil
+	report, err = service.Import(7, payload, ImportModeMerge, true, time.UTC)
+	if err != nil {
+		t.Fatalf("Import() unexpected error: %v", err)
+	}
+	if report.Updated != 1 || len(medications.replaced) != 0 {
+		t.Fatalf("expected a missing intake to count as an update without writing, got %#v", report)
+	}
+}
+
+func TestImportServiceRoundTripsCustomMetrics(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, time.February, 10, 0, 0, 0, 0, time.UTC)
+	low, high := 1.0, 5.0
+	catalog := []models.MetricType{
+		{ID: 4, Name: "Weight", Unit: "kg", Kind: models.MetricKindDecimal},
+		{ID: 5, Name: "Energy", Kind: models.MetricKindScale, MinValue: &low, MaxValue: &high},
+	}
+	exports := NewExportService(
+		&stubExportDayReader{logs: []models.DailyLog{{Date: day}}},
+		&stubExportSymptomReader{},
+		&stubExportMedicationReader{},
+		&stubExportMetricReader{
+			metrics: catalog,
+			values:  []models.MetricValue{{MetricTypeID: 4, Date: day, Value: 61.35}, {MetricTypeID: 5, Date: day, Value: 4}},
+		},
+	)
+	entries, err := exports.BuildJSONEntries(7, nil, nil, time.UTC)
+	if err != nil {
+		t.Fatalf("BuildJSONEntries() unexpected error: %v", err)
+	}
+	definitions, err := exports.BuildCustomMetrics(7)
+	if err != nil {
+		t.Fatalf("BuildCustomMetrics() unexpected error: %v", err)
+	}
+	raw, err := json.Marshal(map[string]any{"custom_metrics": definitions, "entries": entries})
+	if err != nil {
+		t.Fatalf("marshal export: %v", err)
+	}
+	payload, err := DecodeImportJSON(raw)
+	if err != nil {
+		t.Fatalf("DecodeImportJSON() unexpected error: %v", err)
+	}
+
+	for _, mode := range []ImportMode{ImportModeMerge, ImportModeSkipExisting, ImportModeOverwrite} {
+		metrics := &stubImportMetricStore{}
+		service := newTestImportServiceWithStores(ImportStores{Logs: &stubImportLogRepo{}, Symptoms: &stubImportSymptomStore{}, Medications: &stubImportMedicationStore{}, Metrics: metrics})
+		report, err := service.Import(7, payload, mode, false, time.UTC)
+		if err != nil {
+			t.Fatalf("%s: Import() unexpected error: %v", mode, err)
+		}
+		if !reflect.DeepEqual(report.MetricsCreated, []string{"Weight", "Energy"}) {
+			t.Fatalf("%s: expected both metrics to be recreated, got %#v", mode, report.MetricsCreated)
+		}
+		energy := metrics.metrics[1]
+		if energy.Kind != models.MetricKindScale || energy.MinValue == nil || *energy.MaxValue != 5 || metrics.metrics[0].Unit != "kg" {
+			t.Fatalf("%s: expected metric definitions to be kept, got %#v", mode, metrics.metrics)
+		}
+		values := metrics.replaced["2026-02-10"]
+		if len(values) != 2 || values[0].Value != 61.35 || values[1].Value != 4 {
+			t.Fatalf("%s: expected metric values to be restored, got %#v", mode, values)
+		}
+	}
+
+	existing := &stubImportLogRepo{existing: []models.DailyLog{{ID: 3, UserID: 7, Date: day}}}
+	metrics := &stubImportMetricStore{
+		metrics: catalog,
+		values:  []models.MetricValue{{MetricTypeID: 4, Date: day, Value: 61.35}},
+	}
+	service := newTestImportServiceWithStores(ImportStores{Logs: existing, Symptoms: &stubImportSymptomStore{}, Medications: &stubImportMedicationStore{}, Metrics: metrics})
+	report, err := service.Import(7, payload, ImportModeSkipExisting, true, time.UTC)
+	if err != nil {
+		t.Fatalf("Import() unexpected error: %v", err)
+	}
+	if report.Skipped != 1 || !report.Changes[0].Conflict {
+		t.Fatalf("expected the missing energy value to be reported as a conflict, got %#v", report)
+	}
+}
+
+func TestImportServiceDryRunWritesNothing(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, time.February, 10, 0, 0, 0, 0, time.UTC)
+	logs := &stubImportLogRepo{existing: []models.DailyLog{{ID: 9, UserID: 7, Date: day, IsPeriod: true, Flow: models.FlowLight, SymptomIDs: []uint{}}}}
+	symptoms := &stubImportSymptomStore{symptoms: importTestSymptoms()}
+	cycles := &stubImportCycleSync{}
+	service := newTestImportService(logs, symptoms, cycles)
+
+	payload := ImportJSONPayload{Entries: []ExportJSONEntry{
+		{Date: "2026-02-10", Period: true, Flow: "light"},
+		{Date: "2026-02-11", Period: true, Flow: "light", OtherSymptoms: []string{"Dizziness"}},
+	}}
+
+	report, err := service.Import(7, payload, ImportModeOverwrite, true, time.UTC)
+	if err != nil {
+		t.Fatalf("Import() unexpected error: %v", err)
+	}
+	if !report.DryRun || report.Created != 1 || report.Unchanged != 1 {
+		t.Fatalf("unexpected dry-run report: %#v", report)
+	}
+	if report.Conflicts != 0 {
+		t.Fatalf("expected identical and new days not to conflict, got %#v", report.Changes)
+	}
+	if !reflect.DeepEqual(report.SymptomsCreated, []string{"Dizziness"}) {
+		t.Fatalf("expected dry run to report the new symptom, got %#v", report.SymptomsCreated)
+	}
+	if len(logs.created) != 0 || len(logs.saved) != 0 || len(symptoms.created) != 0 || cycles.refreshed != 0 {
+		t.Fatal("expected dry run to leave storage untouched")
+	}
+}
+
+func TestImportServiceRejectsInvalidEntriesBeforeWriting(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		entries []ExportJSONEntry
+	}{
+		{name: "bad date", entries: []ExportJSONEntry{{Date: "10/02/2026"}}},
+		{name: "bad flow", entries: []ExportJSONEntry{{Date: "2026-02-10", Period: true, Flow: "torrential"}}},
+		{name: "duplicate", entries: []ExportJSONEntry{{Date: "2026-02-10"}, {Date: "2026-02-10"}}},
+		{name: "bbt out of range", entries: []ExportJSONEntry{{Date: "2026-02-10", BBT: 98.2}}},
+		{name: "bad bbt time", entries: []ExportJSONEntry{{Date: "2026-02-10", BBT: 36.5, BBTTime: "6am"}}},
+		{name: "bad symptom severity", entries: []ExportJSONEntry{{Date: "2026-02-10", Period: true, Flow: "light", Symptoms: ExportSymptomFlags{Cramps: true}, SymptomSeverities: map[string]int{"Cramps": 7}}}},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			logs := &stubImportLogRepo{}
+			service := newTestImportService(logs, &stubImportSymptomStore{symptoms: importTestSymptoms()}, &stubImportCycleSync{})
+			entries := append([]ExportJSONEntry{{Date: "2026-01-01", Period: true, Flow: "light"}}, testCase.entries...)
+
+			_, err := service.Import(7, ImportJSONPayload{Entries: entries}, ImportModeMerge, false, time.UTC)
+			if !errors.Is(err, ErrImportEntryInvalid) {
+				t.Fatalf("expected ErrImportEntryInvalid, got %v", err)
+			}
+			if len(logs.created) != 0 {
+				t.Fatal("expected no writes for an invalid file")
+			}
+		})
+	}
+}
+
+func TestDecodeImportJSONRejectsMalformedPayload(t *testing.T) {
+	t.Parallel()
+
+	for _, raw := range []string{"not json", "{}", `{"entries": "x"}`} {
+		if _, err := DecodeImportJSON([]byte(raw)); !errors.Is(err, ErrImportPayloadInvalid) {
+			t.Fatalf("expected ErrImportPayloadInvalid for %q, got %v", raw, err)
+		}
+	}
+}
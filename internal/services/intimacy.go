@@ -0,0 +1,152 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+var ErrInvalidDayIntimacy = errors.New("invalid day intimacy")
+
+// intimacyMethodOrder is the order methods are stored and displayed in.
+var intimacyMethodOrder = []string{
+	models.IntimacyMethodCondom,
+	models.IntimacyMethodHormonal,
+	models.IntimacyMethodIUD,
+	models.IntimacyMethodWithdrawal,
+	models.IntimacyMethodOther,
+}
+
+// IntimacyMethods lists the contraception methods accepted per entry.
+func IntimacyMethods() []string {
+	return append([]string(nil), intimacyMethodOrder...)
+}
+
+func IsValidIntimacyProtection(value string) bool {
+	switch value {
+	case "", models.IntimacyProtected, models.IntimacyUnprotected:
+		return true
+	default:
+		return false
+	}
+}
+
+// NormalizeIntimacyMethods lowercases, deduplicates and orders methods. It
+// reports false when a method is unknown.
+func NormalizeIntimacyMethods(raw []string) ([]string, bool) {
+	selected := make(map[string]bool, len(raw))
+	for _, value := range raw {
+		method := strings.ToLower(strings.TrimSpace(value))
+		if method == "" {
+			continue
+		}
+		selected[method] = true
+	}
+
+	methods := make([]string, 0, len(selected))
+	for _, method := range intimacyMethodOrder {
+		if selected[method] {
+			methods = append(methods, method)
+			delete(selected, method)
+		}
+	}
+	if len(selected) > 0 {
+		return nil, false
+	}
+	return methods, true
+}
+
+// normalizeDayIntimacy validates the intimacy entry. Protection and methods
+// are dropped without an entry, and methods mark the entry as protected.
+func normalizeDayIntimacy(input DayEntryInput) (DayEntryInput, error) {
+	if !input.Intimacy {
+		input.IntimacyProtection = ""
+		input.IntimacyMethods = []string{}
+		return input, nil
+	}
+
+	input.IntimacyProtection = strings.ToLower(strings.TrimSpace(input.IntimacyProtection))
+	if !IsValidIntimacyProtection(input.IntimacyProtection) {
+		return input, ErrInvalidDayIntimacy
+	}
+	methods, ok := NormalizeIntimacyMethods(input.IntimacyMethods)
+	if !ok {
+		return input, ErrInvalidDayIntimacy
+	}
+	if len(methods) > 0 {
+		if input.IntimacyProtection == models.IntimacyUnprotected {
+			return input, ErrInvalidDayIntimacy
+		}
+		input.IntimacyProtection = models.IntimacyProtected
+	}
+	input.IntimacyMethods = methods
+	return input, nil
+}
+
+// IsUnprotectedIntimacy reports whether entry records intimacy explicitly
+// marked as unprotected.
+func IsUnprotectedIntimacy(entry models.DailyLog) bool {
+	return entry.Intimacy && entry.IntimacyProtection == models.IntimacyUnprotected
+}
+
+// IntimacyVisibleForViewer reports whether intimacy entries may be shown:
+// only to owners who turned tracking on, never to partners.
+func IntimacyVisibleForViewer(user *models.User) bool {
+	return IsOwnerUser(user) && user.IntimacyTracking
+}
+
+// UnprotectedFertileDays returns the days of the current cycle, up to now,
+// with unprotected intimacy inside the fertile window. A day counts as
+// fertile when it lies in the predicted window or the symptothermal rules
+// observed it as fertile. Nothing is reported while hormonal contraception
+// is active.
+func UnprotectedFertileDays(user *models.User, logs []models.DailyLog, stats CycleStats, now time.Time, location *time.Location) []time.Time {
+	days := make([]time.Time, 0)
+	if stats.ContraceptionActive || stats.LastPeriodStart.IsZero() {
+		return days
+	}
+
+	cycleStart := DateAtLocation(stats.LastPeriodStart, location)
+	today := DateAtLocation(now, location)
+	unprotected := make(map[string]bool)
+	for _, logEntry := range logs {
+		day := DateAtLocation(logEntry.Date, location)
+		if day.Before(cycleStart) || day.After(today) || !IsUnprotectedIntimacy(logEntry) {
+			continue
+		}
+		unprotected[day.Format("2006-01-02")] = true
+	}
+	if len(unprotected) == 0 {
+		return days
+	}
+
+	fertile := make(map[string]bool)
+	if !stats.FertilityWindowStart.IsZero() && !stats.FertilityWindowEnd.IsZero() {
+		windowStart := DateAtLocation(stats.FertilityWindowStart, location)
+		windowEnd := DateAtLocation(stats.FertilityWindowEnd, location)
+		for day := windowStart; !day.After(windowEnd); day = day.AddDate(0, 0, 1) {
+			fertile[day.Format("2006-01-02")] = true
+		}
+	}
+	for _, status := range NewSymptothermalRulesEngine().Evaluate(user, logs, cycleStart, today, now, location) {
+		if status.Status == FertilityStatusFertile {
+			fertile[status.Date.Format("2006-01-02")] = true
+		}
+	}
+
+	for day := cycleStart; !day.After(today); day = day.AddDate(0, 0, 1) {
+		key := day.Format("2006-01-02")
+		if unprotected[key] && fertile[key] {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// SaveIntimacyTracking turns intimacy tracking on or off. Turning it off
+// hides existing entries without deleting them.
+func (service *SettingsService) SaveIntimacyTracking(userID uint, enabled bool) error {
+	return service.users.UpdateByID(userID, map[string]any{"intimacy_tracking": enabled})
+}
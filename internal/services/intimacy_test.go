@@ -0,0 +1,81 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func TestNormalizeDayIntimacy(t *testing.T) {
+	cleared, err := normalizeDayIntimacy(DayEntryInput{
+		IntimacySet:        true,
+		IntimacyProtection: models.IntimacyUnprotected,
+		IntimacyMethods:    []string{"condom"},
+	})
+	if err != nil || cleared.IntimacyProtection != "" || len(cleared.IntimacyMethods) != 0 {
+		t.Fatalf("expected details to be dropped without an entry, got %#v (%v)", cleared, err)
+	}
+
+	protected, err := normalizeDayIntimacy(DayEntryInput{
+		IntimacySet:     true,
+		Intimacy:        true,
+		IntimacyMethods: []string{" Withdrawal ", "condom", "condom"},
+	})
+	if err != nil {
+		t.Fatalf("normalizeDayIntimacy() unexpected error: %v", err)
+	}
+	if protected.IntimacyProtection != models.IntimacyProtected || len(protected.IntimacyMethods) != 2 || protected.IntimacyMethods[0] != models.IntimacyMethodCondom {
+		t.Fatalf("expected ordered methods marking the entry protected, got %#v", protected)
+	}
+
+	for _, input := range []DayEntryInput{
+		{IntimacySet: true, Intimacy: true, IntimacyProtection: "maybe"},
+		{IntimacySet: true, Intimacy: true, IntimacyMethods: []string{"rhythm"}},
+		{IntimacySet: true, Intimacy: true, IntimacyProtection: models.IntimacyUnprotected, IntimacyMethods: []string{"condom"}},
+	} {
+		if _, err := normalizeDayIntimacy(input); !errors.Is(err, ErrInvalidDayIntimacy) {
+			t.Fatalf("expected ErrInvalidDayIntimacy for %#v, got %v", input, err)
+		}
+	}
+}
+
+func TestUnprotectedFertileDays(t *testing.T) {
+	now := time.Date(2026, time.March, 14, 9, 0, 0, 0, time.UTC)
+	stats := CycleStats{
+		LastPeriodStart:      time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC),
+		FertilityWindowStart: time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC),
+		FertilityWindowEnd:   time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC),
+	}
+	logs := []models.DailyLog{
+		{Date: time.Date(2026, time.February, 12, 0, 0, 0, 0, time.UTC), Intimacy: true, IntimacyProtection: models.IntimacyUnprotected},
+		{Date: time.Date(2026, time.March, 4, 0, 0, 0, 0, time.UTC), Intimacy: true, IntimacyProtection: models.IntimacyUnprotected},
+		{Date: time.Date(2026, time.March, 11, 0, 0, 0, 0, time.UTC), Intimacy: true, IntimacyProtection: models.IntimacyProtected},
+		{Date: time.Date(2026, time.March, 12, 0, 0, 0, 0, time.UTC), Intimacy: true, IntimacyProtection: models.IntimacyUnprotected},
+		{Date: time.Date(2026, time.March, 13, 0, 0, 0, 0, time.UTC), Intimacy: true},
+	}
+
+	days := UnprotectedFertileDays(nil, logs, stats, now, time.UTC)
+	if len(days) != 1 || days[0].Format("2006-01-02") != "2026-03-12" {
+		t.Fatalf("expected only the unprotected fertile day, got %#v", days)
+	}
+
+	stats.ContraceptionActive = true
+	if days := UnprotectedFertileDays(nil, logs, stats, now, time.UTC); len(days) != 0 {
+		t.Fatalf("expected no days while contraception is active, got %#v", days)
+	}
+}
+
+func TestSanitizeLogForSharingPolicyStripsIntimacy(t *testing.T) {
+	entry := models.DailyLog{
+		Intimacy:           true,
+		IntimacyProtection: models.IntimacyProtected,
+		IntimacyMethods:    []string{models.IntimacyMethodCondom},
+	}
+
+	sanitized := SanitizeLogForSharingPolicy(models.PartnerSharingPolicy{ShareNotes: true}, entry)
+	if sanitized.Intimacy || sanitized.IntimacyProtection != "" || len(sanitized.IntimacyMethods) != 0 {
+		t.Fatalf("expected intimacy to be stripped for partners, got %#v", sanitized)
+	}
+}
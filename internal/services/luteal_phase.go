@@ -0,0 +1,147 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+var ErrSettingsLutealPhaseLengthInvalid = errors.New("settings luteal phase length invalid")
+
+const (
+	MinLutealPhaseLength = 8
+	MaxLutealPhaseLength = 18
+	// lutealPhaseWindow is how many recent confirmed cycles the learned
+	// luteal length is taken from.
+	lutealPhaseWindow = 6
+)
+
+const (
+	LutealPhaseSourceDefault = "default"
+	LutealPhaseSourceLearned = "learned"
+	LutealPhaseSourceManual  = "manual"
+)
+
+// LutealPhase is the number of days from ovulation to the next period used
+// for the owner's predictions and where it came from. A manual value wins
+// over the learned one; LearnedLength is the median of the Samples cycles
+// with a confirmed ovulation, or 0 when there are none.
+type LutealPhase struct {
+	Length        int    `json:"length"`
+	Source        string `json:"source"`
+	LearnedLength int    `json:"learned_length"`
+	Samples       int    `json:"samples"`
+}
+
+func (phase LutealPhase) Personalised() bool {
+	return phase.Source != LutealPhaseSourceDefault
+}
+
+func IsValidLutealPhaseLength(value int) bool {
+	return value >= MinLutealPhaseLength && value <= MaxLutealPhaseLength
+}
+
+// NormalizeLutealPhaseLength falls back to the default length for values
+// outside the valid range, including 0 for "not set".
+func NormalizeLutealPhaseLength(value int) int {
+	if !IsValidLutealPhaseLength(value) {
+		return models.DefaultLutealPhaseLength
+	}
+	return value
+}
+
+// ObservedLutealPhaseLengths measures, for every completed cycle in logs with
+// a confirmed ovulation, the days between ovulation and the next period,
+// oldest first and counted like CalcOvulationDay does. A temperature shift
+// confirms ovulation; without one the day after the first positive LH test
+// is used.
+func ObservedLutealPhaseLengths(logs []models.DailyLog, location *time.Location) []int {
+	if location == nil {
+		location = time.UTC
+	}
+	sorted := make([]models.DailyLog, 0, len(logs))
+	sorted = append(sorted, logs...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	starts := DetectCycleStarts(sorted)
+	lengths := make([]int, 0, len(starts))
+	for index := 0; index+1 < len(starts); index++ {
+		cycleStart := DateAtLocation(starts[index], location)
+		nextStart := DateAtLocation(starts[index+1], location)
+		cycleEnd := nextStart.AddDate(0, 0, -1)
+
+		ovulation := time.Time{}
+		if shift, ok := DetectTemperatureShift(TemperatureReadings(sorted, cycleStart, cycleEnd, location)); ok {
+			ovulation = shift.OvulationDate
+		} else if surge, ok := FirstPositiveLHTest(sorted, cycleStart, cycleEnd, location); ok {
+			ovulation = surge.AddDate(0, 0, lhSurgeOvulationDelayDays)
+		}
+		if ovulation.IsZero() {
+			continue
+		}
+		if length := calendarDaysBetween(ovulation, nextStart) - 1; IsValidLutealPhaseLength(length) {
+			lengths = append(lengths, length)
+		}
+	}
+	return lengths
+}
+
+// ResolveLutealPhase picks the luteal length for user: the value entered in
+// Settings, else the one learned from logs, else the default.
+func ResolveLutealPhase(user *models.User, logs []models.DailyLog, location *time.Location) LutealPhase {
+	observed := ObservedLutealPhaseLengths(logs, location)
+	phase := LutealPhase{
+		Length:  models.DefaultLutealPhaseLength,
+		Source:  LutealPhaseSourceDefault,
+		Samples: len(observed),
+	}
+	if len(observed) > 0 {
+		phase.LearnedLength = medianInt(tailInts(observed, lutealPhaseWindow))
+	}
+
+	switch {
+	case user != nil && IsValidLutealPhaseLength(user.LutealPhaseLength):
+		phase.Length = user.LutealPhaseLength
+		phase.Source = LutealPhaseSourceManual
+	case phase.LearnedLength > 0:
+		phase.Length = phase.LearnedLength
+		phase.Source = LutealPhaseSourceLearned
+	}
+	return phase
+}
+
+// BuildLutealPhase resolves the owner's luteal length from all of their logs.
+func (service *StatsService) BuildLutealPhase(user *models.User, location *time.Location) (LutealPhase, error) {
+	if !IsOwnerUser(user) {
+		return ResolveLutealPhase(nil, nil, location), nil
+	}
+
+	logs, err := service.days.FetchAllLogsForUser(user.ID)
+	if err != nil {
+		return LutealPhase{}, err
+	}
+	return ResolveLutealPhase(user, MaskWithdrawalBleeding(user, logs, location), location), nil
+}
+
+// SaveLutealPhaseLength stores a manual luteal length; an empty value goes
+// back to learning it from confirmed ovulations.
+func (service *SettingsService) SaveLutealPhaseLength(userID uint, raw string) (int, error) {
+	value := 0
+	if trimmed := strings.TrimSpace(raw); trimmed != "" {
+		parsed, err := strconv.Atoi(trimmed)
+		if err != nil || !IsValidLutealPhaseLength(parsed) {
+			return 0, ErrSettingsLutealPhaseLengthInvalid
+		}
+		value = parsed
+	}
+	if err := service.users.UpdateByID(userID, map[string]any{"luteal_phase_length": value}); err != nil {
+		return 0, err
+	}
+	return value, nil
+}
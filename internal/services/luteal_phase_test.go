@@ -0,0 +1,85 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func lutealTestLogs(t *testing.T) []models.DailyLog {
+	t.Helper()
+
+	period := func(day string) models.DailyLog {
+		return models.DailyLog{Date: mustParseDay(t, day), IsPeriod: true, Flow: models.FlowMedium}
+	}
+	return []models.DailyLog{
+		period("2026-01-01"),
+		{Date: mustParseDay(t, "2026-01-16"), LHTest: models.LHTestPositive},
+		period("2026-01-29"),
+		{Date: mustParseDay(t, "2026-02-14"), LHTest: models.LHTestPeak},
+		period("2026-02-26"),
+		period("2026-03-26"),
+	}
+}
+
+func TestObservedLutealPhaseLengths(t *testing.T) {
+	lengths := ObservedLutealPhaseLengths(lutealTestLogs(t), time.UTC)
+	if len(lengths) != 2 || lengths[0] != 11 || lengths[1] != 10 {
+		t.Fatalf("expected luteal lengths from the two LH surges, got %#v", lengths)
+	}
+}
+
+func TestResolveLutealPhasePrefersManualThenLearned(t *testing.T) {
+	logs := lutealTestLogs(t)
+
+	learned := ResolveLutealPhase(&models.User{Role: models.RoleOwner}, logs, time.UTC)
+	if learned.Source != LutealPhaseSourceLearned || learned.Length != 11 || learned.Samples != 2 || !learned.Personalised() {
+		t.Fatalf("expected the learned length, got %#v", learned)
+	}
+
+	manual := ResolveLutealPhase(&models.User{Role: models.RoleOwner, LutealPhaseLength: 13}, logs, time.UTC)
+	if manual.Source != LutealPhaseSourceManual || manual.Length != 13 || manual.LearnedLength != 11 {
+		t.Fatalf("expected the manual length to win, got %#v", manual)
+	}
+
+	fallback := ResolveLutealPhase(&models.User{Role: models.RoleOwner}, nil, time.UTC)
+	if fallback.Source != LutealPhaseSourceDefault || fallback.Length != models.DefaultLutealPhaseLength || fallback.Personalised() {
+		t.Fatalf("expected the default length, got %#v", fallback)
+	}
+}
+
+func TestBuildCycleStatsForRangeUsesLearnedLutealPhase(t *testing.T) {
+	logs := lutealTestLogs(t)
+	service := NewStatsService(&stubStatsDayReader{logsForRange: logs}, &stubStatsSymptomReader{})
+	owner := &models.User{ID: 1, Role: models.RoleOwner, CycleLength: 28, PeriodLength: 5}
+
+	now := mustParseDay(t, "2026-03-28")
+	stats, _, err := service.BuildCycleStatsForRange(owner, now.AddDate(-1, 0, 0), now, now, time.UTC)
+	if err != nil {
+		t.Fatalf("BuildCycleStatsForRange() unexpected error: %v", err)
+	}
+	if !stats.LutealPhasePersonalised || stats.LutealPhaseLength != 11 {
+		t.Fatalf("expected a personalised 11-day luteal phase, got %#v", stats)
+	}
+	if got := stats.OvulationDate.Format("2006-01-02"); got != "2026-04-11" {
+		t.Fatalf("expected ovulation on cycle day 17, got %s", got)
+	}
+}
+
+func TestSaveLutealPhaseLength(t *testing.T) {
+	service := NewSettingsService(&stubSettingsUserRepo{})
+
+	if value, err := service.SaveLutealPhaseLength(1, " 12 "); err != nil || value != 12 {
+		t.Fatalf("expected 12, got %d (%v)", value, err)
+	}
+	if value, err := service.SaveLutealPhaseLength(1, ""); err != nil || value != 0 {
+		t.Fatalf("expected an empty value to clear the manual length, got %d (%v)", value, err)
+	}
+	for _, raw := range []string{"7", "19", "twelve"} {
+		if _, err := service.SaveLutealPhaseLength(1, raw); !errors.Is(err, ErrSettingsLutealPhaseLengthInvalid) {
+			t.Fatalf("expected ErrSettingsLutealPhaseLengthInvalid for %q, got %v", raw, err)
+		}
+	}
+}
@@ -0,0 +1,413 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+var (
+	ErrMedicationNameInvalid     = errors.New("invalid medication name")
+	ErrMedicationDoseInvalid     = errors.New("invalid medication dose")
+	ErrMedicationUnitInvalid     = errors.New("invalid medication unit")
+	ErrMedicationScheduleInvalid = errors.New("invalid medication schedule")
+	ErrMedicationNotFound        = errors.New("medication not found")
+	ErrInvalidMedicationID       = errors.New("invalid medication id")
+	ErrCreateMedicationFailed    = errors.New("create medication failed")
+	ErrDeleteMedicationFailed    = errors.New("delete medication failed")
+	ErrSaveMedicationIntakes     = errors.New("save medication intakes failed")
+)
+
+const (
+	maxMedicationNameLength = 80
+	maxMedicationDose       = 100000
+
+	// maxMedicationCorrelationSymptoms caps how many symptoms the stats page
+	// lists per medication.
+	maxMedicationCorrelationSymptoms = 5
+)
+
+var medicationUnits = []string{"mg", "mcg", "g", "ml", "iu", "drops", "tablets"}
+
+type MedicationRepository interface {
+	ListByUser(userID uint) ([]models.Medication, error)
+	Create(medication *models.Medication) error
+	CountByUserAndIDs(userID uint, ids []uint) (int64, error)
+	DeleteForUser(medicationID uint, userID uint) (bool, error)
+	ListIntakesByUserRange(userID uint, fromStart *time.Time, toEnd *time.Time) ([]models.MedicationIntake, error)
+	ReplaceIntakesForDay(userID uint, dayStart time.Time, dayEnd time.Time, intakes []models.MedicationIntake) error
+	DeleteIntakesByUserAndDayRange(userID uint, dayStart time.Time, dayEnd time.Time) error
+}
+
+type MedicationService struct {
+	medications MedicationRepository
+}
+
+type MedicationInput struct {
+	Name     string
+	Dose     float64
+	Unit     string
+	Schedule string
+}
+
+// MedicationSymptomCorrelation compares how often a symptom was logged on
+// intake days with comparable days on which the medication was not taken.
+type MedicationSymptomCorrelation struct {
+	Name         string
+	Icon         string
+	IntakeRate   int
+	BaselineRate int
+	HasBaseline  bool
+}
+
+type MedicationCorrelation struct {
+	Medication    models.Medication
+	IntakeDays    int
+	FirstCycleDay int
+	LastCycleDay  int
+	Symptoms      []MedicationSymptomCorrelation
+}
+
+func NewMedicationService(medications MedicationRepository) *MedicationService {
+	return &MedicationService{medications: medications}
+}
+
+// MedicationUnits lists the dose units accepted by the catalog.
+func MedicationUnits() []string {
+	units := make([]string, len(medicationUnits))
+	copy(units, medicationUnits)
+	return units
+}
+
+func NormalizeMedicationSchedule(raw string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", models.MedicationScheduleAsNeeded:
+		return models.MedicationScheduleAsNeeded, true
+	case models.MedicationScheduleDaily:
+		return models.MedicationScheduleDaily, true
+	case models.MedicationScheduleTwiceDaily:
+		return models.MedicationScheduleTwiceDaily, true
+	case models.MedicationScheduleWeekly:
+		return models.MedicationScheduleWeekly, true
+	default:
+		return "", false
+	}
+}
+
+func normalizeMedicationUnit(raw string) (string, bool) {
+	unit := strings.ToLower(strings.TrimSpace(raw))
+	if unit == "" {
+		return "", true
+	}
+	for _, allowed := range medicationUnits {
+		if unit == allowed {
+			return unit, true
+		}
+	}
+	return "", false
+}
+
+func (service *MedicationService) ListMedications(userID uint) ([]models.Medication, error) {
+	return service.medications.ListByUser(userID)
+}
+
+func (service *MedicationService) CreateMedicationForUser(userID uint, input MedicationInput) (models.Medication, error) {
+	medication, err := newMedication(userID, input)
+	if err != nil {
+		return models.Medication{}, err
+	}
+	if err := service.medications.Create(&medication); err != nil {
+		return models.Medication{}, fmt.Errorf("%w: %v", ErrCreateMedicationFailed, err)
+	}
+	return medication, nil
+}
+
+// newMedication validates input and builds the catalog entry to store.
+func newMedication(userID uint, input MedicationInput) (models.Medication, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxMedicationNameLength {
+		return models.Medication{}, ErrMedicationNameInvalid
+	}
+	if math.IsNaN(input.Dose) || input.Dose < 0 || input.Dose > maxMedicationDose {
+		return models.Medication{}, ErrMedicationDoseInvalid
+	}
+	unit, ok := normalizeMedicationUnit(input.Unit)
+	if !ok {
+		return models.Medication{}, ErrMedicationUnitInvalid
+	}
+	schedule, ok := NormalizeMedicationSchedule(input.Schedule)
+	if !ok {
+		return models.Medication{}, ErrMedicationScheduleInvalid
+	}
+
+	return models.Medication{
+		UserID:   userID,
+		Name:     name,
+		Dose:     input.Dose,
+		Unit:     unit,
+		Schedule: schedule,
+	}, nil
+}
+
+// DeleteMedicationForUser removes a catalog entry and every intake of it.
+func (service *MedicationService) DeleteMedicationForUser(userID uint, medicationID uint) error {
+	deleted, err := service.medications.DeleteForUser(medicationID, userID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDeleteMedicationFailed, err)
+	}
+	if !deleted {
+		return ErrMedicationNotFound
+	}
+	return nil
+}
+
+func (service *MedicationService) FetchIntakesForOptionalRange(userID uint, from *time.Time, to *time.Time, location *time.Location) ([]models.MedicationIntake, error) {
+	var fromStart *time.Time
+	var toEnd *time.Time
+	if from != nil {
+		start, _ := DayRange(*from, location)
+		fromStart = &start
+	}
+	if to != nil {
+		_, end := DayRange(*to, location)
+		toEnd = &end
+	}
+	return service.medications.ListIntakesByUserRange(userID, fromStart, toEnd)
+}
+
+func (service *MedicationService) FetchIntakesForDay(userID uint, day time.Time, location *time.Location) ([]models.MedicationIntake, error) {
+	return service.FetchIntakesForOptionalRange(userID, &day, &day, location)
+}
+
+// ValidateMedicationIDs deduplicates ids and checks that all of them belong
+// to the user's catalog.
+func (service *MedicationService) ValidateMedicationIDs(userID uint, ids []uint) ([]uint, error) {
+	if len(ids) == 0 {
+		return []uint{}, nil
+	}
+
+	unique := make(map[uint]struct{}, len(ids))
+	filtered := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := unique[id]; ok {
+			continue
+		}
+		unique[id] = struct{}{}
+		filtered = append(filtered, id)
+	}
+
+	matched, err := service.medications.CountByUserAndIDs(userID, filtered)
+	if err != nil {
+		return nil, err
+	}
+	if int(matched) != len(filtered) {
+		return nil, ErrInvalidMedicationID
+	}
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i] < filtered[j] })
+	return filtered, nil
+}
+
+// ReplaceDayIntakes records exactly the given medications as taken on day.
+// Dose and unit are copied from the catalog.
+func (service *MedicationService) ReplaceDayIntakes(userID uint, day time.Time, medicationIDs []uint, location *time.Location) error {
+	catalog, err := service.medications.ListByUser(userID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSaveMedicationIntakes, err)
+	}
+	byID := make(map[uint]models.Medication, len(catalog))
+	for _, medication := range catalog {
+		byID[medication.ID] = medication
+	}
+
+	dayStart, dayEnd := DayRange(day, location)
+	seen := make(map[uint]struct{}, len(medicationIDs))
+	intakes := make([]models.MedicationIntake, 0, len(medicationIDs))
+	for _, id := range medicationIDs {
+		medication, ok := byID[id]
+		if !ok {
+			return ErrInvalidMedicationID
+		}
+		if _, duplicate := seen[id]; duplicate {
+			continue
+		}
+		seen[id] = struct{}{}
+		intakes = append(intakes, models.MedicationIntake{
+			UserID:       userID,
+			MedicationID: id,
+			Date:         dayStart,
+			Dose:         medication.Dose,
+			Unit:         medication.Unit,
+		})
+	}
+
+	if err := service.medications.ReplaceIntakesForDay(userID, dayStart, dayEnd, intakes); err != nil {
+		return fmt.Errorf("%w: %v", ErrSaveMedicationIntakes, err)
+	}
+	return nil
+}
+
+func (service *MedicationService) DeleteDayIntakes(userID uint, day time.Time, location *time.Location) error {
+	dayStart, dayEnd := DayRange(day, location)
+	return service.medications.DeleteIntakesByUserAndDayRange(userID, dayStart, dayEnd)
+}
+
+// MedicationIntakeIDSet returns the medication IDs taken on a day, for
+// pre-checking the day editor.
+func MedicationIntakeIDSet(intakes []models.MedicationIntake) map[uint]bool {
+	set := make(map[uint]bool, len(intakes))
+	for _, intake := range intakes {
+		set[intake.MedicationID] = true
+	}
+	return set
+}
+
+// BuildMedicationCorrelations summarises when each medication is taken in
+// the cycle and how often symptoms were logged on intake days. The baseline
+// uses logged days without the medication that fall on the same cycle days,
+// so that a painkiller taken on day 1 is compared with other day-1s rather
+// than with the whole cycle.
+func BuildMedicationCorrelations(medications []models.Medication, intakes []models.MedicationIntake, logs []models.DailyLog, symptoms []models.SymptomType, cycleStarts []time.Time, location *time.Location) []MedicationCorrelation {
+	if len(medications) == 0 || len(intakes) == 0 {
+		return []MedicationCorrelation{}
+	}
+
+	logsByDay := make(map[string]models.DailyLog, len(logs))
+	for _, logEntry := range logs {
+		logsByDay[DateAtLocation(logEntry.Date, location).Format("2006-01-02")] = logEntry
+	}
+	symptomsByID := make(map[uint]models.SymptomType, len(symptoms))
+	for _, symptom := range symptoms {
+		symptomsByID[symptom.ID] = symptom
+	}
+	starts := make([]time.Time, 0, len(cycleStarts))
+	for _, start := range cycleStarts {
+		starts = append(starts, DateAtLocation(start, location))
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+
+	intakeDaysByMedication := make(map[uint]map[string]time.Time)
+	for _, intake := range intakes {
+		day := DateAtLocation(intake.Date, location)
+		days, ok := intakeDaysByMedication[intake.MedicationID]
+		if !ok {
+			days = make(map[string]time.Time)
+			intakeDaysByMedication[intake.MedicationID] = days
+		}
+		days[day.Format("2006-01-02")] = day
+	}
+
+	result := make([]MedicationCorrelation, 0, len(intakeDaysByMedication))
+	for _, medication := range medications {
+		intakeDays := intakeDaysByMedication[medication.ID]
+		if len(intakeDays) == 0 {
+			continue
+		}
+
+		correlation := MedicationCorrelation{Medication: medication, IntakeDays: len(intakeDays)}
+		cycleDays := make(map[int]struct{})
+		intakeCounts := make(map[uint]int)
+		for key, day := range intakeDays {
+			if cycleDay := cycleDayFromStarts(starts, day); cycleDay > 0 {
+				cycleDays[cycleDay] = struct{}{}
+				if correlation.FirstCycleDay == 0 || cycleDay < correlation.FirstCycleDay {
+					correlation.FirstCycleDay = cycleDay
+				}
+				if cycleDay > correlation.LastCycleDay {
+					correlation.LastCycleDay = cycleDay
+				}
+			}
+			for _, symptomID := range uniqueSymptomIDs(logsByDay[key].SymptomIDs) {
+				intakeCounts[symptomID]++
+			}
+		}
+
+		baselineDays := 0
+		baselineCounts := make(map[uint]int)
+		for key, logEntry := range logsByDay {
+			if _, taken := intakeDays[key]; taken {
+				continue
+			}
+			if len(cycleDays) > 0 {
+				if _, comparable := cycleDays[cycleDayFromStarts(starts, DateAtLocation(logEntry.Date, location))]; !comparable {
+					continue
+				}
+			}
+			baselineDays++
+			for _, symptomID := range uniqueSymptomIDs(logEntry.SymptomIDs) {
+				baselineCounts[symptomID]++
+			}
+		}
+
+		for symptomID, count := range intakeCounts {
+			symptom, ok := symptomsByID[symptomID]
+			if !ok {
+				continue
+			}
+			item := MedicationSymptomCorrelation{
+				Name:       symptom.Name,
+				Icon:       symptom.Icon,
+				IntakeRate: percentOf(count, len(intakeDays)),
+			}
+			if baselineDays > 0 {
+				item.HasBaseline = true
+				item.BaselineRate = percentOf(baselineCounts[symptomID], baselineDays)
+			}
+			correlation.Symptoms = append(correlation.Symptoms, item)
+		}
+		sort.Slice(correlation.Symptoms, func(i, j int) bool {
+			left, right := correlation.Symptoms[i], correlation.Symptoms[j]
+			if left.IntakeRate != right.IntakeRate {
+				return left.IntakeRate > right.IntakeRate
+			}
+			return left.Name < right.Name
+		})
+		if len(correlation.Symptoms) > maxMedicationCorrelationSymptoms {
+			correlation.Symptoms = correlation.Symptoms[:maxMedicationCorrelationSymptoms]
+		}
+		result = append(result, correlation)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].IntakeDays > result[j].IntakeDays
+	})
+	return result
+}
+
+// cycleDayFromStarts returns the 1-based cycle day of day, or 0 before the
+// first known cycle start.
+func cycleDayFromStarts(starts []time.Time, day time.Time) int {
+	cycleDay := 0
+	for _, start := range starts {
+		if start.After(day) {
+			break
+		}
+		cycleDay = calendarDaysBetween(start, day) + 1
+	}
+	return cycleDay
+}
+
+func uniqueSymptomIDs(ids []uint) []uint {
+	seen := make(map[uint]struct{}, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+func percentOf(count int, total int) int {
+	if total <= 0 {
+		return 0
+	}
+	return int(math.Round(float64(count) * 100 / float64(total)))
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func TestCreateMedicationForUserValidatesInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		input        MedicationInput
+		wantErr      error
+		wantSchedule string
+	}{
+		{name: "painkiller", input: MedicationInput{Name: " Ibuprofen ", Dose: 400, Unit: "MG", Schedule: "as_needed"}, wantSchedule: models.MedicationScheduleAsNeeded},
+		{name: "schedule defaults to as needed", input: MedicationInput{Name: "Iron"}, wantSchedule: models.MedicationScheduleAsNeeded},
+		{name: "daily supplement", input: MedicationInput{Name: "Vitamin D", Dose: 1000, Unit: "iu", Schedule: "daily"}, wantSchedule: models.MedicationScheduleDaily},
+		{name: "empty name", input: MedicationInput{Name: "  "}, wantErr: ErrMedicationNameInvalid},
+		{name: "negative dose", input: MedicationInput{Name: "Iron", Dose: -1}, wantErr: ErrMedicationDoseInvalid},
+		{name: "unknown unit", input: MedicationInput{Name: "Iron", Unit: "spoons"}, wantErr: ErrMedicationUnitInvalid},
+		{name: "unknown schedule", input: MedicationInput{Name: "Iron", Schedule: "hourly"}, wantErr: ErrMedicationScheduleInvalid},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := NewMedicationService(&stubMedicationRepository{})
+			medication, err := service.CreateMedicationForUser(7, testCase.input)
+			if testCase.wantErr != nil {
+				if !errors.Is(err, testCase.wantErr) {
+					t.Fatalf("expected %v, got %v", testCase.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if medication.UserID != 7 || medication.Schedule != testCase.wantSchedule {
+				t.Fatalf("expected schedule %q for user 7, got %#v", testCase.wantSchedule, medication)
+			}
+			if testCase.name == "painkiller" && (medication.Name != "Ibuprofen" || medication.Unit != "mg") {
+				t.Fatalf("expected trimmed name and normalized unit, got %#v", medication)
+			}
+		})
+	}
+}
+
+func TestReplaceDayIntakesCopiesCatalogDose(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubMedicationRepository{medications: []models.Medication{{ID: 1, Name: "Ibuprofen", Dose: 400, Unit: "mg"}}}
+	service := NewMedicationService(repo)
+	day := mustParseDay(t, "2026-02-10")
+
+	if err := service.ReplaceDayIntakes(7, day, []uint{1, 1}, time.UTC); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.replaced) != 1 || repo.replaced[0].Dose != 400 || repo.replaced[0].Unit != "mg" || !repo.replaced[0].Date.Equal(day) {
+		t.Fatalf("expected one intake with the catalog dose, got %#v", repo.replaced)
+	}
+	if err := service.ReplaceDayIntakes(7, day, []uint{2}, time.UTC); !errors.Is(err, ErrInvalidMedicationID) {
+		t.Fatalf("expected ErrInvalidMedicationID, got %v", err)
+	}
+}
+
+func TestBuildMedicationCorrelationsComparesSameCycleDays(t *testing.T) {
+	t.Parallel()
+
+	painkiller := models.Medication{ID: 1, Name: "Ibuprofen"}
+	cramps := models.SymptomType{ID: 10, Name: "Cramps", Icon: "🩸"}
+	headache := models.SymptomType{ID: 11, Name: "Headache", Icon: "🤕"}
+
+	logWithSymptoms := func(day string, ids ...uint) models.DailyLog {
+		entry := makeLog(t, day, false)
+		entry.SymptomIDs = ids
+		return entry
+	}
+	logs := []models.DailyLog{
+		// Cycle 1: painkiller on day 1 with cramps.
+		logWithSymptoms("2026-01-01", 10),
+		logWithSymptoms("2026-01-15", 11),
+		// Cycle 2: no painkiller on day 1 and no cramps.
+		logWithSymptoms("2026-01-29"),
+		// Cycle 3: painkiller on days 1 and 2, cramps on both.
+		logWithSymptoms("2026-02-26", 10),
+		logWithSymptoms("2026-02-27", 10, 11),
+	}
+	intakes := []models.MedicationIntake{
+		{MedicationID: 1, Date: mustParseDay(t, "2026-01-01")},
+		{MedicationID: 1, Date: mustParseDay(t, "2026-02-26")},
+		{MedicationID: 1, Date: mustParseDay(t, "2026-02-27")},
+	}
+	starts := []time.Time{mustParseDay(t, "2026-01-01"), mustParseDay(t, "2026-01-29"), mustParseDay(t, "2026-02-26")}
+
+	correlations := BuildMedicationCorrelations(
+		[]models.Medication{painkiller, {ID: 2, Name: "Iron"}},
+		intakes,
+		logs,
+		[]models.SymptomType{cramps, headache},
+		starts,
+		time.UTC,
+	)
+	if len(correlations) != 1 {
+		t.Fatalf("expected only the medication with intakes, got %#v", correlations)
+	}
+
+	correlation := correlations[0]
+	if correlation.IntakeDays != 3 || correlation.FirstCycleDay != 1 || correlation.LastCycleDay != 2 {
+		t.Fatalf("expected 3 intake days on cycle days 1-2, got %#v", correlation)
+	}
+	if len(correlation.Symptoms) != 2 {
+		t.Fatalf("expected cramps and headache, got %#v", correlation.Symptoms)
+	}
+	// Only 2026-01-29 (cycle day 1 without intake) is comparable; the day-15
+	// headache is outside the intake cycle days.
+	first := correlation.Symptoms[0]
+	if first.Name != "Cramps" || first.IntakeRate != 100 || !first.HasBaseline || first.BaselineRate != 0 {
+		t.Fatalf("expected cramps on every intake day and none otherwise, got %#v", first)
+	}
+	if second := correlation.Symptoms[1]; second.Name != "Headache" || second.IntakeRate != 33 || second.BaselineRate != 0 {
+		t.Fatalf("expected headache on a third of intake days, got %#v", second)
+	}
+}
+
+type stubMedicationRepository struct {
+	medications []models.Medication
+	replaced    []models.MedicationIntake
+}
+
+func (stub *stubMedicationRepository) ListByUser(uint) ([]models.Medication, error) {
+	return stub.medications, nil
+}
+
+func (stub *stubMedicationRepository) Create(medication *models.Medication) error {
+	medication.ID = uint(len(stub.medications) + 1)
+	stub.medications = append(stub.medications, *medication)
+	return nil
+}
+
+func (stub *stubMedicationRepository) CountByUserAndIDs(_ uint, ids []uint) (int64, error) {
+	count := int64(0)
+	for _, medication := range stub.medications {
+		for _, id := range ids {
+			if medication.ID == id {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+func (stub *stubMedicationRepository) DeleteForUser(uint, uint) (bool, error) {
+	return true, nil
+}
+
+func (stub *stubMedicationRepository) ListIntakesByUserRange(uint, *time.Time, *time.Time) ([]models.MedicationIntake, error) {
+	return stub.replaced, nil
+}
+
+func (stub *stubMedicationRepository) ReplaceIntakesForDay(_ uint, _ time.Time, _ time.Time, intakes []models.MedicationIntake) error {
+	stub.replaced = intakes
+	return nil
+}
+
+func (stub *stubMedicationRepository) DeleteIntakesByUserAndDayRange(uint, time.Time, time.Time) error {
+	stub.replaced = nil
+	return nil
+}
@@ -0,0 +1,382 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+var (
+	ErrMetricNameInvalid  = errors.New("invalid metric name")
+	ErrMetricUnitInvalid  = errors.New("invalid metric unit")
+	ErrMetricKindInvalid  = errors.New("invalid metric type")
+	ErrMetricRangeInvalid = errors.New("invalid metric range")
+	ErrMetricNotFound     = errors.New("metric not found")
+	ErrInvalidMetricID    = errors.New("invalid metric id")
+	ErrMetricValueInvalid = errors.New("invalid metric value")
+	ErrCreateMetricFailed = errors.New("create metric failed")
+	ErrDeleteMetricFailed = errors.New("delete metric failed")
+	ErrSaveMetricValues   = errors.New("save metric values failed")
+)
+
+const (
+	maxMetricNameLength = 80
+	maxMetricUnitLength = 16
+	maxMetricMagnitude  = 1000000
+
+	// maxMetricScaleSteps keeps scale metrics small enough to rate by feel.
+	maxMetricScaleSteps = 100
+
+	defaultMetricScaleMin = 1
+	defaultMetricScaleMax = 10
+)
+
+type MetricRepository interface {
+	ListTypesByUser(userID uint) ([]models.MetricType, error)
+	CreateType(metric *models.MetricType) error
+	DeleteTypeForUser(metricTypeID uint, userID uint) (bool, error)
+	ListValuesByUserRange(userID uint, fromStart *time.Time, toEnd *time.Time) ([]models.MetricValue, error)
+	ReplaceValuesForDay(userID uint, dayStart time.Time, dayEnd time.Time, values []models.MetricValue) error
+	DeleteValuesByUserAndDayRange(userID uint, dayStart time.Time, dayEnd time.Time) error
+}
+
+type MetricService struct {
+	metrics MetricRepository
+}
+
+type MetricTypeInput struct {
+	Name     string
+	Unit     string
+	Kind     string
+	MinValue *float64
+	MaxValue *float64
+}
+
+type MetricPoint struct {
+	Date  time.Time
+	Value float64
+}
+
+type MetricCycleDayAverage struct {
+	CycleDay int
+	Average  float64
+	Days     int
+}
+
+// MetricSeries holds a metric's values in date order and their averages by
+// cycle day. Cycle days only cover values logged after the first known
+// cycle start.
+type MetricSeries struct {
+	Metric    models.MetricType
+	Points    []MetricPoint
+	CycleDays []MetricCycleDayAverage
+	Average   float64
+}
+
+func NewMetricService(metrics MetricRepository) *MetricService {
+	return &MetricService{metrics: metrics}
+}
+
+// MetricKinds lists the kinds accepted by the metric catalog.
+func MetricKinds() []string {
+	return []string{models.MetricKindDecimal, models.MetricKindInteger, models.MetricKindScale}
+}
+
+func NormalizeMetricKind(raw string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", models.MetricKindDecimal:
+		return models.MetricKindDecimal, true
+	case models.MetricKindInteger:
+		return models.MetricKindInteger, true
+	case models.MetricKindScale:
+		return models.MetricKindScale, true
+	default:
+		return "", false
+	}
+}
+
+func (service *MetricService) ListMetricTypes(userID uint) ([]models.MetricType, error) {
+	return service.metrics.ListTypesByUser(userID)
+}
+
+func (service *MetricService) CreateMetricTypeForUser(userID uint, input MetricTypeInput) (models.MetricType, error) {
+	metric, err := newMetricType(userID, input)
+	if err != nil {
+		return models.MetricType{}, err
+	}
+	if err := service.metrics.CreateType(&metric); err != nil {
+		return models.MetricType{}, fmt.Errorf("%w: %v", ErrCreateMetricFailed, err)
+	}
+	return metric, nil
+}
+
+// newMetricType validates input and builds the catalog entry to store.
+func newMetricType(userID uint, input MetricTypeInput) (models.MetricType, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxMetricNameLength {
+		return models.MetricType{}, ErrMetricNameInvalid
+	}
+	unit := strings.TrimSpace(input.Unit)
+	if utf8.RuneCountInString(unit) > maxMetricUnitLength {
+		return models.MetricType{}, ErrMetricUnitInvalid
+	}
+	kind, ok := NormalizeMetricKind(input.Kind)
+	if !ok {
+		return models.MetricType{}, ErrMetricKindInvalid
+	}
+	minValue, maxValue, err := normalizeMetricRange(kind, input.MinValue, input.MaxValue)
+	if err != nil {
+		return models.MetricType{}, err
+	}
+
+	return models.MetricType{
+		UserID:   userID,
+		Name:     name,
+		Unit:     unit,
+		Kind:     kind,
+		MinValue: minValue,
+		MaxValue: maxValue,
+	}, nil
+}
+
+// normalizeMetricRange checks the optional bounds of a new metric. Scale
+// metrics without bounds default to 1-10.
+func normalizeMetricRange(kind string, minValue *float64, maxValue *float64) (*float64, *float64, error) {
+	if kind == models.MetricKindScale && minValue == nil && maxValue == nil {
+		low, high := float64(defaultMetricScaleMin), float64(defaultMetricScaleMax)
+		return &low, &high, nil
+	}
+	for _, bound := range []*float64{minValue, maxValue} {
+		if bound == nil {
+			continue
+		}
+		if !isFiniteMetricNumber(*bound) {
+			return nil, nil, ErrMetricRangeInvalid
+		}
+		if kind != models.MetricKindDecimal && *bound != math.Trunc(*bound) {
+			return nil, nil, ErrMetricRangeInvalid
+		}
+	}
+	if minValue != nil && maxValue != nil && *minValue >= *maxValue {
+		return nil, nil, ErrMetricRangeInvalid
+	}
+	if kind == models.MetricKindScale {
+		if minValue == nil || maxValue == nil || *maxValue-*minValue > maxMetricScaleSteps {
+			return nil, nil, ErrMetricRangeInvalid
+		}
+	}
+	return minValue, maxValue, nil
+}
+
+// DeleteMetricTypeForUser removes a metric and every value recorded for it.
+func (service *MetricService) DeleteMetricTypeForUser(userID uint, metricTypeID uint) error {
+	deleted, err := service.metrics.DeleteTypeForUser(metricTypeID, userID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDeleteMetricFailed, err)
+	}
+	if !deleted {
+		return ErrMetricNotFound
+	}
+	return nil
+}
+
+func (service *MetricService) FetchValuesForOptionalRange(userID uint, from *time.Time, to *time.Time, location *time.Location) ([]models.MetricValue, error) {
+	var fromStart *time.Time
+	var toEnd *time.Time
+	if from != nil {
+		start, _ := DayRange(*from, location)
+		fromStart = &start
+	}
+	if to != nil {
+		_, end := DayRange(*to, location)
+		toEnd = &end
+	}
+	return service.metrics.ListValuesByUserRange(userID, fromStart, toEnd)
+}
+
+func (service *MetricService) FetchValuesForDay(userID uint, day time.Time, location *time.Location) ([]models.MetricValue, error) {
+	return service.FetchValuesForOptionalRange(userID, &day, &day, location)
+}
+
+// NormalizeDayValues checks that every metric belongs to the user's catalog
+// and that each value fits its metric.
+func (service *MetricService) NormalizeDayValues(userID uint, values map[uint]float64) (map[uint]float64, error) {
+	if len(values) == 0 {
+		return map[uint]float64{}, nil
+	}
+
+	catalog, err := service.metrics.ListTypesByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.MetricType, len(catalog))
+	for _, metric := range catalog {
+		byID[metric.ID] = metric
+	}
+
+	normalized := make(map[uint]float64, len(values))
+	for id, value := range values {
+		metric, ok := byID[id]
+		if !ok {
+			return nil, ErrInvalidMetricID
+		}
+		normalizedValue, err := NormalizeMetricValue(metric, value)
+		if err != nil {
+			return nil, err
+		}
+		normalized[id] = normalizedValue
+	}
+	return normalized, nil
+}
+
+// NormalizeMetricValue checks value against the metric's kind and range.
+// Decimal values are kept to two places.
+func NormalizeMetricValue(metric models.MetricType, value float64) (float64, error) {
+	if !isFiniteMetricNumber(value) {
+		return 0, ErrMetricValueInvalid
+	}
+	if metric.Kind == models.MetricKindDecimal {
+		value = math.Round(value*100) / 100
+	} else if value != math.Trunc(value) {
+		return 0, ErrMetricValueInvalid
+	}
+	if metric.MinValue != nil && value < *metric.MinValue {
+		return 0, ErrMetricValueInvalid
+	}
+	if metric.MaxValue != nil && value > *metric.MaxValue {
+		return 0, ErrMetricValueInvalid
+	}
+	return value, nil
+}
+
+func isFiniteMetricNumber(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0) && math.Abs(value) <= maxMetricMagnitude
+}
+
+// ReplaceDayValues records exactly the given values for day; metrics left
+// out are cleared. Values are expected to be normalized already.
+func (service *MetricService) ReplaceDayValues(userID uint, day time.Time, values map[uint]float64, location *time.Location) error {
+	dayStart, dayEnd := DayRange(day, location)
+	records := make([]models.MetricValue, 0, len(values))
+	for id, value := range values {
+		records = append(records, models.MetricValue{
+			UserID:       userID,
+			MetricTypeID: id,
+			Date:         dayStart,
+			Value:        value,
+		})
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].MetricTypeID < records[j].MetricTypeID })
+
+	if err := service.metrics.ReplaceValuesForDay(userID, dayStart, dayEnd, records); err != nil {
+		return fmt.Errorf("%w: %v", ErrSaveMetricValues, err)
+	}
+	return nil
+}
+
+func (service *MetricService) DeleteDayValues(userID uint, day time.Time, location *time.Location) error {
+	dayStart, dayEnd := DayRange(day, location)
+	return service.metrics.DeleteValuesByUserAndDayRange(userID, dayStart, dayEnd)
+}
+
+// FormatMetricValue renders a value without trailing zeros, as typed in the
+// day editor and written to exports.
+func FormatMetricValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// MetricRangeLabel renders a metric's bounds as "1–10", "≥ 0" or "≤ 200",
+// or an empty string for an open range.
+func MetricRangeLabel(metric models.MetricType) string {
+	switch {
+	case metric.MinValue != nil && metric.MaxValue != nil:
+		return FormatMetricValue(*metric.MinValue) + "–" + FormatMetricValue(*metric.MaxValue)
+	case metric.MinValue != nil:
+		return "≥ " + FormatMetricValue(*metric.MinValue)
+	case metric.MaxValue != nil:
+		return "≤ " + FormatMetricValue(*metric.MaxValue)
+	default:
+		return ""
+	}
+}
+
+// MetricValueInputs returns the formatted value of each metric, for
+// pre-filling the day editor.
+func MetricValueInputs(values []models.MetricValue) map[uint]string {
+	inputs := make(map[uint]string, len(values))
+	for _, value := range values {
+		inputs[value.MetricTypeID] = FormatMetricValue(value.Value)
+	}
+	return inputs
+}
+
+// BuildMetricSeries groups values per metric for the stats charts. Metrics
+// without values are left out.
+func BuildMetricSeries(metrics []models.MetricType, values []models.MetricValue, cycleStarts []time.Time, location *time.Location) []MetricSeries {
+	if len(metrics) == 0 || len(values) == 0 {
+		return []MetricSeries{}
+	}
+
+	starts := make([]time.Time, 0, len(cycleStarts))
+	for _, start := range cycleStarts {
+		starts = append(starts, DateAtLocation(start, location))
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+
+	pointsByMetric := make(map[uint][]MetricPoint)
+	for _, value := range values {
+		pointsByMetric[value.MetricTypeID] = append(pointsByMetric[value.MetricTypeID], MetricPoint{
+			Date:  DateAtLocation(value.Date, location),
+			Value: value.Value,
+		})
+	}
+
+	result := make([]MetricSeries, 0, len(pointsByMetric))
+	for _, metric := range metrics {
+		points := pointsByMetric[metric.ID]
+		if len(points) == 0 {
+			continue
+		}
+		sort.Slice(points, func(i, j int) bool { return points[i].Date.Before(points[j].Date) })
+
+		total := 0.0
+		totalsByCycleDay := make(map[int]float64)
+		daysByCycleDay := make(map[int]int)
+		for _, point := range points {
+			total += point.Value
+			if cycleDay := cycleDayFromStarts(starts, point.Date); cycleDay > 0 {
+				totalsByCycleDay[cycleDay] += point.Value
+				daysByCycleDay[cycleDay]++
+			}
+		}
+
+		cycleDays := make([]MetricCycleDayAverage, 0, len(daysByCycleDay))
+		for cycleDay, days := range daysByCycleDay {
+			cycleDays = append(cycleDays, MetricCycleDayAverage{
+				CycleDay: cycleDay,
+				Average:  roundMetricAverage(totalsByCycleDay[cycleDay] / float64(days)),
+				Days:     days,
+			})
+		}
+		sort.Slice(cycleDays, func(i, j int) bool { return cycleDays[i].CycleDay < cycleDays[j].CycleDay })
+
+		result = append(result, MetricSeries{
+			Metric:    metric,
+			Points:    points,
+			CycleDays: cycleDays,
+			Average:   roundMetricAverage(total / float64(len(points))),
+		})
+	}
+	return result
+}
+
+func roundMetricAverage(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+type stubMetricRepository struct {
+	metrics  []models.MetricType
+	replaced []models.MetricValue
+}
+
+func (stub *stubMetricRepository) ListTypesByUser(uint) ([]models.MetricType, error) {
+	return stub.metrics, nil
+}
+
+func (stub *stubMetricRepository) CreateType(metric *models.MetricType) error {
+	metric.ID = uint(len(stub.metrics) + 1)
+	stub.metrics = append(stub.metrics, *metric)
+	return nil
+}
+
+func (stub *stubMetricRepository) DeleteTypeForUser(uint, uint) (bool, error) {
+	return true, nil
+}
+
+func (stub *stubMetricRepository) ListValuesByUserRange(uint, *time.Time, *time.Time) ([]models.MetricValue, error) {
+	return []models.MetricValue{}, nil
+}
+
+func (stub *stubMetricRepository) ReplaceValuesForDay(_ uint, _ time.Time, _ time.Time, values []models.MetricValue) error {
+	stub.replaced = values
+	return nil
+}
+
+func (stub *stubMetricRepository) DeleteValuesByUserAndDayRange(uint, time.Time, time.Time) error {
+	return nil
+}
+
+func metricBound(value float64) *float64 {
+	return &value
+}
+
+func TestCreateMetricTypeForUserValidatesInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		input     MetricTypeInput
+		wantErr   error
+		wantKind  string
+		wantRange string
+	}{
+		{name: "weight", input: MetricTypeInput{Name: " Weight ", Unit: "kg", Kind: "decimal", MinValue: metricBound(0)}, wantKind: models.MetricKindDecimal, wantRange: "≥ 0"},
+		{name: "kind defaults to decimal", input: MetricTypeInput{Name: "Sleep"}, wantKind: models.MetricKindDecimal},
+		{name: "scale defaults to one to ten", input: MetricTypeInput{Name: "Energy", Kind: "scale"}, wantKind: models.MetricKindScale, wantRange: "1–10"},
+		{name: "steps", input: MetricTypeInput{Name: "Steps", Kind: "integer", MaxValue: metricBound(100000)}, wantKind: models.MetricKindInteger, wantRange: "≤ 100000"},
+		{name: "empty name", input: MetricTypeInput{Name: " "}, wantErr: ErrMetricNameInvalid},
+		{name: "long unit", input: MetricTypeInput{Name: "Weight", Unit: "kilograms per person"}, wantErr: ErrMetricUnitInvalid},
+		{name: "unknown kind", input: MetricTypeInput{Name: "Weight", Kind: "text"}, wantErr: ErrMetricKindInvalid},
+		{name: "inverted range", input: MetricTypeInput{Name: "Weight", MinValue: metricBound(90), MaxValue: metricBound(40)}, wantErr: ErrMetricRangeInvalid},
+		{name: "fractional integer bound", input: MetricTypeInput{Name: "Steps", Kind: "integer", MinValue: metricBound(0.5)}, wantErr: ErrMetricRangeInvalid},
+		{name: "open scale", input: MetricTypeInput{Name: "Mood", Kind: "scale", MinValue: metricBound(1)}, wantErr: ErrMetricRangeInvalid},
+		{name: "huge scale", input: MetricTypeInput{Name: "Mood", Kind: "scale", MinValue: metricBound(0), MaxValue: metricBound(1000)}, wantErr: ErrMetricRangeInvalid},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := NewMetricService(&stubMetricRepository{})
+			metric, err := service.CreateMetricTypeForUser(7, testCase.input)
+			if testCase.wantErr != nil {
+				if !errors.Is(err, testCase.wantErr) {
+					t.Fatalf("expected %v, got %v", testCase.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if metric.UserID != 7 || metric.Kind != testCase.wantKind || MetricRangeLabel(metric) != testCase.wantRange {
+				t.Fatalf("expected %s metric with range %q, got %#v (%q)", testCase.wantKind, testCase.wantRange, metric, MetricRangeLabel(metric))
+			}
+		})
+	}
+}
+
+func TestNormalizeDayValuesChecksKindAndRange(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubMetricRepository{metrics: []models.MetricType{
+		{ID: 1, Name: "Weight", Kind: models.MetricKindDecimal, MinValue: metricBound(0)},
+		{ID: 2, Name: "Mood", Kind: models.MetricKindScale, MinValue: metricBound(1), MaxValue: metricBound(5)},
+	}}
+	service := NewMetricService(repo)
+
+	values, err := service.NormalizeDayValues(7, map[uint]float64{1: 62.456, 2: 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if values[1] != 62.46 || values[2] != 4 {
+		t.Fatalf("expected rounded weight and mood 4, got %#v", values)
+	}
+
+	for _, invalid := range []map[uint]float64{{2: 3.5}, {2: 6}, {1: -1}} {
+		if _, err := service.NormalizeDayValues(7, invalid); !errors.Is(err, ErrMetricValueInvalid) {
+			t.Fatalf("expected ErrMetricValueInvalid for %v, got %v", invalid, err)
+		}
+	}
+	if _, err := service.NormalizeDayValues(7, map[uint]float64{9: 1}); !errors.Is(err, ErrInvalidMetricID) {
+		t.Fatalf("expected ErrInvalidMetricID, got %v", err)
+	}
+
+	day := mustParseDay(t, "2026-02-10")
+	if err := service.ReplaceDayValues(7, day, values, time.UTC); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.replaced) != 2 || repo.replaced[0].MetricTypeID != 1 || !repo.replaced[0].Date.Equal(day) {
+		t.Fatalf("expected both values stored for the day in metric order, got %#v", repo.replaced)
+	}
+}
+
+func TestBuildMetricSeriesAveragesByCycleDay(t *testing.T) {
+	t.Parallel()
+
+	weight := models.MetricType{ID: 1, Name: "Weight", Kind: models.MetricKindDecimal}
+	sleep := models.MetricType{ID: 2, Name: "Sleep", Kind: models.MetricKindDecimal}
+	value := func(day string, v float64) models.MetricValue {
+		return models.MetricValue{MetricTypeID: 1, Date: mustParseDay(t, day), Value: v}
+	}
+	values := []models.MetricValue{
+		value("2026-03-02", 61),
+		// Before the first known start, so it has no cycle day.
+		value("2026-01-30", 63),
+		value("2026-02-01", 62),
+		value("2026-02-02", 62.5),
+		value("2026-03-01", 61.5),
+	}
+	starts := []time.Time{mustParseDay(t, "2026-03-01"), mustParseDay(t, "2026-02-01")}
+
+	result := BuildMetricSeries([]models.MetricType{weight, sleep}, values, starts, time.UTC)
+	if len(result) != 1 || result[0].Metric.ID != 1 {
+		t.Fatalf("expected only the metric with values, got %#v", result)
+	}
+	series := result[0]
+	if len(series.Points) != 5 || !series.Points[0].Date.Equal(mustParseDay(t, "2026-01-30")) || series.Average != 62 {
+		t.Fatalf("expected five points in date order averaging 62, got %#v", series)
+	}
+	want := []MetricCycleDayAverage{{CycleDay: 1, Average: 61.75, Days: 2}, {CycleDay: 2, Average: 61.75, Days: 2}}
+	if len(series.CycleDays) != len(want) {
+		t.Fatalf("expected %#v, got %#v", want, series.CycleDays)
+	}
+	for index := range want {
+		if series.CycleDays[index] != want[index] {
+			t.Fatalf("expected %#v, got %#v", want, series.CycleDays)
+		}
+	}
+}
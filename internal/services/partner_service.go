@@ -0,0 +1,193 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/security"
+)
+
+const (
+	partnerInviteTokenLength   = 32
+	partnerInviteTokenAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	DefaultPartnerInviteTTL    = 7 * 24 * time.Hour
+)
+
+var (
+	ErrPartnerInviteOwnerRequired = errors.New("partner invite owner required")
+	ErrPartnerInviteInvalid       = errors.New("partner invite invalid")
+	ErrPartnerInviteCreate        = errors.New("partner invite create failed")
+	ErrPartnerInviteNotFound      = errors.New("partner invite not found")
+	ErrPartnerNotFound            = errors.New("partner not found")
+	ErrPartnerOwnerMissing        = errors.New("partner owner missing")
+)
+
+type PartnerRepository interface {
+	CreateInvite(invite *models.PartnerInvite) error
+	FindActiveInviteByTokenHash(tokenHash string, now time.Time) (models.PartnerInvite, error)
+	ListActiveInvitesByOwner(ownerID uint, now time.Time) ([]models.PartnerInvite, error)
+	DeleteInviteForOwner(inviteID uint, ownerID uint) (bool, error)
+	ListPartnersByOwner(ownerID uint) ([]models.User, error)
+	CreatePartnerFromInvite(partner *models.User, inviteID uint, now time.Time) error
+	DeletePartnerForOwner(partnerID uint, ownerID uint) (bool, error)
+	UpdateSharingPolicyForOwner(partnerID uint, ownerID uint, policy models.PartnerSharingPolicy) (bool, error)
+}
+
+type PartnerOwnerLookup interface {
+	FindByID(userID uint) (models.User, error)
+}
+
+type PartnerService struct {
+	partners PartnerRepository
+	users    PartnerOwnerLookup
+}
+
+func NewPartnerService(partners PartnerRepository, users PartnerOwnerLookup) *PartnerService {
+	return &PartnerService{partners: partners, users: users}
+}
+
+func HashPartnerInviteToken(rawToken string) string {
+	sum := sha256.Sum256([]byte("ovumcy.partner-invite.v1:" + strings.TrimSpace(rawToken)))
+	return hex.EncodeToString(sum[:])
+}
+
+func (service *PartnerService) CreateInvite(owner *models.User, now time.Time) (string, models.PartnerInvite, error) {
+	if !IsOwnerUser(owner) {
+		return "", models.PartnerInvite{}, ErrPartnerInviteOwnerRequired
+	}
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	rawToken, err := security.RandomString(partnerInviteTokenLength, partnerInviteTokenAlphabet)
+	if err != nil {
+		return "", models.PartnerInvite{}, fmt.Errorf("%w: %v", ErrPartnerInviteCreate, err)
+	}
+
+	invite := models.PartnerInvite{
+		OwnerID:   owner.ID,
+		TokenHash: HashPartnerInviteToken(rawToken),
+		ExpiresAt: now.Add(DefaultPartnerInviteTTL),
+		CreatedAt: now,
+	}
+	if err := service.partners.CreateInvite(&invite); err != nil {
+		return "", models.PartnerInvite{}, fmt.Errorf("%w: %v", ErrPartnerInviteCreate, err)
+	}
+	return rawToken, invite, nil
+}
+
+func (service *PartnerService) ResolveInvite(rawToken string, now time.Time) (models.PartnerInvite, error) {
+	if strings.TrimSpace(rawToken) == "" {
+		return models.PartnerInvite{}, ErrPartnerInviteInvalid
+	}
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	invite, err := service.partners.FindActiveInviteByTokenHash(HashPartnerInviteToken(rawToken), now)
+	if err != nil {
+		return models.PartnerInvite{}, ErrPartnerInviteInvalid
+	}
+	if _, err := service.users.FindByID(invite.OwnerID); err != nil {
+		return models.PartnerInvite{}, ErrPartnerInviteInvalid
+	}
+	return invite, nil
+}
+
+func (service *PartnerService) AcceptInvite(invite models.PartnerInvite, partner *models.User, now time.Time) error {
+	if partner == nil {
+		return ErrAuthUserRequired
+	}
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	ownerID := invite.OwnerID
+	partner.Role = models.RolePartner
+	partner.LinkedOwnerID = &ownerID
+	partner.OnboardingCompleted = true
+	return service.partners.CreatePartnerFromInvite(partner, invite.ID, now)
+}
+
+func (service *PartnerService) ListActiveInvites(ownerID uint, now time.Time) ([]models.PartnerInvite, error) {
+	if now.IsZero() {
+		now = time.Now()
+	}
+	return service.partners.ListActiveInvitesByOwner(ownerID, now)
+}
+
+func (service *PartnerService) ListPartners(ownerID uint) ([]models.User, error) {
+	return service.partners.ListPartnersByOwner(ownerID)
+}
+
+func (service *PartnerService) RevokeInvite(ownerID uint, inviteID uint) error {
+	deleted, err := service.partners.DeleteInviteForOwner(inviteID, ownerID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrPartnerInviteNotFound
+	}
+	return nil
+}
+
+func (service *PartnerService) RevokePartner(ownerID uint, partnerID uint) error {
+	deleted, err := service.partners.DeletePartnerForOwner(partnerID, ownerID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrPartnerNotFound
+	}
+	return nil
+}
+
+func (service *PartnerService) UpdateSharingPolicy(ownerID uint, partnerID uint, policy models.PartnerSharingPolicy, ownerSymptoms []models.SymptomType) error {
+	policy.SharedSymptomIDs = normalizeSharedSymptomIDs(policy.SharedSymptomIDs, ownerSymptoms)
+	updated, err := service.partners.UpdateSharingPolicyForOwner(partnerID, ownerID, policy)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrPartnerNotFound
+	}
+	return nil
+}
+
+func (service *PartnerService) ResolveDataOwner(viewer *models.User) (*models.User, error) {
+	if viewer == nil {
+		return nil, ErrAuthUserRequired
+	}
+	if !IsPartnerUser(viewer) || viewer.LinkedOwnerID == nil {
+		return viewer, nil
+	}
+
+	owner, err := service.users.FindByID(*viewer.LinkedOwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPartnerOwnerMissing, err)
+	}
+	return &owner, nil
+}
+
+func normalizeSharedSymptomIDs(ids []uint, ownerSymptoms []models.SymptomType) []uint {
+	owned := make(map[uint]bool, len(ownerSymptoms))
+	for _, symptom := range ownerSymptoms {
+		owned[symptom.ID] = true
+	}
+
+	normalized := make([]uint, 0, len(ids))
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if !owned[id] || seen[id] {
+			continue
+		}
+		seen[id] = true
+		normalized = append(normalized, id)
+	}
+	return normalized
+}
@@ -0,0 +1,297 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+type stubPartnerRepo struct {
+	createdInvite    models.PartnerInvite
+	activeInvite     models.PartnerInvite
+	findInviteErr    error
+	lookedUpHash     string
+	acceptedPartner  models.User
+	acceptedInviteID uint
+	acceptErr        error
+	deleteInviteOK   bool
+	deletePartnerOK  bool
+	updatePolicyOK   bool
+	updatedPolicy    models.PartnerSharingPolicy
+}
+
+func (stub *stubPartnerRepo) CreateInvite(invite *models.PartnerInvite) error {
+	invite.ID = 7
+	stub.createdInvite = *invite
+	return nil
+}
+
+func (stub *stubPartnerRepo) FindActiveInviteByTokenHash(tokenHash string, _ time.Time) (models.PartnerInvite, error) {
+	stub.lookedUpHash = tokenHash
+	if stub.findInviteErr != nil {
+		return models.PartnerInvite{}, stub.findInviteErr
+	}
+	return stub.activeInvite, nil
+}
+
+func (stub *stubPartnerRepo) ListActiveInvitesByOwner(uint, time.Time) ([]models.PartnerInvite, error) {
+	return []models.PartnerInvite{}, nil
+}
+
+func (stub *stubPartnerRepo) DeleteInviteForOwner(uint, uint) (bool, error) {
+	return stub.deleteInviteOK, nil
+}
+
+func (stub *stubPartnerRepo) ListPartnersByOwner(uint) ([]models.User, error) {
+	return []models.User{}, nil
+}
+
+func (stub *stubPartnerRepo) CreatePartnerFromInvite(partner *models.User, inviteID uint, _ time.Time) error {
+	if stub.acceptErr != nil {
+		return stub.acceptErr
+	}
+	stub.acceptedPartner = *partner
+	stub.acceptedInviteID = inviteID
+	return nil
+}
+
+func (stub *stubPartnerRepo) DeletePartnerForOwner(uint, uint) (bool, error) {
+	return stub.deletePartnerOK, nil
+}
+
+func (stub *stubPartnerRepo) UpdateSharingPolicyForOwner(_ uint, _ uint, policy models.PartnerSharingPolicy) (bool, error) {
+	stub.updatedPolicy = policy
+	return stub.updatePolicyOK, nil
+}
+
+type stubPartnerOwnerLookup struct {
+	users map[uint]models.User
+}
+
+func (stub *stubPartnerOwnerLookup) FindByID(userID uint) (models.User, error) {
+	user, ok := stub.users[userID]
+	if !ok {
+		return models.User{}, errors.New("not found")
+	}
+	return user, nil
+}
+
+func TestPartnerServiceCreateInviteStoresOnlyTokenHash(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubPartnerRepo{}
+	service := NewPartnerService(repo, &stubPartnerOwnerLookup{})
+	owner := &models.User{ID: 3, Role: models.RoleOwner}
+	now := time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	rawToken, invite, err := service.CreateInvite(owner, now)
+	if err != nil {
+		t.Fatalf("CreateInvite() unexpected error: %v", err)
+	}
+	if len(rawToken) != partnerInviteTokenLength {
+		t.Fatalf("expected token length %d, got %d", partnerInviteTokenLength, len(rawToken))
+	}
+	if repo.createdInvite.TokenHash == rawToken {
+		t.Fatal("expected raw invite token to never be persisted")
+	}
+	if repo.createdInvite.TokenHash != HashPartnerInviteToken(rawToken) {
+		t.Fatalf("expected persisted hash to match token hash")
+	}
+	if invite.OwnerID != owner.ID {
+		t.Fatalf("expected invite owner %d, got %d", owner.ID, invite.OwnerID)
+	}
+	if !invite.ExpiresAt.Equal(now.Add(DefaultPartnerInviteTTL)) {
+		t.Fatalf("expected invite expiry %s, got %s", now.Add(DefaultPartnerInviteTTL), invite.ExpiresAt)
+	}
+}
+
+func TestPartnerServiceCreateInviteRequiresOwner(t *testing.T) {
+	t.Parallel()
+
+	service := NewPartnerService(&stubPartnerRepo{}, &stubPartnerOwnerLookup{})
+	partner := &models.User{ID: 4, Role: models.RolePartner}
+
+	if _, _, err := service.CreateInvite(partner, time.Now()); !errors.Is(err, ErrPartnerInviteOwnerRequired) {
+		t.Fatalf("expected ErrPartnerInviteOwnerRequired, got %v", err)
+	}
+}
+
+func TestPartnerServiceResolveInvite(t *testing.T) {
+	t.Parallel()
+
+	owner := models.User{ID: 3, Role: models.RoleOwner}
+	tests := []struct {
+		name      string
+		token     string
+		repo      *stubPartnerRepo
+		users     map[uint]models.User
+		wantError bool
+	}{
+		{
+			name:      "blank token",
+			token:     "   ",
+			repo:      &stubPartnerRepo{},
+			wantError: true,
+		},
+		{
+			name:      "unknown token",
+			token:     "missing",
+			repo:      &stubPartnerRepo{findInviteErr: errors.New("not found")},
+			users:     map[uint]models.User{owner.ID: owner},
+			wantError: true,
+		},
+		{
+			name:      "owner no longer exists",
+			token:     "orphaned",
+			repo:      &stubPartnerRepo{activeInvite: models.PartnerInvite{ID: 1, OwnerID: 99}},
+			users:     map[uint]models.User{owner.ID: owner},
+			wantError: true,
+		},
+		{
+			name:  "active invite",
+			token: "valid-token",
+			repo:  &stubPartnerRepo{activeInvite: models.PartnerInvite{ID: 1, OwnerID: owner.ID}},
+			users: map[uint]models.User{owner.ID: owner},
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := NewPartnerService(testCase.repo, &stubPartnerOwnerLookup{users: testCase.users})
+			invite, err := service.ResolveInvite(testCase.token, time.Now())
+			if testCase.wantError {
+				if !errors.Is(err, ErrPartnerInviteInvalid) {
+					t.Fatalf("expected ErrPartnerInviteInvalid, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveInvite() unexpected error: %v", err)
+			}
+			if invite.OwnerID != owner.ID {
+				t.Fatalf("expected invite owner %d, got %d", owner.ID, invite.OwnerID)
+			}
+			if testCase.repo.lookedUpHash != HashPartnerInviteToken(testCase.token) {
+				t.Fatalf("expected lookup by token hash")
+			}
+		})
+	}
+}
+
+func TestPartnerServiceAcceptInviteLinksPartnerToOwner(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubPartnerRepo{}
+	service := NewPartnerService(repo, &stubPartnerOwnerLookup{})
+	partner := models.User{Email: "partner@example.com", Role: models.RoleOwner}
+
+	if err := service.AcceptInvite(models.PartnerInvite{ID: 11, OwnerID: 3}, &partner, time.Now()); err != nil {
+		t.Fatalf("AcceptInvite() unexpected error: %v", err)
+	}
+	if repo.acceptedInviteID != 11 {
+		t.Fatalf("expected invite 11 to be claimed, got %d", repo.acceptedInviteID)
+	}
+	if repo.acceptedPartner.Role != models.RolePartner {
+		t.Fatalf("expected partner role, got %q", repo.acceptedPartner.Role)
+	}
+	if repo.acceptedPartner.LinkedOwnerID == nil || *repo.acceptedPartner.LinkedOwnerID != 3 {
+		t.Fatalf("expected partner linked to owner 3, got %v", repo.acceptedPartner.LinkedOwnerID)
+	}
+	if !repo.acceptedPartner.OnboardingCompleted {
+		t.Fatal("expected partner onboarding to be marked completed")
+	}
+}
+
+func TestPartnerServiceRevokeReportsMissingRecords(t *testing.T) {
+	t.Parallel()
+
+	service := NewPartnerService(&stubPartnerRepo{}, &stubPartnerOwnerLookup{})
+	if err := service.RevokeInvite(1, 2); !errors.Is(err, ErrPartnerInviteNotFound) {
+		t.Fatalf("expected ErrPartnerInviteNotFound, got %v", err)
+	}
+	if err := service.RevokePartner(1, 2); !errors.Is(err, ErrPartnerNotFound) {
+		t.Fatalf("expected ErrPartnerNotFound, got %v", err)
+	}
+}
+
+func TestPartnerServiceResolveDataOwner(t *testing.T) {
+	t.Parallel()
+
+	ownerID := uint(3)
+	missingOwnerID := uint(42)
+	owner := models.User{ID: ownerID, Role: models.RoleOwner, Email: "owner@example.com"}
+	lookup := &stubPartnerOwnerLookup{users: map[uint]models.User{ownerID: owner}}
+	service := NewPartnerService(&stubPartnerRepo{}, lookup)
+
+	tests := []struct {
+		name       string
+		viewer     *models.User
+		expectedID uint
+		wantError  bool
+	}{
+		{name: "owner reads own data", viewer: &models.User{ID: ownerID, Role: models.RoleOwner}, expectedID: ownerID},
+		{name: "unlinked partner reads own data", viewer: &models.User{ID: 5, Role: models.RolePartner}, expectedID: 5},
+		{name: "linked partner reads owner data", viewer: &models.User{ID: 6, Role: models.RolePartner, LinkedOwnerID: &ownerID}, expectedID: ownerID},
+		{name: "linked owner missing", viewer: &models.User{ID: 7, Role: models.RolePartner, LinkedOwnerID: &missingOwnerID}, wantError: true},
+		{name: "nil viewer", viewer: nil, wantError: true},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			dataOwner, err := service.ResolveDataOwner(testCase.viewer)
+			if testCase.wantError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveDataOwner() unexpected error: %v", err)
+			}
+			if dataOwner.ID != testCase.expectedID {
+				t.Fatalf("expected data owner %d, got %d", testCase.expectedID, dataOwner.ID)
+			}
+		})
+	}
+}
+
+func TestPartnerServiceUpdateSharingPolicyKeepsOnlyOwnerSymptoms(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubPartnerRepo{updatePolicyOK: true}
+	service := NewPartnerService(repo, &stubPartnerOwnerLookup{})
+	ownerSymptoms := []models.SymptomType{{ID: 3}, {ID: 5}}
+
+	err := service.UpdateSharingPolicy(1, 2, models.PartnerSharingPolicy{
+		HideFlow:         true,
+		ShareNotes:       true,
+		SharedSymptomIDs: []uint{5, 9, 5, 3},
+	}, ownerSymptoms)
+	if err != nil {
+		t.Fatalf("expected update to succeed, got %v", err)
+	}
+	if !repo.updatedPolicy.HideFlow || !repo.updatedPolicy.ShareNotes {
+		t.Fatalf("expected toggles to be persisted, got %#v", repo.updatedPolicy)
+	}
+	if len(repo.updatedPolicy.SharedSymptomIDs) != 2 || repo.updatedPolicy.SharedSymptomIDs[0] != 5 || repo.updatedPolicy.SharedSymptomIDs[1] != 3 {
+		t.Fatalf("expected shared symptoms [5 3], got %#v", repo.updatedPolicy.SharedSymptomIDs)
+	}
+}
+
+func TestPartnerServiceUpdateSharingPolicyReportsMissingPartner(t *testing.T) {
+	t.Parallel()
+
+	service := NewPartnerService(&stubPartnerRepo{}, &stubPartnerOwnerLookup{})
+	err := service.UpdateSharingPolicy(1, 2, models.PartnerSharingPolicy{}, nil)
+	if !errors.Is(err, ErrPartnerNotFound) {
+		t.Fatalf("expected ErrPartnerNotFound, got %v", err)
+	}
+}
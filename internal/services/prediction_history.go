@@ -0,0 +1,168 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+const (
+	// predictionHistoryWindow is how many recent finished cycles make up the
+	// rolling accuracy score.
+	predictionHistoryWindow = 6
+	// predictionHitDays is how far off a prediction may be and still count
+	// as accurate.
+	predictionHitDays = 2
+)
+
+type PredictionSnapshotStore interface {
+	ListByUser(userID uint) ([]models.PredictionSnapshot, error)
+	CreateIfMissing(snapshot *models.PredictionSnapshot) (bool, error)
+}
+
+type PredictionHistoryService struct {
+	snapshots PredictionSnapshotStore
+	days      StatsDayReader
+}
+
+func NewPredictionHistoryService(snapshots PredictionSnapshotStore, days StatsDayReader) *PredictionHistoryService {
+	return &PredictionHistoryService{snapshots: snapshots, days: days}
+}
+
+// PredictionHistoryEntry compares one cycle's snapshot with the start of the
+// cycle that followed. ErrorDays is positive when the period came later than
+// predicted.
+type PredictionHistoryEntry struct {
+	CycleStart           time.Time  `json:"cycle_start"`
+	PredictedPeriodStart time.Time  `json:"predicted_period_start"`
+	PredictedOvulation   *time.Time `json:"predicted_ovulation,omitempty"`
+	ActualPeriodStart    *time.Time `json:"actual_period_start,omitempty"`
+	ErrorDays            int        `json:"error_days"`
+	Completed            bool       `json:"completed"`
+	Algorithm            string     `json:"algorithm"`
+}
+
+// PredictionHistory lists snapshots newest first. The score is the share of
+// the last six finished cycles predicted within two days, in percent.
+type PredictionHistory struct {
+	Entries           []PredictionHistoryEntry `json:"entries"`
+	ScoredCycles      int                      `json:"scored_cycles"`
+	MeanAbsoluteError float64                  `json:"mean_absolute_error"`
+	AccuracyScore     int                      `json:"accuracy_score"`
+}
+
+// Capture snapshots the forecast for the cycle stats currently describe, the
+// first time that cycle is seen. Only forecasts that still lie ahead are
+// kept, so backfilled history never produces hindsight predictions.
+func (service *PredictionHistoryService) Capture(user *models.User, stats CycleStats, now time.Time, location *time.Location) (bool, error) {
+	if !IsOwnerUser(user) || stats.PredictionsPaused || stats.ContraceptionActive {
+		return false, nil
+	}
+	if stats.LastPeriodStart.IsZero() || stats.NextPeriodStart.IsZero() {
+		return false, nil
+	}
+	today := DateAtLocation(now, location)
+	nextPeriodStart := DateAtLocation(stats.NextPeriodStart, location)
+	if !nextPeriodStart.After(today) {
+		return false, nil
+	}
+
+	snapshot := models.PredictionSnapshot{
+		UserID:               user.ID,
+		CycleStart:           DateAtLocation(stats.LastPeriodStart, location),
+		PredictedPeriodStart: nextPeriodStart,
+		Algorithm:            stats.PredictionAlgorithm,
+	}
+	if snapshot.Algorithm == "" {
+		snapshot.Algorithm = models.PredictionAlgorithmMedian
+	}
+	if !stats.OvulationDate.IsZero() && !stats.OvulationImpossible {
+		ovulation := DateAtLocation(stats.OvulationDate, location)
+		snapshot.PredictedOvulation = &ovulation
+	}
+	return service.snapshots.CreateIfMissing(&snapshot)
+}
+
+// BuildHistory matches the owner's snapshots with the cycle starts found in
+// all of their logs.
+func (service *PredictionHistoryService) BuildHistory(user *models.User, location *time.Location) (PredictionHistory, error) {
+	if !IsOwnerUser(user) {
+		return PredictionHistory{Entries: []PredictionHistoryEntry{}}, nil
+	}
+
+	snapshots, err := service.snapshots.ListByUser(user.ID)
+	if err != nil {
+		return PredictionHistory{}, err
+	}
+	logs, err := service.days.FetchAllLogsForUser(user.ID)
+	if err != nil {
+		return PredictionHistory{}, err
+	}
+	starts := DetectCycleStarts(MaskWithdrawalBleeding(user, logs, location))
+	return BuildPredictionHistory(snapshots, starts, location), nil
+}
+
+// BuildPredictionHistory pairs each snapshot with the first detected cycle
+// start after the cycle it was made for. Snapshots for a cycle start that is
+// no longer detected, because its period day was deleted or overridden, are
+// dropped instead of being scored against an unrelated cycle.
+func BuildPredictionHistory(snapshots []models.PredictionSnapshot, starts []time.Time, location *time.Location) PredictionHistory {
+	startByDate := make(map[string]bool, len(starts))
+	for _, start := range starts {
+		startByDate[DateAtLocation(start, location).Format("2006-01-02")] = true
+	}
+
+	entries := make([]PredictionHistoryEntry, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		cycleStart := DateAtLocation(snapshot.CycleStart, location)
+		if !startByDate[cycleStart.Format("2006-01-02")] {
+			continue
+		}
+		entry := PredictionHistoryEntry{
+			CycleStart:           cycleStart,
+			PredictedPeriodStart: DateAtLocation(snapshot.PredictedPeriodStart, location),
+			PredictedOvulation:   snapshot.PredictedOvulation,
+			Algorithm:            snapshot.Algorithm,
+		}
+		for _, start := range starts {
+			actual := DateAtLocation(start, location)
+			if actual.After(cycleStart) {
+				entry.ActualPeriodStart = &actual
+				entry.ErrorDays = calendarDaysBetween(entry.PredictedPeriodStart, actual)
+				entry.Completed = true
+				break
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	history := PredictionHistory{Entries: make([]PredictionHistoryEntry, 0, len(entries))}
+	for index := len(entries) - 1; index >= 0; index-- {
+		history.Entries = append(history.Entries, entries[index])
+	}
+
+	var totalError, hits int
+	for _, entry := range history.Entries {
+		if !entry.Completed {
+			continue
+		}
+		if history.ScoredCycles == predictionHistoryWindow {
+			break
+		}
+		absoluteError := entry.ErrorDays
+		if absoluteError < 0 {
+			absoluteError = -absoluteError
+		}
+		totalError += absoluteError
+		if absoluteError <= predictionHitDays {
+			hits++
+		}
+		history.ScoredCycles++
+	}
+	if history.ScoredCycles > 0 {
+		history.MeanAbsoluteError = math.Round(float64(totalError)/float64(history.ScoredCycles)*100) / 100
+		history.AccuracyScore = int(math.Round(float64(hits) * 100 / float64(history.ScoredCycles)))
+	}
+	return history
+}
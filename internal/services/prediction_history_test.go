@@ -0,0 +1,124 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+type stubPredictionSnapshotStore struct {
+	snapshots []models.PredictionSnapshot
+}
+
+func (stub *stubPredictionSnapshotStore) ListByUser(uint) ([]models.PredictionSnapshot, error) {
+	return stub.snapshots, nil
+}
+
+func (stub *stubPredictionSnapshotStore) CreateIfMissing(snapshot *models.PredictionSnapshot) (bool, error) {
+	for _, existing := range stub.snapshots {
+		if existing.CycleStart.Equal(snapshot.CycleStart) {
+			return false, nil
+		}
+	}
+	stub.snapshots = append(stub.snapshots, *snapshot)
+	return true, nil
+}
+
+func TestPredictionHistoryCaptureKeepsFirstFutureForecast(t *testing.T) {
+	store := &stubPredictionSnapshotStore{}
+	service := NewPredictionHistoryService(store, &stubStatsDayReader{})
+	owner := &models.User{ID: 1, Role: models.RoleOwner}
+	now := mustParseDay(t, "2026-03-03")
+	stats := CycleStats{
+		LastPeriodStart:     mustParseDay(t, "2026-03-01"),
+		NextPeriodStart:     mustParseDay(t, "2026-03-29"),
+		OvulationDate:       mustParseDay(t, "2026-03-15"),
+		PredictionAlgorithm: models.PredictionAlgorithmBayesian,
+	}
+
+	created, err := service.Capture(owner, stats, now, time.UTC)
+	if err != nil || !created {
+		t.Fatalf("expected a snapshot to be created, got %v (%v)", created, err)
+	}
+	saved := store.snapshots[0]
+	if !saved.PredictedPeriodStart.Equal(stats.NextPeriodStart) || saved.PredictedOvulation == nil || saved.Algorithm != models.PredictionAlgorithmBayesian {
+		t.Fatalf("unexpected snapshot %#v", saved)
+	}
+
+	stats.NextPeriodStart = mustParseDay(t, "2026-03-31")
+	if created, _ := service.Capture(owner, stats, now, time.UTC); created || len(store.snapshots) != 1 {
+		t.Fatalf("expected the first forecast to be kept, got %#v", store.snapshots)
+	}
+
+	for _, skipped := range []struct {
+		user  *models.User
+		stats CycleStats
+	}{
+		{user: &models.User{Role: models.RolePartner}, stats: CycleStats{LastPeriodStart: now, NextPeriodStart: now.AddDate(0, 0, 28)}},
+		{user: owner, stats: CycleStats{LastPeriodStart: now, NextPeriodStart: now.AddDate(0, 0, 28), PredictionsPaused: true}},
+		{user: owner, stats: CycleStats{LastPeriodStart: mustParseDay(t, "2025-12-01"), NextPeriodStart: mustParseDay(t, "2025-12-29")}},
+	} {
+		if created, err := service.Capture(skipped.user, skipped.stats, now, time.UTC); created || err != nil {
+			t.Fatalf("expected no snapshot for %#v, got %v (%v)", skipped.stats, created, err)
+		}
+	}
+}
+
+func TestBuildPredictionHistoryScoresFinishedCycles(t *testing.T) {
+	snapshots := []models.PredictionSnapshot{
+		{CycleStart: mustParseDay(t, "2026-01-01"), PredictedPeriodStart: mustParseDay(t, "2026-01-29")},
+		{CycleStart: mustParseDay(t, "2026-02-01"), PredictedPeriodStart: mustParseDay(t, "2026-03-01")},
+		{CycleStart: mustParseDay(t, "2026-03-05"), PredictedPeriodStart: mustParseDay(t, "2026-04-02")},
+	}
+	starts := []time.Time{
+		mustParseDay(t, "2026-01-01"),
+		mustParseDay(t, "2026-02-01"),
+		mustParseDay(t, "2026-03-05"),
+	}
+
+	history := BuildPredictionHistory(snapshots, starts, time.UTC)
+	if len(history.Entries) != 3 || !history.Entries[0].CycleStart.Equal(mustParseDay(t, "2026-03-05")) {
+		t.Fatalf("expected entries newest first, got %#v", history.Entries)
+	}
+	if history.Entries[0].Completed || history.Entries[0].ActualPeriodStart != nil {
+		t.Fatalf("expected the current cycle to be pending, got %#v", history.Entries[0])
+	}
+	if history.Entries[1].ErrorDays != 4 || history.Entries[2].ErrorDays != 3 {
+		t.Fatalf("expected late periods to give positive errors, got %#v", history.Entries)
+	}
+	if history.ScoredCycles != 2 || history.MeanAbsoluteError != 3.5 || history.AccuracyScore != 0 {
+		t.Fatalf("unexpected score %#v", history)
+	}
+
+	starts[1] = mustParseDay(t, "2026-01-30")
+	history = BuildPredictionHistory(snapshots[:1], starts[:2], time.UTC)
+	if history.Entries[0].ErrorDays != 1 || history.AccuracyScore != 100 {
+		t.Fatalf("expected a one-day miss to count as accurate, got %#v", history)
+	}
+}
+
+func TestBuildPredictionHistoryDropsSnapshotsForRemovedCycleStarts(t *testing.T) {
+	snapshots := []models.PredictionSnapshot{
+		{CycleStart: mustParseDay(t, "2026-01-01"), PredictedPeriodStart: mustParseDay(t, "2026-01-29")},
+		{CycleStart: mustParseDay(t, "2026-01-15"), PredictedPeriodStart: mustParseDay(t, "2026-02-12")},
+		{CycleStart: mustParseDay(t, "2026-02-01"), PredictedPeriodStart: mustParseDay(t, "2026-03-01")},
+	}
+	starts := []time.Time{
+		mustParseDay(t, "2026-01-01"),
+		mustParseDay(t, "2026-02-01"),
+	}
+
+	history := BuildPredictionHistory(snapshots, starts, time.UTC)
+	if len(history.Entries) != 2 {
+		t.Fatalf("expected the orphaned snapshot to be dropped, got %#v", history.Entries)
+	}
+	for _, entry := range history.Entries {
+		if entry.CycleStart.Equal(mustParseDay(t, "2026-01-15")) {
+			t.Fatalf("expected no entry for a cycle start that is no longer detected, got %#v", entry)
+		}
+	}
+	if history.ScoredCycles != 1 || history.MeanAbsoluteError != 3 {
+		t.Fatalf("expected only the real cycle to be scored, got %#v", history)
+	}
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+var ErrSettingsPredictionAlgorithmInvalid = errors.New("settings prediction algorithm invalid")
+
+const (
+	// predictorWindow is how many recent cycles the median and weighted
+	// average look at.
+	predictorWindow = 6
+	// bayesianWindow is how many recent cycles feed the Bayesian estimate.
+	bayesianWindow = 12
+	// bayesianPriorDeviation is the spread, in days, of the population prior
+	// centred on the default cycle length.
+	bayesianPriorDeviation = 4.0
+	// backtestMinHistory is how many completed cycles a predictor needs
+	// before its guesses are scored, matching the point where observed data
+	// replaces the onboarding baseline.
+	backtestMinHistory = 2
+)
+
+// Predictor estimates the next cycle length from completed cycle lengths,
+// oldest first. It reports false when history is too short to predict.
+type Predictor interface {
+	Name() string
+	PredictCycleLength(lengths []int) (int, bool)
+}
+
+// MedianPredictor uses the median of the last six cycles.
+type MedianPredictor struct{}
+
+func (MedianPredictor) Name() string {
+	return models.PredictionAlgorithmMedian
+}
+
+func (MedianPredictor) PredictCycleLength(lengths []int) (int, bool) {
+	if len(lengths) == 0 {
+		return 0, false
+	}
+	return medianInt(tailInts(lengths, predictorWindow)), true
+}
+
+// WeightedAveragePredictor averages the last six cycles, weighting the most
+// recent cycle the most so gradual shifts are followed sooner.
+type WeightedAveragePredictor struct{}
+
+func (WeightedAveragePredictor) Name() string {
+	return models.PredictionAlgorithmWeightedAverage
+}
+
+func (WeightedAveragePredictor) PredictCycleLength(lengths []int) (int, bool) {
+	recent := tailInts(lengths, predictorWindow)
+	if len(recent) == 0 {
+		return 0, false
+	}
+	var total, weights float64
+	for index, length := range recent {
+		weight := float64(index + 1)
+		total += weight * float64(length)
+		weights += weight
+	}
+	return int(total/weights + 0.5), true
+}
+
+// BayesianPredictor combines a prior centred on the default cycle length with
+// the user's recent cycles. The more the cycles vary, the less each one
+// counts, so irregular histories are pulled towards the prior while
+// consistent ones follow the user's own mean.
+type BayesianPredictor struct{}
+
+func (BayesianPredictor) Name() string {
+	return models.PredictionAlgorithmBayesian
+}
+
+func (BayesianPredictor) PredictCycleLength(lengths []int) (int, bool) {
+	recent := tailInts(lengths, bayesianWindow)
+	if len(recent) == 0 {
+		return 0, false
+	}
+
+	priorPrecision := 1 / (bayesianPriorDeviation * bayesianPriorDeviation)
+	deviation := bayesianPriorDeviation
+	if len(recent) >= 2 {
+		deviation = math.Max(standardDeviationInts(recent), 1)
+	}
+	observationPrecision := 1 / (deviation * deviation)
+
+	var total int
+	for _, length := range recent {
+		total += length
+	}
+	posterior := (priorPrecision*float64(models.DefaultCycleLength) + observationPrecision*float64(total)) /
+		(priorPrecision + observationPrecision*float64(len(recent)))
+	return int(posterior + 0.5), true
+}
+
+// Predictors lists every available predictor in display order.
+func Predictors() []Predictor {
+	return []Predictor{MedianPredictor{}, WeightedAveragePredictor{}, BayesianPredictor{}}
+}
+
+// PredictionAlgorithms lists the names of the available predictors.
+func PredictionAlgorithms() []string {
+	predictors := Predictors()
+	names := make([]string, 0, len(predictors))
+	for _, predictor := range predictors {
+		names = append(names, predictor.Name())
+	}
+	return names
+}
+
+func NormalizePredictionAlgorithm(raw string) string {
+	algorithm := strings.ToLower(strings.TrimSpace(raw))
+	if algorithm == "" {
+		return models.PredictionAlgorithmMedian
+	}
+	return algorithm
+}
+
+func IsValidPredictionAlgorithm(algorithm string) bool {
+	for _, name := range PredictionAlgorithms() {
+		if name == algorithm {
+			return true
+		}
+	}
+	return false
+}
+
+// PredictorByName returns the named predictor, falling back to the median.
+func PredictorByName(name string) Predictor {
+	algorithm := NormalizePredictionAlgorithm(name)
+	for _, predictor := range Predictors() {
+		if predictor.Name() == algorithm {
+			return predictor
+		}
+	}
+	return MedianPredictor{}
+}
+
+// PredictorForUser returns the predictor the owner picked in Settings.
+func PredictorForUser(user *models.User) Predictor {
+	if user == nil {
+		return MedianPredictor{}
+	}
+	return PredictorByName(user.PredictionAlgorithm)
+}
+
+type PredictionAccuracy struct {
+	Algorithm         string  `json:"algorithm"`
+	Samples           int     `json:"samples"`
+	MeanAbsoluteError float64 `json:"mean_absolute_error"`
+}
+
+// BacktestPredictors replays lengths in order: each predictor sees only the
+// cycles before a cycle and is scored on how far off its guess was.
+// Predictors without a scored cycle report zero samples.
+func BacktestPredictors(lengths []int, predictors []Predictor) []PredictionAccuracy {
+	results := make([]PredictionAccuracy, 0, len(predictors))
+	for _, predictor := range predictors {
+		result := PredictionAccuracy{Algorithm: predictor.Name()}
+		var totalError int
+		for index := backtestMinHistory; index < len(lengths); index++ {
+			predicted, ok := predictor.PredictCycleLength(lengths[:index])
+			if !ok {
+				continue
+			}
+			delta := predicted - lengths[index]
+			if delta < 0 {
+				delta = -delta
+			}
+			totalError += delta
+			result.Samples++
+		}
+		if result.Samples > 0 {
+			result.MeanAbsoluteError = math.Round(float64(totalError)/float64(result.Samples)*100) / 100
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// MostAccuratePredictionAlgorithm returns the algorithm with the lowest mean
+// absolute error, preferring the earlier one on ties, or "" when nothing
+// could be scored.
+func MostAccuratePredictionAlgorithm(results []PredictionAccuracy) string {
+	best := ""
+	bestError := 0.0
+	for _, result := range results {
+		if result.Samples == 0 {
+			continue
+		}
+		if best == "" || result.MeanAbsoluteError < bestError {
+			best = result.Algorithm
+			bestError = result.MeanAbsoluteError
+		}
+	}
+	return best
+}
+
+// BuildPredictionAccuracy backtests every predictor against the owner's
+// completed cycles, leaving out the same spans as the cycle statistics.
+func (service *StatsService) BuildPredictionAccuracy(user *models.User, now time.Time, location *time.Location) ([]PredictionAccuracy, error) {
+	if !IsOwnerUser(user) {
+		return []PredictionAccuracy{}, nil
+	}
+
+	logs, err := service.days.FetchAllLogsForUser(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	logs = MaskWithdrawalBleeding(user, logs, location)
+	lengths := CycleLengthsExcluding(logs, ExcludedCycleSpans(user, now, location))
+	return BacktestPredictors(lengths, Predictors()), nil
+}
+
+func (service *SettingsService) SavePredictionAlgorithm(userID uint, algorithm string) (string, error) {
+	normalized := NormalizePredictionAlgorithm(algorithm)
+	if !IsValidPredictionAlgorithm(normalized) {
+		return "", ErrSettingsPredictionAlgorithmInvalid
+	}
+	if err := service.users.UpdateByID(userID, map[string]any{"prediction_algorithm": normalized}); err != nil {
+		return "", err
+	}
+	return normalized, nil
+}
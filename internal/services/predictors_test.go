@@ -0,0 +1,124 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func TestPredictorsEstimateNextCycleLength(t *testing.T) {
+	lengths := []int{26, 27, 28, 35, 36, 37}
+
+	for _, testCase := range []struct {
+		predictor Predictor
+		expected  int
+	}{
+		{predictor: MedianPredictor{}, expected: 32},
+		{predictor: WeightedAveragePredictor{}, expected: 34},
+		{predictor: BayesianPredictor{}, expected: 31},
+	} {
+		predicted, ok := testCase.predictor.PredictCycleLength(lengths)
+		if !ok || predicted != testCase.expected {
+			t.Fatalf("%s: expected %d, got %d (%v)", testCase.predictor.Name(), testCase.expected, predicted, ok)
+		}
+		if _, ok := testCase.predictor.PredictCycleLength(nil); ok {
+			t.Fatalf("%s: expected no prediction without history", testCase.predictor.Name())
+		}
+	}
+
+	if predicted, _ := (BayesianPredictor{}).PredictCycleLength([]int{30, 30, 30, 30}); predicted != 30 {
+		t.Fatalf("expected consistent cycles to outweigh the prior, got %d", predicted)
+	}
+}
+
+func TestBacktestPredictorsScoresOnlyEarlierCycles(t *testing.T) {
+	results := BacktestPredictors([]int{28, 28, 28, 32}, Predictors())
+	if len(results) != 3 {
+		t.Fatalf("expected one result per predictor, got %#v", results)
+	}
+	for _, result := range results {
+		if result.Samples != 2 || result.MeanAbsoluteError != 2 {
+			t.Fatalf("expected 2 samples with 2 days of error, got %#v", result)
+		}
+	}
+
+	short := BacktestPredictors([]int{28, 30}, Predictors())
+	if short[0].Samples != 0 || MostAccuratePredictionAlgorithm(short) != "" {
+		t.Fatalf("expected no scored cycles for a short history, got %#v", short)
+	}
+
+	best := MostAccuratePredictionAlgorithm([]PredictionAccuracy{
+		{Algorithm: models.PredictionAlgorithmMedian, Samples: 4, MeanAbsoluteError: 2.5},
+		{Algorithm: models.PredictionAlgorithmWeightedAverage, Samples: 4, MeanAbsoluteError: 1.25},
+		{Algorithm: models.PredictionAlgorithmBayesian, Samples: 4, MeanAbsoluteError: 1.25},
+	})
+	if best != models.PredictionAlgorithmWeightedAverage {
+		t.Fatalf("expected the first lowest error to win, got %q", best)
+	}
+}
+
+func TestBuildCycleStatsWithPredictorProjectsNextPeriod(t *testing.T) {
+	logs := []models.DailyLog{}
+	start := mustParseDay(t, "2025-01-01")
+	for _, length := range []int{26, 27, 28, 35, 36, 37} {
+		logs = append(logs, models.DailyLog{Date: start, IsPeriod: true, Flow: models.FlowMedium})
+		start = start.AddDate(0, 0, length)
+	}
+	logs = append(logs, models.DailyLog{Date: start, IsPeriod: true, Flow: models.FlowMedium})
+	now := start.AddDate(0, 0, 3)
+
+	median := BuildCycleStatsExcluding(logs, now, nil)
+	if median.PredictionAlgorithm != models.PredictionAlgorithmMedian || median.PredictedCycleLength != 32 {
+		t.Fatalf("expected the median by default, got %#v", median)
+	}
+
+	weighted := BuildCycleStatsWithPredictor(logs, now, nil, WeightedAveragePredictor{}, models.DefaultLutealPhaseLength)
+	if weighted.PredictedCycleLength != 34 || !weighted.NextPeriodStart.Equal(start.AddDate(0, 0, 34)) {
+		t.Fatalf("expected the next period 34 days out, got %#v", weighted)
+	}
+	if weighted.MedianCycleLength != median.MedianCycleLength {
+		t.Fatalf("expected the median statistic to be unaffected, got %d", weighted.MedianCycleLength)
+	}
+}
+
+func TestStatsServiceBuildPredictionAccuracy(t *testing.T) {
+	logs := []models.DailyLog{}
+	start := mustParseDay(t, "2025-01-01")
+	for _, length := range []int{28, 28, 28, 32} {
+		logs = append(logs, models.DailyLog{Date: start, IsPeriod: true, Flow: models.FlowMedium})
+		start = start.AddDate(0, 0, length)
+	}
+	logs = append(logs, models.DailyLog{Date: start, IsPeriod: true, Flow: models.FlowMedium})
+
+	service := NewStatsService(&stubStatsDayReader{logsForAll: logs}, &stubStatsSymptomReader{})
+	owner := &models.User{Role: models.RoleOwner}
+	results, err := service.BuildPredictionAccuracy(owner, start.AddDate(0, 0, 3), time.UTC)
+	if err != nil {
+		t.Fatalf("BuildPredictionAccuracy() unexpected error: %v", err)
+	}
+	if len(results) != 3 || results[0].Samples != 2 {
+		t.Fatalf("expected scored results for every predictor, got %#v", results)
+	}
+
+	partnerResults, err := service.BuildPredictionAccuracy(&models.User{Role: models.RolePartner}, start, time.UTC)
+	if err != nil || len(partnerResults) != 0 {
+		t.Fatalf("expected no results for partners, got %#v (%v)", partnerResults, err)
+	}
+}
+
+func TestSavePredictionAlgorithmRejectsUnknownNames(t *testing.T) {
+	service := NewSettingsService(&stubSettingsUserRepo{})
+
+	algorithm, err := service.SavePredictionAlgorithm(1, " Bayesian ")
+	if err != nil || algorithm != models.PredictionAlgorithmBayesian {
+		t.Fatalf("expected normalized algorithm, got %q (%v)", algorithm, err)
+	}
+	if _, err := service.SavePredictionAlgorithm(1, "neural"); !errors.Is(err, ErrSettingsPredictionAlgorithmInvalid) {
+		t.Fatalf("expected ErrSettingsPredictionAlgorithmInvalid, got %v", err)
+	}
+	if PredictorByName("neural").Name() != models.PredictionAlgorithmMedian {
+		t.Fatal("expected unknown names to fall back to the median")
+	}
+}
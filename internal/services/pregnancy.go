@@ -0,0 +1,365 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+const (
+	// PregnancyDurationDays is the conventional pregnancy length counted
+	// from the first day of the last menstrual period.
+	PregnancyDurationDays = 280
+
+	// maxPregnancyDays bounds how long ago a pregnancy may have started.
+	maxPregnancyDays = 44 * 7
+	// maxPostpartumDays bounds how long ago a birth may be recorded.
+	maxPostpartumDays = 365
+	// postpartumLochiaDays is how long bleeding after birth is treated as
+	// lochia rather than a returning period.
+	postpartumLochiaDays = 42
+)
+
+var (
+	ErrSettingsCycleModeInvalid       = errors.New("settings cycle mode invalid")
+	ErrSettingsPregnancyDateRequired  = errors.New("settings pregnancy date required")
+	ErrSettingsPregnancyDateInvalid   = errors.New("settings pregnancy date invalid")
+	ErrSettingsPostpartumDateRequired = errors.New("settings postpartum date required")
+	ErrSettingsPostpartumDateInvalid  = errors.New("settings postpartum date invalid")
+)
+
+// DateSpan is an inclusive range of calendar days.
+type DateSpan struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Overlaps reports whether the span shares at least one day with from..to.
+func (span DateSpan) Overlaps(from time.Time, to time.Time) bool {
+	return from.Format("2006-01-02") <= span.End.Format("2006-01-02") &&
+		to.Format("2006-01-02") >= span.Start.Format("2006-01-02")
+}
+
+func spansOverlap(spans []DateSpan, from time.Time, to time.Time) bool {
+	for _, span := range spans {
+		if span.Overlaps(from, to) {
+			return true
+		}
+	}
+	return false
+}
+
+type PregnancyModeValidationInput struct {
+	Mode               string
+	PregnancyStartRaw  string
+	DueDateRaw         string
+	PostpartumStartRaw string
+}
+
+type PregnancyModeUpdate struct {
+	Mode            string
+	PregnancyStart  *time.Time
+	DueDate         *time.Time
+	PostpartumStart *time.Time
+}
+
+// PregnancyStatus is what the dashboard shows instead of the cycle phase
+// while the account is in pregnancy or postpartum mode.
+type PregnancyStatus struct {
+	Mode             string
+	PregnancyStart   time.Time
+	DueDate          time.Time
+	PostpartumStart  time.Time
+	GestationalWeeks int
+	GestationalDays  int
+	Trimester        int
+	DaysUntilDue     int
+	PostpartumWeek   int
+	PeriodsResumed   bool
+}
+
+func NormalizeCycleMode(raw string) string {
+	mode := strings.ToLower(strings.TrimSpace(raw))
+	if mode == "" {
+		return models.CycleModeCycle
+	}
+	return mode
+}
+
+func IsValidCycleMode(mode string) bool {
+	switch mode {
+	case models.CycleModeCycle, models.CycleModePregnancy, models.CycleModePostpartum:
+		return true
+	default:
+		return false
+	}
+}
+
+// DueDateFromPregnancyStart applies Naegele's rule: 40 weeks after the
+// first day of the last menstrual period.
+func DueDateFromPregnancyStart(start time.Time) time.Time {
+	return start.AddDate(0, 0, PregnancyDurationDays)
+}
+
+func PregnancyStartFromDueDate(dueDate time.Time) time.Time {
+	return dueDate.AddDate(0, 0, -PregnancyDurationDays)
+}
+
+// PregnancyTrimester maps completed gestational weeks to a trimester:
+// weeks 0-13, 14-27 and 28 onwards.
+func PregnancyTrimester(weeks int) int {
+	switch {
+	case weeks < 14:
+		return 1
+	case weeks < 28:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// ExcludedCycleSpans returns the days of the user's recorded pregnancy,
+// including the lochia weeks after birth. Cycles overlapping them are left
+// out of cycle-length statistics.
+func ExcludedCycleSpans(user *models.User, now time.Time, location *time.Location) []DateSpan {
+	if user == nil || user.PregnancyStart == nil {
+		return nil
+	}
+	if location == nil {
+		location = time.UTC
+	}
+
+	start := DateAtLocation(*user.PregnancyStart, location)
+	switch {
+	case user.PostpartumStart != nil:
+		birth := DateAtLocation(*user.PostpartumStart, location)
+		return []DateSpan{{Start: start, End: birth.AddDate(0, 0, postpartumLochiaDays-1)}}
+	case NormalizeCycleMode(user.CycleMode) == models.CycleModePregnancy:
+		end := DateAtLocation(now.In(location), location)
+		if end.Before(start) {
+			end = start
+		}
+		return []DateSpan{{Start: start, End: end}}
+	default:
+		return nil
+	}
+}
+
+// FirstPeriodAfterBirth returns the first cycle start logged once the
+// lochia weeks after birth are over.
+func FirstPeriodAfterBirth(logs []models.DailyLog, birth time.Time, location *time.Location) (time.Time, bool) {
+	threshold := DateAtLocation(birth, location).AddDate(0, 0, postpartumLochiaDays)
+	for _, start := range DetectCycleStarts(logs) {
+		if start.Format("2006-01-02") >= threshold.Format("2006-01-02") {
+			return DateAtLocation(start, location), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// CyclePredictionsPaused reports whether the account mode suspends period
+// and ovulation predictions: for the whole pregnancy, and after birth until
+// periods resume.
+func CyclePredictionsPaused(user *models.User, logs []models.DailyLog, location *time.Location) bool {
+	if user == nil {
+		return false
+	}
+	switch NormalizeCycleMode(user.CycleMode) {
+	case models.CycleModePregnancy:
+		return true
+	case models.CycleModePostpartum:
+		if user.PostpartumStart == nil {
+			return true
+		}
+		_, resumed := FirstPeriodAfterBirth(logs, *user.PostpartumStart, location)
+		return !resumed
+	default:
+		return false
+	}
+}
+
+// ApplyPregnancyMode clears period and ovulation predictions while they are
+// suspended. Averages are kept so predictions pick up where they left off.
+func ApplyPregnancyMode(user *models.User, stats CycleStats, logs []models.DailyLog, location *time.Location) CycleStats {
+	if !CyclePredictionsPaused(user, logs, location) {
+		return stats
+	}
+
+	stats.PredictionsPaused = true
+	stats.CurrentCycleDay = 0
+	stats.CurrentPhase = "unknown"
+	stats.LastPeriodStart = time.Time{}
+	stats.NextPeriodStart = time.Time{}
+	stats.OvulationDate = time.Time{}
+	stats.OvulationExact = false
+	stats.OvulationConfirmed = false
+	stats.OvulationImpossible = false
+	stats.FertilityWindowStart = time.Time{}
+	stats.FertilityWindowEnd = time.Time{}
+	stats.MucusPeakDate = time.Time{}
+	stats.LHSurgeDate = time.Time{}
+	return stats
+}
+
+// BuildPregnancyStatus describes the pregnancy or postpartum period for the
+// dashboard. It returns false in the regular cycle mode.
+func BuildPregnancyStatus(user *models.User, logs []models.DailyLog, now time.Time, location *time.Location) (PregnancyStatus, bool) {
+	if user == nil {
+		return PregnancyStatus{}, false
+	}
+	if location == nil {
+		location = time.UTC
+	}
+
+	mode := NormalizeCycleMode(user.CycleMode)
+	if mode != models.CycleModePregnancy && mode != models.CycleModePostpartum {
+		return PregnancyStatus{}, false
+	}
+
+	today := DateAtLocation(now.In(location), location)
+	status := PregnancyStatus{Mode: mode}
+	if user.PregnancyStart != nil {
+		status.PregnancyStart = DateAtLocation(*user.PregnancyStart, location)
+	}
+	if user.DueDate != nil {
+		status.DueDate = DateAtLocation(*user.DueDate, location)
+	}
+
+	if mode == models.CycleModePregnancy {
+		if !status.PregnancyStart.IsZero() && !today.Before(status.PregnancyStart) {
+			elapsed := int(today.Sub(status.PregnancyStart).Hours() / 24)
+			status.GestationalWeeks = elapsed / 7
+			status.GestationalDays = elapsed % 7
+			status.Trimester = PregnancyTrimester(status.GestationalWeeks)
+		}
+		if !status.DueDate.IsZero() {
+			status.DaysUntilDue = int(status.DueDate.Sub(today).Hours() / 24)
+		}
+		return status, true
+	}
+
+	if user.PostpartumStart != nil {
+		status.PostpartumStart = DateAtLocation(*user.PostpartumStart, location)
+		if !today.Before(status.PostpartumStart) {
+			status.PostpartumWeek = int(today.Sub(status.PostpartumStart).Hours()/24)/7 + 1
+		}
+		_, status.PeriodsResumed = FirstPeriodAfterBirth(logs, status.PostpartumStart, location)
+	}
+	return status, true
+}
+
+// ValidatePregnancyMode parses the mode form. Pregnancy mode needs a start
+// or due date and derives the other one; postpartum mode needs the birth
+// date. Returning to cycle mode keeps the dates only when a birth was
+// recorded, so the pregnancy stays out of cycle statistics.
+func (service *SettingsService) ValidatePregnancyMode(input PregnancyModeValidationInput, now time.Time, location *time.Location) (PregnancyModeUpdate, error) {
+	if location == nil {
+		location = time.UTC
+	}
+	today := DateAtLocation(now.In(location), location)
+
+	mode := NormalizeCycleMode(input.Mode)
+	if !IsValidCycleMode(mode) {
+		return PregnancyModeUpdate{}, ErrSettingsCycleModeInvalid
+	}
+
+	pregnancyStart, err := parsePregnancyModeDate(input.PregnancyStartRaw, location, ErrSettingsPregnancyDateInvalid)
+	if err != nil {
+		return PregnancyModeUpdate{}, err
+	}
+	dueDate, err := parsePregnancyModeDate(input.DueDateRaw, location, ErrSettingsPregnancyDateInvalid)
+	if err != nil {
+		return PregnancyModeUpdate{}, err
+	}
+	postpartumStart, err := parsePregnancyModeDate(input.PostpartumStartRaw, location, ErrSettingsPostpartumDateInvalid)
+	if err != nil {
+		return PregnancyModeUpdate{}, err
+	}
+
+	switch {
+	case pregnancyStart == nil && dueDate != nil:
+		derived := PregnancyStartFromDueDate(*dueDate)
+		pregnancyStart = &derived
+	case pregnancyStart != nil && dueDate == nil:
+		derived := DueDateFromPregnancyStart(*pregnancyStart)
+		dueDate = &derived
+	}
+	if pregnancyStart != nil {
+		if pregnancyStart.After(today) || !dueDate.After(*pregnancyStart) || dueDate.After(pregnancyStart.AddDate(0, 0, maxPregnancyDays)) {
+			return PregnancyModeUpdate{}, ErrSettingsPregnancyDateInvalid
+		}
+	}
+
+	update := PregnancyModeUpdate{Mode: mode}
+	switch mode {
+	case models.CycleModePregnancy:
+		if pregnancyStart == nil {
+			return PregnancyModeUpdate{}, ErrSettingsPregnancyDateRequired
+		}
+		if pregnancyStart.Before(today.AddDate(0, 0, -maxPregnancyDays)) {
+			return PregnancyModeUpdate{}, ErrSettingsPregnancyDateInvalid
+		}
+		update.PregnancyStart = pregnancyStart
+		update.DueDate = dueDate
+	case models.CycleModePostpartum:
+		if postpartumStart == nil {
+			return PregnancyModeUpdate{}, ErrSettingsPostpartumDateRequired
+		}
+		fallthrough
+	default:
+		if postpartumStart == nil {
+			return update, nil
+		}
+		if postpartumStart.After(today) || postpartumStart.Before(today.AddDate(0, 0, -maxPostpartumDays)) {
+			return PregnancyModeUpdate{}, ErrSettingsPostpartumDateInvalid
+		}
+		if pregnancyStart != nil && !postpartumStart.After(*pregnancyStart) {
+			return PregnancyModeUpdate{}, ErrSettingsPostpartumDateInvalid
+		}
+		update.PregnancyStart = pregnancyStart
+		update.DueDate = dueDate
+		update.PostpartumStart = postpartumStart
+	}
+	return update, nil
+}
+
+func (service *SettingsService) SavePregnancyMode(userID uint, update PregnancyModeUpdate) error {
+	return service.users.UpdateByID(userID, map[string]any{
+		"cycle_mode":       update.Mode,
+		"pregnancy_start":  optionalDateValue(update.PregnancyStart),
+		"due_date":         optionalDateValue(update.DueDate),
+		"postpartum_start": optionalDateValue(update.PostpartumStart),
+	})
+}
+
+func (service *SettingsService) ApplyPregnancyModeSettings(user *models.User, update PregnancyModeUpdate) {
+	if user == nil {
+		return
+	}
+	user.CycleMode = update.Mode
+	user.PregnancyStart = update.PregnancyStart
+	user.DueDate = update.DueDate
+	user.PostpartumStart = update.PostpartumStart
+}
+
+func parsePregnancyModeDate(raw string, location *time.Location, invalid error) (*time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	parsed, err := time.ParseInLocation("2006-01-02", raw, location)
+	if err != nil {
+		return nil, invalid
+	}
+	day := DateAtLocation(parsed, location)
+	return &day, nil
+}
+
+func optionalDateValue(value *time.Time) any {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
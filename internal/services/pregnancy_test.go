@@ -0,0 +1,262 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func pregnancyTestUser(t *testing.T, mode string, pregnancyStart string, postpartumStart string) *models.User {
+	t.Helper()
+
+	user := &models.User{Role: models.RoleOwner, CycleMode: mode, CycleLength: 28, PeriodLength: 5}
+	if pregnancyStart != "" {
+		day := mustParseDay(t, pregnancyStart)
+		user.PregnancyStart = &day
+	}
+	if postpartumStart != "" {
+		day := mustParseDay(t, postpartumStart)
+		user.PostpartumStart = &day
+	}
+	return user
+}
+
+func TestCycleLengthsExcludingPregnancySpan(t *testing.T) {
+	t.Parallel()
+
+	logs := []models.DailyLog{
+		makeLog(t, "2025-01-01", true),
+		makeLog(t, "2025-01-29", true),
+		makeLog(t, "2025-02-26", true),
+		makeLog(t, "2025-12-20", true),
+		makeLog(t, "2026-01-20", true),
+		makeLog(t, "2026-02-18", true),
+	}
+	user := pregnancyTestUser(t, models.CycleModeCycle, "2025-02-26", "2025-10-20")
+	spans := ExcludedCycleSpans(user, mustParseDay(t, "2026-03-01"), time.UTC)
+
+	got := CycleLengthsExcluding(logs, spans)
+	want := []int{28, 28, 31, 29}
+	if len(got) != len(want) {
+		t.Fatalf("expected lengths %v, got %v", want, got)
+	}
+	for index := range want {
+		if got[index] != want[index] {
+			t.Fatalf("expected lengths %v, got %v", want, got)
+		}
+	}
+
+	stats := BuildCycleStatsExcluding(logs, mustParseDay(t, "2026-03-01"), spans)
+	if stats.AverageCycleLength != 29 {
+		t.Fatalf("expected average 29 without the pregnancy, got %v", stats.AverageCycleLength)
+	}
+	if trend := CompletedCycleTrendLengthsExcluding(logs, mustParseDay(t, "2026-03-01"), time.UTC, spans); len(trend) != 4 {
+		t.Fatalf("expected 4 trend points without the pregnancy, got %v", trend)
+	}
+}
+
+func TestApplyPregnancyMode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		user       *models.User
+		periodDays []string
+		wantPaused bool
+	}{
+		{
+			name:       "regular cycles keep predictions",
+			user:       pregnancyTestUser(t, models.CycleModeCycle, "", ""),
+			periodDays: []string{"2026-02-01"},
+		},
+		{
+			name:       "pregnancy pauses predictions",
+			user:       pregnancyTestUser(t, models.CycleModePregnancy, "2026-02-01", ""),
+			periodDays: []string{"2026-02-01"},
+			wantPaused: true,
+		},
+		{
+			name:       "postpartum bleeding within six weeks keeps them paused",
+			user:       pregnancyTestUser(t, models.CycleModePostpartum, "2025-05-01", "2026-01-20"),
+			periodDays: []string{"2026-01-21"},
+			wantPaused: true,
+		},
+		{
+			name:       "first period after birth resumes predictions",
+			user:       pregnancyTestUser(t, models.CycleModePostpartum, "2025-05-01", "2026-01-01"),
+			periodDays: []string{"2026-01-02", "2026-02-20"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			logs := make([]models.DailyLog, 0, len(testCase.periodDays))
+			for _, day := range testCase.periodDays {
+				logs = append(logs, makeLog(t, day, true))
+			}
+			now := mustParseDay(t, "2026-03-01")
+			stats := BuildCycleStats(logs, now)
+			stats = ApplyUserCycleBaseline(testCase.user, logs, stats, now, time.UTC)
+			stats = ApplyPregnancyMode(testCase.user, stats, logs, time.UTC)
+
+			if stats.PredictionsPaused != testCase.wantPaused {
+				t.Fatalf("expected paused=%t, got %#v", testCase.wantPaused, stats)
+			}
+			if testCase.wantPaused && (!stats.NextPeriodStart.IsZero() || !stats.OvulationDate.IsZero() || !stats.LastPeriodStart.IsZero() || stats.CurrentCycleDay != 0) {
+				t.Fatalf("expected predictions cleared, got %#v", stats)
+			}
+			if !testCase.wantPaused && stats.NextPeriodStart.IsZero() {
+				t.Fatalf("expected a next period prediction, got %#v", stats)
+			}
+			context := BuildDashboardCycleContext(testCase.user, stats, now, time.UTC)
+			if testCase.wantPaused && (context.CycleDataStale || !context.DisplayNextPeriodStart.IsZero()) {
+				t.Fatalf("expected no stale flag or display prediction while paused, got %#v", context)
+			}
+		})
+	}
+}
+
+func TestBuildPregnancyStatus(t *testing.T) {
+	t.Parallel()
+
+	user := pregnancyTestUser(t, models.CycleModePregnancy, "2026-01-01", "")
+	dueDate := DueDateFromPregnancyStart(*user.PregnancyStart)
+	user.DueDate = &dueDate
+
+	status, ok := BuildPregnancyStatus(user, nil, mustParseDay(t, "2026-04-12"), time.UTC)
+	if !ok {
+		t.Fatal("expected pregnancy status in pregnancy mode")
+	}
+	if status.GestationalWeeks != 14 || status.GestationalDays != 3 || status.Trimester != 2 {
+		t.Fatalf("expected 14 weeks 3 days in the second trimester, got %#v", status)
+	}
+	if status.DueDate.Format("2006-01-02") != "2026-10-08" || status.DaysUntilDue != 179 {
+		t.Fatalf("expected due date 2026-10-08 in 179 days, got %#v", status)
+	}
+
+	user = pregnancyTestUser(t, models.CycleModePostpartum, "2025-04-01", "2026-01-05")
+	status, ok = BuildPregnancyStatus(user, nil, mustParseDay(t, "2026-01-20"), time.UTC)
+	if !ok || status.PostpartumWeek != 3 || status.PeriodsResumed {
+		t.Fatalf("expected third postpartum week without periods, got %#v (ok=%t)", status, ok)
+	}
+
+	if _, ok := BuildPregnancyStatus(pregnancyTestUser(t, models.CycleModeCycle, "", ""), nil, mustParseDay(t, "2026-01-20"), time.UTC); ok {
+		t.Fatal("expected no pregnancy status in cycle mode")
+	}
+}
+
+func TestValidatePregnancyMode(t *testing.T) {
+	t.Parallel()
+
+	service := NewSettingsService(nil)
+	now := mustParseDay(t, "2026-03-01")
+
+	testCases := []struct {
+		name          string
+		input         PregnancyModeValidationInput
+		wantErr       error
+		wantStart     string
+		wantDue       string
+		wantBirthDate string
+	}{
+		{
+			name:      "start date derives the due date",
+			input:     PregnancyModeValidationInput{Mode: "pregnancy", PregnancyStartRaw: "2026-01-10"},
+			wantStart: "2026-01-10",
+			wantDue:   "2026-10-17",
+		},
+		{
+			name:      "due date derives the start date",
+			input:     PregnancyModeValidationInput{Mode: "pregnancy", DueDateRaw: "2026-10-17"},
+			wantStart: "2026-01-10",
+			wantDue:   "2026-10-17",
+		},
+		{
+			name:    "pregnancy needs a date",
+			input:   PregnancyModeValidationInput{Mode: "pregnancy"},
+			wantErr: ErrSettingsPregnancyDateRequired,
+		},
+		{
+			name:    "future start is rejected",
+			input:   PregnancyModeValidationInput{Mode: "pregnancy", PregnancyStartRaw: "2026-03-05"},
+			wantErr: ErrSettingsPregnancyDateInvalid,
+		},
+		{
+			name:    "start older than 44 weeks is rejected",
+			input:   PregnancyModeValidationInput{Mode: "pregnancy", PregnancyStartRaw: "2025-04-01"},
+			wantErr: ErrSettingsPregnancyDateInvalid,
+		},
+		{
+			name:    "postpartum needs a birth date",
+			input:   PregnancyModeValidationInput{Mode: "postpartum", PregnancyStartRaw: "2025-05-01"},
+			wantErr: ErrSettingsPostpartumDateRequired,
+		},
+		{
+			name:    "birth before the pregnancy start is rejected",
+			input:   PregnancyModeValidationInput{Mode: "postpartum", PregnancyStartRaw: "2025-05-01", PostpartumStartRaw: "2025-04-01"},
+			wantErr: ErrSettingsPostpartumDateInvalid,
+		},
+		{
+			name:          "postpartum keeps the pregnancy dates",
+			input:         PregnancyModeValidationInput{Mode: "postpartum", PregnancyStartRaw: "2025-05-01", PostpartumStartRaw: "2026-02-01"},
+			wantStart:     "2025-05-01",
+			wantDue:       "2026-02-05",
+			wantBirthDate: "2026-02-01",
+		},
+		{
+			name:          "cycle mode keeps a recorded birth",
+			input:         PregnancyModeValidationInput{Mode: "cycle", PregnancyStartRaw: "2025-05-01", PostpartumStartRaw: "2026-02-01"},
+			wantStart:     "2025-05-01",
+			wantDue:       "2026-02-05",
+			wantBirthDate: "2026-02-01",
+		},
+		{
+			name:  "cycle mode without a birth clears the pregnancy",
+			input: PregnancyModeValidationInput{Mode: "cycle", PregnancyStartRaw: "2026-01-10"},
+		},
+		{
+			name:    "unknown mode is rejected",
+			input:   PregnancyModeValidationInput{Mode: "trying"},
+			wantErr: ErrSettingsCycleModeInvalid,
+		},
+	}
+
+	formatOptional := func(value *time.Time) string {
+		if value == nil {
+			return ""
+		}
+		return value.Format("2006-01-02")
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			update, err := service.ValidatePregnancyMode(testCase.input, now, time.UTC)
+			if testCase.wantErr != nil {
+				if !errors.Is(err, testCase.wantErr) {
+					t.Fatalf("expected %v, got %v", testCase.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := formatOptional(update.PregnancyStart); got != testCase.wantStart {
+				t.Fatalf("expected start %q, got %q", testCase.wantStart, got)
+			}
+			if got := formatOptional(update.DueDate); got != testCase.wantDue {
+				t.Fatalf("expected due date %q, got %q", testCase.wantDue, got)
+			}
+			if got := formatOptional(update.PostpartumStart); got != testCase.wantBirthDate {
+				t.Fatalf("expected birth date %q, got %q", testCase.wantBirthDate, got)
+			}
+		})
+	}
+}
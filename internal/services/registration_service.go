@@ -0,0 +1,151 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/security"
+)
+
+type RegistrationMode string
+
+const (
+	RegistrationModeOpen          RegistrationMode = "open"
+	RegistrationModeFirstUserOnly RegistrationMode = "first-user-only"
+	RegistrationModeInvite        RegistrationMode = "invite"
+
+	registrationInviteTokenLength = 32
+	DefaultRegistrationInviteTTL  = 7 * 24 * time.Hour
+)
+
+var (
+	ErrRegistrationModeInvalid    = errors.New("invalid registration mode")
+	ErrRegistrationDisabled       = errors.New("registration disabled")
+	ErrRegistrationInviteRequired = errors.New("registration invite required")
+	ErrRegistrationInviteInvalid  = errors.New("registration invite invalid")
+	ErrRegistrationInviteCreate   = errors.New("registration invite create failed")
+)
+
+type RegistrationUserRepository interface {
+	CountUsers() (int64, error)
+	Create(user *models.User) error
+}
+
+type RegistrationInviteRepository interface {
+	CreateInvite(invite *models.RegistrationInvite) error
+	FindActiveInviteByTokenHash(tokenHash string, now time.Time) (models.RegistrationInvite, error)
+	CreateUserFromInvite(user *models.User, inviteID uint, now time.Time) error
+}
+
+// RegistrationGrant is returned by AuthorizeSignUp and records which invite,
+// if any, has to be consumed when the account is created.
+type RegistrationGrant struct {
+	InviteID uint
+}
+
+type RegistrationService struct {
+	mode    RegistrationMode
+	users   RegistrationUserRepository
+	invites RegistrationInviteRepository
+}
+
+func NewRegistrationService(mode RegistrationMode, users RegistrationUserRepository, invites RegistrationInviteRepository) *RegistrationService {
+	if mode == "" {
+		mode = RegistrationModeOpen
+	}
+	return &RegistrationService{mode: mode, users: users, invites: invites}
+}
+
+func ParseRegistrationMode(raw string) (RegistrationMode, error) {
+	switch RegistrationMode(strings.ToLower(strings.TrimSpace(raw))) {
+	case "", RegistrationModeOpen:
+		return RegistrationModeOpen, nil
+	case RegistrationModeFirstUserOnly:
+		return RegistrationModeFirstUserOnly, nil
+	case RegistrationModeInvite:
+		return RegistrationModeInvite, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrRegistrationModeInvalid, raw)
+	}
+}
+
+func HashRegistrationInviteToken(rawToken string) string {
+	sum := sha256.Sum256([]byte("ovumcy.registration-invite.v1:" + strings.TrimSpace(rawToken)))
+	return hex.EncodeToString(sum[:])
+}
+
+func (service *RegistrationService) Mode() RegistrationMode {
+	return service.mode
+}
+
+func (service *RegistrationService) AuthorizeSignUp(rawToken string, now time.Time) (RegistrationGrant, error) {
+	if service.mode == RegistrationModeOpen {
+		return RegistrationGrant{}, nil
+	}
+
+	usersCount, err := service.users.CountUsers()
+	if err != nil {
+		return RegistrationGrant{}, err
+	}
+	if usersCount == 0 {
+		return RegistrationGrant{}, nil
+	}
+
+	if service.mode == RegistrationModeFirstUserOnly {
+		return RegistrationGrant{}, ErrRegistrationDisabled
+	}
+
+	if strings.TrimSpace(rawToken) == "" {
+		return RegistrationGrant{}, ErrRegistrationInviteRequired
+	}
+	if now.IsZero() {
+		now = time.Now()
+	}
+	invite, err := service.invites.FindActiveInviteByTokenHash(HashRegistrationInviteToken(rawToken), now)
+	if err != nil {
+		return RegistrationGrant{}, ErrRegistrationInviteInvalid
+	}
+	return RegistrationGrant{InviteID: invite.ID}, nil
+}
+
+func (service *RegistrationService) CreateUser(user *models.User, grant RegistrationGrant, now time.Time) error {
+	if user == nil {
+		return ErrAuthUserRequired
+	}
+	if grant.InviteID == 0 {
+		return service.users.Create(user)
+	}
+	if now.IsZero() {
+		now = time.Now()
+	}
+	return service.invites.CreateUserFromInvite(user, grant.InviteID, now)
+}
+
+func (service *RegistrationService) CreateInvite(now time.Time, ttl time.Duration) (string, models.RegistrationInvite, error) {
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if ttl <= 0 {
+		ttl = DefaultRegistrationInviteTTL
+	}
+
+	rawToken, err := security.RandomString(registrationInviteTokenLength, partnerInviteTokenAlphabet)
+	if err != nil {
+		return "", models.RegistrationInvite{}, fmt.Errorf("%w: %v", ErrRegistrationInviteCreate, err)
+	}
+
+	invite := models.RegistrationInvite{
+		TokenHash: HashRegistrationInviteToken(rawToken),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+	if err := service.invites.CreateInvite(&invite); err != nil {
+		return "", models.RegistrationInvite{}, fmt.Errorf("%w: %v", ErrRegistrationInviteCreate, err)
+	}
+	return rawToken, invite, nil
+}
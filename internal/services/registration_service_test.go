@@ -0,0 +1,178 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+type stubRegistrationUserRepo struct {
+	count   int64
+	created []models.User
+}
+
+func (stub *stubRegistrationUserRepo) CountUsers() (int64, error) {
+	return stub.count, nil
+}
+
+func (stub *stubRegistrationUserRepo) Create(user *models.User) error {
+	stub.created = append(stub.created, *user)
+	return nil
+}
+
+type stubRegistrationInviteRepo struct {
+	invites        map[string]models.RegistrationInvite
+	createdInvite  models.RegistrationInvite
+	claimedInvites []uint
+}
+
+func (stub *stubRegistrationInviteRepo) CreateInvite(invite *models.RegistrationInvite) error {
+	invite.ID = 11
+	stub.createdInvite = *invite
+	return nil
+}
+
+func (stub *stubRegistrationInviteRepo) FindActiveInviteByTokenHash(tokenHash string, _ time.Time) (models.RegistrationInvite, error) {
+	invite, ok := stub.invites[tokenHash]
+	if !ok {
+		return models.RegistrationInvite{}, errors.New("not found")
+	}
+	return invite, nil
+}
+
+func (stub *stubRegistrationInviteRepo) CreateUserFromInvite(_ *models.User, inviteID uint, _ time.Time) error {
+	stub.claimedInvites = append(stub.claimedInvites, inviteID)
+	return nil
+}
+
+func TestParseRegistrationMode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		raw     string
+		want    RegistrationMode
+		wantErr bool
+	}{
+		{raw: "", want: RegistrationModeOpen},
+		{raw: "open", want: RegistrationModeOpen},
+		{raw: " First-User-Only ", want: RegistrationModeFirstUserOnly},
+		{raw: "invite", want: RegistrationModeInvite},
+		{raw: "closed", wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.raw, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseRegistrationMode(testCase.raw)
+			if testCase.wantErr {
+				if !errors.Is(err, ErrRegistrationModeInvalid) {
+					t.Fatalf("expected ErrRegistrationModeInvalid, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if got != testCase.want {
+				t.Fatalf("expected mode %q, got %q", testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestRegistrationServiceAuthorizeSignUp(t *testing.T) {
+	t.Parallel()
+
+	validToken := "valid-registration-token"
+	testCases := []struct {
+		name         string
+		mode         RegistrationMode
+		usersCount   int64
+		token        string
+		wantErr      error
+		wantInviteID uint
+	}{
+		{name: "open allows anyone", mode: RegistrationModeOpen, usersCount: 3},
+		{name: "first user only allows bootstrap", mode: RegistrationModeFirstUserOnly, usersCount: 0},
+		{name: "first user only closes after owner", mode: RegistrationModeFirstUserOnly, usersCount: 1, wantErr: ErrRegistrationDisabled},
+		{name: "first user only ignores tokens", mode: RegistrationModeFirstUserOnly, usersCount: 1, token: validToken, wantErr: ErrRegistrationDisabled},
+		{name: "invite allows bootstrap", mode: RegistrationModeInvite, usersCount: 0},
+		{name: "invite requires token", mode: RegistrationModeInvite, usersCount: 1, wantErr: ErrRegistrationInviteRequired},
+		{name: "invite rejects unknown token", mode: RegistrationModeInvite, usersCount: 1, token: "unknown", wantErr: ErrRegistrationInviteInvalid},
+		{name: "invite accepts issued token", mode: RegistrationModeInvite, usersCount: 1, token: validToken, wantInviteID: 4},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			invites := &stubRegistrationInviteRepo{invites: map[string]models.RegistrationInvite{
+				HashRegistrationInviteToken(validToken): {ID: 4},
+			}}
+			service := NewRegistrationService(testCase.mode, &stubRegistrationUserRepo{count: testCase.usersCount}, invites)
+
+			grant, err := service.AuthorizeSignUp(testCase.token, time.Now())
+			if testCase.wantErr != nil {
+				if !errors.Is(err, testCase.wantErr) {
+					t.Fatalf("expected %v, got %v", testCase.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if grant.InviteID != testCase.wantInviteID {
+				t.Fatalf("expected invite id %d, got %d", testCase.wantInviteID, grant.InviteID)
+			}
+		})
+	}
+}
+
+func TestRegistrationServiceCreateUserConsumesInvite(t *testing.T) {
+	t.Parallel()
+
+	users := &stubRegistrationUserRepo{}
+	invites := &stubRegistrationInviteRepo{}
+	service := NewRegistrationService(RegistrationModeInvite, users, invites)
+
+	if err := service.CreateUser(&models.User{Email: "plain@example.com"}, RegistrationGrant{}, time.Now()); err != nil {
+		t.Fatalf("expected plain create to succeed, got %v", err)
+	}
+	if err := service.CreateUser(&models.User{Email: "invited@example.com"}, RegistrationGrant{InviteID: 9}, time.Now()); err != nil {
+		t.Fatalf("expected invited create to succeed, got %v", err)
+	}
+
+	if len(users.created) != 1 || users.created[0].Email != "plain@example.com" {
+		t.Fatalf("expected only plain user to be created directly, got %#v", users.created)
+	}
+	if len(invites.claimedInvites) != 1 || invites.claimedInvites[0] != 9 {
+		t.Fatalf("expected invite 9 to be claimed, got %#v", invites.claimedInvites)
+	}
+}
+
+func TestRegistrationServiceCreateInviteStoresOnlyTokenHash(t *testing.T) {
+	t.Parallel()
+
+	invites := &stubRegistrationInviteRepo{}
+	service := NewRegistrationService(RegistrationModeInvite, &stubRegistrationUserRepo{}, invites)
+	now := time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	rawToken, invite, err := service.CreateInvite(now, 0)
+	if err != nil {
+		t.Fatalf("expected invite creation to succeed, got %v", err)
+	}
+	if len(rawToken) != registrationInviteTokenLength {
+		t.Fatalf("expected token length %d, got %d", registrationInviteTokenLength, len(rawToken))
+	}
+	if invites.createdInvite.TokenHash != HashRegistrationInviteToken(rawToken) || invites.createdInvite.TokenHash == rawToken {
+		t.Fatal("expected only the token hash to be stored")
+	}
+	if !invite.ExpiresAt.Equal(now.Add(DefaultRegistrationInviteTTL)) {
+		t.Fatalf("expected default ttl expiry, got %s", invite.ExpiresAt)
+	}
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"github.com/terraincognita07/ovumcy/internal/security"
+)
+
+const (
+	sessionTokenLength      = 40
+	sessionUserAgentMaxLen  = 255
+	sessionIPAddressMaxLen  = 64
+	SessionLastSeenInterval = time.Minute
+)
+
+var (
+	ErrSessionUserRequired = errors.New("session user required")
+	ErrSessionCreate       = errors.New("session create failed")
+	ErrSessionInvalid      = errors.New("session invalid")
+	ErrSessionNotFound     = errors.New("session not found")
+)
+
+type SessionRepository interface {
+	Create(session *models.AuthSession) error
+	FindActiveByTokenHash(tokenHash string, now time.Time) (models.AuthSession, error)
+	ListActiveByUser(userID uint, now time.Time) ([]models.AuthSession, error)
+	TouchLastSeen(sessionID uint, seenAt time.Time) error
+	DeleteForUser(sessionID uint, userID uint) (bool, error)
+	DeleteAllForUserExcept(userID uint, keepSessionID uint) (int64, error)
+	DeleteExpired(now time.Time) error
+}
+
+// SessionClient describes the device a session was opened from.
+type SessionClient struct {
+	UserAgent string
+	IPAddress string
+}
+
+type SessionService struct {
+	sessions SessionRepository
+}
+
+func NewSessionService(sessions SessionRepository) *SessionService {
+	return &SessionService{sessions: sessions}
+}
+
+func HashSessionToken(rawToken string) string {
+	sum := sha256.Sum256([]byte("ovumcy.auth-session.v1:" + strings.TrimSpace(rawToken)))
+	return hex.EncodeToString(sum[:])
+}
+
+func (service *SessionService) Start(userID uint, client SessionClient, ttl time.Duration, now time.Time) (string, models.AuthSession, error) {
+	if userID == 0 {
+		return "", models.AuthSession{}, ErrSessionUserRequired
+	}
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	rawToken, err := security.RandomString(sessionTokenLength, partnerInviteTokenAlphabet)
+	if err != nil {
+		return "", models.AuthSession{}, fmt.Errorf("%w: %v", ErrSessionCreate, err)
+	}
+
+	session := models.AuthSession{
+		UserID:     userID,
+		TokenHash:  HashSessionToken(rawToken),
+		UserAgent:  truncateSessionField(client.UserAgent, sessionUserAgentMaxLen),
+		IPAddress:  truncateSessionField(client.IPAddress, sessionIPAddressMaxLen),
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(ttl),
+	}
+	if err := service.sessions.Create(&session); err != nil {
+		return "", models.AuthSession{}, fmt.Errorf("%w: %v", ErrSessionCreate, err)
+	}
+	// Expired rows are never read again, so sweeping them on sign-in keeps
+	// the table small without a background job.
+	_ = service.sessions.DeleteExpired(now)
+	return rawToken, session, nil
+}
+
+// Resolve returns the active session for a token presented by userID and
+// refreshes its last-seen time at most once per SessionLastSeenInterval.
+func (service *SessionService) Resolve(rawToken string, userID uint, now time.Time) (models.AuthSession, error) {
+	if strings.TrimSpace(rawToken) == "" || userID == 0 {
+		return models.AuthSession{}, ErrSessionInvalid
+	}
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	session, err := service.sessions.FindActiveByTokenHash(HashSessionToken(rawToken), now)
+	if err != nil || session.UserID != userID {
+		return models.AuthSession{}, ErrSessionInvalid
+	}
+
+	if now.Sub(session.LastSeenAt) >= SessionLastSeenInterval {
+		if err := service.sessions.TouchLastSeen(session.ID, now); err == nil {
+			session.LastSeenAt = now
+		}
+	}
+	return session, nil
+}
+
+func (service *SessionService) List(userID uint, now time.Time) ([]models.AuthSession, error) {
+	if now.IsZero() {
+		now = time.Now()
+	}
+	return service.sessions.ListActiveByUser(userID, now)
+}
+
+func (service *SessionService) Revoke(userID uint, sessionID uint) error {
+	deleted, err := service.sessions.DeleteForUser(sessionID, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// RevokeOthers signs out every session of userID except keepSessionID. A
+// zero keepSessionID revokes all of them.
+func (service *SessionService) RevokeOthers(userID uint, keepSessionID uint) error {
+	_, err := service.sessions.DeleteAllForUserExcept(userID, keepSessionID)
+	return err
+}
+
+func truncateSessionField(value string, maxLen int) string {
+	value = strings.TrimSpace(value)
+	if len(value) <= maxLen {
+		return value
+	}
+	value = value[:maxLen]
+	for !utf8.ValidString(value) {
+		value = value[:len(value)-1]
+	}
+	return value
+}
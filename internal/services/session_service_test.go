@@ -0,0 +1,203 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+type stubSessionRepo struct {
+	created       models.AuthSession
+	active        models.AuthSession
+	findErr       error
+	touchedID     uint
+	touchedAt     time.Time
+	deleteOK      bool
+	keptSessionID uint
+	revokedUserID uint
+}
+
+func (stub *stubSessionRepo) Create(session *models.AuthSession) error {
+	session.ID = 11
+	stub.created = *session
+	return nil
+}
+
+func (stub *stubSessionRepo) FindActiveByTokenHash(string, time.Time) (models.AuthSession, error) {
+	if stub.findErr != nil {
+		return models.AuthSession{}, stub.findErr
+	}
+	return stub.active, nil
+}
+
+func (stub *stubSessionRepo) ListActiveByUser(uint, time.Time) ([]models.AuthSession, error) {
+	return []models.AuthSession{stub.active}, nil
+}
+
+func (stub *stubSessionRepo) TouchLastSeen(sessionID uint, seenAt time.Time) error {
+	stub.touchedID = sessionID
+	stub.touchedAt = seenAt
+	return nil
+}
+
+func (stub *stubSessionRepo) DeleteForUser(uint, uint) (bool, error) {
+	return stub.deleteOK, nil
+}
+
+func (stub *stubSessionRepo) DeleteAllForUserExcept(userID uint, keepSessionID uint) (int64, error) {
+	stub.revokedUserID = userID
+	stub.keptSessionID = keepSessionID
+	return 1, nil
+}
+
+func (stub *stubSessionRepo) DeleteExpired(time.Time) error {
+	return nil
+}
+
+func TestSessionServiceStartStoresHashedTokenAndClient(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubSessionRepo{}
+	service := NewSessionService(repo)
+	now := time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	rawToken, session, err := service.Start(5, SessionClient{
+		UserAgent: "  " + strings.Repeat("a", 300) + "  ",
+		IPAddress: "203.0.113.7",
+	}, time.Hour, now)
+	if err != nil {
+		t.Fatalf("Start() unexpected error: %v", err)
+	}
+	if rawToken == "" || session.ID != 11 {
+		t.Fatalf("expected raw token and persisted session, got %q / %#v", rawToken, session)
+	}
+	if repo.created.TokenHash != HashSessionToken(rawToken) || repo.created.TokenHash == rawToken {
+		t.Fatalf("expected stored token hash, got %q", repo.created.TokenHash)
+	}
+	if len(repo.created.UserAgent) != sessionUserAgentMaxLen {
+		t.Fatalf("expected user agent truncated to %d, got %d", sessionUserAgentMaxLen, len(repo.created.UserAgent))
+	}
+	if repo.created.IPAddress != "203.0.113.7" {
+		t.Fatalf("expected ip address to be stored, got %q", repo.created.IPAddress)
+	}
+	if !repo.created.ExpiresAt.Equal(now.Add(time.Hour)) || !repo.created.LastSeenAt.Equal(now) {
+		t.Fatalf("unexpected session timestamps: %#v", repo.created)
+	}
+}
+
+func TestSessionServiceStartRequiresUser(t *testing.T) {
+	t.Parallel()
+
+	service := NewSessionService(&stubSessionRepo{})
+	if _, _, err := service.Start(0, SessionClient{}, time.Hour, time.Now()); !errors.Is(err, ErrSessionUserRequired) {
+		t.Fatalf("expected ErrSessionUserRequired, got %v", err)
+	}
+}
+
+func TestSessionServiceResolve(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name        string
+		token       string
+		userID      uint
+		active      models.AuthSession
+		findErr     error
+		wantErr     bool
+		wantTouched bool
+	}{
+		{
+			name:        "fresh session is not touched",
+			token:       "token",
+			userID:      5,
+			active:      models.AuthSession{ID: 3, UserID: 5, LastSeenAt: now.Add(-10 * time.Second)},
+			wantTouched: false,
+		},
+		{
+			name:        "stale last seen is refreshed",
+			token:       "token",
+			userID:      5,
+			active:      models.AuthSession{ID: 3, UserID: 5, LastSeenAt: now.Add(-2 * time.Minute)},
+			wantTouched: true,
+		},
+		{
+			name:    "session of another user is rejected",
+			token:   "token",
+			userID:  6,
+			active:  models.AuthSession{ID: 3, UserID: 5, LastSeenAt: now},
+			wantErr: true,
+		},
+		{
+			name:    "unknown session is rejected",
+			token:   "token",
+			userID:  5,
+			findErr: errors.New("not found"),
+			wantErr: true,
+		},
+		{
+			name:    "missing token is rejected",
+			token:   " ",
+			userID:  5,
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &stubSessionRepo{active: testCase.active, findErr: testCase.findErr}
+			service := NewSessionService(repo)
+
+			session, err := service.Resolve(testCase.token, testCase.userID, now)
+			if testCase.wantErr {
+				if !errors.Is(err, ErrSessionInvalid) {
+					t.Fatalf("expected ErrSessionInvalid, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve() unexpected error: %v", err)
+			}
+			if session.ID != testCase.active.ID {
+				t.Fatalf("expected session %d, got %d", testCase.active.ID, session.ID)
+			}
+			touched := repo.touchedID == testCase.active.ID && repo.touchedAt.Equal(now)
+			if touched != testCase.wantTouched {
+				t.Fatalf("expected touched=%v, got %v", testCase.wantTouched, touched)
+			}
+		})
+	}
+}
+
+func TestSessionServiceRevoke(t *testing.T) {
+	t.Parallel()
+
+	service := NewSessionService(&stubSessionRepo{deleteOK: false})
+	if err := service.Revoke(5, 3); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound, got %v", err)
+	}
+
+	service = NewSessionService(&stubSessionRepo{deleteOK: true})
+	if err := service.Revoke(5, 3); err != nil {
+		t.Fatalf("Revoke() unexpected error: %v", err)
+	}
+}
+
+func TestSessionServiceRevokeOthersKeepsCurrentSession(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubSessionRepo{}
+	service := NewSessionService(repo)
+	if err := service.RevokeOthers(5, 3); err != nil {
+		t.Fatalf("RevokeOthers() unexpected error: %v", err)
+	}
+	if repo.revokedUserID != 5 || repo.keptSessionID != 3 {
+		t.Fatalf("expected user 5 sessions revoked except 3, got user=%d keep=%d", repo.revokedUserID, repo.keptSessionID)
+	}
+}
@@ -13,6 +13,7 @@ var (
 	ErrSettingsPeriodLengthOutOfRange   = errors.New("settings period length out of range")
 	ErrSettingsPeriodLengthIncompatible = errors.New("settings period length incompatible with cycle length")
 	ErrSettingsCycleStartDateInvalid    = errors.New("settings cycle start date invalid")
+	ErrSettingsTemperatureUnitInvalid   = errors.New("settings temperature unit invalid")
 )
 
 type CycleSettingsValidationInput struct {
@@ -21,6 +22,7 @@ type CycleSettingsValidationInput struct {
 	AutoPeriodFill     bool
 	LastPeriodStartRaw string
 	LastPeriodStartSet bool
+	TemperatureUnit    string
 }
 
 func (service *SettingsService) ValidateCycleSettings(input CycleSettingsValidationInput, now time.Time, location *time.Location) (CycleSettingsUpdate, error) {
@@ -31,16 +33,22 @@ func (service *SettingsService) ValidateCycleSettings(input CycleSettingsValidat
 		return CycleSettingsUpdate{}, ErrSettingsPeriodLengthOutOfRange
 	}
 
-	ovulationDay, _ := CalcOvulationDay(input.CycleLength, input.PeriodLength)
+	ovulationDay, _ := CalcOvulationDay(input.CycleLength, input.PeriodLength, models.DefaultLutealPhaseLength)
 	if ovulationDay <= 0 {
 		return CycleSettingsUpdate{}, ErrSettingsPeriodLengthIncompatible
 	}
 
+	temperatureUnit := strings.ToLower(strings.TrimSpace(input.TemperatureUnit))
+	if temperatureUnit != "" && !IsValidTemperatureUnit(temperatureUnit) {
+		return CycleSettingsUpdate{}, ErrSettingsTemperatureUnitInvalid
+	}
+
 	update := CycleSettingsUpdate{
 		CycleLength:        input.CycleLength,
 		PeriodLength:       input.PeriodLength,
 		AutoPeriodFill:     input.AutoPeriodFill,
 		LastPeriodStartSet: input.LastPeriodStartSet,
+		TemperatureUnit:    temperatureUnit,
 	}
 
 	if !input.LastPeriodStartSet {
@@ -79,6 +87,9 @@ func (service *SettingsService) ApplyCycleSettings(user *models.User, update Cyc
 	user.CycleLength = update.CycleLength
 	user.PeriodLength = update.PeriodLength
 	user.AutoPeriodFill = update.AutoPeriodFill
+	if update.TemperatureUnit != "" {
+		user.TemperatureUnit = update.TemperatureUnit
+	}
 
 	if !update.LastPeriodStartSet {
 		return
@@ -30,6 +30,8 @@ type CycleSettingsUpdate struct {
 	AutoPeriodFill     bool
 	LastPeriodStartSet bool
 	LastPeriodStart    *time.Time
+	// TemperatureUnit is left unchanged when empty.
+	TemperatureUnit string
 }
 
 type SettingsService struct {
@@ -69,6 +71,9 @@ func (service *SettingsService) SaveCycleSettings(userID uint, settings CycleSet
 		"period_length":    settings.PeriodLength,
 		"auto_period_fill": settings.AutoPeriodFill,
 	}
+	if settings.TemperatureUnit != "" {
+		updates["temperature_unit"] = settings.TemperatureUnit
+	}
 	if settings.LastPeriodStartSet {
 		if settings.LastPeriodStart == nil {
 			updates["last_period_start"] = nil
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"strings"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+// SpottingSymptomID returns the ID of the built-in Spotting symptom.
+func SpottingSymptomID(symptoms []models.SymptomType) (uint, bool) {
+	for _, symptom := range symptoms {
+		if symptom.IsBuiltin && strings.EqualFold(strings.TrimSpace(symptom.Name), "Spotting") {
+			return symptom.ID, true
+		}
+	}
+	return 0, false
+}
+
+// IsSpottingSymptomDay reports whether entry was logged with the Spotting
+// symptom and at most a light flow, the way spotting was recorded before it
+// became a flow level.
+func IsSpottingSymptomDay(entry models.DailyLog, spottingSymptomID uint) bool {
+	if entry.Flow != models.FlowNone && entry.Flow != models.FlowLight {
+		return false
+	}
+	for _, id := range entry.SymptomIDs {
+		if id == spottingSymptomID {
+			return true
+		}
+	}
+	return false
+}
+
+// ConvertSpottingSymptomDays moves days logged with the Spotting symptom to
+// the spotting flow and drops the symptom, so they stop starting cycles. It
+// returns the converted days, oldest first; with dryRun nothing is saved.
+func (service *DayService) ConvertSpottingSymptomDays(userID uint, spottingSymptomID uint, dryRun bool, location *time.Location) ([]time.Time, error) {
+	logs, err := service.logs.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	converted := make([]time.Time, 0)
+	for _, entry := range logs {
+		if !IsSpottingSymptomDay(entry, spottingSymptomID) {
+			continue
+		}
+		converted = append(converted, DateAtLocation(entry.Date, location))
+		if dryRun {
+			continue
+		}
+
+		entry.IsPeriod = true
+		entry.Flow = models.FlowSpotting
+		entry.SymptomIDs = RemoveUint(entry.SymptomIDs, spottingSymptomID)
+		delete(entry.SymptomSeverities, spottingSymptomID)
+		if err := service.logs.Save(&entry); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(converted) > 0 && !dryRun {
+		if err := service.RefreshUserLastPeriodStart(userID, location); err != nil {
+			return nil, err
+		}
+	}
+	return converted, nil
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func TestSpottingDoesNotStartCyclesOrCountAsPeriod(t *testing.T) {
+	period := func(day string, flow string) models.DailyLog {
+		return models.DailyLog{Date: mustParseDay(t, day), IsPeriod: true, Flow: flow}
+	}
+	logs := []models.DailyLog{
+		period("2026-01-01", models.FlowMedium),
+		period("2026-01-02", models.FlowMedium),
+		period("2026-01-03", models.FlowSpotting),
+		period("2026-01-15", models.FlowSpotting),
+		period("2026-01-28", models.FlowSpotting),
+		period("2026-01-29", models.FlowHeavy),
+		period("2026-01-30", models.FlowMedium),
+	}
+
+	starts := DetectCycleStarts(logs)
+	if len(starts) != 2 || starts[1].Format("2006-01-02") != "2026-01-29" {
+		t.Fatalf("expected spotting to be ignored by cycle detection, got %v", starts)
+	}
+	stats := BuildCycleStats(logs, mustParseDay(t, "2026-01-30"))
+	if stats.AveragePeriodLength != 2 {
+		t.Fatalf("expected spotting to be left out of period length, got %v", stats.AveragePeriodLength)
+	}
+	if lengths := CycleLengths(logs); len(lengths) != 1 || lengths[0] != 28 {
+		t.Fatalf("expected one 28-day cycle, got %#v", lengths)
+	}
+}
+
+func TestNormalizeDayEntryInputAcceptsSpottingFlow(t *testing.T) {
+	input, err := NormalizeDayEntryInput(DayEntryInput{IsPeriod: true, Flow: models.FlowSpotting})
+	if err != nil || input.Flow != models.FlowSpotting {
+		t.Fatalf("expected spotting to be a valid flow, got %#v (%v)", input, err)
+	}
+}
+
+func TestBuildCalendarDayStatesMarksSpottingApartFromPeriod(t *testing.T) {
+	monthStart := mustParseDay(t, "2026-02-01")
+	logs := []models.DailyLog{
+		{Date: mustParseDay(t, "2026-02-10"), IsPeriod: true, Flow: models.FlowSpotting},
+		{Date: mustParseDay(t, "2026-02-20"), IsPeriod: true, Flow: models.FlowLight},
+	}
+
+	days := BuildCalendarDayStates(nil, monthStart, logs, CycleStats{}, mustParseDay(t, "2026-02-25"), time.UTC)
+	for _, day := range days {
+		switch day.DateString {
+		case "2026-02-10":
+			if !day.IsSpotting || day.IsPeriod {
+				t.Fatalf("expected a spotting day, got %#v", day)
+			}
+		case "2026-02-20":
+			if day.IsSpotting || !day.IsPeriod {
+				t.Fatalf("expected a period day, got %#v", day)
+			}
+		}
+	}
+}
+
+func TestIsSpottingSymptomDay(t *testing.T) {
+	symptoms := []models.SymptomType{
+		{ID: 3, Name: "Spotting", IsBuiltin: false},
+		{ID: 7, Name: "Spotting", IsBuiltin: true},
+	}
+	id, ok := SpottingSymptomID(symptoms)
+	if !ok || id != 7 {
+		t.Fatalf("expected the built-in spotting symptom, got %d", id)
+	}
+
+	if !IsSpottingSymptomDay(models.DailyLog{IsPeriod: true, Flow: models.FlowLight, SymptomIDs: []uint{7}}, id) {
+		t.Fatal("expected a light day with spotting to be converted")
+	}
+	if IsSpottingSymptomDay(models.DailyLog{IsPeriod: true, Flow: models.FlowMedium, SymptomIDs: []uint{7}}, id) {
+		t.Fatal("expected medium flow days to stay period days")
+	}
+}
@@ -40,8 +40,16 @@ func (service *StatsService) BuildCycleStatsForRange(user *models.User, from tim
 		return CycleStats{}, nil, err
 	}
 
-	stats := BuildCycleStats(logs, now)
-	stats = ApplyUserCycleBaseline(user, logs, stats, now, location)
+	cycleLogs := MaskWithdrawalBleeding(user, logs, location)
+	luteal := ResolveLutealPhase(user, cycleLogs, location)
+	stats := BuildCycleStatsWithPredictor(cycleLogs, now, ExcludedCycleSpans(user, now, location), PredictorForUser(user), luteal.Length)
+	stats.LutealPhasePersonalised = luteal.Personalised()
+	stats = ApplyUserCycleBaseline(user, cycleLogs, stats, now, location)
+	stats = ApplyLHTest(stats, cycleLogs, now, location)
+	stats = ApplyTemperatureShift(stats, cycleLogs, now, location)
+	stats = ApplyMucusPeak(stats, cycleLogs, now, location)
+	stats = ApplyContraception(user, stats, logs, now, location)
+	stats = ApplyPregnancyMode(user, stats, cycleLogs, location)
 	return stats, logs, nil
 }
 
@@ -60,13 +68,15 @@ func OwnerBaselineCycleLength(user *models.User) int {
 }
 
 func (service *StatsService) BuildTrend(user *models.User, logs []models.DailyLog, now time.Time, location *time.Location, maxTrendPoints int) ([]int, int) {
-	lengths := CompletedCycleTrendLengths(logs, now, location)
+	logs = MaskWithdrawalBleeding(user, logs, location)
+	lengths := CompletedCycleTrendLengthsExcluding(logs, now, location, ExcludedCycleSpans(user, now, location))
 	lengths = TrimTrailingCycleTrendLengths(lengths, maxTrendPoints)
 	return lengths, OwnerBaselineCycleLength(user)
 }
 
 func (service *StatsService) BuildFlags(user *models.User, logs []models.DailyLog, stats CycleStats, now time.Time, location *time.Location, trendPointCount int) StatsFlags {
-	observedCycleCount := len(CycleLengths(logs))
+	logs = MaskWithdrawalBleeding(user, logs, location)
+	observedCycleCount := len(CycleLengthsExcluding(logs, ExcludedCycleSpans(user, now, location)))
 	today := DateAtLocation(now, location)
 	cycleDayReference := DashboardCycleReferenceLength(user, stats)
 	cycleStaleAnchor := DashboardCycleStaleAnchor(user, stats, location)
@@ -75,7 +85,7 @@ func (service *StatsService) BuildFlags(user *models.User, logs []models.DailyLo
 		HasObservedCycleData: observedCycleCount > 0,
 		HasTrendData:         trendPointCount > 0,
 		HasReliableTrend:     trendPointCount >= 3,
-		CycleDataStale:       DashboardCycleDataLooksStale(cycleStaleAnchor, today, cycleDayReference),
+		CycleDataStale:       !stats.PredictionsPaused && !stats.ContraceptionActive && DashboardCycleDataLooksStale(cycleStaleAnchor, today, cycleDayReference),
 	}
 }
 
@@ -50,10 +50,11 @@ type SymptomService struct {
 }
 
 type SymptomFrequency struct {
-	Name      string
-	Icon      string
-	Count     int
-	TotalDays int
+	Name            string
+	Icon            string
+	Count           int
+	TotalDays       int
+	AverageSeverity float64
 }
 
 func NewSymptomService(symptoms SymptomRepository, logs SymptomLogRepository) *SymptomService {
@@ -129,9 +130,11 @@ func (service *SymptomService) CalculateFrequencies(userID uint, logs []models.D
 	totalDays := len(logs)
 
 	counts := make(map[uint]int)
+	severityTotals := make(map[uint]int)
 	for _, logEntry := range logs {
 		for _, id := range logEntry.SymptomIDs {
 			counts[id]++
+			severityTotals[id] += SymptomSeverity(logEntry, id)
 		}
 	}
 	if len(counts) == 0 {
@@ -152,10 +155,11 @@ func (service *SymptomService) CalculateFrequencies(userID uint, logs []models.D
 	for id, count := range counts {
 		if symptom, ok := symptomByID[id]; ok {
 			result = append(result, SymptomFrequency{
-				Name:      symptom.Name,
-				Icon:      symptom.Icon,
-				Count:     count,
-				TotalDays: totalDays,
+				Name:            symptom.Name,
+				Icon:            symptom.Icon,
+				Count:           count,
+				TotalDays:       totalDays,
+				AverageSeverity: averageSymptomSeverity(severityTotals[id], count),
 			})
 		}
 	}
@@ -250,6 +254,7 @@ func (service *SymptomService) RemoveSymptomFromLogs(userID uint, symptomID uint
 			continue
 		}
 		logs[index].SymptomIDs = updated
+		delete(logs[index].SymptomSeverities, symptomID)
 		if err := service.logs.UpdateSymptomIDs(&logs[index]); err != nil {
 			return err
 		}
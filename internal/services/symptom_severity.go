@@ -0,0 +1,175 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+var ErrInvalidDaySymptomSeverity = errors.New("invalid symptom severity")
+
+func IsValidSymptomSeverity(severity int) bool {
+	return severity >= models.SymptomSeverityMild && severity <= models.SymptomSeveritySevere
+}
+
+// SymptomSeverity returns the severity logged for symptomID, falling back to
+// the default for entries saved before severities existed.
+func SymptomSeverity(entry models.DailyLog, symptomID uint) int {
+	if severity, ok := entry.SymptomSeverities[symptomID]; ok && IsValidSymptomSeverity(severity) {
+		return severity
+	}
+	return models.DefaultSymptomSeverity
+}
+
+// NormalizeSymptomSeverities returns a severity for every selected symptom.
+// Missing severities default to moderate and severities for symptoms that
+// are not selected are dropped.
+func NormalizeSymptomSeverities(symptomIDs []uint, severities map[uint]int) (map[uint]int, error) {
+	normalized := make(map[uint]int, len(symptomIDs))
+	for _, id := range symptomIDs {
+		severity, ok := severities[id]
+		if !ok || severity == 0 {
+			severity = models.DefaultSymptomSeverity
+		}
+		if !IsValidSymptomSeverity(severity) {
+			return nil, ErrInvalidDaySymptomSeverity
+		}
+		normalized[id] = severity
+	}
+	return normalized, nil
+}
+
+// symptomSeveritiesFor keeps the stored severities of the given symptoms,
+// filling in the default where none was logged.
+func symptomSeveritiesFor(entry models.DailyLog, symptomIDs []uint) map[uint]int {
+	severities := make(map[uint]int, len(symptomIDs))
+	for _, id := range symptomIDs {
+		severities[id] = SymptomSeverity(entry, id)
+	}
+	return severities
+}
+
+// symptomSeverityPhases lists the phases reported on the stats page in cycle
+// order. The fertile window includes the ovulation day.
+var symptomSeverityPhases = []string{"menstrual", "follicular", "fertile", "luteal"}
+
+type SymptomPhaseSeverity struct {
+	Phase           string
+	Days            int
+	AverageSeverity float64
+}
+
+// SymptomSeverityByPhase holds one entry per phase in symptomSeverityPhases;
+// phases without a logged day have zero Days.
+type SymptomSeverityByPhase struct {
+	Name   string
+	Icon   string
+	Phases []SymptomPhaseSeverity
+}
+
+// BuildSymptomSeverityByPhase averages each symptom's severity per cycle
+// phase. Completed cycles use their own length; the current cycle falls back
+// to cycleLength. Days before the first known cycle start are skipped.
+func BuildSymptomSeverityByPhase(logs []models.DailyLog, symptoms []models.SymptomType, cycleStarts []time.Time, cycleLength int, periodLength int, lutealLength int, location *time.Location) []SymptomSeverityByPhase {
+	starts := make([]time.Time, 0, len(cycleStarts))
+	for _, start := range cycleStarts {
+		starts = append(starts, DateAtLocation(start, location))
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+
+	type phaseTotals struct {
+		days     int
+		severity int
+	}
+	totals := make(map[uint]map[string]phaseTotals)
+	for _, logEntry := range logs {
+		if len(logEntry.SymptomIDs) == 0 {
+			continue
+		}
+		phase := historicalCyclePhase(starts, DateAtLocation(logEntry.Date, location), IsMenstrualDay(logEntry), cycleLength, periodLength, lutealLength)
+		if phase == "" {
+			continue
+		}
+		for _, id := range uniqueSymptomIDs(logEntry.SymptomIDs) {
+			byPhase, ok := totals[id]
+			if !ok {
+				byPhase = make(map[string]phaseTotals, len(symptomSeverityPhases))
+				totals[id] = byPhase
+			}
+			current := byPhase[phase]
+			current.days++
+			current.severity += SymptomSeverity(logEntry, id)
+			byPhase[phase] = current
+		}
+	}
+
+	result := make([]SymptomSeverityByPhase, 0, len(totals))
+	for _, symptom := range symptoms {
+		byPhase, ok := totals[symptom.ID]
+		if !ok {
+			continue
+		}
+		item := SymptomSeverityByPhase{Name: symptom.Name, Icon: symptom.Icon}
+		for _, phase := range symptomSeverityPhases {
+			current := byPhase[phase]
+			item.Phases = append(item.Phases, SymptomPhaseSeverity{
+				Phase:           phase,
+				Days:            current.days,
+				AverageSeverity: averageSymptomSeverity(current.severity, current.days),
+			})
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// historicalCyclePhase places a past day in its cycle using the same
+// ovulation estimate as the predictions. Logged period days are always
+// menstrual.
+func historicalCyclePhase(starts []time.Time, day time.Time, isPeriod bool, cycleLength int, periodLength int, lutealLength int) string {
+	index := -1
+	for candidate, start := range starts {
+		if start.After(day) {
+			break
+		}
+		index = candidate
+	}
+	if index < 0 {
+		return ""
+	}
+	if isPeriod {
+		return "menstrual"
+	}
+
+	start := starts[index]
+	if index+1 < len(starts) {
+		cycleLength = calendarDaysBetween(start, starts[index+1])
+	}
+	ovulation, fertileStart, fertileEnd, _, ok := PredictCycleWindow(start, cycleLength, periodLength, lutealLength)
+	if !ok {
+		return ""
+	}
+	if fertileStart.IsZero() {
+		fertileStart, fertileEnd = ovulation, ovulation
+	}
+
+	offset := calendarDaysBetween(start, day)
+	switch {
+	case offset < calendarDaysBetween(start, fertileStart):
+		return "follicular"
+	case offset <= calendarDaysBetween(start, fertileEnd):
+		return "fertile"
+	default:
+		return "luteal"
+	}
+}
+
+func averageSymptomSeverity(total int, days int) float64 {
+	if days <= 0 {
+		return 0
+	}
+	return math.Round(float64(total)/float64(days)*10) / 10
+}
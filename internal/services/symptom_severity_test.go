@@ -0,0 +1,122 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func TestNormalizeSymptomSeverities(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		symptomIDs []uint
+		severities map[uint]int
+		want       map[uint]int
+		wantErr    error
+	}{
+		{name: "missing severity defaults to moderate", symptomIDs: []uint{1, 2}, severities: map[uint]int{1: 3}, want: map[uint]int{1: 3, 2: 2}},
+		{name: "unselected symptoms are dropped", symptomIDs: []uint{1}, severities: map[uint]int{1: 1, 5: 3}, want: map[uint]int{1: 1}},
+		{name: "no symptoms", symptomIDs: []uint{}, severities: map[uint]int{4: 2}, want: map[uint]int{}},
+		{name: "above the scale", symptomIDs: []uint{1}, severities: map[uint]int{1: 4}, wantErr: ErrInvalidDaySymptomSeverity},
+		{name: "negative", symptomIDs: []uint{1}, severities: map[uint]int{1: -1}, wantErr: ErrInvalidDaySymptomSeverity},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NormalizeSymptomSeverities(testCase.symptomIDs, testCase.severities)
+			if testCase.wantErr != nil {
+				if !errors.Is(err, testCase.wantErr) {
+					t.Fatalf("expected %v, got %v", testCase.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(testCase.want) {
+				t.Fatalf("expected %v, got %v", testCase.want, got)
+			}
+			for id, severity := range testCase.want {
+				if got[id] != severity {
+					t.Fatalf("expected %v, got %v", testCase.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCalculateFrequenciesAveragesSeverity(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubSymptomRepo{
+		builtinCnt: 1,
+		listed:     []models.SymptomType{{ID: 1, Name: "Cramps"}, {ID: 2, Name: "Headache"}},
+	}
+	service := NewSymptomService(repo, &stubSymptomLogRepo{})
+
+	logs := []models.DailyLog{
+		{SymptomIDs: []uint{1, 2}, SymptomSeverities: map[uint]int{1: 3, 2: 1}},
+		{SymptomIDs: []uint{1}, SymptomSeverities: map[uint]int{1: 2}},
+		// Logged before severities existed.
+		{SymptomIDs: []uint{1}},
+	}
+
+	result, err := service.CalculateFrequencies(10, logs)
+	if err != nil {
+		t.Fatalf("CalculateFrequencies() unexpected error: %v", err)
+	}
+	if len(result) != 2 || result[0].Name != "Cramps" || result[0].AverageSeverity != 2.3 {
+		t.Fatalf("expected cramps averaging 2.3, got %#v", result)
+	}
+	if result[1].AverageSeverity != 1 {
+		t.Fatalf("expected headache averaging 1, got %#v", result[1])
+	}
+}
+
+func TestBuildSymptomSeverityByPhase(t *testing.T) {
+	t.Parallel()
+
+	symptoms := []models.SymptomType{{ID: 1, Name: "Cramps", Icon: "C"}, {ID: 2, Name: "Acne", Icon: "A"}}
+	withSymptom := func(date string, isPeriod bool, severity int) models.DailyLog {
+		entry := makeLog(t, date, isPeriod)
+		entry.SymptomIDs = []uint{1}
+		entry.SymptomSeverities = map[uint]int{1: severity}
+		return entry
+	}
+	logs := []models.DailyLog{
+		// Before the first known start, so it has no phase.
+		withSymptom("2026-01-20", false, 3),
+		// A 28-day cycle: ovulation on day 14, fertile window on days 9-15.
+		withSymptom("2026-02-01", true, 3),
+		withSymptom("2026-02-02", true, 2),
+		withSymptom("2026-02-06", false, 1),
+		withSymptom("2026-02-12", false, 2),
+		withSymptom("2026-02-20", false, 1),
+		withSymptom("2026-03-01", true, 1),
+	}
+	starts := []time.Time{mustParseDay(t, "2026-02-01"), mustParseDay(t, "2026-03-01")}
+
+	result := BuildSymptomSeverityByPhase(logs, symptoms, starts, 28, 5, models.DefaultLutealPhaseLength, time.UTC)
+	if len(result) != 1 || result[0].Name != "Cramps" || len(result[0].Phases) != 4 {
+		t.Fatalf("expected one symptom with four phases, got %#v", result)
+	}
+
+	want := map[string]SymptomPhaseSeverity{
+		"menstrual":  {Phase: "menstrual", Days: 3, AverageSeverity: 2},
+		"follicular": {Phase: "follicular", Days: 1, AverageSeverity: 1},
+		"fertile":    {Phase: "fertile", Days: 1, AverageSeverity: 2},
+		"luteal":     {Phase: "luteal", Days: 1, AverageSeverity: 1},
+	}
+	for _, phase := range result[0].Phases {
+		if phase != want[phase.Phase] {
+			t.Fatalf("expected %#v, got %#v", want[phase.Phase], phase)
+		}
+	}
+}
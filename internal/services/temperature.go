@@ -0,0 +1,232 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+const (
+	MinBBTCelsius = 34.0
+	MaxBBTCelsius = 42.0
+
+	// The 3-over-6 rule: three readings above the highest of the six
+	// readings before them, the last at least 0.2 °C above that cover line.
+	temperatureShiftLowReadings  = 6
+	temperatureShiftHighReadings = 3
+	temperatureShiftMinRise      = 0.2
+)
+
+var (
+	ErrInvalidDayTemperature     = errors.New("invalid day temperature")
+	ErrInvalidDayTemperatureTime = errors.New("invalid day temperature time")
+)
+
+// TemperatureReading is one undisturbed BBT value in Celsius.
+type TemperatureReading struct {
+	Date    time.Time
+	Celsius float64
+}
+
+// TemperatureShift is a confirmed post-ovulatory rise. OvulationDate is the
+// last low reading before the rise and ConfirmedDate the reading that
+// completed the rule.
+type TemperatureShift struct {
+	OvulationDate time.Time
+	ShiftDate     time.Time
+	ConfirmedDate time.Time
+	CoverLine     float64
+}
+
+func NormalizeTemperatureUnit(raw string) string {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case models.TemperatureUnitFahrenheit, "fahrenheit", "°f":
+		return models.TemperatureUnitFahrenheit
+	default:
+		return models.TemperatureUnitCelsius
+	}
+}
+
+func IsValidTemperatureUnit(raw string) bool {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case models.TemperatureUnitCelsius, models.TemperatureUnitFahrenheit:
+		return true
+	default:
+		return false
+	}
+}
+
+// TemperatureToCelsius converts a reading entered in unit to Celsius,
+// rounded to hundredths.
+func TemperatureToCelsius(value float64, unit string) float64 {
+	if NormalizeTemperatureUnit(unit) == models.TemperatureUnitFahrenheit {
+		value = (value - 32) * 5 / 9
+	}
+	return roundTemperature(value)
+}
+
+// TemperatureInUnit converts a stored Celsius reading for display in unit.
+func TemperatureInUnit(celsius float64, unit string) float64 {
+	if NormalizeTemperatureUnit(unit) == models.TemperatureUnitFahrenheit {
+		celsius = celsius*9/5 + 32
+	}
+	return roundTemperature(celsius)
+}
+
+func IsValidBBTCelsius(value float64) bool {
+	return value >= MinBBTCelsius && value <= MaxBBTCelsius
+}
+
+func IsValidBBTTime(raw string) bool {
+	if raw == "" {
+		return true
+	}
+	_, err := time.Parse("15:04", raw)
+	return err == nil
+}
+
+func roundTemperature(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// TemperatureReadings collects the undisturbed readings from logs between
+// from and to inclusive, in date order.
+func TemperatureReadings(logs []models.DailyLog, from time.Time, to time.Time, location *time.Location) []TemperatureReading {
+	readings := make([]TemperatureReading, 0)
+	for _, logEntry := range logs {
+		if logEntry.BBT <= 0 || logEntry.BBTDisturbed {
+			continue
+		}
+		day := DateAtLocation(logEntry.Date, location)
+		if (!from.IsZero() && day.Before(from)) || (!to.IsZero() && day.After(to)) {
+			continue
+		}
+		readings = append(readings, TemperatureReading{Date: day, Celsius: logEntry.BBT})
+	}
+	sort.Slice(readings, func(i, j int) bool {
+		return readings[i].Date.Before(readings[j].Date)
+	})
+	return readings
+}
+
+// DetectTemperatureShift applies the 3-over-6 rule to the readings of one
+// cycle and returns the first shift it finds.
+func DetectTemperatureShift(readings []TemperatureReading) (TemperatureShift, bool) {
+	for index := temperatureShiftLowReadings; index+temperatureShiftHighReadings <= len(readings); index++ {
+		coverLine := 0.0
+		for _, low := range readings[index-temperatureShiftLowReadings : index] {
+			coverLine = math.Max(coverLine, low.Celsius)
+		}
+
+		highs := readings[index : index+temperatureShiftHighReadings]
+		confirmed := true
+		for _, high := range highs {
+			if high.Celsius <= coverLine {
+				confirmed = false
+				break
+			}
+		}
+		last := highs[len(highs)-1]
+		if !confirmed || roundTemperature(last.Celsius-coverLine) < temperatureShiftMinRise {
+			continue
+		}
+
+		return TemperatureShift{
+			OvulationDate: readings[index-1].Date,
+			ShiftDate:     highs[0].Date,
+			ConfirmedDate: last.Date,
+			CoverLine:     coverLine,
+		}, true
+	}
+	return TemperatureShift{}, false
+}
+
+// ConfirmedOvulationDates runs DetectTemperatureShift on every cycle found
+// in logs and returns the confirmed ovulation days.
+func ConfirmedOvulationDates(logs []models.DailyLog, location *time.Location) []time.Time {
+	sorted := make([]models.DailyLog, 0, len(logs))
+	sorted = append(sorted, logs...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	starts := DetectCycleStarts(sorted)
+	dates := make([]time.Time, 0, len(starts))
+	for index, start := range starts {
+		cycleStart := DateAtLocation(start, location)
+		cycleEnd := time.Time{}
+		if index+1 < len(starts) {
+			cycleEnd = DateAtLocation(starts[index+1], location).AddDate(0, 0, -1)
+		}
+		if shift, ok := DetectTemperatureShift(TemperatureReadings(sorted, cycleStart, cycleEnd, location)); ok {
+			dates = append(dates, shift.OvulationDate)
+		}
+	}
+	return dates
+}
+
+// TemperatureChart is the current cycle's temperature curve as shown on
+// the stats page.
+type TemperatureChart struct {
+	CycleStart time.Time
+	Readings   []TemperatureReading
+	Shift      TemperatureShift
+	ShiftFound bool
+}
+
+// CycleDay returns the 1-based cycle day of reading.
+func (chart TemperatureChart) CycleDay(reading TemperatureReading) int {
+	return int(reading.Date.Sub(chart.CycleStart).Hours()/24) + 1
+}
+
+// BuildTemperatureChart collects the readings of the cycle that started on
+// stats.LastPeriodStart and the shift detected in them.
+func BuildTemperatureChart(stats CycleStats, logs []models.DailyLog, now time.Time, location *time.Location) TemperatureChart {
+	if stats.LastPeriodStart.IsZero() {
+		return TemperatureChart{Readings: []TemperatureReading{}}
+	}
+	if location == nil {
+		location = time.UTC
+	}
+
+	chart := TemperatureChart{CycleStart: DateAtLocation(stats.LastPeriodStart, location)}
+	today := DateAtLocation(now.In(location), location)
+	chart.Readings = TemperatureReadings(logs, chart.CycleStart, today, location)
+	chart.Shift, chart.ShiftFound = DetectTemperatureShift(chart.Readings)
+	return chart
+}
+
+// ApplyTemperatureShift replaces the estimated ovulation of the current
+// cycle with the one confirmed by a temperature shift, if any.
+func ApplyTemperatureShift(stats CycleStats, logs []models.DailyLog, now time.Time, location *time.Location) CycleStats {
+	if stats.LastPeriodStart.IsZero() {
+		return stats
+	}
+	if location == nil {
+		location = time.UTC
+	}
+
+	chart := BuildTemperatureChart(stats, logs, now, location)
+	if !chart.ShiftFound {
+		return stats
+	}
+	shift := chart.Shift
+	cycleStart := chart.CycleStart
+	today := DateAtLocation(now.In(location), location)
+
+	stats.OvulationDate = shift.OvulationDate
+	stats.OvulationExact = true
+	stats.OvulationConfirmed = true
+	stats.OvulationImpossible = false
+	stats.FertilityWindowStart = shift.OvulationDate.AddDate(0, 0, -5)
+	if stats.FertilityWindowStart.Before(cycleStart) {
+		stats.FertilityWindowStart = cycleStart
+	}
+	stats.FertilityWindowEnd = shift.OvulationDate.AddDate(0, 0, 1)
+	stats.CurrentPhase = DetectCurrentPhase(stats, logs, today, location)
+	return stats
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
+
+func temperatureLogs(t *testing.T, start string, values []float64) []models.DailyLog {
+	t.Helper()
+
+	first := mustParseDay(t, start)
+	logs := make([]models.DailyLog, 0, len(values))
+	for index, value := range values {
+		logs = append(logs, models.DailyLog{Date: first.AddDate(0, 0, index), Flow: models.FlowNone, BBT: value})
+	}
+	return logs
+}
+
+func TestDetectTemperatureShift(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		values        []float64
+		wantFound     bool
+		wantOvulation string
+		wantConfirmed string
+		wantCoverLine float64
+	}{
+		{
+			name:          "three over six",
+			values:        []float64{36.3, 36.4, 36.35, 36.3, 36.45, 36.4, 36.6, 36.65, 36.7},
+			wantFound:     true,
+			wantOvulation: "2026-03-06",
+			wantConfirmed: "2026-03-09",
+			wantCoverLine: 36.45,
+		},
+		{
+			name:          "shift found after an earlier false start",
+			values:        []float64{36.3, 36.3, 36.3, 36.3, 36.3, 36.3, 36.5, 36.2, 36.3, 36.6, 36.6, 36.7},
+			wantFound:     true,
+			wantOvulation: "2026-03-09",
+			wantConfirmed: "2026-03-12",
+			wantCoverLine: 36.5,
+		},
+		{
+			name:   "third high reading too close to cover line",
+			values: []float64{36.3, 36.4, 36.35, 36.3, 36.45, 36.4, 36.5, 36.55, 36.6},
+		},
+		{
+			name:   "one reading back below cover line",
+			values: []float64{36.3, 36.4, 36.35, 36.3, 36.45, 36.4, 36.6, 36.4, 36.7},
+		},
+		{
+			name:   "not enough readings",
+			values: []float64{36.3, 36.4, 36.35, 36.7, 36.8},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			readings := TemperatureReadings(temperatureLogs(t, "2026-03-01", testCase.values), time.Time{}, time.Time{}, time.UTC)
+			shift, found := DetectTemperatureShift(readings)
+			if found != testCase.wantFound {
+				t.Fatalf("expected found=%t, got %t (%#v)", testCase.wantFound, found, shift)
+			}
+			if !found {
+				return
+			}
+			if got := shift.OvulationDate.Format("2006-01-02"); got != testCase.wantOvulation {
+				t.Fatalf("expected ovulation %s, got %s", testCase.wantOvulation, got)
+			}
+			if got := shift.ConfirmedDate.Format("2006-01-02"); got != testCase.wantConfirmed {
+				t.Fatalf("expected confirmation %s, got %s", testCase.wantConfirmed, got)
+			}
+			if shift.CoverLine != testCase.wantCoverLine {
+				t.Fatalf("expected cover line %.2f, got %.2f", testCase.wantCoverLine, shift.CoverLine)
+			}
+		})
+	}
+}
+
+func TestTemperatureReadingsSkipsDisturbedAndEmptyDays(t *testing.T) {
+	t.Parallel()
+
+	logs := temperatureLogs(t, "2026-03-01", []float64{36.3, 0, 36.9, 36.4})
+	logs[2].BBTDisturbed = true
+
+	readings := TemperatureReadings(logs, mustParseDay(t, "2026-03-01"), mustParseDay(t, "2026-03-03"), time.UTC)
+	if len(readings) != 1 || readings[0].Celsius != 36.3 {
+		t.Fatalf("expected only the first undisturbed reading in range, got %#v", readings)
+	}
+}
+
+func TestTemperatureUnitConversions(t *testing.T) {
+	t.Parallel()
+
+	if got := TemperatureToCelsius(97.7, "F"); got != 36.5 {
+		t.Fatalf("expected 97.7 °F to be 36.5 °C, got %.2f", got)
+	}
+	if got := TemperatureInUnit(36.5, models.TemperatureUnitFahrenheit); got != 97.7 {
+		t.Fatalf("expected 36.5 °C to be 97.7 °F, got %.2f", got)
+	}
+	if got := TemperatureInUnit(36.5, ""); got != 36.5 {
+		t.Fatalf("expected celsius by default, got %.2f", got)
+	}
+	if IsValidTemperatureUnit("k") || !IsValidTemperatureUnit(" F ") {
+		t.Fatal("expected only c and f to be valid units")
+	}
+}
+
+func TestApplyTemperatureShiftConfirmsOvulation(t *testing.T) {
+	t.Parallel()
+
+	logs := []models.DailyLog{makeLog(t, "2026-03-01", true), makeLog(t, "2026-03-02", true)}
+	values := []float64{36.3, 36.4, 36.35, 36.3, 36.45, 36.4, 36.6, 36.65, 36.7}
+	logs = append(logs, temperatureLogs(t, "2026-03-08", values)...)
+
+	now := mustParseDay(t, "2026-03-18")
+	stats := BuildCycleStats(logs, now)
+	if stats.OvulationConfirmed {
+		t.Fatal("expected baseline stats to be unconfirmed")
+	}
+
+	confirmed := ApplyTemperatureShift(stats, logs, now, time.UTC)
+	if !confirmed.OvulationConfirmed || !confirmed.OvulationExact {
+		t.Fatalf("expected confirmed exact ovulation, got %#v", confirmed)
+	}
+	if got := confirmed.OvulationDate.Format("2006-01-02"); got != "2026-03-13" {
+		t.Fatalf("expected ovulation on the last low day, got %s", got)
+	}
+	if got := confirmed.FertilityWindowEnd.Format("2006-01-02"); got != "2026-03-14" {
+		t.Fatalf("expected fertile window to end a day after ovulation, got %s", got)
+	}
+	if confirmed.CurrentPhase != "luteal" {
+		t.Fatalf("expected luteal phase after confirmation, got %q", confirmed.CurrentPhase)
+	}
+
+	early := ApplyTemperatureShift(stats, logs, mustParseDay(t, "2026-03-15"), time.UTC)
+	if early.OvulationConfirmed {
+		t.Fatal("expected readings after now to be ignored")
+	}
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	totpSecretBytes = 20
+	totpDigits      = 6
+	totpPeriod      = 30 * time.Second
+	// totpSkewSteps accepts codes from the neighbouring time steps so a
+	// phone clock that is a few seconds off still works.
+	totpSkewSteps = 1
+	totpIssuer    = "Ovumcy"
+)
+
+var (
+	ErrTOTPSecretInvalid = errors.New("invalid totp secret")
+	totpSecretEncoding   = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+// GenerateTOTPSecret returns a new random RFC 4648 base32 secret suitable for
+// authenticator apps.
+func GenerateTOTPSecret() (string, error) {
+	raw := make([]byte, totpSecretBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return totpSecretEncoding.EncodeToString(raw), nil
+}
+
+// BuildTOTPProvisioningURI builds the otpauth:// URI that authenticator apps
+// read from a QR code or accept as a pasted link.
+func BuildTOTPProvisioningURI(accountName string, secret string) string {
+	label := url.PathEscape(totpIssuer + ":" + strings.TrimSpace(accountName))
+	values := url.Values{}
+	values.Set("secret", secret)
+	values.Set("issuer", totpIssuer)
+	values.Set("algorithm", "SHA1")
+	values.Set("digits", fmt.Sprintf("%d", totpDigits))
+	values.Set("period", fmt.Sprintf("%d", int(totpPeriod/time.Second)))
+	return "otpauth://totp/" + label + "?" + values.Encode()
+}
+
+// TOTPTimeStep returns the RFC 6238 counter for now.
+func TOTPTimeStep(now time.Time) int64 {
+	return now.Unix() / int64(totpPeriod/time.Second)
+}
+
+// GenerateTOTPCode returns the code for the given secret and time step.
+func GenerateTOTPCode(secret string, step int64) (string, error) {
+	key, err := decodeTOTPSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	truncated := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for index := 0; index < totpDigits; index++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, truncated%modulo), nil
+}
+
+// MatchTOTPCode checks code against the steps around now. Steps at or before
+// lastUsedStep are rejected so an observed code cannot be replayed. On success
+// it returns the matched step, which callers persist as the new lastUsedStep.
+func MatchTOTPCode(secret string, code string, now time.Time, lastUsedStep int64) (int64, bool) {
+	code = NormalizeTOTPCode(code)
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	current := TOTPTimeStep(now)
+	for step := current - totpSkewSteps; step <= current+totpSkewSteps; step++ {
+		if step <= lastUsedStep {
+			continue
+		}
+		expected, err := GenerateTOTPCode(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// NormalizeTOTPCode strips the spaces and dashes some apps show between
+// digit groups.
+func NormalizeTOTPCode(raw string) string {
+	normalized := strings.TrimSpace(raw)
+	normalized = strings.ReplaceAll(normalized, " ", "")
+	normalized = strings.ReplaceAll(normalized, "-", "")
+	return normalized
+}
+
+// LooksLikeTOTPCode reports whether raw is shaped like an authenticator code
+// rather than a recovery code.
+func LooksLikeTOTPCode(raw string) bool {
+	code := NormalizeTOTPCode(raw)
+	if len(code) != totpDigits {
+		return false
+	}
+	for _, char := range code {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func decodeTOTPSecret(secret string) ([]byte, error) {
+	normalized := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(secret), " ", ""))
+	normalized = strings.TrimRight(normalized, "=")
+	if normalized == "" {
+		return nil, ErrTOTPSecretInvalid
+	}
+	key, err := totpSecretEncoding.DecodeString(normalized)
+	if err != nil || len(key) == 0 {
+		return nil, ErrTOTPSecretInvalid
+	}
+	return key, nil
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 seed from RFC 6238 appendix B.
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateTOTPCodeMatchesRFC6238Vectors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+	}
+
+	for _, testCase := range testCases {
+		got, err := GenerateTOTPCode(rfc6238Secret, TOTPTimeStep(time.Unix(testCase.unix, 0)))
+		if err != nil {
+			t.Fatalf("GenerateTOTPCode(%d) unexpected error: %v", testCase.unix, err)
+		}
+		if got != testCase.want {
+			t.Fatalf("GenerateTOTPCode(%d) = %q, want %q", testCase.unix, got, testCase.want)
+		}
+	}
+}
+
+func TestMatchTOTPCode(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1111111111, 0)
+	currentStep := TOTPTimeStep(now)
+	previousCode, _ := GenerateTOTPCode(rfc6238Secret, currentStep-1)
+	farCode, _ := GenerateTOTPCode(rfc6238Secret, currentStep-3)
+
+	testCases := []struct {
+		name     string
+		code     string
+		lastStep int64
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "current code", code: "050471", wantStep: currentStep, wantOK: true},
+		{name: "grouped digits", code: "050 471", wantStep: currentStep, wantOK: true},
+		{name: "previous step within skew", code: previousCode, wantStep: currentStep - 1, wantOK: true},
+		{name: "outside skew window", code: farCode},
+		{name: "replayed step", code: "050471", lastStep: currentStep},
+		{name: "wrong code", code: "000000"},
+		{name: "too short", code: "12345"},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			step, ok := MatchTOTPCode(rfc6238Secret, testCase.code, now, testCase.lastStep)
+			if ok != testCase.wantOK {
+				t.Fatalf("MatchTOTPCode() ok = %v, want %v", ok, testCase.wantOK)
+			}
+			if ok && step != testCase.wantStep {
+				t.Fatalf("MatchTOTPCode() step = %d, want %d", step, testCase.wantStep)
+			}
+		})
+	}
+}
+
+func TestGenerateTOTPSecretIsDecodable(t *testing.T) {
+	t.Parallel()
+
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret() unexpected error: %v", err)
+	}
+	if _, err := GenerateTOTPCode(secret, 1); err != nil {
+		t.Fatalf("expected generated secret to be usable, got %v", err)
+	}
+	if _, err := GenerateTOTPCode("not base32!", 1); err == nil {
+		t.Fatal("expected invalid secret to be rejected")
+	}
+}
+
+func TestBuildTOTPProvisioningURI(t *testing.T) {
+	t.Parallel()
+
+	uri := BuildTOTPProvisioningURI("user@example.com", "ABCDEF")
+	if !strings.HasPrefix(uri, "otpauth://totp/Ovumcy:user@example.com?") {
+		t.Fatalf("unexpected provisioning uri prefix: %q", uri)
+	}
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		t.Fatalf("parse provisioning uri: %v", err)
+	}
+	query := parsed.Query()
+	if query.Get("secret") != "ABCDEF" || query.Get("issuer") != "Ovumcy" || query.Get("digits") != "6" || query.Get("period") != "30" {
+		t.Fatalf("unexpected provisioning uri query: %v", query)
+	}
+}
+
+func TestLooksLikeTOTPCode(t *testing.T) {
+	t.Parallel()
+
+	if !LooksLikeTOTPCode(" 123 456 ") {
+		t.Fatal("expected grouped six digit code to look like totp")
+	}
+	if LooksLikeTOTPCode("OVUM-ABCD-EFGH-JKLM") {
+		t.Fatal("expected recovery code not to look like totp")
+	}
+}
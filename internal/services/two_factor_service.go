@@ -0,0 +1,186 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrTwoFactorAlreadyEnabled  = errors.New("two factor already enabled")
+	ErrTwoFactorNotEnabled      = errors.New("two factor not enabled")
+	ErrTwoFactorNotPending      = errors.New("two factor enrollment not started")
+	ErrTwoFactorCodeInvalid     = errors.New("two factor code invalid")
+	ErrTwoFactorPasswordInvalid = errors.New("two factor password invalid")
+	ErrTwoFactorUpdate          = errors.New("two factor update failed")
+)
+
+type TwoFactorUserRepository interface {
+	UpdateByID(userID uint, updates map[string]any) error
+	UpdateRecoveryCodeHash(userID uint, recoveryHash string) error
+}
+
+// TwoFactorEnrollment is what Settings shows while the user adds the secret
+// to an authenticator app.
+type TwoFactorEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// TwoFactorVerification reports how a login challenge was satisfied. When the
+// recovery code was used it is rotated and the new code is returned so it can
+// be shown once.
+type TwoFactorVerification struct {
+	UsedRecoveryCode bool
+	RecoveryCode     string
+}
+
+type TwoFactorService struct {
+	users TwoFactorUserRepository
+}
+
+func NewTwoFactorService(users TwoFactorUserRepository) *TwoFactorService {
+	return &TwoFactorService{users: users}
+}
+
+func IsTwoFactorEnabled(user *models.User) bool {
+	return user != nil && user.TOTPEnabled && strings.TrimSpace(user.TOTPSecret) != ""
+}
+
+func (service *TwoFactorService) BeginEnrollment(user *models.User) (TwoFactorEnrollment, error) {
+	if user == nil {
+		return TwoFactorEnrollment{}, ErrAuthUserRequired
+	}
+	if IsTwoFactorEnabled(user) {
+		return TwoFactorEnrollment{}, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		return TwoFactorEnrollment{}, fmt.Errorf("%w: %v", ErrTwoFactorUpdate, err)
+	}
+	if err := service.users.UpdateByID(user.ID, map[string]any{
+		"totp_secret":    secret,
+		"totp_enabled":   false,
+		"totp_last_step": 0,
+	}); err != nil {
+		return TwoFactorEnrollment{}, fmt.Errorf("%w: %v", ErrTwoFactorUpdate, err)
+	}
+	user.TOTPSecret = secret
+	user.TOTPEnabled = false
+	user.TOTPLastStep = 0
+	return service.enrollmentFor(user), nil
+}
+
+// PendingEnrollment returns the secret of an enrollment that was started but
+// not yet confirmed with a code.
+func (service *TwoFactorService) PendingEnrollment(user *models.User) (TwoFactorEnrollment, bool) {
+	if user == nil || user.TOTPEnabled || strings.TrimSpace(user.TOTPSecret) == "" {
+		return TwoFactorEnrollment{}, false
+	}
+	return service.enrollmentFor(user), true
+}
+
+func (service *TwoFactorService) ConfirmEnrollment(user *models.User, code string, now time.Time) error {
+	if user == nil {
+		return ErrAuthUserRequired
+	}
+	if IsTwoFactorEnabled(user) {
+		return ErrTwoFactorAlreadyEnabled
+	}
+	if strings.TrimSpace(user.TOTPSecret) == "" {
+		return ErrTwoFactorNotPending
+	}
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	step, ok := MatchTOTPCode(user.TOTPSecret, code, now, 0)
+	if !ok {
+		return ErrTwoFactorCodeInvalid
+	}
+	if err := service.users.UpdateByID(user.ID, map[string]any{
+		"totp_enabled":   true,
+		"totp_last_step": step,
+	}); err != nil {
+		return fmt.Errorf("%w: %v", ErrTwoFactorUpdate, err)
+	}
+	user.TOTPEnabled = true
+	user.TOTPLastStep = step
+	return nil
+}
+
+func (service *TwoFactorService) Disable(user *models.User, password string) error {
+	if user == nil {
+		return ErrAuthUserRequired
+	}
+	if strings.TrimSpace(user.TOTPSecret) == "" {
+		return ErrTwoFactorNotEnabled
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+		return ErrTwoFactorPasswordInvalid
+	}
+	if err := service.users.UpdateByID(user.ID, map[string]any{
+		"totp_secret":    "",
+		"totp_enabled":   false,
+		"totp_last_step": 0,
+	}); err != nil {
+		return fmt.Errorf("%w: %v", ErrTwoFactorUpdate, err)
+	}
+	user.TOTPSecret = ""
+	user.TOTPEnabled = false
+	user.TOTPLastStep = 0
+	return nil
+}
+
+// VerifyLogin completes the second login step with either an authenticator
+// code or the account recovery code.
+func (service *TwoFactorService) VerifyLogin(user *models.User, code string, now time.Time) (TwoFactorVerification, error) {
+	if user == nil {
+		return TwoFactorVerification{}, ErrAuthUserRequired
+	}
+	if !IsTwoFactorEnabled(user) {
+		return TwoFactorVerification{}, ErrTwoFactorNotEnabled
+	}
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	if LooksLikeTOTPCode(code) {
+		step, ok := MatchTOTPCode(user.TOTPSecret, code, now, user.TOTPLastStep)
+		if !ok {
+			return TwoFactorVerification{}, ErrTwoFactorCodeInvalid
+		}
+		if err := service.users.UpdateByID(user.ID, map[string]any{"totp_last_step": step}); err != nil {
+			return TwoFactorVerification{}, fmt.Errorf("%w: %v", ErrTwoFactorUpdate, err)
+		}
+		user.TOTPLastStep = step
+		return TwoFactorVerification{}, nil
+	}
+
+	recoveryHash := strings.TrimSpace(user.RecoveryCodeHash)
+	if recoveryHash == "" || bcrypt.CompareHashAndPassword([]byte(recoveryHash), []byte(NormalizeRecoveryCode(code))) != nil {
+		return TwoFactorVerification{}, ErrTwoFactorCodeInvalid
+	}
+
+	recoveryCode, newHash, err := GenerateRecoveryCodeHash()
+	if err != nil {
+		return TwoFactorVerification{}, fmt.Errorf("%w: %v", ErrRecoveryCodeGenerate, err)
+	}
+	if err := service.users.UpdateRecoveryCodeHash(user.ID, newHash); err != nil {
+		return TwoFactorVerification{}, fmt.Errorf("%w: %v", ErrRecoveryCodeUpdate, err)
+	}
+	user.RecoveryCodeHash = newHash
+	return TwoFactorVerification{UsedRecoveryCode: true, RecoveryCode: recoveryCode}, nil
+}
+
+func (service *TwoFactorService) enrollmentFor(user *models.User) TwoFactorEnrollment {
+	return TwoFactorEnrollment{
+		Secret: user.TOTPSecret,
+		URI:    BuildTOTPProvisioningURI(user.Email, user.TOTPSecret),
+	}
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type stubTwoFactorUserRepo struct {
+	updates      map[string]any
+	recoveryHash string
+}
+
+func (stub *stubTwoFactorUserRepo) UpdateByID(_ uint, updates map[string]any) error {
+	stub.updates = updates
+	return nil
+}
+
+func (stub *stubTwoFactorUserRepo) UpdateRecoveryCodeHash(_ uint, recoveryHash string) error {
+	stub.recoveryHash = recoveryHash
+	return nil
+}
+
+func TestTwoFactorServiceEnrollmentFlow(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubTwoFactorUserRepo{}
+	service := NewTwoFactorService(repo)
+	user := &models.User{ID: 3, Email: "totp@example.com"}
+	now := time.Unix(1700000000, 0)
+
+	enrollment, err := service.BeginEnrollment(user)
+	if err != nil {
+		t.Fatalf("BeginEnrollment() unexpected error: %v", err)
+	}
+	if enrollment.Secret == "" || repo.updates["totp_secret"] != enrollment.Secret || repo.updates["totp_enabled"] != false {
+		t.Fatalf("expected pending secret to be stored, got %#v", repo.updates)
+	}
+	if pending, ok := service.PendingEnrollment(user); !ok || pending.Secret != enrollment.Secret {
+		t.Fatalf("expected pending enrollment, got %#v ok=%v", pending, ok)
+	}
+
+	if err := service.ConfirmEnrollment(user, "000000", now); !errors.Is(err, ErrTwoFactorCodeInvalid) {
+		t.Fatalf("expected ErrTwoFactorCodeInvalid for wrong code, got %v", err)
+	}
+
+	code, _ := GenerateTOTPCode(enrollment.Secret, TOTPTimeStep(now))
+	if err := service.ConfirmEnrollment(user, code, now); err != nil {
+		t.Fatalf("ConfirmEnrollment() unexpected error: %v", err)
+	}
+	if !IsTwoFactorEnabled(user) || repo.updates["totp_enabled"] != true {
+		t.Fatalf("expected two factor to be enabled, got %#v", repo.updates)
+	}
+	if _, err := service.BeginEnrollment(user); !errors.Is(err, ErrTwoFactorAlreadyEnabled) {
+		t.Fatalf("expected ErrTwoFactorAlreadyEnabled, got %v", err)
+	}
+}
+
+func TestTwoFactorServiceConfirmRequiresPendingSecret(t *testing.T) {
+	t.Parallel()
+
+	service := NewTwoFactorService(&stubTwoFactorUserRepo{})
+	if err := service.ConfirmEnrollment(&models.User{ID: 1}, "123456", time.Now()); !errors.Is(err, ErrTwoFactorNotPending) {
+		t.Fatalf("expected ErrTwoFactorNotPending, got %v", err)
+	}
+}
+
+func TestTwoFactorServiceVerifyLogin(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret() unexpected error: %v", err)
+	}
+	validCode, _ := GenerateTOTPCode(secret, TOTPTimeStep(now))
+	recoveryHash, err := bcrypt.GenerateFromPassword([]byte("OVUM-ABCD-EFGH-JKLM"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("hash recovery code: %v", err)
+	}
+
+	testCases := []struct {
+		name         string
+		code         string
+		lastStep     int64
+		wantErr      error
+		wantRecovery bool
+	}{
+		{name: "authenticator code", code: validCode},
+		{name: "replayed authenticator code", code: validCode, lastStep: TOTPTimeStep(now) + 1, wantErr: ErrTwoFactorCodeInvalid},
+		{name: "wrong authenticator code", code: "000000", wantErr: ErrTwoFactorCodeInvalid},
+		{name: "recovery code fallback", code: "ovum abcd efgh jklm", wantRecovery: true},
+		{name: "wrong recovery code", code: "OVUM-AAAA-BBBB-CCCC", wantErr: ErrTwoFactorCodeInvalid},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &stubTwoFactorUserRepo{}
+			service := NewTwoFactorService(repo)
+			user := &models.User{
+				ID:               4,
+				TOTPSecret:       secret,
+				TOTPEnabled:      true,
+				TOTPLastStep:     testCase.lastStep,
+				RecoveryCodeHash: string(recoveryHash),
+			}
+
+			result, err := service.VerifyLogin(user, testCase.code, now)
+			if testCase.wantErr != nil {
+				if !errors.Is(err, testCase.wantErr) {
+					t.Fatalf("expected %v, got %v", testCase.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifyLogin() unexpected error: %v", err)
+			}
+			if result.UsedRecoveryCode != testCase.wantRecovery {
+				t.Fatalf("expected UsedRecoveryCode=%v, got %v", testCase.wantRecovery, result.UsedRecoveryCode)
+			}
+			if testCase.wantRecovery {
+				if result.RecoveryCode == "" || repo.recoveryHash == "" || repo.recoveryHash == string(recoveryHash) {
+					t.Fatal("expected recovery code to be rotated after use")
+				}
+				return
+			}
+			if repo.updates["totp_last_step"] != TOTPTimeStep(now) {
+				t.Fatalf("expected last used step to be persisted, got %#v", repo.updates)
+			}
+		})
+	}
+}
+
+func TestTwoFactorServiceDisableRequiresPassword(t *testing.T) {
+	t.Parallel()
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte("StrongPass1"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+	repo := &stubTwoFactorUserRepo{}
+	service := NewTwoFactorService(repo)
+	user := &models.User{ID: 5, PasswordHash: string(passwordHash), TOTPSecret: "ABCDEFGH", TOTPEnabled: true}
+
+	if err := service.Disable(user, "WrongPass1"); !errors.Is(err, ErrTwoFactorPasswordInvalid) {
+		t.Fatalf("expected ErrTwoFactorPasswordInvalid, got %v", err)
+	}
+	if err := service.Disable(user, "StrongPass1"); err != nil {
+		t.Fatalf("Disable() unexpected error: %v", err)
+	}
+	if IsTwoFactorEnabled(user) || repo.updates["totp_secret"] != "" {
+		t.Fatalf("expected two factor to be cleared, got %#v", repo.updates)
+	}
+}
@@ -1,6 +1,10 @@
 package services
 
-import "github.com/terraincognita07/ovumcy/internal/models"
+import (
+	"time"
+
+	"github.com/terraincognita07/ovumcy/internal/models"
+)
 
 func IsOwnerUser(user *models.User) bool {
 	return user != nil && user.Role == models.RoleOwner
@@ -11,27 +15,231 @@ func IsPartnerUser(user *models.User) bool {
 }
 
 func SanitizeLogForPartner(entry models.DailyLog) models.DailyLog {
-	entry.Notes = ""
-	entry.SymptomIDs = []uint{}
+	return SanitizeLogForSharingPolicy(models.PartnerSharingPolicy{}, entry)
+}
+
+func SanitizeLogForSharingPolicy(policy models.PartnerSharingPolicy, entry models.DailyLog) models.DailyLog {
+	if policy.HidePeriodDays {
+		entry.IsPeriod = false
+		entry.Flow = models.FlowNone
+	}
+	if policy.HideFlow {
+		// Spotting is only told apart from a period by its flow, so hiding
+		// the flow hides spotting days rather than showing them as periods.
+		if entry.Flow == models.FlowSpotting {
+			entry.IsPeriod = false
+		}
+		entry.Flow = models.FlowNone
+	}
+	if !policy.ShareNotes {
+		entry.Notes = ""
+	}
+	entry.SymptomIDs = filterSharedSymptomIDs(policy, entry.SymptomIDs)
+	entry.SymptomSeverities = symptomSeveritiesFor(entry, entry.SymptomIDs)
+	entry.BBT = 0
+	entry.BBTTime = ""
+	entry.BBTDisturbed = false
+	entry.Mucus = ""
+	entry.CervixPosition = ""
+	entry.CervixFirmness = ""
+	entry.LHTest = ""
+	entry.PregnancyTest = ""
+	entry.TestBrand = ""
+	entry.PillTaken = false
+	// Cycle overrides stay so partners see the same cycles as the owner,
+	// but the reason a cycle was excluded is not shared.
+	if entry.CycleExclusion != "" {
+		entry.CycleExclusion = models.CycleExclusionOther
+	}
+	return stripLogIntimacy(entry)
+}
+
+// stripLogIntimacy removes the intimacy entry. No sharing policy can expose
+// it to a partner.
+func stripLogIntimacy(entry models.DailyLog) models.DailyLog {
+	entry.Intimacy = false
+	entry.IntimacyProtection = ""
+	entry.IntimacyMethods = nil
 	return entry
 }
 
+// SanitizeLogForViewer applies the partner sharing policy and hides
+// intimacy entries from owners who have not turned tracking on.
 func SanitizeLogForViewer(user *models.User, entry models.DailyLog) models.DailyLog {
 	if IsPartnerUser(user) {
-		return SanitizeLogForPartner(entry)
+		return SanitizeLogForSharingPolicy(user.SharingPolicy, entry)
+	}
+	if !IntimacyVisibleForViewer(user) {
+		return stripLogIntimacy(entry)
 	}
 	return entry
 }
 
 func SanitizeLogsForViewer(user *models.User, logs []models.DailyLog) {
-	if !IsPartnerUser(user) {
+	if !IsPartnerUser(user) && IntimacyVisibleForViewer(user) {
 		return
 	}
 	for index := range logs {
-		logs[index] = SanitizeLogForPartner(logs[index])
+		logs[index] = SanitizeLogForViewer(user, logs[index])
+	}
+}
+
+func SanitizeCycleStatsForViewer(user *models.User, stats CycleStats) CycleStats {
+	if !IsPartnerUser(user) {
+		return stats
+	}
+
+	policy := user.SharingPolicy
+	if policy.HidePeriodDays {
+		stats.CurrentCycleDay = 0
+		stats.CurrentPhase = "unknown"
+		stats.AverageCycleLength = 0
+		stats.MedianCycleLength = 0
+		stats.CycleLengthDeviation = 0
+		stats.PredictedCycleLength = 0
+		stats.AveragePeriodLength = 0
+		stats.LastPeriodStart = time.Time{}
+		stats.LastWithdrawalBleed = time.Time{}
+	}
+	if policy.HidePredictions {
+		stats.NextPeriodStart = time.Time{}
+		stats.NextWithdrawalBleed = time.Time{}
+	}
+	if policy.HideFertileWindow {
+		stats.OvulationDate = time.Time{}
+		stats.OvulationExact = false
+		stats.OvulationConfirmed = false
+		stats.MucusPeakDate = time.Time{}
+		stats.LHSurgeDate = time.Time{}
+		stats.OvulationImpossible = false
+		stats.LutealPhaseLength = 0
+		stats.LutealPhasePersonalised = false
+		stats.FertilityWindowStart = time.Time{}
+		stats.FertilityWindowEnd = time.Time{}
+	}
+	return stats
+}
+
+func SanitizeCyclePredictionsForViewer(user *models.User, predictions []CyclePrediction) []CyclePrediction {
+	if !IsPartnerUser(user) {
+		return predictions
+	}
+
+	policy := user.SharingPolicy
+	if policy.HidePredictions {
+		return []CyclePrediction{}
+	}
+	if policy.HideFertileWindow {
+		for index := range predictions {
+			predictions[index].OvulationDate = time.Time{}
+			predictions[index].OvulationExact = false
+			predictions[index].OvulationImpossible = false
+			predictions[index].FertilityWindowStart = time.Time{}
+			predictions[index].FertilityWindowEnd = time.Time{}
+		}
+	}
+	return predictions
+}
+
+func SanitizeDashboardCycleContextForViewer(user *models.User, context DashboardCycleContext) DashboardCycleContext {
+	if !IsPartnerUser(user) {
+		return context
+	}
+
+	policy := user.SharingPolicy
+	if policy.HidePeriodDays {
+		context.CycleDayWarning = false
+		context.CycleDataStale = false
+	}
+	if policy.HidePredictions {
+		context.DisplayNextPeriodStart = time.Time{}
+		context.NextPeriodInPast = false
+	}
+	if policy.HideFertileWindow {
+		context.DisplayOvulationDate = time.Time{}
+		context.DisplayOvulationExact = false
+		context.DisplayOvulationImpossible = false
+		context.OvulationInPast = false
+	}
+	return context
+}
+
+func SanitizeCalendarDayStatesForViewer(user *models.User, days []CalendarDayState) {
+	if !IntimacyVisibleForViewer(user) {
+		for index := range days {
+			days[index].Intimacy = false
+			days[index].IntimacyUnprotected = false
+		}
+	}
+	if !IsPartnerUser(user) {
+		return
+	}
+
+	policy := user.SharingPolicy
+	for index := range days {
+		// Observed fertility is derived from temperature and mucus, which
+		// are never shared.
+		days[index].FertilityStatus = ""
+		days[index].FertilityRule = ""
+		if policy.HidePeriodDays {
+			days[index].IsPeriod = false
+			days[index].IsSpotting = false
+		}
+		if policy.HidePredictions {
+			days[index].IsPredicted = false
+			days[index].IsPredictionRange = false
+		}
+		if policy.HideFertileWindow {
+			days[index].IsFertility = false
+			days[index].IsOvulation = false
+		}
 	}
 }
 
 func ShouldExposeSymptomsForViewer(user *models.User) bool {
-	return IsOwnerUser(user)
+	if IsOwnerUser(user) {
+		return true
+	}
+	return IsPartnerUser(user) && len(user.SharingPolicy.SharedSymptomIDs) > 0
+}
+
+func FilterSymptomsForViewer(user *models.User, symptoms []models.SymptomType) []models.SymptomType {
+	if IsOwnerUser(user) {
+		return symptoms
+	}
+	if !IsPartnerUser(user) {
+		return []models.SymptomType{}
+	}
+
+	shared := symptomIDLookup(user.SharingPolicy.SharedSymptomIDs)
+	filtered := make([]models.SymptomType, 0, len(shared))
+	for _, symptom := range symptoms {
+		if shared[symptom.ID] {
+			filtered = append(filtered, symptom)
+		}
+	}
+	return filtered
+}
+
+func filterSharedSymptomIDs(policy models.PartnerSharingPolicy, symptomIDs []uint) []uint {
+	filtered := make([]uint, 0, len(policy.SharedSymptomIDs))
+	if len(policy.SharedSymptomIDs) == 0 {
+		return filtered
+	}
+
+	shared := symptomIDLookup(policy.SharedSymptomIDs)
+	for _, id := range symptomIDs {
+		if shared[id] {
+			filtered = append(filtered, id)
+		}
+	}
+	return filtered
+}
+
+func symptomIDLookup(ids []uint) map[uint]bool {
+	lookup := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		lookup[id] = true
+	}
+	return lookup
 }
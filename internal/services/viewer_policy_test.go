@@ -2,6 +2,7 @@ package services
 
 import (
 	"testing"
+	"time"
 
 	"github.com/terraincognita07/ovumcy/internal/models"
 )
@@ -46,3 +47,165 @@ func TestShouldExposeSymptomsForViewer(t *testing.T) {
 		t.Fatal("expected partner not to see symptoms")
 	}
 }
+
+func TestSanitizeLogForViewerAppliesPartnerSharingPolicy(t *testing.T) {
+	t.Parallel()
+
+	entry := models.DailyLog{
+		IsPeriod:   true,
+		Flow:       models.FlowHeavy,
+		Notes:      "private",
+		SymptomIDs: []uint{1, 2, 3},
+	}
+
+	testCases := []struct {
+		name         string
+		policy       models.PartnerSharingPolicy
+		wantPeriod   bool
+		wantFlow     string
+		wantNotes    string
+		wantSymptoms []uint
+	}{
+		{
+			name:         "default policy",
+			policy:       models.PartnerSharingPolicy{},
+			wantPeriod:   true,
+			wantFlow:     models.FlowHeavy,
+			wantSymptoms: []uint{},
+		},
+		{
+			name:         "hide period days",
+			policy:       models.PartnerSharingPolicy{HidePeriodDays: true},
+			wantPeriod:   false,
+			wantFlow:     models.FlowNone,
+			wantSymptoms: []uint{},
+		},
+		{
+			name:         "hide flow only",
+			policy:       models.PartnerSharingPolicy{HideFlow: true},
+			wantPeriod:   true,
+			wantFlow:     models.FlowNone,
+			wantSymptoms: []uint{},
+		},
+		{
+			name:         "share notes and selected symptoms",
+			policy:       models.PartnerSharingPolicy{ShareNotes: true, SharedSymptomIDs: []uint{3, 9}},
+			wantPeriod:   true,
+			wantFlow:     models.FlowHeavy,
+			wantNotes:    "private",
+			wantSymptoms: []uint{3},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			partner := &models.User{Role: models.RolePartner, SharingPolicy: testCase.policy}
+			sanitized := SanitizeLogForViewer(partner, entry)
+			if sanitized.IsPeriod != testCase.wantPeriod {
+				t.Fatalf("expected is_period=%t, got %t", testCase.wantPeriod, sanitized.IsPeriod)
+			}
+			if sanitized.Flow != testCase.wantFlow {
+				t.Fatalf("expected flow %q, got %q", testCase.wantFlow, sanitized.Flow)
+			}
+			if sanitized.Notes != testCase.wantNotes {
+				t.Fatalf("expected notes %q, got %q", testCase.wantNotes, sanitized.Notes)
+			}
+			if len(sanitized.SymptomIDs) != len(testCase.wantSymptoms) {
+				t.Fatalf("expected symptom IDs %#v, got %#v", testCase.wantSymptoms, sanitized.SymptomIDs)
+			}
+			for index := range testCase.wantSymptoms {
+				if sanitized.SymptomIDs[index] != testCase.wantSymptoms[index] {
+					t.Fatalf("expected symptom IDs %#v, got %#v", testCase.wantSymptoms, sanitized.SymptomIDs)
+				}
+			}
+		})
+	}
+}
+
+func TestSanitizeCycleStatsForViewerAppliesPartnerSharingPolicy(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	stats := CycleStats{
+		CurrentCycleDay:      9,
+		CurrentPhase:         "follicular",
+		MedianCycleLength:    28,
+		LastPeriodStart:      day,
+		NextPeriodStart:      day.AddDate(0, 0, 28),
+		OvulationDate:        day.AddDate(0, 0, 14),
+		FertilityWindowStart: day.AddDate(0, 0, 9),
+		FertilityWindowEnd:   day.AddDate(0, 0, 14),
+	}
+
+	owner := &models.User{Role: models.RoleOwner, SharingPolicy: models.PartnerSharingPolicy{HidePredictions: true}}
+	if got := SanitizeCycleStatsForViewer(owner, stats); got.NextPeriodStart.IsZero() {
+		t.Fatal("expected owner stats to ignore sharing policy")
+	}
+
+	defaultPartner := &models.User{Role: models.RolePartner}
+	if got := SanitizeCycleStatsForViewer(defaultPartner, stats); got != stats {
+		t.Fatalf("expected default partner policy to keep stats, got %#v", got)
+	}
+
+	restricted := &models.User{Role: models.RolePartner, SharingPolicy: models.PartnerSharingPolicy{
+		HidePeriodDays:    true,
+		HidePredictions:   true,
+		HideFertileWindow: true,
+	}}
+	got := SanitizeCycleStatsForViewer(restricted, stats)
+	if got.CurrentCycleDay != 0 || got.CurrentPhase != "unknown" || !got.LastPeriodStart.IsZero() || got.MedianCycleLength != 0 {
+		t.Fatalf("expected period history to be hidden, got %#v", got)
+	}
+	if !got.NextPeriodStart.IsZero() {
+		t.Fatalf("expected next period prediction to be hidden, got %s", got.NextPeriodStart)
+	}
+	if !got.OvulationDate.IsZero() || !got.FertilityWindowStart.IsZero() || !got.FertilityWindowEnd.IsZero() {
+		t.Fatalf("expected fertile window to be hidden, got %#v", got)
+	}
+}
+
+func TestSanitizeCalendarDayStatesForViewerAppliesPartnerSharingPolicy(t *testing.T) {
+	t.Parallel()
+
+	days := []CalendarDayState{{IsPeriod: true, IsPredicted: true, IsFertility: true, IsOvulation: true, HasData: true, FertilityStatus: FertilityStatusFertile, FertilityRule: FertilityRuleMucusObserved}}
+	partner := &models.User{Role: models.RolePartner, SharingPolicy: models.PartnerSharingPolicy{
+		HidePredictions:   true,
+		HideFertileWindow: true,
+	}}
+
+	SanitizeCalendarDayStatesForViewer(partner, days)
+	if !days[0].IsPeriod {
+		t.Fatal("expected period marker to stay visible")
+	}
+	if days[0].IsPredicted || days[0].IsFertility || days[0].IsOvulation {
+		t.Fatalf("expected prediction markers to be hidden, got %#v", days[0])
+	}
+	if days[0].FertilityStatus != "" || days[0].FertilityRule != "" {
+		t.Fatalf("expected observed fertility status to be hidden, got %#v", days[0])
+	}
+}
+
+func TestFilterSymptomsForViewer(t *testing.T) {
+	t.Parallel()
+
+	symptoms := []models.SymptomType{{ID: 1, Name: "Cramps"}, {ID: 2, Name: "Headache"}}
+
+	if got := FilterSymptomsForViewer(&models.User{Role: models.RoleOwner}, symptoms); len(got) != 2 {
+		t.Fatalf("expected owner to see all symptoms, got %#v", got)
+	}
+	if got := FilterSymptomsForViewer(&models.User{Role: models.RolePartner}, symptoms); len(got) != 0 {
+		t.Fatalf("expected default partner to see no symptoms, got %#v", got)
+	}
+
+	partner := &models.User{Role: models.RolePartner, SharingPolicy: models.PartnerSharingPolicy{SharedSymptomIDs: []uint{2}}}
+	got := FilterSymptomsForViewer(partner, symptoms)
+	if len(got) != 1 || got[0].ID != 2 {
+		t.Fatalf("expected only shared symptom, got %#v", got)
+	}
+	if !ShouldExposeSymptomsForViewer(partner) {
+		t.Fatal("expected partner with shared symptoms to see symptoms")
+	}
+}
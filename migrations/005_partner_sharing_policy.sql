@@ -0,0 +1,6 @@
+ALTER TABLE users ADD COLUMN share_hide_period_days BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN share_hide_flow BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN share_hide_predictions BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN share_hide_fertile_window BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN share_notes BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN shared_symptom_ids TEXT;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL UNIQUE,
+  token_hash TEXT NOT NULL UNIQUE,
+  language TEXT NOT NULL DEFAULT '',
+  cycles INTEGER NOT NULL DEFAULT 6,
+  include_fertility BOOLEAN NOT NULL DEFAULT 0,
+  include_ovulation BOOLEAN NOT NULL DEFAULT 0,
+  neutral_titles BOOLEAN NOT NULL DEFAULT 0,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
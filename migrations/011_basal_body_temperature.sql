@@ -0,0 +1,4 @@
+ALTER TABLE daily_logs ADD COLUMN bbt REAL NOT NULL DEFAULT 0;
+ALTER TABLE daily_logs ADD COLUMN bbt_time TEXT NOT NULL DEFAULT '';
+ALTER TABLE daily_logs ADD COLUMN bbt_disturbed BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN temperature_unit TEXT NOT NULL DEFAULT 'c';
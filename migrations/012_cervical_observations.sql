@@ -0,0 +1,3 @@
+ALTER TABLE daily_logs ADD COLUMN mucus TEXT NOT NULL DEFAULT '';
+ALTER TABLE daily_logs ADD COLUMN cervix_position TEXT NOT NULL DEFAULT '';
+ALTER TABLE daily_logs ADD COLUMN cervix_firmness TEXT NOT NULL DEFAULT '';
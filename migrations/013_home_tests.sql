@@ -0,0 +1,3 @@
+ALTER TABLE daily_logs ADD COLUMN lh_test TEXT NOT NULL DEFAULT '';
+ALTER TABLE daily_logs ADD COLUMN pregnancy_test TEXT NOT NULL DEFAULT '';
+ALTER TABLE daily_logs ADD COLUMN test_brand TEXT NOT NULL DEFAULT '';
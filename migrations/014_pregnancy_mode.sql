@@ -0,0 +1,4 @@
+ALTER TABLE users ADD COLUMN cycle_mode TEXT NOT NULL DEFAULT 'cycle';
+ALTER TABLE users ADD COLUMN pregnancy_start DATE;
+ALTER TABLE users ADD COLUMN due_date DATE;
+ALTER TABLE users ADD COLUMN postpartum_start DATE;
@@ -0,0 +1,5 @@
+ALTER TABLE users ADD COLUMN contraception_method TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN contraception_pack_length INTEGER NOT NULL DEFAULT 28;
+ALTER TABLE users ADD COLUMN contraception_placebo_days INTEGER NOT NULL DEFAULT 7;
+ALTER TABLE users ADD COLUMN contraception_start DATE;
+ALTER TABLE daily_logs ADD COLUMN pill_taken BOOLEAN NOT NULL DEFAULT 0;
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS medications (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL,
+  name TEXT NOT NULL,
+  dose REAL NOT NULL DEFAULT 0,
+  unit TEXT NOT NULL DEFAULT '',
+  schedule TEXT NOT NULL DEFAULT 'as_needed',
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_medications_user_id ON medications(user_id);
+
+CREATE TABLE IF NOT EXISTS medication_intakes (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL,
+  medication_id INTEGER NOT NULL,
+  date DATE NOT NULL,
+  dose REAL NOT NULL DEFAULT 0,
+  unit TEXT NOT NULL DEFAULT '',
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (medication_id) REFERENCES medications(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uidx_medication_intakes_day ON medication_intakes(user_id, medication_id, date);
@@ -0,0 +1,12 @@
+ALTER TABLE daily_logs ADD COLUMN symptom_severities TEXT;
+
+-- Symptoms logged before severities existed are treated as moderate.
+UPDATE daily_logs
+SET symptom_severities = (
+  SELECT json_group_object(CAST(value AS TEXT), 2)
+  FROM json_each(daily_logs.symptom_ids)
+)
+WHERE CASE
+  WHEN json_valid(symptom_ids) THEN json_type(symptom_ids) = 'array' AND json_array_length(symptom_ids) > 0
+  ELSE 0
+END;
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS metric_types (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL,
+  name TEXT NOT NULL,
+  unit TEXT NOT NULL DEFAULT '',
+  kind TEXT NOT NULL DEFAULT 'decimal',
+  min_value REAL,
+  max_value REAL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_metric_types_user_id ON metric_types(user_id);
+
+CREATE TABLE IF NOT EXISTS metric_values (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL,
+  metric_type_id INTEGER NOT NULL,
+  date DATE NOT NULL,
+  value REAL NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (metric_type_id) REFERENCES metric_types(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uidx_metric_values_day ON metric_values(user_id, metric_type_id, date);
@@ -0,0 +1,4 @@
+ALTER TABLE users ADD COLUMN intimacy_tracking BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE daily_logs ADD COLUMN intimacy BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE daily_logs ADD COLUMN intimacy_protection TEXT NOT NULL DEFAULT '';
+ALTER TABLE daily_logs ADD COLUMN intimacy_methods TEXT;
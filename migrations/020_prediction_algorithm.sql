@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN prediction_algorithm TEXT NOT NULL DEFAULT 'median';
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS prediction_snapshots (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL,
+  cycle_start DATE NOT NULL,
+  predicted_period_start DATE NOT NULL,
+  predicted_ovulation DATE,
+  algorithm TEXT NOT NULL DEFAULT 'median',
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uidx_prediction_snapshots_cycle ON prediction_snapshots(user_id, cycle_start);
@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN luteal_phase_length INTEGER NOT NULL DEFAULT 0;
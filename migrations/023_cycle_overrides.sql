@@ -0,0 +1,2 @@
+ALTER TABLE daily_logs ADD COLUMN cycle_start_override TEXT NOT NULL DEFAULT '';
+ALTER TABLE daily_logs ADD COLUMN cycle_exclusion TEXT NOT NULL DEFAULT '';
@@ -131,8 +131,11 @@
     };
   }
 
-  function formatDays(value, daySuffix) {
-    return String(Math.round(value)) + daySuffix;
+  function formatValue(value, suffix, decimals) {
+    if (decimals > 0) {
+      return value.toFixed(decimals) + suffix;
+    }
+    return String(Math.round(value)) + suffix;
   }
 
   function drawGrid(context, padding, width, height, color) {
@@ -149,7 +152,7 @@
     context.stroke();
   }
 
-  function drawBaseline(context, padding, width, yForValue, baseline, baselineLabel, daySuffix, color) {
+  function drawBaseline(context, padding, width, yForValue, baseline, baselineLabel, daySuffix, decimals, color) {
     var baselineY = yForValue(baseline);
 
     context.save();
@@ -167,7 +170,7 @@
     context.textAlign = "right";
     context.textBaseline = "bottom";
     context.fillText(
-      baselineLabel + " " + formatDays(baseline, daySuffix),
+      baselineLabel + " " + formatValue(baseline, daySuffix, decimals),
       padding.left + width - 8,
       Math.max(padding.top + 12, baselineY - 6)
     );
@@ -228,13 +231,13 @@
     }
   }
 
-  function drawYLabels(context, domain, padding, height, daySuffix, color) {
+  function drawYLabels(context, domain, padding, height, daySuffix, decimals, color) {
     context.fillStyle = color;
     context.font = "12px Quicksand, Nunito, sans-serif";
     context.textAlign = "right";
     context.textBaseline = "middle";
-    context.fillText(formatDays(domain.max, daySuffix), padding.left - 8, padding.top + 2);
-    context.fillText(formatDays(domain.min, daySuffix), padding.left - 8, padding.top + height);
+    context.fillText(formatValue(domain.max, daySuffix, decimals), padding.left - 8, padding.top + 2);
+    context.fillText(formatValue(domain.min, daySuffix, decimals), padding.left - 8, padding.top + height);
   }
 
   function drawChart(container) {
@@ -245,6 +248,7 @@
     var emptyText = container.getAttribute("data-empty-text") || "Not enough cycle data yet.";
     var daySuffix = container.getAttribute("data-days-suffix") || "d";
     var baselineLabel = container.getAttribute("data-baseline-label") || "Baseline";
+    var decimals = Math.max(0, Math.min(3, parseInt(container.getAttribute("data-decimals") || "0", 10) || 0));
     var chartData = parseChartData(container);
 
     container.textContent = "";
@@ -301,13 +305,13 @@
     drawGrid(context, padding, innerWidth, innerHeight, colors.grid);
 
     if (hasBaseline) {
-      drawBaseline(context, padding, innerWidth, yForValue, chartData.baseline, baselineLabel, daySuffix, colors.baseline);
+      drawBaseline(context, padding, innerWidth, yForValue, chartData.baseline, baselineLabel, daySuffix, decimals, colors.baseline);
     }
 
     drawValueLine(context, chartData.values, xForIndex, yForValue, colors.line);
     drawValuePoints(context, chartData.values, xForIndex, yForValue, colors.dot);
     drawXLabels(context, chartData.labels, xForIndex, size.height, padding, colors.label);
-    drawYLabels(context, domain, padding, innerHeight, daySuffix, colors.label);
+    drawYLabels(context, domain, padding, innerHeight, daySuffix, decimals, colors.label);
   }
 
   function renderCharts(root) {